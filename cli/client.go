@@ -19,6 +19,7 @@ import (
 	"github.com/jhump/protoreflect/grpcreflect"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
+	"go.uber.org/multierr"
 	apppb "go.viam.com/api/app/v1"
 	"go.viam.com/utils"
 	"go.viam.com/utils/rpc"
@@ -670,29 +671,24 @@ func (c *AppClient) RunRobotPartCommand(
 	}
 }
 
-// StartRobotPartShell starts a shell on a robot part.
-func (c *AppClient) StartRobotPartShell(
+// robotPartShell connects to the robot part and returns its first shell service, along with the
+// robot client to close once done with it.
+func (c *AppClient) robotPartShell(
 	orgStr, locStr, robotStr, partStr string,
 	debug bool,
 	logger golog.Logger,
-) error {
+) (shell.Service, *client.RobotClient, error) {
 	dialCtx, fqdn, rpcOpts, err := c.prepareDial(orgStr, locStr, robotStr, partStr, debug)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	fmt.Fprintln(c.c.App.Writer, "establishing connection...")
+	fmt.Fprintln(c.c.App.ErrWriter, "establishing connection...")
 	robotClient, err := client.New(dialCtx, fqdn, logger, client.WithDialOptions(rpcOpts...))
 	if err != nil {
-		fmt.Fprintln(c.c.App.ErrWriter, err)
-		cli.OsExiter(1)
-		return nil
+		return nil, nil, err
 	}
 
-	defer func() {
-		utils.UncheckedError(robotClient.Close(c.c.Context))
-	}()
-
 	// Returns the first shell service found in the robot resources
 	var found *resource.Name
 	for _, name := range robotClient.ResourceNames() {
@@ -703,24 +699,36 @@ func (c *AppClient) StartRobotPartShell(
 		}
 	}
 	if found == nil {
-		fmt.Fprintln(c.c.App.ErrWriter, "shell service is not enabled")
-		cli.OsExiter(1)
-		return nil
+		return nil, nil, multierr.Combine(errors.New("shell service is not enabled"), robotClient.Close(c.c.Context))
 	}
 
 	shellRes, err := robotClient.ResourceByName(*found)
 	if err != nil {
-		fmt.Fprintln(c.c.App.ErrWriter, err)
-		cli.OsExiter(1)
-		return nil
+		return nil, nil, multierr.Combine(err, robotClient.Close(c.c.Context))
 	}
 
 	shellSvc, ok := shellRes.(shell.Service)
 	if !ok {
-		fmt.Fprintln(c.c.App.ErrWriter, "shell service is not a shell service")
+		return nil, nil, multierr.Combine(errors.New("shell service is not a shell service"), robotClient.Close(c.c.Context))
+	}
+	return shellSvc, robotClient, nil
+}
+
+// StartRobotPartShell starts a shell on a robot part.
+func (c *AppClient) StartRobotPartShell(
+	orgStr, locStr, robotStr, partStr string,
+	debug bool,
+	logger golog.Logger,
+) error {
+	shellSvc, robotClient, err := c.robotPartShell(orgStr, locStr, robotStr, partStr, debug, logger)
+	if err != nil {
+		fmt.Fprintln(c.c.App.ErrWriter, err)
 		cli.OsExiter(1)
 		return nil
 	}
+	defer func() {
+		utils.UncheckedError(robotClient.Close(c.c.Context))
+	}()
 
 	input, output, err := shellSvc.Shell(c.c.Context)
 	if err != nil {
@@ -803,3 +811,66 @@ func (c *AppClient) StartRobotPartShell(
 	outputLoop()
 	return nil
 }
+
+// ExecRobotPartCommand runs a command to completion on a robot part and exits with its exit code.
+func (c *AppClient) ExecRobotPartCommand(
+	orgStr, locStr, robotStr, partStr string,
+	cmd shell.Command,
+	debug bool,
+	logger golog.Logger,
+) error {
+	shellSvc, robotClient, err := c.robotPartShell(orgStr, locStr, robotStr, partStr, debug, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		utils.UncheckedError(robotClient.Close(c.c.Context))
+	}()
+
+	extendedSvc, ok := shellSvc.(shell.ExtendedService)
+	if !ok {
+		return shell.NewUnimplementedExtendedInterfaceError(shellSvc)
+	}
+	exitCode, err := extendedSvc.Exec(c.c.Context, cmd, c.c.App.Writer, c.c.App.ErrWriter)
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		cli.OsExiter(exitCode)
+	}
+	return nil
+}
+
+// CopyRobotPartFile uploads the local file to the remote path of a robot part, or downloads the
+// remote file to the local path if download is set. Interrupted transfers are resumed.
+func (c *AppClient) CopyRobotPartFile(
+	orgStr, locStr, robotStr, partStr string,
+	localPath, remotePath string,
+	download bool,
+	debug bool,
+	logger golog.Logger,
+) error {
+	shellSvc, robotClient, err := c.robotPartShell(orgStr, locStr, robotStr, partStr, debug, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		utils.UncheckedError(robotClient.Close(c.c.Context))
+	}()
+
+	extendedSvc, ok := shellSvc.(shell.ExtendedService)
+	if !ok {
+		return shell.NewUnimplementedExtendedInterfaceError(shellSvc)
+	}
+	var info shell.FileInfo
+	if download {
+		info, err = shell.DownloadFile(c.c.Context, extendedSvc, remotePath, localPath)
+	} else {
+		info, err = shell.UploadFile(c.c.Context, extendedSvc, localPath, remotePath)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.c.App.Writer, "%s: %d bytes, sha256 %s\n", info.Path, info.Size, info.SHA256)
+	return nil
+}
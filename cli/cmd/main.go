@@ -13,11 +13,44 @@ import (
 	"go.uber.org/zap"
 
 	rdkcli "go.viam.com/rdk/cli"
+	"go.viam.com/rdk/services/shell"
 )
 
 func main() {
 	var logger golog.Logger
 
+	// copyFileAction returns the action of the command copying a file to or from a robot part.
+	copyFileAction := func(download bool) cli.ActionFunc {
+		return func(c *cli.Context) error {
+			if c.NArg() != 2 {
+				fmt.Fprintln(c.App.ErrWriter, "source and destination paths required")
+				cli.ShowSubcommandHelpAndExit(c, 1)
+				return nil
+			}
+			localPath, remotePath := c.Args().Get(0), c.Args().Get(1)
+			if download {
+				localPath, remotePath = remotePath, localPath
+			}
+
+			client, err := rdkcli.NewAppClient(c)
+			if err != nil {
+				return err
+			}
+
+			return client.CopyRobotPartFile(
+				c.String("organization"),
+				c.String("location"),
+				c.String("robot"),
+				c.String("part"),
+				localPath,
+				remotePath,
+				download,
+				c.Bool("debug"),
+				logger,
+			)
+		}
+	}
+
 	app := &cli.App{
 		Name:  "viam",
 		Usage: "interact with your robots",
@@ -576,6 +609,107 @@ func main() {
 									)
 								},
 							},
+							{
+								Name:      "exec",
+								Usage:     "run a command to completion on a robot part with its shell service",
+								ArgsUsage: "<command> [args...]",
+								Flags: []cli.Flag{
+									&cli.StringFlag{
+										Name:     "organization",
+										Required: true,
+									},
+									&cli.StringFlag{
+										Name:     "location",
+										Required: true,
+									},
+									&cli.StringFlag{
+										Name:     "robot",
+										Required: true,
+									},
+									&cli.StringFlag{
+										Name:     "part",
+										Required: true,
+									},
+									&cli.StringSliceFlag{
+										Name:  "env",
+										Usage: "environment variable of the form key=value",
+									},
+									&cli.StringFlag{
+										Name:  "dir",
+										Usage: "working directory of the command",
+									},
+								},
+								Action: func(c *cli.Context) error {
+									if c.NArg() == 0 {
+										fmt.Fprintln(c.App.ErrWriter, "command required")
+										cli.ShowSubcommandHelpAndExit(c, 1)
+										return nil
+									}
+
+									client, err := rdkcli.NewAppClient(c)
+									if err != nil {
+										return err
+									}
+
+									return client.ExecRobotPartCommand(
+										c.String("organization"),
+										c.String("location"),
+										c.String("robot"),
+										c.String("part"),
+										shell.Command{Args: c.Args().Slice(), Env: c.StringSlice("env"), Dir: c.String("dir")},
+										c.Bool("debug"),
+										logger,
+									)
+								},
+							},
+							{
+								Name:      "upload",
+								Usage:     "upload a local file to a robot part with its shell service",
+								ArgsUsage: "<local path> <remote path>",
+								Flags: []cli.Flag{
+									&cli.StringFlag{
+										Name:     "organization",
+										Required: true,
+									},
+									&cli.StringFlag{
+										Name:     "location",
+										Required: true,
+									},
+									&cli.StringFlag{
+										Name:     "robot",
+										Required: true,
+									},
+									&cli.StringFlag{
+										Name:     "part",
+										Required: true,
+									},
+								},
+								Action: copyFileAction(false),
+							},
+							{
+								Name:      "download",
+								Usage:     "download a file of a robot part with its shell service",
+								ArgsUsage: "<remote path> <local path>",
+								Flags: []cli.Flag{
+									&cli.StringFlag{
+										Name:     "organization",
+										Required: true,
+									},
+									&cli.StringFlag{
+										Name:     "location",
+										Required: true,
+									},
+									&cli.StringFlag{
+										Name:     "robot",
+										Required: true,
+									},
+									&cli.StringFlag{
+										Name:     "part",
+										Required: true,
+									},
+								},
+								Action: copyFileAction(true),
+							},
 						},
 					},
 				},
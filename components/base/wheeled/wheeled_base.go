@@ -51,6 +51,8 @@ type wheeledBase struct {
 	left      []motor.Motor
 	right     []motor.Motor
 	allMotors []motor.Motor
+	// group moves the motors when they are to be synchronized, and is nil otherwise.
+	group *motor.Group
 
 	opMgr operation.SingleOperationManager
 }
@@ -94,13 +96,19 @@ func (base *wheeledBase) MoveStraight(ctx context.Context, distanceMm int, mmPer
 }
 
 func (base *wheeledBase) runAll(ctx context.Context, leftRPM, leftRotations, rightRPM, rightRotations float64) error {
+	if base.group != nil && (leftRotations != 0 || rightRotations != 0) {
+		return base.runSynchronized(ctx, leftRPM, leftRotations, rightRPM, rightRotations)
+	}
+
 	fs := []rdkutils.SimpleFunc{}
 
 	for _, m := range base.left {
+		m := m
 		fs = append(fs, func(ctx context.Context) error { return m.GoFor(ctx, leftRPM, leftRotations, nil) })
 	}
 
 	for _, m := range base.right {
+		m := m
 		fs = append(fs, func(ctx context.Context) error { return m.GoFor(ctx, rightRPM, rightRotations, nil) })
 	}
 
@@ -110,6 +118,22 @@ func (base *wheeledBase) runAll(ctx context.Context, leftRPM, leftRotations, rig
 	return nil
 }
 
+// runSynchronized moves the motors of the base as a group, so that both sides start together and
+// finish at the same time.
+func (base *wheeledBase) runSynchronized(ctx context.Context, leftRPM, leftRotations, rightRPM, rightRotations float64) error {
+	rpms := make([]float64, 0, len(base.allMotors))
+	rotations := make([]float64, 0, len(base.allMotors))
+	for range base.left {
+		rpms = append(rpms, leftRPM)
+		rotations = append(rotations, leftRotations)
+	}
+	for range base.right {
+		rpms = append(rpms, rightRPM)
+		rotations = append(rotations, rightRotations)
+	}
+	return base.group.GoFor(ctx, rpms, rotations, nil)
+}
+
 // differentialDrive takes forward and left direction inputs from a first person
 // perspective on a 2D plane and converts them to left and right motor powers. negative
 // forward means backward and negative left means right.
@@ -284,6 +308,9 @@ type Config struct {
 	SpinSlipFactor       float64  `json:"spin_slip_factor,omitempty"`
 	Left                 []string `json:"left"`
 	Right                []string `json:"right"`
+	// SynchronizeMotors makes the moves of a given distance or angle start and finish all motors
+	// together (see motor.Group) rather than leave each motor to its own GoFor.
+	SynchronizeMotors bool `json:"synchronize_motors,omitempty"`
 }
 
 // Validate ensures all parts of the config are valid.
@@ -348,5 +375,11 @@ func CreateWheeledBase(
 	base.allMotors = append(base.allMotors, base.left...)
 	base.allMotors = append(base.allMotors, base.right...)
 
+	if config.SynchronizeMotors {
+		var err error
+		if base.group, err = motor.NewGroup(base.allMotors, logger); err != nil {
+			return nil, err
+		}
+	}
 	return base, nil
 }
@@ -3,6 +3,7 @@ package wheeled
 import (
 	"context"
 	"math"
+	"sync"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"go.viam.com/rdk/components/motor"
 	"go.viam.com/rdk/components/motor/fake"
 	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/testutils/inject"
 )
 
 func fakeMotorDependencies(t *testing.T, deps []string) registry.Dependencies {
@@ -350,3 +352,65 @@ func TestValidate(t *testing.T) {
 	test.That(t, deps, test.ShouldResemble, []string{"fl-m", "bl-m", "fr-m", "br-m"})
 	test.That(t, err, test.ShouldBeNil)
 }
+
+func TestMotorMoves(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	cfg := &Config{
+		WidthMM:              100,
+		WheelCircumferenceMM: 1000,
+		Left:                 []string{"fl-m", "bl-m"},
+		Right:                []string{"fr-m", "br-m"},
+	}
+	deps, err := cfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+
+	var mu sync.Mutex
+	goFors := map[string][]float64{}
+	motorDeps := make(registry.Dependencies)
+	for _, dep := range deps {
+		name := dep
+		m := &inject.Motor{}
+		m.GoForFunc = func(ctx context.Context, rpm, revolutions float64, extra map[string]interface{}) error {
+			mu.Lock()
+			defer mu.Unlock()
+			goFors[name] = []float64{rpm, revolutions}
+			return nil
+		}
+		m.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+			return nil
+		}
+		motorDeps[motor.Named(name)] = m
+	}
+
+	baseBase, err := CreateWheeledBase(ctx, motorDeps, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	base := baseBase.(*wheeledBase)
+	test.That(t, base.group, test.ShouldBeNil)
+
+	// each motor covers the distance on its own
+	test.That(t, base.Spin(ctx, 360*10, 360, nil), test.ShouldBeNil)
+	rpm, revolutions := base.spinMath(360*10, 360)
+	test.That(t, goFors, test.ShouldResemble, map[string][]float64{
+		"fl-m": {-rpm, revolutions},
+		"bl-m": {-rpm, revolutions},
+		"fr-m": {rpm, revolutions},
+		"br-m": {rpm, revolutions},
+	})
+
+	t.Run("synchronized", func(t *testing.T) {
+		cfg.SynchronizeMotors = true
+		motorDeps := fakeMotorDependencies(t, deps)
+		baseBase, err := CreateWheeledBase(ctx, motorDeps, cfg, logger)
+		test.That(t, err, test.ShouldBeNil)
+		base := baseBase.(*wheeledBase)
+		test.That(t, base.group, test.ShouldNotBeNil)
+
+		test.That(t, base.MoveStraight(ctx, 100, 1000, nil), test.ShouldBeNil)
+		for _, m := range base.allMotors {
+			powered, _, err := m.IsPowered(ctx, nil)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, powered, test.ShouldBeFalse)
+		}
+	})
+}
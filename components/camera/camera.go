@@ -225,8 +225,10 @@ func (vs *videoSource) Projector(ctx context.Context) (transform.Projector, erro
 	return vs.system.PinholeCameraIntrinsics, nil
 }
 
+// DoCommand passes the command to the source the camera was made from, which for cameras made from
+// a reader is the reader rather than the video source wrapping it.
 func (vs *videoSource) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
-	if doer, ok := vs.videoSource.(generic.Generic); ok {
+	if doer, ok := vs.actualSource.(generic.Generic); ok {
 		return doer.DoCommand(ctx, cmd)
 	}
 	return nil, generic.ErrUnimplemented
@@ -74,6 +74,29 @@ func TestGenericDo(t *testing.T) {
 	test.That(t, ret, test.ShouldEqual, command)
 }
 
+// doerSource is a reader which answers commands.
+type doerSource struct {
+	simpleSource
+}
+
+func (s *doerSource) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	return map[string]interface{}{"echo": cmd}, nil
+}
+
+func TestDoCommandFromReader(t *testing.T) {
+	command := map[string]interface{}{"cmd": "test"}
+	cam, err := camera.NewFromReader(context.Background(), &doerSource{}, nil, camera.UnspecifiedStream)
+	test.That(t, err, test.ShouldBeNil)
+	ret, err := cam.DoCommand(context.Background(), command)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, ret, test.ShouldResemble, map[string]interface{}{"echo": command})
+
+	cam, err = camera.NewFromReader(context.Background(), &simpleSource{}, nil, camera.UnspecifiedStream)
+	test.That(t, err, test.ShouldBeNil)
+	_, err = cam.DoCommand(context.Background(), command)
+	test.That(t, err, test.ShouldEqual, generic.ErrUnimplemented)
+}
+
 func TestFromDependencies(t *testing.T) {
 	deps := setupDependencies(t)
 
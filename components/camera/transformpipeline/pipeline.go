@@ -16,6 +16,7 @@ import (
 
 	"go.viam.com/rdk/components/camera"
 	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/pointcloud"
 	"go.viam.com/rdk/registry"
 	"go.viam.com/rdk/rimage/transform"
 	"go.viam.com/rdk/robot"
@@ -97,9 +98,17 @@ func newTransformPipeline(
 	if cfg.AttrConfig != nil {
 		props = cfg.AttrConfig.CameraParameters
 	}
+	var reader gostream.VideoReader = transformPipeline{pipeline, lastSourceStream, props}
+	if pointCloudTransforms[transformType(cfg.Pipeline[len(cfg.Pipeline)-1].Type)] {
+		pcSource, ok := lastSource.(camera.PointCloudSource)
+		if !ok {
+			return nil, camera.NewUnimplementedInterfaceError(lastSource)
+		}
+		reader = pointCloudTransformPipeline{transformPipeline{pipeline, lastSourceStream, props}, pcSource}
+	}
 	return camera.NewFromReader(
 		ctx,
-		transformPipeline{pipeline, lastSourceStream, props},
+		reader,
 		&transform.PinholeCameraModel{props, nil},
 		camera.StreamType(cfg.Stream),
 	)
@@ -117,6 +126,18 @@ func (tp transformPipeline) Read(ctx context.Context) (image.Image, func(), erro
 	return tp.stream.Next(ctx)
 }
 
+// pointCloudTransformPipeline is a pipeline whose last transform outputs point clouds.
+type pointCloudTransformPipeline struct {
+	transformPipeline
+	pcSource camera.PointCloudSource
+}
+
+func (tp pointCloudTransformPipeline) NextPointCloud(ctx context.Context) (pointcloud.PointCloud, error) {
+	ctx, span := trace.StartSpan(ctx, "camera::transformpipeline::NextPointCloud")
+	defer span.End()
+	return tp.pcSource.NextPointCloud(ctx)
+}
+
 func (tp transformPipeline) Close(ctx context.Context) error {
 	var errs error
 	for _, src := range tp.pipeline {
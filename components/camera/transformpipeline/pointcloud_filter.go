@@ -0,0 +1,187 @@
+package transformpipeline
+
+import (
+	"context"
+	"math"
+
+	"github.com/edaniels/gostream"
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/spatialmath"
+	rdkutils "go.viam.com/rdk/utils"
+)
+
+// filterType is the list of allowed filters of the point cloud filter transform.
+type filterType string
+
+// the allowed filters.
+const (
+	filterTypeVoxelGrid          = filterType("voxel_grid")
+	filterTypeStatisticalOutlier = filterType("statistical_outlier")
+	filterTypeRadiusOutlier      = filterType("radius_outlier")
+	filterTypeCropBox            = filterType("crop_box")
+	filterTypeOrientedCropBox    = filterType("oriented_crop_box")
+	filterTypePassThrough        = filterType("pass_through")
+	filterTypeNormals            = filterType("normals")
+)
+
+// pointCloudFilter states the type of a point cloud filter and the attributes specific to it.
+type pointCloudFilter struct {
+	Type       string              `json:"type"`
+	Attributes config.AttributeMap `json:"attributes"`
+}
+
+// pointCloudFilterAttrs is the attribute struct for filtering the point clouds of the source
+// camera, by applying the filters in order.
+type pointCloudFilterAttrs struct {
+	Filters []pointCloudFilter `json:"filters"`
+}
+
+type voxelGridAttrs struct {
+	VoxelSize float64 `json:"voxel_size_mm"`
+}
+
+type statisticalOutlierAttrs struct {
+	MeanK int     `json:"mean_k"`
+	Sigma float64 `json:"sigma"`
+}
+
+type radiusOutlierAttrs struct {
+	Radius       float64 `json:"radius_mm"`
+	MinNeighbors int     `json:"min_neighbors"`
+}
+
+type cropBoxAttrs struct {
+	Min *spatialmath.TranslationConfig `json:"min"`
+	Max *spatialmath.TranslationConfig `json:"max"`
+}
+
+type orientedCropBoxAttrs struct {
+	Translation spatialmath.TranslationConfig         `json:"translation"`
+	Orientation *spatialmath.OrientationVectorDegrees `json:"orientation"`
+	Dims        spatialmath.TranslationConfig         `json:"dims_mm"`
+}
+
+type passThroughAttrs struct {
+	Axis string   `json:"axis"`
+	Min  *float64 `json:"min"`
+	Max  *float64 `json:"max"`
+}
+
+type normalsAttrs struct {
+	// Number of neighbors the normals are estimated from. Defaults to 10.
+	KNeighbors int `json:"k_neighbors"`
+	// Point the normals point towards. Defaults to the origin of the camera.
+	Viewpoint *spatialmath.TranslationConfig `json:"viewpoint"`
+}
+
+// decodeFilterAttrs decodes the attributes of a filter into the given attribute struct.
+func decodeFilterAttrs(to interface{}, am config.AttributeMap) error {
+	_, err := config.TransformAttributeMapToStruct(to, am)
+	return err
+}
+
+// buildPointCloudFilter uses the filter config to build the desired point cloud filter.
+func buildPointCloudFilter(f pointCloudFilter) (pointcloud.Filter, error) {
+	switch filterType(f.Type) {
+	case filterTypeVoxelGrid:
+		var attrs voxelGridAttrs
+		if err := decodeFilterAttrs(&attrs, f.Attributes); err != nil {
+			return nil, err
+		}
+		return pointcloud.VoxelGridFilter(attrs.VoxelSize)
+	case filterTypeStatisticalOutlier:
+		var attrs statisticalOutlierAttrs
+		if err := decodeFilterAttrs(&attrs, f.Attributes); err != nil {
+			return nil, err
+		}
+		return pointcloud.StatisticalOutlierFilter(attrs.MeanK, attrs.Sigma)
+	case filterTypeRadiusOutlier:
+		var attrs radiusOutlierAttrs
+		if err := decodeFilterAttrs(&attrs, f.Attributes); err != nil {
+			return nil, err
+		}
+		return pointcloud.RadiusOutlierFilter(attrs.Radius, attrs.MinNeighbors)
+	case filterTypeCropBox:
+		var attrs cropBoxAttrs
+		if err := decodeFilterAttrs(&attrs, f.Attributes); err != nil {
+			return nil, err
+		}
+		if attrs.Min == nil || attrs.Max == nil {
+			return nil, errors.New("crop_box filter needs both a min and a max")
+		}
+		return pointcloud.CropBoxFilter(attrs.Min.ParseConfig(), attrs.Max.ParseConfig())
+	case filterTypeOrientedCropBox:
+		var attrs orientedCropBoxAttrs
+		if err := decodeFilterAttrs(&attrs, f.Attributes); err != nil {
+			return nil, err
+		}
+		pose := spatialmath.NewPoseFromPoint(attrs.Translation.ParseConfig())
+		if attrs.Orientation != nil {
+			pose = spatialmath.NewPoseFromOrientation(attrs.Translation.ParseConfig(), attrs.Orientation)
+		}
+		return pointcloud.OrientedCropBoxFilter(pose, attrs.Dims.ParseConfig())
+	case filterTypePassThrough:
+		var attrs passThroughAttrs
+		if err := decodeFilterAttrs(&attrs, f.Attributes); err != nil {
+			return nil, err
+		}
+		minValue, maxValue := math.Inf(-1), math.Inf(1)
+		if attrs.Min != nil {
+			minValue = *attrs.Min
+		}
+		if attrs.Max != nil {
+			maxValue = *attrs.Max
+		}
+		return pointcloud.PassThroughFilter(attrs.Axis, minValue, maxValue)
+	case filterTypeNormals:
+		var attrs normalsAttrs
+		if err := decodeFilterAttrs(&attrs, f.Attributes); err != nil {
+			return nil, err
+		}
+		if attrs.KNeighbors == 0 {
+			attrs.KNeighbors = 10
+		}
+		var viewpoint r3.Vector
+		if attrs.Viewpoint != nil {
+			viewpoint = attrs.Viewpoint.ParseConfig()
+		}
+		return pointcloud.NormalEstimationFilter(attrs.KNeighbors, viewpoint)
+	default:
+		return nil, errors.Errorf("do not know point cloud filter of type %q", f.Type)
+	}
+}
+
+func newPointCloudFilterTransform(
+	ctx context.Context,
+	source gostream.VideoSource, stream camera.StreamType, am config.AttributeMap,
+) (gostream.VideoSource, error) {
+	conf, err := config.TransformAttributeMapToStruct(&(pointCloudFilterAttrs{}), am)
+	if err != nil {
+		return nil, err
+	}
+	attrs, ok := conf.(*pointCloudFilterAttrs)
+	if !ok {
+		return nil, rdkutils.NewUnexpectedTypeError(attrs, conf)
+	}
+	if len(attrs.Filters) == 0 {
+		return nil, errors.Errorf("%s transform has no filters in it", transformTypePointCloudFilter)
+	}
+	filters := make([]pointcloud.Filter, 0, len(attrs.Filters))
+	for i, f := range attrs.Filters {
+		filter, err := buildPointCloudFilter(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "filter %d", i)
+		}
+		filters = append(filters, filter)
+	}
+	filter := pointcloud.ChainFilters(filters...)
+	return newPointCloudTransformSource(ctx, transformTypePointCloudFilter, source, stream,
+		func(ctx context.Context, pc pointcloud.PointCloud) (pointcloud.PointCloud, error) {
+			return filter(pc)
+		})
+}
@@ -0,0 +1,109 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"testing"
+
+	"github.com/edaniels/gostream"
+	"github.com/pion/mediadevices/pkg/prop"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/camera/videosource"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+// flatRGBDCamera returns a camera seeing a 10x10 wall 1m away.
+func flatRGBDCamera(t *testing.T) camera.Camera {
+	t.Helper()
+	dm := rimage.NewEmptyDepthMap(10, 10)
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			dm.Set(x, y, 1000)
+		}
+	}
+	proj := &transform.PinholeCameraIntrinsics{Width: 10, Height: 10, Fx: 100, Fy: 100, Ppx: 5, Ppy: 5}
+	source := &videosource.StaticSource{ColorImg: image.NewRGBA(image.Rect(0, 0, 10, 10)), DepthImg: dm, Proj: proj}
+	cam, err := camera.NewFromReader(context.Background(), source, &transform.PinholeCameraModel{proj, nil}, camera.DepthStream)
+	test.That(t, err, test.ShouldBeNil)
+	return cam
+}
+
+func TestPointCloudFilterSource(t *testing.T) {
+	cam := flatRGBDCamera(t)
+	pc, err := cam.NextPointCloud(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pc.Size(), test.ShouldEqual, 100)
+
+	am := config.AttributeMap{"filters": []interface{}{
+		map[string]interface{}{"type": "pass_through", "attributes": map[string]interface{}{"axis": "x", "min": 0}},
+		map[string]interface{}{"type": "crop_box", "attributes": map[string]interface{}{
+			"min": map[string]interface{}{"x": -100, "y": -100, "z": 0},
+			"max": map[string]interface{}{"x": 100, "y": 0, "z": 2000},
+		}},
+		map[string]interface{}{"type": "normals", "attributes": map[string]interface{}{"k_neighbors": 5}},
+	}}
+	pfs, err := newPointCloudFilterTransform(context.Background(), cam, camera.DepthStream, am)
+	test.That(t, err, test.ShouldBeNil)
+	filtered, err := pfs.(camera.Camera).NextPointCloud(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, filtered.Size(), test.ShouldEqual, 30)
+	test.That(t, filtered.MetaData().MinX, test.ShouldBeGreaterThanOrEqualTo, 0)
+	test.That(t, filtered.MetaData().MaxY, test.ShouldBeLessThanOrEqualTo, 0)
+	test.That(t, filtered.MetaData().HasNormal, test.ShouldBeTrue)
+	// images pass through
+	img, _, err := camera.ReadImage(context.Background(), pfs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, img.Bounds().Dx(), test.ShouldEqual, 10)
+	test.That(t, pfs.Close(context.Background()), test.ShouldBeNil)
+
+	_, err = newPointCloudFilterTransform(context.Background(), cam, camera.DepthStream, config.AttributeMap{})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = newPointCloudFilterTransform(context.Background(), cam, camera.DepthStream, config.AttributeMap{"filters": []interface{}{
+		map[string]interface{}{"type": "voxel_grid", "attributes": map[string]interface{}{"voxel_size_mm": -1}},
+	}})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = newPointCloudFilterTransform(context.Background(), cam, camera.DepthStream, config.AttributeMap{"filters": []interface{}{
+		map[string]interface{}{"type": "smoothing"},
+	}})
+	test.That(t, err, test.ShouldNotBeNil)
+	source := gostream.NewVideoSource(&videosource.StaticSource{ColorImg: image.NewRGBA(image.Rect(0, 0, 10, 10))}, prop.Video{})
+	_, err = newPointCloudFilterTransform(context.Background(), source, camera.ColorStream, am)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+}
+
+func TestTransformPipelinePointCloud(t *testing.T) {
+	cam := flatRGBDCamera(t)
+	filters := config.AttributeMap{"filters": []interface{}{
+		map[string]interface{}{"type": "voxel_grid", "attributes": map[string]interface{}{"voxel_size_mm": 25}},
+	}}
+	transformConf := &transformConfig{
+		AttrConfig: &camera.AttrConfig{Stream: "depth"},
+		Source:     "source",
+		Pipeline:   []Transformation{{Type: "pointcloud_filter", Attributes: filters}},
+	}
+	pipe, err := newTransformPipeline(context.Background(), cam, transformConf, &inject.Robot{})
+	test.That(t, err, test.ShouldBeNil)
+	props, err := pipe.Properties(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, props.SupportsPCD, test.ShouldBeTrue)
+	pc, err := pipe.NextPointCloud(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	// the wall is 90mm wide, in 4x4 voxels
+	test.That(t, pc.Size(), test.ShouldEqual, 16)
+	test.That(t, pipe.Close(context.Background()), test.ShouldBeNil)
+
+	// a pipeline ending with an image transform projects its images, without intrinsics here
+	transformConf.Pipeline = append(transformConf.Pipeline, Transformation{Type: "rotate"})
+	pipe, err = newTransformPipeline(context.Background(), cam, transformConf, &inject.Robot{})
+	test.That(t, err, test.ShouldBeNil)
+	_, err = pipe.NextPointCloud(context.Background())
+	test.That(t, err, test.ShouldWrap, transform.ErrNoIntrinsics)
+	test.That(t, pipe.Close(context.Background()), test.ShouldBeNil)
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+}
@@ -0,0 +1,252 @@
+package transformpipeline
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/edaniels/gostream"
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/spatialmath"
+	rdkutils "go.viam.com/rdk/utils"
+	"go.viam.com/rdk/vision/segmentation"
+)
+
+// pointCloudProcessor makes the point cloud of a point cloud transform from the point cloud of its source.
+type pointCloudProcessor func(ctx context.Context, pc pointcloud.PointCloud) (pointcloud.PointCloud, error)
+
+// pointCloudTransformSource passes the images of the source camera through, and processes its point clouds.
+type pointCloudTransformSource struct {
+	name    transformType
+	stream  gostream.VideoStream
+	source  camera.PointCloudSource
+	process pointCloudProcessor
+}
+
+func newPointCloudTransformSource(
+	ctx context.Context,
+	name transformType, source gostream.VideoSource, stream camera.StreamType, process pointCloudProcessor,
+) (gostream.VideoSource, error) {
+	pcSource, ok := source.(camera.PointCloudSource)
+	if !ok {
+		return nil, errors.Errorf("%s transform needs a source returning point clouds, got %T", name, source)
+	}
+	pts := &pointCloudTransformSource{name, gostream.NewEmbeddedVideoStream(source), pcSource, process}
+	return camera.NewFromReader(ctx, pts, nil, stream)
+}
+
+// Read returns the image of the source camera unchanged.
+func (pts *pointCloudTransformSource) Read(ctx context.Context) (image.Image, func(), error) {
+	return pts.stream.Next(ctx)
+}
+
+// NextPointCloud returns the processed point cloud of the source camera.
+func (pts *pointCloudTransformSource) NextPointCloud(ctx context.Context) (pointcloud.PointCloud, error) {
+	ctx, span := trace.StartSpan(ctx, "camera::transformpipeline::"+string(pts.name)+"::NextPointCloud")
+	defer span.End()
+	pc, err := pts.source.NextPointCloud(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get next source point cloud: %w", err)
+	}
+	return pts.process(ctx, pc)
+}
+
+func (pts *pointCloudTransformSource) Close(ctx context.Context) error {
+	return pts.stream.Close(ctx)
+}
+
+// transformPointCloudAttrs is the attribute struct for moving point clouds into another frame of
+// the frame system.
+type transformPointCloudAttrs struct {
+	Frame string `json:"frame"`
+	// Frame of the source point clouds. Defaults to the source camera of the pipeline.
+	SourceFrame string `json:"source_frame"`
+}
+
+func newTransformPointCloudTransform(
+	ctx context.Context,
+	source gostream.VideoSource, r robot.Robot, cfg *transformConfig, am config.AttributeMap,
+) (gostream.VideoSource, error) {
+	conf, err := config.TransformAttributeMapToStruct(&(transformPointCloudAttrs{}), am)
+	if err != nil {
+		return nil, err
+	}
+	attrs, ok := conf.(*transformPointCloudAttrs)
+	if !ok {
+		return nil, rdkutils.NewUnexpectedTypeError(attrs, conf)
+	}
+	if attrs.SourceFrame == "" {
+		attrs.SourceFrame = cfg.Source
+	}
+	if attrs.Frame == "" || attrs.SourceFrame == "" {
+		return nil, errors.Errorf("%s transform needs a frame and a source frame", transformTypeTransformPointCloud)
+	}
+	return newPointCloudTransformSource(ctx, transformTypeTransformPointCloud, source, camera.StreamType(cfg.Stream),
+		func(ctx context.Context, pc pointcloud.PointCloud) (pointcloud.PointCloud, error) {
+			origin := referenceframe.NewPoseInFrame(attrs.SourceFrame, spatialmath.NewZeroPose())
+			pose, err := r.TransformPose(ctx, origin, attrs.Frame, nil)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not transform %q into %q", attrs.SourceFrame, attrs.Frame)
+			}
+			return pointcloud.ApplyOffset(pc, pose.Pose())
+		})
+}
+
+// groundPlaneAttrs is the attribute struct for removing the biggest plane of point clouds, found
+// by RANSAC.
+type groundPlaneAttrs struct {
+	// Maximum distance of the points of the plane to it. Defaults to 10mm.
+	Threshold float64 `json:"threshold_mm"`
+	// Number of candidate planes. Defaults to 2000.
+	Iterations int `json:"iterations"`
+	// If set, the plane is only removed when its normal is within max_angle_degs of this normal,
+	// such as the up direction in the frame of the point clouds.
+	GroundNormal *spatialmath.TranslationConfig `json:"ground_normal"`
+	// Defaults to 20 degrees.
+	MaxAngle float64 `json:"max_angle_degs"`
+}
+
+func newGroundPlaneTransform(
+	ctx context.Context,
+	source gostream.VideoSource, stream camera.StreamType, am config.AttributeMap,
+) (gostream.VideoSource, error) {
+	conf, err := config.TransformAttributeMapToStruct(&(groundPlaneAttrs{}), am)
+	if err != nil {
+		return nil, err
+	}
+	attrs, ok := conf.(*groundPlaneAttrs)
+	if !ok {
+		return nil, rdkutils.NewUnexpectedTypeError(attrs, conf)
+	}
+	if attrs.Threshold == 0 {
+		attrs.Threshold = 10
+	}
+	if attrs.Iterations == 0 {
+		attrs.Iterations = 2000
+	}
+	if attrs.MaxAngle == 0 {
+		attrs.MaxAngle = 20
+	}
+	if attrs.Threshold < 0 || attrs.Iterations < 0 || attrs.MaxAngle < 0 {
+		return nil, errors.New("threshold_mm, iterations and max_angle_degs must be positive")
+	}
+	var groundNormal r3.Vector
+	if attrs.GroundNormal != nil {
+		if groundNormal = attrs.GroundNormal.ParseConfig(); groundNormal.Norm() == 0 {
+			return nil, errors.New("ground_normal cannot be a zero vector")
+		}
+		groundNormal = groundNormal.Normalize()
+	}
+	return newPointCloudTransformSource(ctx, transformTypeRemoveGroundPlane, source, stream,
+		func(ctx context.Context, pc pointcloud.PointCloud) (pointcloud.PointCloud, error) {
+			plane, rest, err := segmentation.SegmentPlane(ctx, pc, attrs.Iterations, attrs.Threshold)
+			if err != nil {
+				return nil, err
+			}
+			if attrs.GroundNormal != nil {
+				angle := math.Acos(math.Min(1, math.Abs(plane.Normal().Dot(groundNormal))))
+				if math.IsNaN(angle) || rdkutils.RadToDeg(angle) > attrs.MaxAngle {
+					return pc, nil
+				}
+			}
+			return rest, nil
+		})
+}
+
+// colorizePointCloudAttrs is the attribute struct for coloring point clouds with the images of a
+// color camera. The point clouds must be in the frame of the color camera.
+type colorizePointCloudAttrs struct {
+	ColorCameraName string `json:"color_camera_name"`
+}
+
+func newColorizePointCloudTransform(
+	ctx context.Context,
+	source gostream.VideoSource, r robot.Robot, stream camera.StreamType, am config.AttributeMap,
+) (gostream.VideoSource, error) {
+	conf, err := config.TransformAttributeMapToStruct(&(colorizePointCloudAttrs{}), am)
+	if err != nil {
+		return nil, err
+	}
+	attrs, ok := conf.(*colorizePointCloudAttrs)
+	if !ok {
+		return nil, rdkutils.NewUnexpectedTypeError(attrs, conf)
+	}
+	if attrs.ColorCameraName == "" {
+		return nil, errors.Errorf("%s transform needs a color_camera_name", transformTypeColorizePointCloud)
+	}
+	return newPointCloudTransformSource(ctx, transformTypeColorizePointCloud, source, stream,
+		func(ctx context.Context, pc pointcloud.PointCloud) (pointcloud.PointCloud, error) {
+			colorCam, err := camera.FromRobot(r, attrs.ColorCameraName)
+			if err != nil {
+				return nil, err
+			}
+			props, err := colorCam.Properties(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if props.IntrinsicParams == nil {
+				return nil, transform.NewNoIntrinsicsError(fmt.Sprintf("color camera %q", attrs.ColorCameraName))
+			}
+			img, release, err := camera.ReadImage(ctx, colorCam)
+			if err != nil {
+				return nil, fmt.Errorf("could not get next color image: %w", err)
+			}
+			defer release()
+			return colorizePointCloud(pc, img, props.IntrinsicParams)
+		})
+}
+
+// colorizePointCloud returns a copy of the point cloud whose points have the color of the pixel of
+// the image they project to. Points projecting outside of the image keep their data.
+func colorizePointCloud(
+	pc pointcloud.PointCloud, img image.Image, intrinsics *transform.PinholeCameraIntrinsics,
+) (pointcloud.PointCloud, error) {
+	if intrinsics.Width <= 0 || intrinsics.Height <= 0 {
+		return nil, errors.New("color camera intrinsics need a width and height")
+	}
+	bounds := img.Bounds()
+	// the image may be scaled from the one the intrinsics are for
+	scaleX := float64(bounds.Dx()) / float64(intrinsics.Width)
+	scaleY := float64(bounds.Dy()) / float64(intrinsics.Height)
+	colored := pointcloud.NewWithPrealloc(pc.Size())
+	var err error
+	pc.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+		if p.Z > 0 {
+			px, py := intrinsics.PointToPixel(p.X, p.Y, p.Z)
+			// the center of the pixel in the scaled image
+			pixel := image.Pt(bounds.Min.X+int((px+0.5)*scaleX), bounds.Min.Y+int((py+0.5)*scaleY))
+			if px >= 0 && py >= 0 && pixel.In(bounds) {
+				c := color.NRGBAModel.Convert(img.At(pixel.X, pixel.Y)).(color.NRGBA)
+				c.A = 255
+				nd := pointcloud.NewColoredData(c)
+				if d != nil {
+					if d.HasValue() {
+						nd.SetValue(d.Value())
+					}
+					if d.HasNormal() {
+						nd.SetNormal(d.Normal())
+					}
+					nd.SetIntensity(d.Intensity())
+				}
+				d = nd
+			}
+		}
+		err = colored.Set(p, d)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return colored, nil
+}
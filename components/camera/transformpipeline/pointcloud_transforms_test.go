@@ -0,0 +1,147 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	commonpb "go.viam.com/api/common/v1"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/camera/videosource"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+func TestTransformPointCloudSource(t *testing.T) {
+	cam := flatRGBDCamera(t)
+	r := &inject.Robot{}
+	r.TransformPoseFunc = func(
+		ctx context.Context, pose *referenceframe.PoseInFrame, dst string, additionalTransforms []*commonpb.Transform,
+	) (*referenceframe.PoseInFrame, error) {
+		test.That(t, pose.FrameName(), test.ShouldEqual, "source")
+		test.That(t, dst, test.ShouldEqual, "world")
+		return referenceframe.NewPoseInFrame(dst, spatialmath.NewPoseFromPoint(r3.Vector{0, 0, 100})), nil
+	}
+	transformConf := &transformConfig{
+		AttrConfig: &camera.AttrConfig{Stream: "depth"},
+		Source:     "source",
+		Pipeline:   []Transformation{{Type: "transform_pointcloud", Attributes: config.AttributeMap{"frame": "world"}}},
+	}
+	pipe, err := newTransformPipeline(context.Background(), cam, transformConf, r)
+	test.That(t, err, test.ShouldBeNil)
+	pc, err := pipe.NextPointCloud(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pc.Size(), test.ShouldEqual, 100)
+	test.That(t, pc.MetaData().MinZ, test.ShouldEqual, 1100)
+	test.That(t, pipe.Close(context.Background()), test.ShouldBeNil)
+
+	_, err = newTransformPointCloudTransform(context.Background(), cam, r, transformConf, config.AttributeMap{})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+}
+
+func TestGroundPlaneSource(t *testing.T) {
+	cam := flatRGBDCamera(t)
+	// the wall faces the camera, along z
+	ground, err := newGroundPlaneTransform(context.Background(), cam, camera.DepthStream,
+		config.AttributeMap{"ground_normal": map[string]interface{}{"x": 0, "y": 0, "z": 1}})
+	test.That(t, err, test.ShouldBeNil)
+	pc, err := ground.(camera.Camera).NextPointCloud(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pc.Size(), test.ShouldEqual, 0)
+	test.That(t, ground.Close(context.Background()), test.ShouldBeNil)
+
+	// so it is not a floor
+	ground, err = newGroundPlaneTransform(context.Background(), cam, camera.DepthStream,
+		config.AttributeMap{"ground_normal": map[string]interface{}{"x": 0, "y": -1, "z": 0}})
+	test.That(t, err, test.ShouldBeNil)
+	pc, err = ground.(camera.Camera).NextPointCloud(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pc.Size(), test.ShouldEqual, 100)
+	test.That(t, ground.Close(context.Background()), test.ShouldBeNil)
+
+	_, err = newGroundPlaneTransform(context.Background(), cam, camera.DepthStream,
+		config.AttributeMap{"ground_normal": map[string]interface{}{"x": 0, "y": 0, "z": 0}})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = newGroundPlaneTransform(context.Background(), cam, camera.DepthStream, config.AttributeMap{"threshold_mm": -1})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+}
+
+func TestColorizePointCloudSource(t *testing.T) {
+	cam := flatRGBDCamera(t)
+	red := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	for i := 0; i < len(red.Pix); i += 4 {
+		red.Pix[i], red.Pix[i+3] = 255, 255
+	}
+	proj := &transform.PinholeCameraIntrinsics{Width: 10, Height: 10, Fx: 100, Fy: 100, Ppx: 5, Ppy: 5}
+	// the color images are twice the size of the intrinsics
+	colorCam, err := camera.NewFromReader(
+		context.Background(),
+		&videosource.StaticSource{ColorImg: red},
+		&transform.PinholeCameraModel{PinholeCameraIntrinsics: proj},
+		camera.ColorStream,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	r := &inject.Robot{}
+	r.ResourceByNameFunc = func(name resource.Name) (interface{}, error) {
+		test.That(t, name, test.ShouldResemble, camera.Named("color"))
+		return colorCam, nil
+	}
+
+	colorize, err := newColorizePointCloudTransform(context.Background(), cam, r, camera.DepthStream,
+		config.AttributeMap{"color_camera_name": "color"})
+	test.That(t, err, test.ShouldBeNil)
+	pc, err := colorize.(camera.Camera).NextPointCloud(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pc.Size(), test.ShouldEqual, 100)
+	test.That(t, pc.MetaData().HasColor, test.ShouldBeTrue)
+	pc.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+		test.That(t, d.HasColor(), test.ShouldBeTrue)
+		r, g, b := d.RGB255()
+		test.That(t, []uint8{r, g, b}, test.ShouldResemble, []uint8{255, 0, 0})
+		return true
+	})
+	test.That(t, colorize.Close(context.Background()), test.ShouldBeNil)
+
+	_, err = newColorizePointCloudTransform(context.Background(), cam, r, camera.DepthStream, config.AttributeMap{})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+}
+
+func TestColorizePointCloud(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	img.Set(3, 1, color.NRGBA{0, 0, 255, 255})
+	proj := &transform.PinholeCameraIntrinsics{Width: 2, Height: 1, Fx: 1, Fy: 1, Ppx: 1, Ppy: 0.4}
+	pc := pointcloud.New()
+	// projects to the last pixel, scaled by 2
+	test.That(t, pc.Set(r3.Vector{0.36, 0, 1.8}, pointcloud.NewValueData(3)), test.ShouldBeNil)
+	// behind the camera, and outside of the image
+	test.That(t, pc.Set(r3.Vector{0, 0, -1}, pointcloud.NewValueData(4)), test.ShouldBeNil)
+	test.That(t, pc.Set(r3.Vector{-10, 0, 1}, nil), test.ShouldBeNil)
+
+	colored, err := colorizePointCloud(pc, img, proj)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, colored.Size(), test.ShouldEqual, 3)
+	d, got := colored.At(0.36, 0, 1.8)
+	test.That(t, got, test.ShouldBeTrue)
+	test.That(t, d.HasColor(), test.ShouldBeTrue)
+	test.That(t, d.Value(), test.ShouldEqual, 3)
+	_, _, b := d.RGB255()
+	test.That(t, b, test.ShouldEqual, 255)
+	d, _ = colored.At(0, 0, -1)
+	test.That(t, d.HasColor(), test.ShouldBeFalse)
+	test.That(t, d.Value(), test.ShouldEqual, 4)
+
+	_, err = colorizePointCloud(pc, img, &transform.PinholeCameraIntrinsics{})
+	test.That(t, err, test.ShouldNotBeNil)
+}
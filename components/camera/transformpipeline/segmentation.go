@@ -0,0 +1,86 @@
+package transformpipeline
+
+import (
+	"context"
+	"fmt"
+	"image"
+
+	"github.com/edaniels/gostream"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/services/vision"
+	rdkutils "go.viam.com/rdk/utils"
+	"go.viam.com/rdk/vision/segmentation"
+)
+
+// segmentationAttrs is the attribute struct for overlaying the mask of a segmenter (its name as
+// found in the vision service).
+type segmentationAttrs struct {
+	SegmenterName string `json:"segmenter_name"`
+	// Opacity of the mask, in [0, 1]. Defaults to 0.5.
+	Opacity *float64 `json:"opacity"`
+}
+
+// segmentationSource takes an image from the camera, and overlays the per pixel mask the segmenter
+// computes on it.
+type segmentationSource struct {
+	stream        gostream.VideoStream
+	segmenterName string
+	opacity       float64
+	r             robot.Robot
+}
+
+func newSegmentationTransform(
+	ctx context.Context,
+	source gostream.VideoSource, r robot.Robot, am config.AttributeMap,
+) (gostream.VideoSource, error) {
+	conf, err := config.TransformAttributeMapToStruct(&(segmentationAttrs{}), am)
+	if err != nil {
+		return nil, err
+	}
+	attrs, ok := conf.(*segmentationAttrs)
+	if !ok {
+		return nil, rdkutils.NewUnexpectedTypeError(attrs, conf)
+	}
+	opacity := 0.5
+	if attrs.Opacity != nil {
+		opacity = *attrs.Opacity
+	}
+	if opacity < 0 || opacity > 1 {
+		return nil, errors.Errorf("opacity must be between 0 and 1, got %v", opacity)
+	}
+	ss := &segmentationSource{
+		gostream.NewEmbeddedVideoStream(source),
+		attrs.SegmenterName,
+		opacity,
+		r,
+	}
+	return camera.NewFromReader(ctx, ss, nil, camera.ColorStream)
+}
+
+// Read returns the image overlaid with the colors of the classes of its pixels.
+func (ss *segmentationSource) Read(ctx context.Context) (image.Image, func(), error) {
+	ctx, span := trace.StartSpan(ctx, "camera::transformpipeline::segmentation::Read")
+	defer span.End()
+	srv, err := vision.FirstFromRobot(ss.r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("source_segmentation cant find vision service: %w", err)
+	}
+	img, release, err := ss.stream.Next(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get next source image: %w", err)
+	}
+	mask, err := srv.SegmentationMask(ctx, img, ss.segmenterName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get segmentation mask: %w", err)
+	}
+	return segmentation.OverlayMask(img, mask, ss.opacity), release, nil
+}
+
+func (ss *segmentationSource) Close(ctx context.Context) error {
+	return ss.stream.Close(ctx)
+}
@@ -0,0 +1,50 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/edaniels/gostream"
+	"github.com/pion/mediadevices/pkg/prop"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/camera/videosource"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/services/vision"
+	"go.viam.com/rdk/testutils/inject"
+	"go.viam.com/rdk/vision/segmentation"
+)
+
+func TestSegmentationSource(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	visionSvc := &inject.VisionService{}
+	visionSvc.SegmentationMaskFunc = func(ctx context.Context, img image.Image, segmenterName string) (*segmentation.Mask, error) {
+		test.That(t, segmenterName, test.ShouldEqual, "segmenter")
+		// a 2x2 mask whose top left quarter is an object
+		mask := segmentation.NewMask(image.Rect(0, 0, 2, 2), []string{"background", "object"}, 0)
+		mask.Classes.SetGray16(0, 0, color.Gray16{1})
+		return mask, nil
+	}
+	r := &inject.Robot{}
+	r.ResourceNamesFunc = func() []resource.Name { return []resource.Name{vision.Named("vision")} }
+	r.ResourceByNameFunc = func(name resource.Name) (interface{}, error) { return visionSvc, nil }
+
+	source := gostream.NewVideoSource(&videosource.StaticSource{ColorImg: img}, prop.Video{})
+	ss, err := newSegmentationTransform(context.Background(), source, r, config.AttributeMap{"segmenter_name": "segmenter"})
+	test.That(t, err, test.ShouldBeNil)
+	res, _, err := camera.ReadImage(context.Background(), ss)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, res.Bounds(), test.ShouldResemble, img.Bounds())
+	ovImg := rimage.ConvertImage(res)
+	test.That(t, ovImg.GetXY(25, 25), test.ShouldNotResemble, rimage.Black)
+	test.That(t, ovImg.GetXY(75, 75), test.ShouldResemble, rimage.Black)
+	test.That(t, ss.Close(context.Background()), test.ShouldBeNil)
+
+	_, err = newSegmentationTransform(context.Background(), source, r, config.AttributeMap{"opacity": 1.5})
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -0,0 +1,107 @@
+package transformpipeline
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"time"
+
+	"github.com/edaniels/gostream"
+	"go.opencensus.io/trace"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/services/vision"
+	rdkutils "go.viam.com/rdk/utils"
+	"go.viam.com/rdk/vision/objectdetection"
+)
+
+// tracksAttrs is the attribute struct for tracking the detections of a detector (its name as found
+// in the vision service).
+type tracksAttrs struct {
+	DetectorName        string  `json:"detector_name"`
+	ConfidenceThreshold float64 `json:"confidence_threshold"`
+	IoUThreshold        float64 `json:"iou_threshold"`
+	MinHits             int     `json:"min_hits"`
+	MaxLostFrames       int     `json:"max_lost_frames"`
+}
+
+// tracksSource takes an image from the camera, and overlays the tracks of the objects detected by
+// the detector across the images of the camera.
+type tracksSource struct {
+	stream       gostream.VideoStream
+	detectorName string
+	confFilter   objectdetection.Postprocessor
+	tracker      *objectdetection.Tracker
+	r            robot.Robot
+}
+
+func newTracksTransform(
+	ctx context.Context,
+	source gostream.VideoSource, r robot.Robot, am config.AttributeMap,
+) (gostream.VideoSource, error) {
+	conf, err := config.TransformAttributeMapToStruct(&(tracksAttrs{}), am)
+	if err != nil {
+		return nil, err
+	}
+	attrs, ok := conf.(*tracksAttrs)
+	if !ok {
+		return nil, rdkutils.NewUnexpectedTypeError(attrs, conf)
+	}
+	tracker, err := objectdetection.NewTracker(objectdetection.TrackerConfig{
+		IoUThreshold:  attrs.IoUThreshold,
+		MinHits:       attrs.MinHits,
+		MaxLostFrames: attrs.MaxLostFrames,
+	})
+	if err != nil {
+		return nil, err
+	}
+	tracks := &tracksSource{
+		gostream.NewEmbeddedVideoStream(source),
+		attrs.DetectorName,
+		objectdetection.NewScoreFilter(attrs.ConfidenceThreshold),
+		tracker,
+		r,
+	}
+	return camera.NewFromReader(ctx, tracks, nil, camera.ColorStream)
+}
+
+// Read returns the image overlaid with the boxes and ids of the objects tracked in it.
+func (ts *tracksSource) Read(ctx context.Context) (image.Image, func(), error) {
+	ctx, span := trace.StartSpan(ctx, "camera::transformpipeline::tracks::Read")
+	defer span.End()
+	srv, err := vision.FirstFromRobot(ts.r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("source_tracks cant find vision service: %w", err)
+	}
+	img, release, err := ts.stream.Next(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get next source image: %w", err)
+	}
+	captured := time.Now()
+	dets, err := srv.Detections(ctx, img, ts.detectorName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get detections: %w", err)
+	}
+	tracks, _ := ts.tracker.Update(ts.confFilter(dets), captured)
+	toDraw := make([]objectdetection.Detection, 0, len(tracks))
+	for i := range tracks {
+		if tracks[i].State() != objectdetection.TrackTracked {
+			continue
+		}
+		// the estimated box may reach past the edges of the image
+		box := tracks[i].BoundingBox()
+		*box = box.Intersect(img.Bounds())
+		toDraw = append(toDraw, &tracks[i])
+	}
+	res, err := objectdetection.Overlay(img, toDraw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not overlay tracks: %w", err)
+	}
+	return res, release, nil
+}
+
+func (ts *tracksSource) Close(ctx context.Context) error {
+	return ts.stream.Close(ctx)
+}
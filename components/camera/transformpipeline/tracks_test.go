@@ -0,0 +1,54 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"testing"
+
+	"github.com/edaniels/gostream"
+	"github.com/pion/mediadevices/pkg/prop"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/camera/videosource"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/services/vision"
+	"go.viam.com/rdk/testutils/inject"
+	"go.viam.com/rdk/vision/objectdetection"
+)
+
+func TestTracksSource(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 200, 200))
+	var frame int
+	visionSvc := &inject.VisionService{}
+	visionSvc.DetectionsFunc = func(ctx context.Context, img image.Image, detectorName string) ([]objectdetection.Detection, error) {
+		test.That(t, detectorName, test.ShouldEqual, "detector")
+		frame++
+		// an object moving right, and a detection below the confidence threshold
+		return []objectdetection.Detection{
+			objectdetection.NewDetection(image.Rect(10*frame, 50, 10*frame+50, 100), 0.9, "object"),
+			objectdetection.NewDetection(image.Rect(0, 150, 50, 199), 0.1, "object"),
+		}, nil
+	}
+	r := &inject.Robot{}
+	r.ResourceNamesFunc = func() []resource.Name { return []resource.Name{vision.Named("vision")} }
+	r.ResourceByNameFunc = func(name resource.Name) (interface{}, error) { return visionSvc, nil }
+
+	source := gostream.NewVideoSource(&videosource.StaticSource{ColorImg: img}, prop.Video{})
+	am := config.AttributeMap{"detector_name": "detector", "confidence_threshold": 0.5}
+	ts, err := newTracksTransform(context.Background(), source, r, am)
+	test.That(t, err, test.ShouldBeNil)
+	for i := 1; i <= 3; i++ {
+		res, _, err := camera.ReadImage(context.Background(), ts)
+		test.That(t, err, test.ShouldBeNil)
+		ovImg := rimage.ConvertImage(res)
+		test.That(t, ovImg.GetXY(10*i+25, 99), test.ShouldResemble, rimage.Red)
+		test.That(t, ovImg.GetXY(25, 199), test.ShouldResemble, rimage.Black)
+	}
+	test.That(t, ts.Close(context.Background()), test.ShouldBeNil)
+
+	_, err = newTracksTransform(context.Background(), source, r, config.AttributeMap{"iou_threshold": 1.5})
+	test.That(t, err, test.ShouldNotBeNil)
+}
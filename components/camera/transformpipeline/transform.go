@@ -16,18 +16,33 @@ type transformType string
 
 // the allowed transforms.
 const (
-	transformTypeUnspecified     = transformType("")
-	transformTypeIdentity        = transformType("identity")
-	transformTypeRotate          = transformType("rotate")
-	transformTypeResize          = transformType("resize")
-	transformTypeDepthPretty     = transformType("depth_to_pretty")
-	transformTypeOverlay         = transformType("overlay")
-	transformTypeUndistort       = transformType("undistort")
-	transformTypeDetections      = transformType("detections")
-	transformTypeDepthEdges      = transformType("depth_edges")
-	transformTypeDepthPreprocess = transformType("depth_preprocess")
+	transformTypeUnspecified         = transformType("")
+	transformTypeIdentity            = transformType("identity")
+	transformTypeRotate              = transformType("rotate")
+	transformTypeResize              = transformType("resize")
+	transformTypeDepthPretty         = transformType("depth_to_pretty")
+	transformTypeOverlay             = transformType("overlay")
+	transformTypeUndistort           = transformType("undistort")
+	transformTypeDetections          = transformType("detections")
+	transformTypeTracks              = transformType("tracks")
+	transformTypeDepthEdges          = transformType("depth_edges")
+	transformTypeDepthPreprocess     = transformType("depth_preprocess")
+	transformTypeSegmentation        = transformType("segmentation")
+	transformTypePointCloudFilter    = transformType("pointcloud_filter")
+	transformTypeTransformPointCloud = transformType("transform_pointcloud")
+	transformTypeRemoveGroundPlane   = transformType("remove_ground_plane")
+	transformTypeColorizePointCloud  = transformType("colorize_pointcloud")
 )
 
+// pointCloudTransforms are the transforms outputting point clouds. A pipeline ending with one of
+// them returns its point clouds, rather than projecting its images to point clouds.
+var pointCloudTransforms = map[transformType]bool{
+	transformTypePointCloudFilter:    true,
+	transformTypeTransformPointCloud: true,
+	transformTypeRemoveGroundPlane:   true,
+	transformTypeColorizePointCloud:  true,
+}
+
 // Transformation states the type of transformation and the attributes that are specific to the given type.
 type Transformation struct {
 	Type       string              `json:"type"`
@@ -54,10 +69,22 @@ func buildTransform(
 		return newUndistortTransform(ctx, source, stream, tr.Attributes)
 	case transformTypeDetections:
 		return newDetectionsTransform(ctx, source, r, tr.Attributes)
+	case transformTypeTracks:
+		return newTracksTransform(ctx, source, r, tr.Attributes)
 	case transformTypeDepthEdges:
 		return newDepthEdgesTransform(ctx, source, tr.Attributes)
 	case transformTypeDepthPreprocess:
 		return newDepthPreprocessTransform(ctx, source)
+	case transformTypeSegmentation:
+		return newSegmentationTransform(ctx, source, r, tr.Attributes)
+	case transformTypePointCloudFilter:
+		return newPointCloudFilterTransform(ctx, source, stream, tr.Attributes)
+	case transformTypeTransformPointCloud:
+		return newTransformPointCloudTransform(ctx, source, r, cfg, tr.Attributes)
+	case transformTypeRemoveGroundPlane:
+		return newGroundPlaneTransform(ctx, source, stream, tr.Attributes)
+	case transformTypeColorizePointCloud:
+		return newColorizePointCloudTransform(ctx, source, r, stream, tr.Attributes)
 	default:
 		return nil, errors.Errorf("do not know camera transform of type %q", tr.Type)
 	}
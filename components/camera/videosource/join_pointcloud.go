@@ -2,10 +2,12 @@ package videosource
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"image"
 	"math"
 	"strings"
+	"sync"
 
 	"github.com/edaniels/golog"
 	"github.com/golang/geo/r3"
@@ -68,6 +70,8 @@ type JoinAttrs struct {
 	MergeMethod   string   `json:"merge_method"`
 	// Closeness defines how close 2 points should be together to be considered the same point when merged.
 	Closeness float64 `json:"closeness_mm"`
+	// Registration configures the global registration of the source cameras to the target camera.
+	Registration *pointcloud.RegistrationConfig `json:"registration"`
 }
 
 type (
@@ -84,6 +88,9 @@ const (
 	Naive = MergeMethodType("naive")
 	// ICP is the ICP merge method.
 	ICP = MergeMethodType("icp")
+	// Global is the merge method that registers the point clouds to each other without using the frame system.
+	// The poses it finds are kept by the camera only, and found again once it is rebuilt.
+	Global = MergeMethodType("global")
 )
 
 func newMergeMethodUnsupportedError(method string) MergeMethodUnsupportedError {
@@ -104,6 +111,12 @@ type joinPointCloudSource struct {
 	logger        golog.Logger
 	debug         bool
 	closeness     float64
+	registration  pointcloud.RegistrationConfig
+
+	mu              sync.Mutex
+	registeredPoses map[string]spatialmath.Pose
+	// registerMu serializes the registrations of the source cameras
+	registerMu sync.Mutex
 }
 
 // newJoinPointCloudSource creates a camera that combines point cloud sources into one point cloud in the
@@ -131,6 +144,18 @@ func newJoinPointCloudSource(ctx context.Context, r robot.Robot, l golog.Logger,
 	joinSource.debug = attrs.Debug
 
 	joinSource.mergeMethod = MergeMethodType(attrs.MergeMethod)
+	if joinSource.mergeMethod == Global {
+		if _, ok := contains(joinSource.sourceNames, joinSource.targetName); !ok {
+			return nil, errors.Errorf("target frame %q must be one of the source cameras to register them", joinSource.targetName)
+		}
+		if attrs.Registration == nil {
+			return nil, errors.New("registration must be configured to register the source cameras")
+		}
+		if err := attrs.Registration.Validate(); err != nil {
+			return nil, err
+		}
+		joinSource.registration = *attrs.Registration
+	}
 
 	if idx, ok := contains(joinSource.sourceNames, joinSource.targetName); ok {
 		parentCamera := joinSource.sourceCameras[idx]
@@ -156,6 +181,8 @@ func (jpcs *joinPointCloudSource) NextPointCloud(ctx context.Context) (pointclou
 		return jpcs.NextPointCloudNaive(ctx)
 	case ICP:
 		return jpcs.NextPointCloudICP(ctx)
+	case Global:
+		return jpcs.NextPointCloudGlobal(ctx)
 	default:
 		return nil, newMergeMethodUnsupportedError(string(jpcs.mergeMethod))
 	}
@@ -284,6 +311,205 @@ func (jpcs *joinPointCloudSource) NextPointCloudICP(ctx context.Context) (pointc
 	return finalPointCloud, nil
 }
 
+// NextPointCloudGlobal merges the point clouds of the source cameras with the poses found by registering
+// them to the target camera the first time, instead of the poses from the frame system.
+func (jpcs *joinPointCloudSource) NextPointCloudGlobal(ctx context.Context) (pointcloud.PointCloud, error) {
+	ctx, span := trace.StartSpan(ctx, "joinPointCloudSource::NextPointCloudGlobal")
+	defer span.End()
+
+	jpcs.mu.Lock()
+	poses := jpcs.registeredPoses
+	jpcs.mu.Unlock()
+	if poses == nil {
+		var err error
+		if poses, err = jpcs.registerCameras(ctx, false); err != nil {
+			return nil, err
+		}
+	}
+
+	cloudFuncs := make([]pointcloud.CloudAndOffsetFunc, len(jpcs.sourceCameras))
+	for i, cam := range jpcs.sourceCameras {
+		name := jpcs.sourceNames[i]
+		camCopy := cam
+		cloudFuncs[i] = func(ctx context.Context) (pointcloud.PointCloud, spatialmath.Pose, error) {
+			pc, err := camCopy.NextPointCloud(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			if pc == nil {
+				return nil, nil, errors.Errorf("camera %q returned a nil point cloud", name)
+			}
+			return pc, poses[name], nil
+		}
+	}
+	return pointcloud.MergePointClouds(ctx, cloudFuncs, jpcs.logger)
+}
+
+// registerCameras finds the pose of each source camera in the frame of the target camera by registering
+// their point clouds to the one of the target camera, and keeps them to merge the next point clouds.
+// Registrations run one at a time, and unless again is true, the poses found by a registration that
+// ran meanwhile are returned instead of registering the cameras again.
+func (jpcs *joinPointCloudSource) registerCameras(ctx context.Context, again bool) (map[string]spatialmath.Pose, error) {
+	ctx, span := trace.StartSpan(ctx, "joinPointCloudSource::registerCameras")
+	defer span.End()
+
+	jpcs.registerMu.Lock()
+	defer jpcs.registerMu.Unlock()
+	if !again {
+		jpcs.mu.Lock()
+		poses := jpcs.registeredPoses
+		jpcs.mu.Unlock()
+		if poses != nil {
+			return poses, nil
+		}
+	}
+
+	targetIndex, _ := contains(jpcs.sourceNames, jpcs.targetName)
+	target, err := jpcs.sourceCameras[targetIndex].NextPointCloud(ctx)
+	if err != nil {
+		return nil, err
+	}
+	poses := map[string]spatialmath.Pose{jpcs.targetName: spatialmath.NewZeroPose()}
+	for i, cam := range jpcs.sourceCameras {
+		if i == targetIndex {
+			continue
+		}
+		source, err := cam.NextPointCloud(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result, err := pointcloud.RegisterPointCloudGlobal(source, target, jpcs.registration)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not register camera %q to %q", jpcs.sourceNames[i], jpcs.targetName)
+		}
+		if jpcs.debug {
+			jpcs.logger.Debugf("registered %q to %q with fitness %f and RMSE %f",
+				jpcs.sourceNames[i], jpcs.targetName, result.Fitness, result.RMSE)
+		}
+		poses[jpcs.sourceNames[i]] = result.Pose
+	}
+
+	jpcs.mu.Lock()
+	jpcs.registeredPoses = poses
+	jpcs.mu.Unlock()
+	return poses, nil
+}
+
+// DoCommand registers the source cameras again when given {"command": "register"}, and returns the frames
+// of the source cameras relative to the target camera as they would be written in the robot config.
+// The merged point clouds use the new poses right away. With "apply": true, the frames are also applied
+// to the source cameras in the config of the robot, which reconfigures itself so that the frame system
+// and the other merge methods use them. They are not persisted: they are lost when the robot restarts or
+// gets a new config, unless copied into the config it reads.
+func (jpcs *joinPointCloudSource) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	name, ok := cmd["command"]
+	if !ok {
+		return nil, errors.New("missing 'command' value")
+	}
+	switch name {
+	case "register":
+		if jpcs.mergeMethod != Global {
+			return nil, errors.Errorf("merge method must be %q to register the source cameras", Global)
+		}
+		poses, err := jpcs.registerCameras(ctx, true)
+		if err != nil {
+			return nil, err
+		}
+		frames := map[string]interface{}{}
+		for camName, pose := range poses {
+			if camName == jpcs.targetName {
+				continue
+			}
+			frame, err := frameConfig(jpcs.targetName, pose)
+			if err != nil {
+				return nil, err
+			}
+			frames[camName] = frame
+		}
+		resp := map[string]interface{}{"frames": frames}
+		if apply, _ := cmd["apply"].(bool); apply {
+			if err := jpcs.applyFrames(ctx, poses); err != nil {
+				return nil, err
+			}
+			resp["applied"] = true
+		}
+		return resp, nil
+	default:
+		return nil, fmt.Errorf("no such command: %s", name)
+	}
+}
+
+// applyFrames reconfigures the robot with the source cameras in the frames of the given poses relative to
+// the target camera.
+func (jpcs *joinPointCloudSource) applyFrames(ctx context.Context, poses map[string]spatialmath.Pose) error {
+	lr, ok := jpcs.robot.(robot.LocalRobot)
+	if !ok {
+		return errors.New("the frames can only be applied on the robot the camera is configured on")
+	}
+	cfg, err := lr.Config(ctx)
+	if err != nil {
+		return err
+	}
+	newCfg := *cfg
+	newCfg.Components = append([]config.Component{}, cfg.Components...)
+	applied := map[string]*config.Frame{}
+	for camName, pose := range poses {
+		if camName == jpcs.targetName {
+			continue
+		}
+		idx := -1
+		for i, c := range newCfg.Components {
+			if c.Name == camName {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return errors.Errorf("camera %q is not configured on the robot, its frame cannot be applied", camName)
+		}
+		frame := &config.Frame{
+			Parent:      jpcs.targetName,
+			Translation: pose.Point(),
+			Orientation: pose.Orientation().OrientationVectorDegrees(),
+		}
+		newCfg.Components[idx].Frame = frame
+		applied[camName] = frame
+	}
+	lr.Reconfigure(ctx, &newCfg)
+
+	// the robot keeps its config if it fails to reconfigure
+	cfg, err = lr.Config(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range cfg.Components {
+		if frame, ok := applied[c.Name]; ok && c.Frame != frame {
+			return errors.Errorf("the robot could not be reconfigured with the frame of camera %q", c.Name)
+		}
+	}
+	return nil
+}
+
+// frameConfig returns the frame config of the pose in the parent frame, as it is in the robot config.
+func frameConfig(parent string, pose spatialmath.Pose) (map[string]interface{}, error) {
+	frame := &config.Frame{
+		Parent:      parent,
+		Translation: pose.Point(),
+		Orientation: pose.Orientation().OrientationVectorDegrees(),
+	}
+	data, err := json.Marshal(frame)
+	if err != nil {
+		return nil, err
+	}
+	var frameMap map[string]interface{}
+	if err := json.Unmarshal(data, &frameMap); err != nil {
+		return nil, err
+	}
+	// the frame of a camera has no geometry
+	delete(frameMap, "geometry")
+	return frameMap, nil
+}
+
 // initalizeInputs gets all the input positions for the robot components in order to calculate the frame system offsets.
 func (jpcs *joinPointCloudSource) initializeInputs(
 	ctx context.Context,
@@ -2,9 +2,12 @@ package videosource
 
 import (
 	"context"
+	"encoding/json"
 	"image"
 	"image/color"
 	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -464,3 +467,170 @@ func TestMultiPointCloudICP(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, pc, test.ShouldNotBeNil)
 }
+
+// makeRoomCorner returns the points 20mm apart of the corner of a room seen from a camera at the pose,
+// made of a floor and two walls of different sizes with a box on the floor.
+func makeRoomCorner(t *testing.T, pose spatialmath.Pose) pointcloud.PointCloud {
+	t.Helper()
+	pc := pointcloud.New()
+	inverse := spatialmath.PoseInverse(pose)
+	set := func(x, y, z float64) {
+		p := spatialmath.Compose(inverse, spatialmath.NewPoseFromPoint(r3.Vector{x, y, z})).Point()
+		test.That(t, pc.Set(p, nil), test.ShouldBeNil)
+	}
+	for x := 0.; x <= 1000; x += 20 {
+		for y := 0.; y <= 600; y += 20 {
+			if x < 600 || x > 800 || y < 200 || y > 400 {
+				set(x, y, 0)
+			}
+		}
+		for z := 20.; z <= 300; z += 20 {
+			set(x, 0, z)
+		}
+	}
+	for y := 20.; y <= 600; y += 20 {
+		for z := 20.; z <= 400; z += 20 {
+			set(0, y, z)
+		}
+	}
+	for u := 0.; u <= 200; u += 20 {
+		for v := 0.; v <= 200; v += 20 {
+			set(600+u, 200+v, 200)
+			set(800, 200+u, v)
+			set(600+u, 400, v)
+		}
+	}
+	return pc
+}
+
+func TestJoinPointCloudGlobal(t *testing.T) {
+	pose1 := spatialmath.NewPoseFromOrientation(r3.Vector{1000, 800, 600}, &spatialmath.OrientationVectorDegrees{OX: -1, OY: -1, OZ: -1})
+	pose2 := spatialmath.NewPoseFromOrientation(
+		r3.Vector{700, 1100, 900},
+		&spatialmath.OrientationVectorDegrees{OX: -0.5, OY: -1, OZ: -1, Theta: 30},
+	)
+	cloud1 := makeRoomCorner(t, pose1)
+	cloud2 := makeRoomCorner(t, pose2)
+	cams := map[string]*inject.Camera{}
+	var cam2Reads atomic.Int32
+	for name, cloud := range map[string]pointcloud.PointCloud{"cam1": cloud1, "cam2": cloud2} {
+		cloudCopy := cloud
+		nameCopy := name
+		cam := &inject.Camera{}
+		cam.NextPointCloudFunc = func(ctx context.Context) (pointcloud.PointCloud, error) {
+			if nameCopy == "cam2" {
+				cam2Reads.Add(1)
+			}
+			return cloudCopy, nil
+		}
+		cam.PropertiesFunc = func(ctx context.Context) (camera.Properties, error) {
+			return camera.Properties{}, nil
+		}
+		cams[name] = cam
+	}
+	r := &inject.Robot{}
+	r.ResourceByNameFunc = func(n resource.Name) (interface{}, error) {
+		if cam, ok := cams[n.Name]; ok {
+			return cam, nil
+		}
+		return nil, rdkutils.NewResourceNotFoundError(n)
+	}
+
+	attrs := &JoinAttrs{
+		AttrConfig:    &camera.AttrConfig{Debug: true},
+		SourceCameras: []string{"cam1", "cam2"},
+		TargetFrame:   "cam1",
+		MergeMethod:   "global",
+		Registration:  &pointcloud.RegistrationConfig{VoxelSize: 20, Levels: 2},
+	}
+	joinedCam, err := newJoinPointCloudSource(context.Background(), r, golog.NewTestLogger(t), attrs)
+	test.That(t, err, test.ShouldBeNil)
+	// concurrent first callers wait for a single registration
+	const callers = 3
+	clouds := make([]pointcloud.PointCloud, callers)
+	errs := make([]error, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clouds[i], errs[i] = joinedCam.NextPointCloud(context.Background())
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		test.That(t, err, test.ShouldBeNil)
+	}
+	test.That(t, cam2Reads.Load(), test.ShouldEqual, 1+callers)
+	pc := clouds[0]
+	test.That(t, pc.Size(), test.ShouldEqual, cloud1.Size()+cloud2.Size())
+	// the points of cam2 are moved onto the ones of cam1
+	kd := pointcloud.ToKDTree(cloud1)
+	pc.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+		_, _, dist, _ := kd.NearestNeighbor(p)
+		test.That(t, dist, test.ShouldBeLessThan, 10)
+		return true
+	})
+
+	resp, err := joinedCam.DoCommand(context.Background(), map[string]interface{}{"command": "register"})
+	test.That(t, err, test.ShouldBeNil)
+	frames, ok := resp["frames"].(map[string]interface{})
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, frames, test.ShouldHaveLength, 1)
+	frame, ok := frames["cam2"].(map[string]interface{})
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, frame["parent"], test.ShouldEqual, "cam1")
+	data, err := json.Marshal(frame)
+	test.That(t, err, test.ShouldBeNil)
+	var parsed config.Frame
+	test.That(t, json.Unmarshal(data, &parsed), test.ShouldBeNil)
+	expected := spatialmath.Compose(spatialmath.PoseInverse(pose1), pose2)
+	test.That(t, parsed.Translation.Distance(expected.Point()), test.ShouldBeLessThan, 10)
+	test.That(t, spatialmath.OrientationAlmostEqual(parsed.Orientation, expected.Orientation()), test.ShouldBeTrue)
+
+	// the frames are applied to the config of the robot
+	robotCfg := &config.Config{Components: []config.Component{
+		{Name: "cam1", Type: camera.SubtypeName},
+		{Name: "cam2", Type: camera.SubtypeName, Frame: &config.Frame{Parent: "world"}},
+	}}
+	r.ConfigFunc = func(ctx context.Context) (*config.Config, error) {
+		return robotCfg, nil
+	}
+	var reconfigured *config.Config
+	r.ReconfigureFunc = func(ctx context.Context, newConfig *config.Config) {
+		reconfigured = newConfig
+	}
+	_, err = joinedCam.DoCommand(context.Background(), map[string]interface{}{"command": "register", "apply": true})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "could not be reconfigured")
+	test.That(t, reconfigured.Components[0].Frame, test.ShouldBeNil)
+	test.That(t, robotCfg.Components[1].Frame.Parent, test.ShouldEqual, "world")
+
+	r.ReconfigureFunc = func(ctx context.Context, newConfig *config.Config) {
+		reconfigured = newConfig
+		robotCfg = newConfig
+	}
+	resp, err = joinedCam.DoCommand(context.Background(), map[string]interface{}{"command": "register", "apply": true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["applied"], test.ShouldBeTrue)
+	applied := reconfigured.Components[1].Frame
+	test.That(t, applied.Parent, test.ShouldEqual, "cam1")
+	test.That(t, applied.Translation.Distance(expected.Point()), test.ShouldBeLessThan, 10)
+	test.That(t, spatialmath.OrientationAlmostEqual(applied.Orientation, expected.Orientation()), test.ShouldBeTrue)
+
+	r.ConfigFunc = func(ctx context.Context) (*config.Config, error) {
+		return &config.Config{Components: []config.Component{{Name: "cam1", Type: camera.SubtypeName}}}, nil
+	}
+	_, err = joinedCam.DoCommand(context.Background(), map[string]interface{}{"command": "register", "apply": true})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "not configured on the robot")
+	test.That(t, joinedCam.Close(context.Background()), test.ShouldBeNil)
+
+	attrs.Registration = nil
+	_, err = newJoinPointCloudSource(context.Background(), r, golog.NewTestLogger(t), attrs)
+	test.That(t, err, test.ShouldNotBeNil)
+	attrs.Registration = &pointcloud.RegistrationConfig{VoxelSize: 20}
+	attrs.TargetFrame = "world"
+	_, err = newJoinPointCloudSource(context.Background(), r, golog.NewTestLogger(t), attrs)
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -11,6 +11,7 @@ import (
 	"go.viam.com/utils/rpc"
 
 	"go.viam.com/rdk/components/generic"
+	"go.viam.com/rdk/components/motor"
 	"go.viam.com/rdk/data"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/registry"
@@ -85,6 +86,20 @@ type Gantry interface {
 	referenceframe.InputEnabled
 }
 
+// A MotorGantry is a gantry of a single axis moved by one motor. Gantries composing several of them,
+// like the multi-axis gantry, can then move their motors together as a motor.Group.
+type MotorGantry interface {
+	Gantry
+
+	// Motor returns the motor moving the gantry.
+	Motor() motor.Motor
+
+	// MotorTarget returns the rpm at which, and the position in revolutions to which, the motor is to
+	// move for the gantry to reach the given position in millimeters. It errors if the gantry cannot
+	// get there by a plain move of its motor.
+	MotorTarget(ctx context.Context, positionMm float64) (rpm, revolutions float64, err error)
+}
+
 // FromDependencies is a helper for getting the named gantry from a collection of
 // dependencies.
 func FromDependencies(deps registry.Dependencies, name string) (Gantry, error) {
@@ -12,6 +12,7 @@ import (
 
 	"go.viam.com/rdk/components/gantry"
 	"go.viam.com/rdk/components/generic"
+	"go.viam.com/rdk/components/motor"
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/referenceframe"
@@ -24,6 +25,10 @@ const modelname = "multiaxis"
 // AttrConfig is used for converting multiAxis config attributes.
 type AttrConfig struct {
 	SubAxes []string `json:"subaxes_list"`
+	// SynchronizeAxes makes the moves of the gantry start and finish all axes together (see
+	// motor.Group) rather than move one axis after the other. Every subaxis must then be moved by
+	// a single motor, like the one-axis gantry.
+	SynchronizeAxes bool `json:"synchronize_axes,omitempty"`
 }
 
 type multiAxis struct {
@@ -31,6 +36,10 @@ type multiAxis struct {
 	name      string
 	subAxes   []gantry.Gantry
 	lengthsMm []float64
+	// motorAxes and group move the subaxes together when they are to be synchronized, and are nil
+	// otherwise.
+	motorAxes []gantry.MotorGantry
+	group     *motor.Group
 	logger    golog.Logger
 	model     referenceframe.Model
 	opMgr     operation.SingleOperationManager
@@ -95,6 +104,21 @@ func newMultiAxis(
 		return nil, err
 	}
 
+	if conf.SynchronizeAxes {
+		motors := make([]motor.Motor, 0, len(mAx.subAxes))
+		for i, subAx := range mAx.subAxes {
+			motorAx, ok := rdkutils.UnwrapProxy(subAx).(gantry.MotorGantry)
+			if !ok {
+				return nil, errors.Errorf("cannot synchronize axis [%s] as it is not moved by a single motor", conf.SubAxes[i])
+			}
+			mAx.motorAxes = append(mAx.motorAxes, motorAx)
+			motors = append(motors, motorAx.Motor())
+		}
+		if mAx.group, err = motor.NewGroup(motors, logger); err != nil {
+			return nil, err
+		}
+	}
+
 	return mAx, nil
 }
 
@@ -111,6 +135,9 @@ func (g *multiAxis) MoveToPosition(
 	if len(positions) == 0 {
 		return errors.Errorf("need position inputs for %v-axis gantry, have %v positions", len(g.subAxes), len(positions))
 	}
+	if moved, err := g.moveSynchronized(ctx, positions, extra); moved {
+		return err
+	}
 
 	idx := 0
 	for _, subAx := range g.subAxes {
@@ -136,6 +163,10 @@ func (g *multiAxis) GoToInputs(ctx context.Context, goal []referenceframe.Input)
 	ctx, done := g.opMgr.New(ctx)
 	defer done()
 
+	if moved, err := g.moveSynchronized(ctx, referenceframe.InputsToFloats(goal), nil); moved {
+		return err
+	}
+
 	idx := 0
 	for _, subAx := range g.subAxes {
 		subAxNum, err := subAx.Lengths(ctx, nil)
@@ -152,6 +183,31 @@ func (g *multiAxis) GoToInputs(ctx context.Context, goal []referenceframe.Input)
 	return nil
 }
 
+// moveSynchronized moves the motors of all subaxes together to the given positions, when the axes
+// are to be synchronized. It returns false, without moving, if they are not or if one of them cannot
+// get to its position by a plain move of its motor, e.g. because a limit switch is hit, in which case
+// the axes are to be moved one after the other.
+func (g *multiAxis) moveSynchronized(ctx context.Context, positions []float64, extra map[string]interface{}) (bool, error) {
+	if g.group == nil {
+		return false, nil
+	}
+	if len(positions) != len(g.motorAxes) {
+		return true, errors.Errorf("need %v positions for %v-axis gantry, have %v", len(g.motorAxes), len(g.motorAxes), len(positions))
+	}
+	rpms := make([]float64, 0, len(g.motorAxes))
+	targets := make([]float64, 0, len(g.motorAxes))
+	for i, motorAx := range g.motorAxes {
+		rpm, target, err := motorAx.MotorTarget(ctx, positions[i])
+		if err != nil {
+			g.logger.Debugw("moving the axes one after the other", "axis", i, "error", err)
+			return false, nil
+		}
+		rpms = append(rpms, rpm)
+		targets = append(targets, target)
+	}
+	return true, g.group.GoTo(ctx, rpms, targets, extra)
+}
+
 // Position returns the position in millimeters.
 func (g *multiAxis) Position(ctx context.Context, extra map[string]interface{}) ([]float64, error) {
 	positions := []float64{}
@@ -2,12 +2,14 @@ package multiaxis
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/edaniels/golog"
 	commonpb "go.viam.com/api/common/v1"
 	"go.viam.com/test"
 
+	fakeencoder "go.viam.com/rdk/components/encoder/fake"
 	"go.viam.com/rdk/components/gantry"
 	"go.viam.com/rdk/components/motor"
 	fm "go.viam.com/rdk/components/motor/fake"
@@ -219,3 +221,80 @@ func TestModelFrame(t *testing.T) {
 	model = fakemultiaxis.ModelFrame()
 	test.That(t, model, test.ShouldNotBeNil)
 }
+
+// motorAxis is a one-axis gantry moved by a fake motor, at 10 mm per revolution. The fake encoder
+// only updates every 100ms, so the motor overshoots by up to 0.1 revolutions.
+type motorAxis struct {
+	*inject.Gantry
+	motor   *fm.Motor
+	atLimit bool
+	moved   []float64
+}
+
+func newMotorAxis(ctx context.Context, logger golog.Logger) *motorAxis {
+	m := &fm.Motor{
+		Encoder:           &fakeencoder.Encoder{},
+		Logger:            logger,
+		PositionReporting: true,
+		MaxRPM:            60,
+		TicksPerRotation:  1000,
+	}
+	m.Encoder.Start(ctx)
+	ax := &motorAxis{Gantry: createFakeOneaAxis(100, []float64{0}), motor: m}
+	ax.MoveToPositionFunc = func(ctx context.Context, positions []float64, worldState *commonpb.WorldState, extra map[string]interface{}) error {
+		ax.moved = positions
+		return nil
+	}
+	return ax
+}
+
+func (ax *motorAxis) Motor() motor.Motor {
+	return ax.motor
+}
+
+func (ax *motorAxis) MotorTarget(ctx context.Context, positionMm float64) (float64, float64, error) {
+	if ax.atLimit {
+		return 0, 0, errors.New("at a limit switch")
+	}
+	return 60, positionMm / 10, nil
+}
+
+func TestSynchronizedMoves(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+
+	ax1, ax2 := newMotorAxis(ctx, logger), newMotorAxis(ctx, logger)
+	deps := registry.Dependencies{gantry.Named("1"): ax1, gantry.Named("2"): ax2}
+	cfg := config.Component{
+		Name:                "gantry",
+		ConvertedAttributes: &AttrConfig{SubAxes: []string{"1", "2"}, SynchronizeAxes: true},
+	}
+	g, err := newMultiAxis(ctx, deps, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	err = g.MoveToPosition(ctx, []float64{10}, &commonpb.WorldState{}, nil)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	err = g.MoveToPosition(ctx, []float64{2, 4}, &commonpb.WorldState{}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	pos1, err := ax1.motor.Position(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pos1, test.ShouldAlmostEqual, 0.2, 0.1)
+	pos2, err := ax2.motor.Position(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pos2, test.ShouldAlmostEqual, 0.4, 0.1)
+	test.That(t, ax1.moved, test.ShouldBeNil)
+
+	// an axis at a limit switch has the axes moved one after the other
+	ax2.atLimit = true
+	err = g.MoveToPosition(ctx, []float64{30, 40}, &commonpb.WorldState{}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, ax1.moved, test.ShouldNotBeNil)
+
+	t.Run("subaxis without a single motor", func(t *testing.T) {
+		deps := registry.Dependencies{gantry.Named("1"): ax1, gantry.Named("2"): createFakeOneaAxis(5, []float64{1})}
+		_, err := newMultiAxis(ctx, deps, cfg, logger)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "cannot synchronize axis [2]")
+	})
+}
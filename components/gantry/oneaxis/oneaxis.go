@@ -132,6 +132,8 @@ type oneAxis struct {
 	opMgr  operation.SingleOperationManager
 }
 
+var _ = gantry.MotorGantry(&oneAxis{})
+
 type limitType string
 
 const (
@@ -436,6 +438,29 @@ func (g *oneAxis) MoveToPosition(
 }
 
 // Stop stops the motor of the gantry.
+// Motor returns the motor moving the gantry.
+func (g *oneAxis) Motor() motor.Motor {
+	return g.motor
+}
+
+// MotorTarget returns the rpm and the position of the motor for the gantry to reach positionMm. It
+// errors when a limit switch is hit, since moving off a limit switch is left to MoveToPosition.
+func (g *oneAxis) MotorTarget(ctx context.Context, positionMm float64) (float64, float64, error) {
+	if positionMm < 0 || positionMm > g.lengthMm {
+		return 0, 0, fmt.Errorf("oneAxis gantry position out of range, got %.02f max is %.02f", positionMm, g.lengthMm)
+	}
+	for i := range g.limitSwitchPins {
+		hit, err := g.limitHit(ctx, i == 0)
+		if err != nil {
+			return 0, 0, err
+		}
+		if hit {
+			return 0, 0, errors.New("oneAxis gantry is at a limit switch")
+		}
+	}
+	return g.rpm, g.rotationalToLinear(positionMm), nil
+}
+
 func (g *oneAxis) Stop(ctx context.Context, extra map[string]interface{}) error {
 	ctx, done := g.opMgr.New(ctx)
 	defer done()
@@ -682,6 +682,43 @@ func TestMoveToPosition(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 }
 
+func TestMotorTarget(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	injectGPIOPin := &inject.GPIOPin{}
+	injectGPIOPin.GetFunc = func(ctx context.Context, extra map[string]interface{}) (bool, error) {
+		return false, nil
+	}
+	fakegantry := &oneAxis{
+		logger:          logger,
+		board:           &inject.Board{GPIOPinByNameFunc: func(pin string) (board.GPIOPin, error) { return injectGPIOPin, nil }},
+		motor:           createFakeMotor(),
+		limitHigh:       true,
+		limitSwitchPins: []string{"1", "2"},
+		lengthMm:        4,
+		positionLimits:  []float64{1, 9},
+		rpm:             300,
+	}
+	test.That(t, fakegantry.Motor(), test.ShouldEqual, fakegantry.motor)
+
+	rpm, revolutions, err := fakegantry.MotorTarget(ctx, 1)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rpm, test.ShouldEqual, 300)
+	test.That(t, revolutions, test.ShouldEqual, 3)
+
+	_, _, err = fakegantry.MotorTarget(ctx, 5)
+	test.That(t, err.Error(), test.ShouldEqual, "oneAxis gantry position out of range, got 5.00 max is 4.00")
+
+	// moving off a limit switch is left to MoveToPosition
+	fakegantry.limitSwitchPins = []string{"1"}
+	injectGPIOPin.GetFunc = func(ctx context.Context, extra map[string]interface{}) (bool, error) {
+		return true, nil
+	}
+	_, _, err = fakegantry.MotorTarget(ctx, 1)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "limit switch")
+}
+
 func TestModelFrame(t *testing.T) {
 	fakegantry := &oneAxis{
 		name:     "test",
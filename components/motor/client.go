@@ -3,31 +3,40 @@ package motor
 
 import (
 	"context"
+	"sync"
 
 	"github.com/edaniels/golog"
 	pb "go.viam.com/api/component/motor/v1"
 	"go.viam.com/utils/protoutils"
 	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.viam.com/rdk/components/generic"
+	motorpb "go.viam.com/rdk/proto/api/component/motor/v1"
 )
 
 // client implements MotorServiceClient.
 type client struct {
-	name   string
-	conn   rpc.ClientConn
-	client pb.MotorServiceClient
-	logger golog.Logger
+	name           string
+	conn           rpc.ClientConn
+	client         pb.MotorServiceClient
+	extendedClient motorpb.ExtendedMotorServiceClient
+	logger         golog.Logger
+
+	mu                sync.Mutex
+	noExtendedService bool
 }
 
 // NewClientFromConn constructs a new Client from connection passed in.
 func NewClientFromConn(ctx context.Context, conn rpc.ClientConn, name string, logger golog.Logger) Motor {
 	c := pb.NewMotorServiceClient(conn)
 	return &client{
-		name:   name,
-		conn:   conn,
-		client: c,
-		logger: logger,
+		name:           name,
+		conn:           conn,
+		client:         c,
+		extendedClient: motorpb.NewExtendedMotorServiceClient(conn),
+		logger:         logger,
 	}
 }
 
@@ -107,6 +116,20 @@ func (c *client) Properties(ctx context.Context, extra map[string]interface{}) (
 	if err != nil {
 		return nil, err
 	}
+	if c.hasExtendedService() {
+		req := &motorpb.GetPropertiesRequest{Name: c.name, Extra: ext}
+		resp, err := c.extendedClient.GetProperties(ctx, req)
+		if err == nil {
+			return ExtendedProtoFeaturesToMap(resp), nil
+		}
+		if status.Code(err) != codes.Unimplemented {
+			return nil, err
+		}
+		c.logger.Debugw("server has no extended motor service, telemetry is not reported", "name", c.name)
+		c.mu.Lock()
+		c.noExtendedService = true
+		c.mu.Unlock()
+	}
 	req := &pb.GetPropertiesRequest{Name: c.name, Extra: ext}
 	resp, err := c.client.GetProperties(ctx, req)
 	if err != nil {
@@ -115,6 +138,27 @@ func (c *client) Properties(ctx context.Context, extra map[string]interface{}) (
 	return ProtoFeaturesToMap(resp), nil
 }
 
+// hasExtendedService returns false once the server turned out not to serve the extended motor
+// service, in which case the properties are asked of MotorService, without the telemetry features.
+func (c *client) hasExtendedService() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !c.noExtendedService
+}
+
+func (c *client) Telemetry(ctx context.Context, extra map[string]interface{}) (Telemetry, error) {
+	ext, err := protoutils.StructToStructPb(extra)
+	if err != nil {
+		return Telemetry{}, err
+	}
+	req := &motorpb.GetTelemetryRequest{Name: c.name, Extra: ext}
+	resp, err := c.extendedClient.GetTelemetry(ctx, req)
+	if err != nil {
+		return Telemetry{}, err
+	}
+	return ProtoToTelemetry(resp), nil
+}
+
 func (c *client) Stop(ctx context.Context, extra map[string]interface{}) error {
 	ext, err := protoutils.StructToStructPb(extra)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net"
+	"strings"
 	"testing"
 
 	"github.com/edaniels/golog"
@@ -12,6 +13,8 @@ import (
 	"go.viam.com/utils"
 	"go.viam.com/utils/rpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.viam.com/rdk/components/generic"
 	"go.viam.com/rdk/components/motor"
@@ -257,6 +260,117 @@ func TestClient(t *testing.T) {
 	test.That(t, conn.Close(), test.ShouldBeNil)
 }
 
+func TestClientTelemetry(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	rpcServer, err := rpc.NewServer(logger, rpc.WithUnauthenticated())
+	test.That(t, err, test.ShouldBeNil)
+
+	injectMotor := &inject.Motor{}
+	propertiesCalls := 0
+	injectMotor.PropertiesFunc = func(ctx context.Context, extra map[string]interface{}) (map[motor.Feature]bool, error) {
+		propertiesCalls++
+		return map[motor.Feature]bool{motor.PositionReporting: true, motor.CurrentReporting: true, motor.FaultReporting: true}, nil
+	}
+	var actualExtra map[string]interface{}
+	injectMotor.TelemetryFunc = func(ctx context.Context, extra map[string]interface{}) (motor.Telemetry, error) {
+		actualExtra = extra
+		return motor.Telemetry{CurrentAmps: 0.8, Faults: []string{"stall"}}, nil
+	}
+	wrapped, err := motor.WrapWithReconfigurable(injectMotor)
+	test.That(t, err, test.ShouldBeNil)
+	motorSvc, err := subtype.New(map[resource.Name]interface{}{motor.Named(testMotorName): wrapped})
+	test.That(t, err, test.ShouldBeNil)
+	resourceSubtype := registry.ResourceSubtypeLookup(motor.Subtype)
+	resourceSubtype.RegisterRPCService(context.Background(), rpcServer, motorSvc)
+
+	go rpcServer.Serve(listener)
+	defer rpcServer.Stop()
+
+	conn, err := viamgrpc.Dial(context.Background(), listener.Addr().String(), logger)
+	test.That(t, err, test.ShouldBeNil)
+	client := motor.NewClientFromConn(context.Background(), conn, testMotorName, logger)
+
+	features, err := client.Properties(context.Background(), nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, features, test.ShouldResemble, map[motor.Feature]bool{
+		motor.PositionReporting:    true,
+		motor.CurrentReporting:     true,
+		motor.TemperatureReporting: false,
+		motor.VoltageReporting:     false,
+		motor.FaultReporting:       true,
+	})
+	test.That(t, propertiesCalls, test.ShouldEqual, 1)
+
+	telemetry, err := client.(motor.TelemetryReporter).Telemetry(context.Background(), map[string]interface{}{"foo": "bar"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, telemetry, test.ShouldResemble, motor.Telemetry{CurrentAmps: 0.8, Faults: []string{"stall"}})
+	test.That(t, actualExtra, test.ShouldResemble, map[string]interface{}{"foo": "bar"})
+
+	injectMotor.TelemetryFunc = func(ctx context.Context, extra map[string]interface{}) (motor.Telemetry, error) {
+		return motor.Telemetry{}, errors.New("driver unreachable")
+	}
+	_, err = client.(motor.TelemetryReporter).Telemetry(context.Background(), nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "driver unreachable")
+
+	test.That(t, utils.TryClose(context.Background(), client), test.ShouldBeNil)
+	test.That(t, conn.Close(), test.ShouldBeNil)
+}
+
+func TestClientPropertiesWithoutExtendedService(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	// answer the extended service the way servers that predate it do
+	extendedCalls := 0
+	noExtendedService := func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, "/proto.api.component.motor.v1.ExtendedMotorService/") {
+			extendedCalls++
+			return nil, status.Errorf(codes.Unimplemented, "unknown service %s", info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+	rpcServer, err := rpc.NewServer(logger, rpc.WithUnauthenticated(), rpc.WithUnaryServerInterceptor(noExtendedService))
+	test.That(t, err, test.ShouldBeNil)
+
+	injectMotor := &inject.Motor{}
+	injectMotor.PropertiesFunc = func(ctx context.Context, extra map[string]interface{}) (map[motor.Feature]bool, error) {
+		return map[motor.Feature]bool{motor.PositionReporting: true, motor.CurrentReporting: true}, nil
+	}
+	wrapped, err := motor.WrapWithReconfigurable(injectMotor)
+	test.That(t, err, test.ShouldBeNil)
+	motorSvc, err := subtype.New(map[resource.Name]interface{}{motor.Named(testMotorName): wrapped})
+	test.That(t, err, test.ShouldBeNil)
+	resourceSubtype := registry.ResourceSubtypeLookup(motor.Subtype)
+	resourceSubtype.RegisterRPCService(context.Background(), rpcServer, motorSvc)
+
+	go rpcServer.Serve(listener)
+	defer rpcServer.Stop()
+
+	conn, err := viamgrpc.Dial(context.Background(), listener.Addr().String(), logger)
+	test.That(t, err, test.ShouldBeNil)
+	client := motor.NewClientFromConn(context.Background(), conn, testMotorName, logger)
+
+	features, err := client.Properties(context.Background(), nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, features, test.ShouldResemble, map[motor.Feature]bool{motor.PositionReporting: true})
+	// the extended service is not asked for again
+	features, err = client.Properties(context.Background(), nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, features, test.ShouldResemble, map[motor.Feature]bool{motor.PositionReporting: true})
+	test.That(t, extendedCalls, test.ShouldEqual, 1)
+
+	_, err = client.(motor.TelemetryReporter).Telemetry(context.Background(), nil)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Unimplemented)
+
+	test.That(t, utils.TryClose(context.Background(), client), test.ShouldBeNil)
+	test.That(t, conn.Close(), test.ShouldBeNil)
+}
+
 func TestClientDialerOption(t *testing.T) {
 	logger := golog.NewTestLogger(t)
 	listener, err := net.Listen("tcp", "localhost:0")
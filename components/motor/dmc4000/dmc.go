@@ -64,6 +64,8 @@ type Motor struct {
 	powerPct         float64
 }
 
+var _ = motor.TelemetryReporter(&Motor{})
+
 // Config adds DMC-specific config options.
 type Config struct {
 	DirectionFlip    bool    `json:"dir_flip,omitempty"` // Flip the direction of the signal sent if there is a Dir pin
@@ -845,5 +847,54 @@ func (m *Motor) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[
 
 // Properties returns the additional features supported by this motor.
 func (m *Motor) Properties(ctx context.Context, extra map[string]interface{}) (map[motor.Feature]bool, error) {
-	return map[motor.Feature]bool{motor.PositionReporting: true}, nil
+	return map[motor.Feature]bool{motor.PositionReporting: true, motor.FaultReporting: true}, nil
+}
+
+// amplifierFaults maps the bits of the amplifier error status (TA0) to a description, for the
+// amplifier of axes A-D. The bits for the amplifier of axes E-H are 4 bits higher.
+// https://www.galil.com/download/comref/com4103/index.html#tell_error_code.html
+var amplifierFaults = []struct {
+	bit  int
+	name string
+}{
+	{0, "amplifier over current"},
+	{1, "amplifier over voltage"},
+	{2, "amplifier over temperature"},
+	{3, "amplifier under voltage"},
+}
+
+// Telemetry returns the amplifier faults of the axis. The controller does not measure the current,
+// temperature or voltage of its amplifiers, so those are left at zero.
+func (m *Motor) Telemetry(ctx context.Context, extra map[string]interface{}) (motor.Telemetry, error) {
+	m.c.mu.Lock()
+	defer m.c.mu.Unlock()
+	axis := int(m.Axis[0] - 'A')
+	status, err := m.c.tellAmplifierError(0)
+	if err != nil {
+		return motor.Telemetry{}, err
+	}
+	peak, err := m.c.tellAmplifierError(1)
+	if err != nil {
+		return motor.Telemetry{}, err
+	}
+	faults := []string{}
+	shift := 4 * (axis / 4)
+	for _, f := range amplifierFaults {
+		if status&(1<<(f.bit+shift)) != 0 {
+			faults = append(faults, f.name)
+		}
+	}
+	if peak&(1<<axis) != 0 {
+		faults = append(faults, "amplifier peak current")
+	}
+	return motor.Telemetry{Faults: faults}, nil
+}
+
+// Must be run inside a lock.
+func (c *controller) tellAmplifierError(n int) (int, error) {
+	ret, err := c.sendCmd(fmt.Sprintf("TA%d", n))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(ret))
 }
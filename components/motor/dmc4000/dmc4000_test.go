@@ -131,6 +131,23 @@ func TestDMC4000Motor(t *testing.T) {
 		features, err := _motor.Properties(ctx, nil)
 		test.That(t, err, test.ShouldBeNil)
 		test.That(t, features[motor.PositionReporting], test.ShouldBeTrue)
+		test.That(t, features[motor.FaultReporting], test.ShouldBeTrue)
+	})
+
+	t.Run("motor telemetry testing", func(t *testing.T) {
+		// over temperature on the amplifier of axes A-D, over current on the one of axes E-H,
+		// peak current on axes A and B
+		txMu.Lock()
+		go checkRx(resChan, c,
+			[]string{"TA0", "TA1"},
+			[]string{" 20\r\n:", " 3\r\n:"},
+		)
+		telemetry, err := _motor.(motor.TelemetryReporter).Telemetry(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, telemetry, test.ShouldResemble, motor.Telemetry{
+			Faults: []string{"amplifier over temperature", "amplifier peak current"},
+		})
+		waitTx(t, resChan)
 	})
 
 	t.Run("motor SetPower testing", func(t *testing.T) {
@@ -23,6 +23,13 @@ import (
 
 const modelName = "fake"
 
+// simulated telemetry of the fake motor driver.
+const (
+	fakeStallCurrentAmps   = 2.0
+	fakeTemperatureCelsius = 25.0
+	fakeBusVoltage         = 12.0
+)
+
 // PinConfig defines the mapping of where motor are wired.
 type PinConfig struct {
 	Direction string `json:"dir"`
@@ -139,7 +146,24 @@ func (m *Motor) Position(ctx context.Context, extra map[string]interface{}) (flo
 // Properties returns the status of whether the motor supports certain optional features.
 func (m *Motor) Properties(ctx context.Context, extra map[string]interface{}) (map[motor.Feature]bool, error) {
 	return map[motor.Feature]bool{
-		motor.PositionReporting: m.PositionReporting,
+		motor.PositionReporting:    m.PositionReporting,
+		motor.CurrentReporting:     true,
+		motor.TemperatureReporting: true,
+		motor.VoltageReporting:     true,
+		motor.FaultReporting:       true,
+	}, nil
+}
+
+// Telemetry returns a simulated current draw proportional to the set power, at a fixed
+// temperature and bus voltage, without any faults.
+func (m *Motor) Telemetry(ctx context.Context, extra map[string]interface{}) (motor.Telemetry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return motor.Telemetry{
+		CurrentAmps:        math.Abs(m.powerPct) * fakeStallCurrentAmps,
+		TemperatureCelsius: fakeTemperatureCelsius,
+		BusVoltage:         fakeBusVoltage,
+		Faults:             []string{},
 	}, nil
 }
 
@@ -31,6 +31,12 @@ func TestMotorInit(t *testing.T) {
 	featureMap, err := m.Properties(ctx, nil)
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, featureMap[motor.PositionReporting], test.ShouldBeTrue)
+	test.That(t, featureMap[motor.CurrentReporting], test.ShouldBeTrue)
+
+	telemetry, err := m.Telemetry(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, telemetry.CurrentAmps, test.ShouldEqual, 0)
+	test.That(t, telemetry.Faults, test.ShouldBeEmpty)
 }
 
 func TestGoFor(t *testing.T) {
@@ -3,6 +3,8 @@ package motor
 
 import (
 	pb "go.viam.com/api/component/motor/v1"
+
+	motorpb "go.viam.com/rdk/proto/api/component/motor/v1"
 )
 
 // Feature is an enum representing an optional motor feature.
@@ -12,6 +14,22 @@ type Feature string
 // able to report its own position.
 const PositionReporting Feature = "PositionReporting"
 
+// The telemetry features are supported by motors implementing TelemetryReporter, and
+// say which of the Telemetry values the motor driver actually measures.
+const (
+	// CurrentReporting represents the driver reporting the current draw of the motor.
+	CurrentReporting Feature = "CurrentReporting"
+	// TemperatureReporting represents the driver reporting its own temperature.
+	TemperatureReporting Feature = "TemperatureReporting"
+	// VoltageReporting represents the driver reporting the voltage of its supply bus.
+	VoltageReporting Feature = "VoltageReporting"
+	// FaultReporting represents the driver reporting its active fault conditions.
+	FaultReporting Feature = "FaultReporting"
+)
+
+// TelemetryFeatures lists the features that are only advertised by motors implementing TelemetryReporter.
+var TelemetryFeatures = []Feature{CurrentReporting, TemperatureReporting, VoltageReporting, FaultReporting}
+
 // ProtoFeaturesToMap takes a GetPropertiesResponse and returns
 // an equivalent Feature-to-boolean map.
 func ProtoFeaturesToMap(resp *pb.GetPropertiesResponse) map[Feature]bool {
@@ -29,3 +47,28 @@ func FeatureMapToProtoResponse(
 		PositionReporting: featureMap[PositionReporting],
 	}, nil
 }
+
+// ExtendedProtoFeaturesToMap takes a GetPropertiesResponse of the extended motor service, which
+// includes the telemetry features, and returns an equivalent Feature-to-boolean map.
+func ExtendedProtoFeaturesToMap(resp *motorpb.GetPropertiesResponse) map[Feature]bool {
+	return map[Feature]bool{
+		PositionReporting:    resp.PositionReporting,
+		CurrentReporting:     resp.CurrentReporting,
+		TemperatureReporting: resp.TemperatureReporting,
+		VoltageReporting:     resp.VoltageReporting,
+		FaultReporting:       resp.FaultReporting,
+	}
+}
+
+// FeatureMapToExtendedProtoResponse takes a map of features to booleans (indicating
+// whether the feature is supported) and converts it to a GetPropertiesResponse of the
+// extended motor service.
+func FeatureMapToExtendedProtoResponse(featureMap map[Feature]bool) *motorpb.GetPropertiesResponse {
+	return &motorpb.GetPropertiesResponse{
+		PositionReporting:    featureMap[PositionReporting],
+		CurrentReporting:     featureMap[CurrentReporting],
+		TemperatureReporting: featureMap[TemperatureReporting],
+		VoltageReporting:     featureMap[VoltageReporting],
+		FaultReporting:       featureMap[FaultReporting],
+	}
+}
@@ -0,0 +1,453 @@
+package motor
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	goutils "go.viam.com/utils"
+
+	rdkutils "go.viam.com/rdk/utils"
+)
+
+const (
+	// groupPollInterval is how often a Group updates the speeds of its motors and checks their progress
+	// and faults during a move.
+	groupPollInterval = 50 * time.Millisecond
+	// groupCorrectionTime is the time, in seconds, a Group gives a motor to catch up with the position
+	// it should be at.
+	groupCorrectionTime = 0.2
+	// groupPositionTolerance is how close, in revolutions, a motor has to get to the end of its move.
+	groupPositionTolerance = 0.01
+	// groupMaxLead is how far ahead of the motor furthest behind, as a fraction of the move, the profile
+	// is allowed to get before it is held back for that motor to catch up.
+	groupMaxLead = 0.02
+	// groupMinSpeed is the lowest speed, relative to its planned rpm, a motor is slowed down to for the
+	// others to catch up with it.
+	groupMinSpeed = 0.05
+)
+
+// A Group moves a set of motors together. Timed moves are planned so that every motor starts at the
+// same time and follows the same velocity profile, scaled to its distance, so that all of them finish
+// together. The profile is trapezoidal when the group has an acceleration (see SetAcceleration). The
+// motors run continuously while their speeds are updated along the profile, and when they support
+// PositionReporting each of them is corrected towards its planned position and stopped once it has
+// covered its distance. If any motor fails or reports a fault (see TelemetryReporter) during a move,
+// every motor of the group is stopped.
+type Group struct {
+	motors []Motor
+	logger golog.Logger
+
+	mu           sync.Mutex
+	acceleration float64
+	moving       bool
+	progress     float64
+}
+
+// NewGroup returns a group moving the given motors together.
+func NewGroup(motors []Motor, logger golog.Logger) (*Group, error) {
+	if len(motors) == 0 {
+		return nil, errors.New("a motor group needs at least one motor")
+	}
+	return &Group{motors: motors, logger: logger, progress: 1}, nil
+}
+
+// Motors returns the motors of the group.
+func (g *Group) Motors() []Motor {
+	return g.motors
+}
+
+// SetAcceleration sets the acceleration, in rpm per second, of the fastest motor of a timed move. The
+// other motors accelerate in proportion to their speed. 0, the default, starts and stops the motors
+// at full speed. Moves of 0 revolutions, which run until stopped, always start at full speed.
+func (g *Group) SetAcceleration(rpmPerSec float64) error {
+	if rpmPerSec < 0 {
+		return errors.Errorf("acceleration cannot be negative, got %v", rpmPerSec)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.acceleration = rpmPerSec
+	return nil
+}
+
+// groupMove is the plan for one motor of a timed group move. The rpm and revolutions are both signed
+// with the direction the motor turns in.
+type groupMove struct {
+	rpm, revolutions float64
+	start            float64
+	// lastRPM is the rpm the motor was last set to run at, and done whether it has covered its distance
+	lastRPM float64
+	done    bool
+}
+
+// groupProfile is the velocity profile of a timed group move, as a speed relative to the planned rpm
+// of each motor over time.
+type groupProfile struct {
+	// seconds is the duration of the move at full speed
+	seconds float64
+	// rampTime is the time, in seconds, taken to accelerate to the peak speed and to decelerate from it
+	rampTime float64
+	peak     float64
+}
+
+// profile returns the profile of a move lasting the given number of minutes at full speed, in which
+// the fastest motor, at maxRPM, accelerates at the acceleration of the group. Moves too short to reach
+// full speed are slowed down to a triangular profile.
+func (g *Group) profile(minutes, maxRPM float64) groupProfile {
+	g.mu.Lock()
+	acceleration := g.acceleration
+	g.mu.Unlock()
+	p := groupProfile{seconds: minutes * 60, peak: 1}
+	if acceleration == 0 {
+		return p
+	}
+	p.rampTime = maxRPM / acceleration
+	if p.rampTime > p.seconds {
+		p.peak = math.Sqrt(p.seconds / p.rampTime)
+		p.rampTime *= p.peak
+	}
+	return p
+}
+
+// duration returns the length of the move in seconds.
+func (p groupProfile) duration() float64 {
+	return p.seconds/p.peak + p.rampTime
+}
+
+// speed returns the relative speed of the motors t seconds into the move.
+func (p groupProfile) speed(t float64) float64 {
+	if p.rampTime == 0 {
+		return p.peak
+	}
+	return p.peak * math.Max(0, math.Min(1, math.Min(t, p.duration()-t)/p.rampTime))
+}
+
+// covered returns the fraction of its distance each motor should have covered t seconds into the move.
+func (p groupProfile) covered(t float64) float64 {
+	total := p.duration()
+	t = math.Max(0, math.Min(t, total))
+	if p.rampTime == 0 {
+		return t / p.seconds
+	}
+	// distances are in seconds at full speed
+	ramp := func(x float64) float64 {
+		x = math.Min(x, p.rampTime)
+		return p.peak * x * x / (2 * p.rampTime)
+	}
+	d := ramp(t) + p.peak*math.Max(0, math.Min(t, total-p.rampTime)-p.rampTime)
+	if t > total-p.rampTime {
+		d += ramp(p.rampTime) - ramp(total-t)
+	}
+	return d / p.seconds
+}
+
+// minSpeed returns the lowest relative speed the motors are run at, since a motor cannot be started at
+// 0 rpm: the speed reached half a poll interval into the move.
+func (p groupProfile) minSpeed() float64 {
+	return p.speed(groupPollInterval.Seconds() / 2)
+}
+
+// GoFor moves each motor of the group the given number of revolutions, with the same semantics as
+// Motor.GoFor. The motor whose move takes longest at its requested rpm runs at that rpm, and the other
+// motors are slowed down to finish at the same time. Motors asked to move 0 revolutions stay still.
+// If every motor is asked to move 0 revolutions, all of them start running at their rpm indefinitely.
+// This blocks until all motors have finished their move.
+func (g *Group) GoFor(ctx context.Context, rpms, revolutions []float64, extra map[string]interface{}) error {
+	if len(rpms) != len(g.motors) || len(revolutions) != len(g.motors) {
+		return errors.Errorf("need an rpm and revolutions for each of the %d motors of the group, have %d and %d",
+			len(g.motors), len(rpms), len(revolutions))
+	}
+
+	timed := false
+	for _, revs := range revolutions {
+		if revs != 0 {
+			timed = true
+			break
+		}
+	}
+	if !timed {
+		err := g.run(ctx, func(ctx context.Context, i int) error {
+			return g.motors[i].GoFor(ctx, rpms[i], 0, extra)
+		})
+		if err != nil {
+			return multierr.Combine(err, g.Stop(ctx, extra))
+		}
+		return nil
+	}
+
+	// the duration of each move, in minutes, at the requested speed
+	longest := 0.
+	for i, revs := range revolutions {
+		if revs == 0 {
+			continue
+		}
+		if rpms[i] == 0 {
+			return NewZeroRPMError()
+		}
+		longest = math.Max(longest, math.Abs(revs/rpms[i]))
+	}
+	moves := make([]*groupMove, len(g.motors))
+	maxRPM := 0.
+	for i, revs := range revolutions {
+		if revs == 0 {
+			continue
+		}
+		// motors running indefinitely turn in the direction of their rpm
+		dir := math.Copysign(1, rpms[i]*revs)
+		moves[i] = &groupMove{rpm: dir * math.Abs(revs) / longest, revolutions: dir * math.Abs(revs)}
+		maxRPM = math.Max(maxRPM, math.Abs(moves[i].rpm))
+	}
+	return g.runTimed(ctx, moves, g.profile(longest, maxRPM), extra)
+}
+
+// GoTo moves each motor of the group to the given position, in revolutions from home, with the same
+// semantics as Motor.GoTo. Speeds are scaled as in GoFor so that all motors arrive together.
+// All motors of the group must support PositionReporting.
+func (g *Group) GoTo(ctx context.Context, rpms, positions []float64, extra map[string]interface{}) error {
+	if len(rpms) != len(g.motors) || len(positions) != len(g.motors) {
+		return errors.Errorf("need an rpm and position for each of the %d motors of the group, have %d and %d",
+			len(g.motors), len(rpms), len(positions))
+	}
+	speeds := make([]float64, len(g.motors))
+	revolutions := make([]float64, len(g.motors))
+	for i, m := range g.motors {
+		pos, err := m.Position(ctx, extra)
+		if err != nil {
+			return err
+		}
+		speeds[i] = math.Abs(rpms[i])
+		revolutions[i] = positions[i] - pos
+	}
+	return g.GoFor(ctx, speeds, revolutions, extra)
+}
+
+// Stop stops every motor of the group.
+func (g *Group) Stop(ctx context.Context, extra map[string]interface{}) error {
+	var err error
+	for _, m := range g.motors {
+		err = multierr.Combine(err, m.Stop(ctx, extra))
+	}
+	return err
+}
+
+// IsMoving returns whether the group is in the middle of a move.
+func (g *Group) IsMoving() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.moving
+}
+
+// Progress returns how far along the current timed move is, from 0 to 1, as the progress of the motor
+// furthest behind. Progress is measured from the motor positions when all moving motors support
+// PositionReporting, and from the elapsed time otherwise. It is 1 when no timed move is running.
+func (g *Group) Progress() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.progress
+}
+
+func (g *Group) setState(moving bool, progress float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.moving, g.progress = moving, progress
+}
+
+// run calls f for every motor in parallel, canceling the remaining calls if any of them fails. The calls
+// wait for all of them to be ready, so that the motors are started as close together as possible.
+func (g *Group) run(ctx context.Context, f func(ctx context.Context, i int) error) error {
+	var ready sync.WaitGroup
+	ready.Add(len(g.motors))
+	start := make(chan struct{})
+	fs := make([]rdkutils.SimpleFunc, 0, len(g.motors))
+	for i := range g.motors {
+		i := i
+		fs = append(fs, func(ctx context.Context) error {
+			ready.Done()
+			select {
+			case <-start:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			return f(ctx, i)
+		})
+	}
+	goutils.PanicCapturingGo(func() {
+		ready.Wait()
+		close(start)
+	})
+	_, err := rdkutils.RunInParallel(ctx, fs)
+	return err
+}
+
+// groupRun is the state of a timed group move.
+type groupRun struct {
+	moves       []*groupMove
+	profile     groupProfile
+	usePosition bool
+	extra       map[string]interface{}
+	// t is how far along the profile the move is, in seconds. When the motor positions are known, it
+	// only advances while every motor keeps up with the profile.
+	t float64
+}
+
+func (g *Group) runTimed(ctx context.Context, moves []*groupMove, profile groupProfile, extra map[string]interface{}) error {
+	run := &groupRun{moves: moves, profile: profile, usePosition: true, extra: extra}
+	faultReporting := make([]bool, len(g.motors))
+	for i, m := range g.motors {
+		features, err := m.Properties(ctx, extra)
+		if err != nil {
+			return err
+		}
+		_, isReporter := m.(TelemetryReporter)
+		faultReporting[i] = isReporter && features[FaultReporting]
+		if moves[i] == nil {
+			continue
+		}
+		if !features[PositionReporting] {
+			run.usePosition = false
+			continue
+		}
+		if moves[i].start, err = m.Position(ctx, extra); err != nil {
+			return err
+		}
+	}
+
+	g.setState(true, 0)
+	defer g.setState(false, 1)
+
+	last := time.Now()
+	err := g.run(ctx, func(ctx context.Context, i int) error {
+		if moves[i] == nil {
+			return nil
+		}
+		moves[i].lastRPM = moves[i].rpm * profile.minSpeed()
+		return g.motors[i].GoFor(ctx, moves[i].lastRPM, 0, extra)
+	})
+	ticker := time.NewTicker(groupPollInterval)
+	defer ticker.Stop()
+	for err == nil {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			continue
+		case <-ticker.C:
+		}
+		if err = g.checkFaults(ctx, faultReporting); err != nil {
+			break
+		}
+		now := time.Now()
+		var finished bool
+		if finished, err = g.step(ctx, run, now.Sub(last).Seconds()); finished {
+			break
+		}
+		last = now
+	}
+	if err != nil {
+		return multierr.Combine(err, g.Stop(ctx, extra))
+	}
+	return nil
+}
+
+// step advances the move by dt seconds: it stops the motors that have covered their distance, updates
+// the speed of the others to follow the profile, and returns whether all of them are done. Without
+// positions to go by, every motor is stopped once the profile ends.
+func (g *Group) step(ctx context.Context, run *groupRun, dt float64) (bool, error) {
+	profile := run.profile
+	if !run.usePosition {
+		run.t += dt
+		if run.t >= profile.duration() {
+			return true, g.Stop(ctx, run.extra)
+		}
+		g.setState(true, run.t/profile.duration())
+		speed := math.Max(profile.speed(run.t+groupPollInterval.Seconds()/2), profile.minSpeed())
+		for i, mv := range run.moves {
+			if mv == nil {
+				continue
+			}
+			if err := g.setRPM(ctx, i, mv, mv.rpm*speed, run.extra); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+
+	covered := make([]float64, len(run.moves))
+	slowest := 1.
+	finished := true
+	for i, mv := range run.moves {
+		if mv == nil || mv.done {
+			continue
+		}
+		pos, err := g.motors[i].Position(ctx, run.extra)
+		if err != nil {
+			return false, err
+		}
+		covered[i] = (pos - mv.start) / mv.revolutions
+		slowest = math.Min(slowest, covered[i])
+		if math.Abs(mv.revolutions)*(1-covered[i]) < groupPositionTolerance {
+			mv.done = true
+			if err := g.motors[i].Stop(ctx, run.extra); err != nil {
+				return false, err
+			}
+			continue
+		}
+		finished = false
+	}
+	g.setState(true, math.Max(0, slowest))
+	if finished {
+		return true, nil
+	}
+	if profile.covered(run.t+dt)-slowest <= groupMaxLead {
+		run.t += dt
+	}
+
+	speed := math.Max(profile.speed(run.t+groupPollInterval.Seconds()/2), profile.minSpeed())
+	for i, mv := range run.moves {
+		if mv == nil || mv.done {
+			continue
+		}
+		// catch up with, or wait for, the planned position, without stopping the motor or running it
+		// faster than its planned rpm
+		lag := (profile.covered(run.t) - covered[i]) * mv.revolutions
+		rpm := mv.rpm*speed + lag/groupCorrectionTime*60
+		rpm = mv.rpm * math.Max(math.Min(profile.minSpeed(), groupMinSpeed), math.Min(rpm/mv.rpm, 1))
+		// slow down to reach the end of the move by the next update rather than overshoot it
+		remaining := mv.revolutions * (1 - covered[i])
+		rpm = math.Copysign(math.Min(math.Abs(rpm), math.Abs(remaining)/groupPollInterval.Minutes()), rpm)
+		if err := g.setRPM(ctx, i, mv, rpm, run.extra); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// setRPM runs the motor of the move indefinitely at the given rpm, unless it already is.
+func (g *Group) setRPM(ctx context.Context, i int, mv *groupMove, rpm float64, extra map[string]interface{}) error {
+	if rpm == mv.lastRPM {
+		return nil
+	}
+	mv.lastRPM = rpm
+	return g.motors[i].GoFor(ctx, rpm, 0, extra)
+}
+
+// checkFaults returns an error if any motor reports an active fault.
+func (g *Group) checkFaults(ctx context.Context, faultReporting []bool) error {
+	for i, m := range g.motors {
+		if !faultReporting[i] {
+			continue
+		}
+		t, err := m.(TelemetryReporter).Telemetry(ctx, nil)
+		if err != nil {
+			g.logger.Debugw("failed to read motor telemetry", "motor", i, "error", err)
+			continue
+		}
+		if len(t.Faults) > 0 {
+			return errors.Errorf("motor %d of the group reported faults %v", i, t.Faults)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package motor_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/motor"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+// groupMotor simulates a motor whose position follows the rpm it was last set to run at, scaled by slip.
+type groupMotor struct {
+	*inject.Motor
+	mu      sync.Mutex
+	goFors  [][2]float64
+	rpm     float64
+	slip    float64
+	pos     float64
+	since   time.Time
+	stopped bool
+}
+
+func newGroupMotor(features map[motor.Feature]bool) *groupMotor {
+	m := &groupMotor{Motor: &inject.Motor{}, slip: 1}
+	m.GoForFunc = func(ctx context.Context, rpm, revolutions float64, extra map[string]interface{}) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.advance()
+		m.goFors = append(m.goFors, [2]float64{rpm, revolutions})
+		m.rpm, m.stopped = rpm, false
+		if revolutions != 0 {
+			m.pos += math.Copysign(revolutions, rpm*revolutions)
+			m.rpm = 0
+		}
+		return nil
+	}
+	m.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.advance()
+		m.rpm, m.stopped = 0, true
+		return nil
+	}
+	m.PropertiesFunc = func(ctx context.Context, extra map[string]interface{}) (map[motor.Feature]bool, error) {
+		return features, nil
+	}
+	m.PositionFunc = func(ctx context.Context, extra map[string]interface{}) (float64, error) {
+		return m.position(), nil
+	}
+	return m
+}
+
+// advance moves the motor along at its current rpm; the caller must hold mu.
+func (m *groupMotor) advance() {
+	now := time.Now()
+	if !m.since.IsZero() {
+		m.pos += m.slip * m.rpm * now.Sub(m.since).Minutes()
+	}
+	m.since = now
+}
+
+func (m *groupMotor) position() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.advance()
+	return m.pos
+}
+
+func (m *groupMotor) setPosition(pos float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.advance()
+	m.pos = pos
+}
+
+func (m *groupMotor) calls() [][2]float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([][2]float64{}, m.goFors...)
+}
+
+func (m *groupMotor) isStopped() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stopped
+}
+
+func TestGroupGoFor(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+
+	_, err := motor.NewGroup(nil, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	features := map[motor.Feature]bool{motor.PositionReporting: true}
+	m1 := newGroupMotor(features)
+	m2 := newGroupMotor(features)
+	m3 := newGroupMotor(features)
+	group, err := motor.NewGroup([]motor.Motor{m1, m2, m3}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	err = group.GoFor(ctx, []float64{60}, []float64{1, 2, 3}, nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	err = group.GoFor(ctx, []float64{60, 0, 60}, []float64{1, 2, 3}, nil)
+	test.That(t, err, test.ShouldBeError, motor.NewZeroRPMError())
+
+	t.Run("speeds are scaled to finish together", func(t *testing.T) {
+		err := group.GoFor(ctx, []float64{-600, 600, 100}, []float64{1, -2, 0}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		// the motors run indefinitely in the direction of their move, at speeds scaled to its length
+		calls1, calls2 := m1.calls(), m2.calls()
+		test.That(t, calls1[0], test.ShouldResemble, [2]float64{-300, 0})
+		test.That(t, calls2[0], test.ShouldResemble, [2]float64{-600, 0})
+		for _, c := range append(calls1, calls2...) {
+			test.That(t, c[1], test.ShouldEqual, 0)
+		}
+		test.That(t, m1.position(), test.ShouldAlmostEqual, -1, 0.05)
+		test.That(t, m2.position(), test.ShouldAlmostEqual, -2, 0.05)
+		test.That(t, m1.isStopped(), test.ShouldBeTrue)
+		test.That(t, m2.isStopped(), test.ShouldBeTrue)
+		test.That(t, m3.calls(), test.ShouldBeEmpty)
+		test.That(t, group.Progress(), test.ShouldEqual, 1)
+		test.That(t, group.IsMoving(), test.ShouldBeFalse)
+	})
+
+	t.Run("velocity moves start every motor", func(t *testing.T) {
+		err := group.GoFor(ctx, []float64{10, 20, 30}, []float64{0, 0, 0}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		calls := m3.calls()
+		test.That(t, calls[len(calls)-1], test.ShouldResemble, [2]float64{30, 0})
+		test.That(t, group.Stop(ctx, nil), test.ShouldBeNil)
+	})
+
+	t.Run("go to", func(t *testing.T) {
+		m1.setPosition(1)
+		m2.setPosition(1)
+		m3.setPosition(1)
+		err := group.GoTo(ctx, []float64{-300, 600, 30}, []float64{3, 0, 1}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, m1.position(), test.ShouldAlmostEqual, 3, 0.05)
+		test.That(t, m2.position(), test.ShouldAlmostEqual, 0, 0.05)
+		test.That(t, m3.position(), test.ShouldEqual, 1)
+	})
+
+	t.Run("acceleration ramps every motor together", func(t *testing.T) {
+		test.That(t, group.SetAcceleration(-1), test.ShouldNotBeNil)
+		test.That(t, group.SetAcceleration(3000), test.ShouldBeNil)
+		defer func() {
+			test.That(t, group.SetAcceleration(0), test.ShouldBeNil)
+		}()
+		m1.setPosition(0)
+		m2.setPosition(0)
+		before1, before2 := len(m1.calls()), len(m2.calls())
+		started := time.Now()
+		err := group.GoFor(ctx, []float64{600, 600, 600}, []float64{1, 2, 0}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		// too short to reach full speed, the move ramps up to 600 rpm in 0.2 seconds and straight back down
+		test.That(t, time.Since(started), test.ShouldBeGreaterThanOrEqualTo, 350*time.Millisecond)
+		calls1, calls2 := m1.calls()[before1:], m2.calls()[before2:]
+		test.That(t, calls1[0][0], test.ShouldAlmostEqual, calls2[0][0]/2)
+		test.That(t, calls2[0][0], test.ShouldBeLessThan, 100)
+		// the speed is updated continuously while the motors keep running
+		test.That(t, len(calls2), test.ShouldBeGreaterThan, 4)
+		peak := 0.
+		for _, c := range calls2 {
+			test.That(t, c[1], test.ShouldEqual, 0)
+			peak = math.Max(peak, c[0])
+		}
+		test.That(t, peak, test.ShouldBeGreaterThan, 400)
+		test.That(t, peak, test.ShouldBeLessThanOrEqualTo, 600)
+		test.That(t, m1.position(), test.ShouldAlmostEqual, 1, 0.05)
+		test.That(t, m2.position(), test.ShouldAlmostEqual, 2, 0.05)
+	})
+
+	t.Run("a slipping motor holds back the others", func(t *testing.T) {
+		m1.setPosition(0)
+		m2.setPosition(0)
+		m1.mu.Lock()
+		m1.slip = 0.5
+		m1.mu.Unlock()
+		defer func() {
+			m1.mu.Lock()
+			m1.slip = 1
+			m1.mu.Unlock()
+		}()
+		before2 := len(m2.calls())
+		err := group.GoFor(ctx, []float64{120, 120, 120}, []float64{1, 1, 0}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		slowest := 120.
+		for _, c := range m2.calls()[before2:] {
+			slowest = math.Min(slowest, c[0])
+		}
+		test.That(t, slowest, test.ShouldBeLessThan, 100)
+		test.That(t, m1.position(), test.ShouldAlmostEqual, 1, 0.05)
+		test.That(t, m2.position(), test.ShouldAlmostEqual, 1, 0.05)
+	})
+
+	t.Run("a failing motor stops the group", func(t *testing.T) {
+		m2.GoForFunc = func(ctx context.Context, rpm, revolutions float64, extra map[string]interface{}) error {
+			return errors.New("encoder lost")
+		}
+		err := group.GoFor(ctx, []float64{60, 60, 60}, []float64{1, 1, 1}, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "encoder lost")
+		test.That(t, m1.isStopped(), test.ShouldBeTrue)
+		test.That(t, m3.isStopped(), test.ShouldBeTrue)
+	})
+}
+
+func TestGroupGoForWithoutPositions(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	m1 := newGroupMotor(map[motor.Feature]bool{})
+	m2 := newGroupMotor(map[motor.Feature]bool{})
+	group, err := motor.NewGroup([]motor.Motor{m1, m2}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	started := time.Now()
+	err = group.GoFor(context.Background(), []float64{600, 600}, []float64{1, 2}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	// the motors are stopped once the move has lasted long enough for the longest of them
+	test.That(t, time.Since(started), test.ShouldBeGreaterThanOrEqualTo, 200*time.Millisecond)
+	test.That(t, m1.calls()[0], test.ShouldResemble, [2]float64{300, 0})
+	test.That(t, m2.calls()[0], test.ShouldResemble, [2]float64{600, 0})
+	test.That(t, m1.isStopped(), test.ShouldBeTrue)
+	test.That(t, m2.isStopped(), test.ShouldBeTrue)
+	test.That(t, group.IsMoving(), test.ShouldBeFalse)
+}
+
+func TestGroupFault(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+
+	m1 := newGroupMotor(map[motor.Feature]bool{motor.PositionReporting: true})
+	m2 := newGroupMotor(map[motor.Feature]bool{motor.PositionReporting: true, motor.FaultReporting: true})
+	m2.TelemetryFunc = func(ctx context.Context, extra map[string]interface{}) (motor.Telemetry, error) {
+		return motor.Telemetry{Faults: []string{"overtemperature"}}, nil
+	}
+	group, err := motor.NewGroup([]motor.Motor{m1, m2}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	err = group.GoFor(ctx, []float64{60, 60}, []float64{10, 10}, nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "overtemperature")
+	test.That(t, m1.isStopped(), test.ShouldBeTrue)
+	test.That(t, m2.isStopped(), test.ShouldBeTrue)
+	test.That(t, group.IsMoving(), test.ShouldBeFalse)
+}
@@ -11,6 +11,7 @@ import (
 
 	"go.viam.com/rdk/components/generic"
 	"go.viam.com/rdk/data"
+	motorpb "go.viam.com/rdk/proto/api/component/motor/v1"
 	"go.viam.com/rdk/registry"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
@@ -25,11 +26,19 @@ func init() {
 			return CreateStatus(ctx, resource)
 		},
 		RegisterRPCService: func(ctx context.Context, rpcServer rpc.Server, subtypeSvc subtype.Service) error {
-			return rpcServer.RegisterServiceServer(
+			if err := rpcServer.RegisterServiceServer(
 				ctx,
 				&pb.MotorService_ServiceDesc,
 				NewServer(subtypeSvc),
 				pb.RegisterMotorServiceHandlerFromEndpoint,
+			); err != nil {
+				return err
+			}
+			return rpcServer.RegisterServiceServer(
+				ctx,
+				&motorpb.ExtendedMotorService_ServiceDesc,
+				NewExtendedServer(subtypeSvc),
+				motorpb.RegisterExtendedMotorServiceHandlerFromEndpoint,
 			)
 		},
 		RPCServiceDesc: &pb.MotorService_ServiceDesc,
@@ -45,6 +54,10 @@ func init() {
 		Subtype:    SubtypeName,
 		MethodName: isPowered.String(),
 	}, newIsPoweredCollector)
+	data.RegisterCollector(data.MethodMetadata{
+		Subtype:    SubtypeName,
+		MethodName: telemetry.String(),
+	}, newTelemetryCollector)
 }
 
 // SubtypeName is a constant that identifies the component resource subtype string "motor".
@@ -120,6 +133,7 @@ var (
 	_ = Motor(&reconfigurableMotor{})
 	_ = LocalMotor(&reconfigurableLocalMotor{})
 	_ = resource.Reconfigurable(&reconfigurableMotor{})
+	_ = TelemetryReporter(&reconfigurableMotor{})
 	_ = resource.Reconfigurable(&reconfigurableLocalMotor{})
 	_ = viamutils.ContextCloser(&reconfigurableLocalMotor{})
 )
@@ -269,6 +283,16 @@ func (r *reconfigurableMotor) IsPowered(ctx context.Context, extra map[string]in
 	return r.actual.IsPowered(ctx, extra)
 }
 
+func (r *reconfigurableMotor) Telemetry(ctx context.Context, extra map[string]interface{}) (Telemetry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tr, ok := r.actual.(TelemetryReporter)
+	if !ok {
+		return Telemetry{}, utils.NewUnimplementedInterfaceError((*TelemetryReporter)(nil), r.actual)
+	}
+	return tr.Telemetry(ctx, extra)
+}
+
 func (r *reconfigurableMotor) Close(ctx context.Context) error {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -405,6 +405,37 @@ func TestProperties(t *testing.T) {
 	test.That(t, actualMotor1.featuresCount, test.ShouldEqual, 1)
 }
 
+func TestTelemetry(t *testing.T) {
+	actualMotor1 := &mock{Name: testMotorName}
+	reconfMotor1, err := motor.WrapWithReconfigurable(actualMotor1)
+	test.That(t, err, test.ShouldBeNil)
+	_, err = motor.TelemetryFromMotor(context.Background(), reconfMotor1.(motor.Motor), testMotorName, nil)
+	test.That(t, err, test.ShouldBeError, motor.NewTelemetryUnsupportedError(testMotorName))
+
+	telemetry := motor.Telemetry{CurrentAmps: 1.5, TemperatureCelsius: 40, BusVoltage: 24, Faults: []string{"overtemperature"}}
+	injectMotor := &inject.Motor{}
+	injectMotor.TelemetryFunc = func(ctx context.Context, extra map[string]interface{}) (motor.Telemetry, error) {
+		return telemetry, nil
+	}
+	injectMotor.PropertiesFunc = func(ctx context.Context, extra map[string]interface{}) (map[motor.Feature]bool, error) {
+		return map[motor.Feature]bool{motor.PositionReporting: false, motor.FaultReporting: true}, nil
+	}
+	reconfMotor2, err := motor.WrapWithReconfigurable(injectMotor)
+	test.That(t, err, test.ShouldBeNil)
+	result, err := reconfMotor2.(motor.TelemetryReporter).Telemetry(context.Background(), nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, result, test.ShouldResemble, telemetry)
+
+	// commands are the motor's own, whatever their name
+	injectMotor.DoFunc = func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+		return cmd, nil
+	}
+	cmd := map[string]interface{}{"command": "get_telemetry"}
+	resp, err := reconfMotor2.(motor.Motor).DoCommand(context.Background(), cmd)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp, test.ShouldResemble, cmd)
+}
+
 func TestStop(t *testing.T) {
 	actualMotor1 := &mock{Name: testMotorName}
 	reconfMotor1, err := motor.WrapWithReconfigurable(actualMotor1)
@@ -73,7 +73,24 @@ func init() {
 	)
 }
 
-func getOrCreateConnection(deps registry.Dependencies, config *AttrConfig) (*roboclaw.Roboclaw, error) {
+// roboclawConn is the part of the roboclaw API the motors use, so that it can be faked in tests.
+type roboclawConn interface {
+	DutyM1(address uint8, duty int16) error
+	DutyM2(address uint8, duty int16) error
+	SpeedDistanceM1(address uint8, speed int32, distance uint32, buffer bool) error
+	SpeedDistanceM2(address uint8, speed int32, distance uint32, buffer bool) error
+	SetEncM1(address uint8, val int32) error
+	SetEncM2(address uint8, val int32) error
+	ReadEncM1(address uint8) (uint32, uint8, error)
+	ReadEncM2(address uint8) (uint32, uint8, error)
+	ReadPWMs(address uint8) (int16, int16, error)
+	ReadCurrents(address uint8) (int16, int16, error)
+	ReadTemp(address uint8) (uint16, error)
+	ReadMainBatteryVoltage(address uint8) (uint16, error)
+	ReadError(address uint8) (uint32, error)
+}
+
+func getOrCreateConnection(deps registry.Dependencies, config *AttrConfig) (roboclawConn, error) {
 	for _, res := range deps {
 		m, ok := rdkutils.UnwrapProxy(res).(*roboclawMotor)
 		if !ok {
@@ -121,10 +138,13 @@ func newRoboClaw(deps registry.Dependencies, config config.Component, logger gol
 	return &roboclawMotor{conn: c, conf: motorConfig, addr: uint8(motorConfig.Address), logger: logger}, nil
 }
 
-var _ = motor.LocalMotor(&roboclawMotor{})
+var (
+	_ = motor.LocalMotor(&roboclawMotor{})
+	_ = motor.TelemetryReporter(&roboclawMotor{})
+)
 
 type roboclawMotor struct {
-	conn *roboclaw.Roboclaw
+	conn roboclawConn
 	conf *AttrConfig
 
 	addr uint8
@@ -222,7 +242,79 @@ func (m *roboclawMotor) Position(ctx context.Context, extra map[string]interface
 
 func (m *roboclawMotor) Properties(ctx context.Context, extra map[string]interface{}) (map[motor.Feature]bool, error) {
 	return map[motor.Feature]bool{
-		motor.PositionReporting: true,
+		motor.PositionReporting:    true,
+		motor.CurrentReporting:     true,
+		motor.TemperatureReporting: true,
+		motor.VoltageReporting:     true,
+		motor.FaultReporting:       true,
+	}, nil
+}
+
+// roboclawFaults maps the status bits of the controller to a description, and to the motor
+// channel they apply to (0 for both).
+var roboclawFaults = []struct {
+	bit    uint32
+	number int
+	name   string
+}{
+	{0x000001, 0, "e-stop"},
+	{0x000002, 0, "temperature error"},
+	{0x000004, 0, "temperature 2 error"},
+	{0x000008, 0, "main voltage high error"},
+	{0x000010, 0, "logic voltage high error"},
+	{0x000020, 0, "logic voltage low error"},
+	{0x000040, 1, "driver fault"},
+	{0x000080, 2, "driver fault"},
+	{0x000100, 1, "speed error"},
+	{0x000200, 2, "speed error"},
+	{0x000400, 1, "position error"},
+	{0x000800, 2, "position error"},
+	{0x001000, 1, "current error"},
+	{0x002000, 2, "current error"},
+	{0x010000, 1, "over current warning"},
+	{0x020000, 2, "over current warning"},
+	{0x040000, 0, "main voltage high warning"},
+	{0x080000, 0, "main voltage low warning"},
+	{0x100000, 0, "temperature warning"},
+	{0x200000, 0, "temperature 2 warning"},
+}
+
+func (m *roboclawMotor) Telemetry(ctx context.Context, extra map[string]interface{}) (motor.Telemetry, error) {
+	if m.conf.Number != 1 && m.conf.Number != 2 {
+		return motor.Telemetry{}, m.conf.wrongNumberError()
+	}
+	// currents are in 10 mA, temperature in 0.1 C and voltage in 0.1 V
+	current1, current2, err := m.conn.ReadCurrents(m.addr)
+	if err != nil {
+		return motor.Telemetry{}, err
+	}
+	current := current1
+	if m.conf.Number == 2 {
+		current = current2
+	}
+	temp, err := m.conn.ReadTemp(m.addr)
+	if err != nil {
+		return motor.Telemetry{}, err
+	}
+	voltage, err := m.conn.ReadMainBatteryVoltage(m.addr)
+	if err != nil {
+		return motor.Telemetry{}, err
+	}
+	status, err := m.conn.ReadError(m.addr)
+	if err != nil {
+		return motor.Telemetry{}, err
+	}
+	faults := []string{}
+	for _, f := range roboclawFaults {
+		if status&f.bit != 0 && (f.number == 0 || f.number == m.conf.Number) {
+			faults = append(faults, f.name)
+		}
+	}
+	return motor.Telemetry{
+		CurrentAmps:        float64(current) / 100,
+		TemperatureCelsius: float64(temp) / 10,
+		BusVoltage:         float64(voltage) / 10,
+		Faults:             faults,
 	}, nil
 }
 
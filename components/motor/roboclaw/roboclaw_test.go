@@ -0,0 +1,85 @@
+package roboclaw
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/motor"
+)
+
+// fakeConn answers the telemetry reads of a roboclaw controller; the calls it does not implement
+// panic on the nil embedded interface.
+type fakeConn struct {
+	roboclawConn
+	addr               uint8
+	current1, current2 int16
+	temp, voltage      uint16
+	status             uint32
+	err                error
+}
+
+func (c *fakeConn) ReadCurrents(address uint8) (int16, int16, error) {
+	c.addr = address
+	return c.current1, c.current2, c.err
+}
+
+func (c *fakeConn) ReadTemp(address uint8) (uint16, error) {
+	return c.temp, nil
+}
+
+func (c *fakeConn) ReadMainBatteryVoltage(address uint8) (uint16, error) {
+	return c.voltage, nil
+}
+
+func (c *fakeConn) ReadError(address uint8) (uint32, error) {
+	return c.status, nil
+}
+
+func TestTelemetry(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	conn := &fakeConn{current1: 150, current2: 320, temp: 412, voltage: 241}
+	m1 := &roboclawMotor{conn: conn, conf: &AttrConfig{Number: 1}, addr: 128, logger: logger}
+	m2 := &roboclawMotor{conn: conn, conf: &AttrConfig{Number: 2}, addr: 128, logger: logger}
+
+	telemetry, err := m1.Telemetry(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, conn.addr, test.ShouldEqual, 128)
+	test.That(t, telemetry, test.ShouldResemble, motor.Telemetry{
+		CurrentAmps:        1.5,
+		TemperatureCelsius: 41.2,
+		BusVoltage:         24.1,
+		Faults:             []string{},
+	})
+	telemetry, err = m2.Telemetry(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, telemetry.CurrentAmps, test.ShouldEqual, 3.2)
+
+	t.Run("faults of the channel and the controller", func(t *testing.T) {
+		// e-stop, driver fault of motor 2, over current warning of motor 1 and temperature warning
+		conn.status = 0x000001 | 0x000080 | 0x010000 | 0x100000
+		telemetry, err := m1.Telemetry(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, telemetry.Faults, test.ShouldResemble, []string{"e-stop", "over current warning", "temperature warning"})
+		telemetry, err = m2.Telemetry(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, telemetry.Faults, test.ShouldResemble, []string{"e-stop", "driver fault", "temperature warning"})
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		conn.err = errors.New("no reply")
+		_, err := m1.Telemetry(ctx, nil)
+		test.That(t, err, test.ShouldBeError, conn.err)
+		conn.err = nil
+	})
+
+	t.Run("wrong number of motors", func(t *testing.T) {
+		m := &roboclawMotor{conn: conn, conf: &AttrConfig{Number: 3}, logger: logger}
+		_, err := m.Telemetry(ctx, nil)
+		test.That(t, err, test.ShouldBeError, m.conf.wrongNumberError())
+	})
+}
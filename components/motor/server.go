@@ -8,6 +8,7 @@ import (
 	pb "go.viam.com/api/component/motor/v1"
 
 	"go.viam.com/rdk/operation"
+	motorpb "go.viam.com/rdk/proto/api/component/motor/v1"
 	"go.viam.com/rdk/subtype"
 )
 
@@ -170,3 +171,49 @@ func (server *subtypeServer) ResetZeroPosition(
 
 	return &pb.ResetZeroPositionResponse{}, motor.ResetZeroPosition(ctx, req.GetOffset(), req.Extra.AsMap())
 }
+
+type extendedServer struct {
+	motorpb.UnimplementedExtendedMotorServiceServer
+	server *subtypeServer
+}
+
+// NewExtendedServer constructs a gRPC server of the motor calls MotorService does not cover.
+func NewExtendedServer(service subtype.Service) motorpb.ExtendedMotorServiceServer {
+	return &extendedServer{server: &subtypeServer{service: service}}
+}
+
+// GetProperties returns a message of booleans indicating which optional features the robot's motor
+// supports, the telemetry features included.
+func (server *extendedServer) GetProperties(
+	ctx context.Context,
+	req *motorpb.GetPropertiesRequest,
+) (*motorpb.GetPropertiesResponse, error) {
+	motorName := req.GetName()
+	motor, err := server.server.getMotor(motorName)
+	if err != nil {
+		return nil, errors.Errorf("no motor (%s) found", motorName)
+	}
+	features, err := motor.Properties(ctx, req.Extra.AsMap())
+	if err != nil {
+		return nil, err
+	}
+	return FeatureMapToExtendedProtoResponse(features), nil
+}
+
+// GetTelemetry returns the current draw, driver temperature, bus voltage and active faults of the
+// motor of the underlying robot, or an error if the motor does not report telemetry.
+func (server *extendedServer) GetTelemetry(
+	ctx context.Context,
+	req *motorpb.GetTelemetryRequest,
+) (*motorpb.GetTelemetryResponse, error) {
+	motorName := req.GetName()
+	motor, err := server.server.getMotor(motorName)
+	if err != nil {
+		return nil, errors.Errorf("no motor (%s) found", motorName)
+	}
+	telemetry, err := TelemetryFromMotor(ctx, motor, motorName, req.Extra.AsMap())
+	if err != nil {
+		return nil, err
+	}
+	return TelemetryToProto(telemetry), nil
+}
@@ -0,0 +1,64 @@
+package motor
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	motorpb "go.viam.com/rdk/proto/api/component/motor/v1"
+)
+
+// Telemetry is a snapshot of the electrical and thermal state of a motor driver. Only the values
+// whose feature (CurrentReporting, TemperatureReporting, VoltageReporting, FaultReporting) the
+// motor advertises through Properties are measured; the rest are left at zero.
+type Telemetry struct {
+	CurrentAmps        float64  `json:"current_amps"`
+	TemperatureCelsius float64  `json:"temperature_celsius"`
+	BusVoltage         float64  `json:"bus_voltage"`
+	Faults             []string `json:"faults"`
+}
+
+// A TelemetryReporter is a motor whose driver can report telemetry.
+type TelemetryReporter interface {
+	// Telemetry returns the current draw, driver temperature, bus voltage and active faults of the motor.
+	Telemetry(ctx context.Context, extra map[string]interface{}) (Telemetry, error)
+}
+
+// NewTelemetryUnsupportedError returns a standard error for when a motor does not implement TelemetryReporter.
+func NewTelemetryUnsupportedError(motorName string) error {
+	return errors.Errorf("motor with name %s does not support Telemetry", motorName)
+}
+
+// TelemetryFromMotor returns the telemetry of the named motor, or an error if the motor does not report any.
+func TelemetryFromMotor(ctx context.Context, m Motor, name string, extra map[string]interface{}) (Telemetry, error) {
+	if r, ok := m.(*reconfigurableMotor); ok {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		m = r.actual
+	}
+	tr, ok := m.(TelemetryReporter)
+	if !ok {
+		return Telemetry{}, NewTelemetryUnsupportedError(name)
+	}
+	return tr.Telemetry(ctx, extra)
+}
+
+// TelemetryToProto converts the telemetry of a motor to a GetTelemetryResponse.
+func TelemetryToProto(t Telemetry) *motorpb.GetTelemetryResponse {
+	return &motorpb.GetTelemetryResponse{
+		CurrentAmps:        t.CurrentAmps,
+		TemperatureCelsius: t.TemperatureCelsius,
+		BusVoltage:         t.BusVoltage,
+		Faults:             t.Faults,
+	}
+}
+
+// ProtoToTelemetry converts a GetTelemetryResponse to the telemetry of a motor.
+func ProtoToTelemetry(resp *motorpb.GetTelemetryResponse) Telemetry {
+	return Telemetry{
+		CurrentAmps:        resp.CurrentAmps,
+		TemperatureCelsius: resp.TemperatureCelsius,
+		BusVoltage:         resp.BusVoltage,
+		Faults:             resp.Faults,
+	}
+}
@@ -376,9 +376,40 @@ func (m *Motor) Position(ctx context.Context, extra map[string]interface{}) (flo
 func (m *Motor) Properties(ctx context.Context, extra map[string]interface{}) (map[motor.Feature]bool, error) {
 	return map[motor.Feature]bool{
 		motor.PositionReporting: true,
+		motor.FaultReporting:    true,
 	}, nil
 }
 
+// driver status flags reported in the DRV_STATUS register.
+var drvStatusFaults = []struct {
+	bit  int32
+	name string
+}{
+	{1 << 30, "open load phase B"},
+	{1 << 29, "open load phase A"},
+	{1 << 28, "short to ground phase B"},
+	{1 << 27, "short to ground phase A"},
+	{1 << 26, "overtemperature pre-warning"},
+	{1 << 25, "overtemperature"},
+	{1 << 24, "stall"},
+}
+
+// Telemetry returns the fault flags of the driver. The driver does not measure current,
+// temperature or voltage.
+func (m *Motor) Telemetry(ctx context.Context, extra map[string]interface{}) (motor.Telemetry, error) {
+	status, err := m.readReg(ctx, drvStatus)
+	if err != nil {
+		return motor.Telemetry{}, err
+	}
+	faults := []string{}
+	for _, f := range drvStatusFaults {
+		if status&f.bit != 0 {
+			faults = append(faults, f.name)
+		}
+	}
+	return motor.Telemetry{Faults: faults}, nil
+}
+
 // SetPower sets the motor at a particular rpm based on the percent of
 // maxRPM supplied by powerPct (between -1 and 1).
 func (m *Motor) SetPower(ctx context.Context, powerPct float64, extra map[string]interface{}) error {
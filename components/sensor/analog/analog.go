@@ -0,0 +1,242 @@
+// Package analog implements a sensor that converts the readings of a board analog reader to engineering units.
+package analog
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+	goutils "go.viam.com/utils"
+
+	"go.viam.com/rdk/components/board"
+	"go.viam.com/rdk/components/generic"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/control"
+	"go.viam.com/rdk/registry"
+)
+
+const modelname = "analog"
+
+// AlarmConfig describes the thresholds, in engineering units, at which the sensor reports an alarm.
+// An alarm clears once the value is back past its threshold by more than the hysteresis.
+type AlarmConfig struct {
+	Low        *float64 `json:"low,omitempty"`
+	High       *float64 `json:"high,omitempty"`
+	Hysteresis float64  `json:"hysteresis,omitempty"`
+}
+
+// AttrConfig is used for converting config attributes.
+type AttrConfig struct {
+	Board            string              `json:"board"`
+	AnalogReader     string              `json:"analog_reader"`
+	Calibration      *CalibrationConfig  `json:"calibration,omitempty"`
+	Filter           config.AttributeMap `json:"filter,omitempty"`
+	SamplesPerSecond int                 `json:"samples_per_second,omitempty"`
+	Units            string              `json:"units,omitempty"`
+	Alarms           *AlarmConfig        `json:"alarms,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (config *AttrConfig) Validate(path string) error {
+	if len(config.Board) == 0 {
+		return goutils.NewConfigValidationFieldRequiredError(path, "board")
+	}
+	if len(config.AnalogReader) == 0 {
+		return goutils.NewConfigValidationFieldRequiredError(path, "analog_reader")
+	}
+	if config.Calibration != nil {
+		if err := config.Calibration.Validate(); err != nil {
+			return goutils.NewConfigValidationError(path, err)
+		}
+	}
+	if config.Filter != nil {
+		if config.SamplesPerSecond <= 0 {
+			return goutils.NewConfigValidationFieldRequiredError(path, "samples_per_second")
+		}
+		if _, err := control.NewFilter(path, config.Filter); err != nil {
+			return goutils.NewConfigValidationError(path, err)
+		}
+	}
+	if config.Alarms != nil {
+		if config.Alarms.Low != nil && config.Alarms.High != nil && *config.Alarms.Low >= *config.Alarms.High {
+			return goutils.NewConfigValidationError(path, errors.New("low alarm must be below high alarm"))
+		}
+		if config.Alarms.Hysteresis < 0 {
+			return goutils.NewConfigValidationError(path, errors.New("alarm hysteresis cannot be negative"))
+		}
+	}
+	return nil
+}
+
+func init() {
+	registry.RegisterComponent(
+		sensor.Subtype,
+		modelname,
+		registry.Component{Constructor: func(
+			ctx context.Context,
+			deps registry.Dependencies,
+			config config.Component,
+			logger golog.Logger,
+		) (interface{}, error) {
+			return newSensor(ctx, deps, config.Name, config.ConvertedAttributes.(*AttrConfig), logger)
+		}})
+
+	config.RegisterComponentAttributeMapConverter(sensor.SubtypeName, modelname,
+		func(attributes config.AttributeMap) (interface{}, error) {
+			var conf AttrConfig
+			return config.TransformAttributeMapToStruct(&conf, attributes)
+		}, &AttrConfig{})
+}
+
+func newSensor(
+	ctx context.Context,
+	deps registry.Dependencies,
+	name string,
+	config *AttrConfig,
+	logger golog.Logger,
+) (sensor.Sensor, error) {
+	b, err := board.FromDependencies(deps, config.Board)
+	if err != nil {
+		return nil, errors.Wrapf(err, "analog sensor %q", name)
+	}
+	reader, ok := b.AnalogReaderByName(config.AnalogReader)
+	if !ok {
+		return nil, errors.Errorf("analog sensor %q: cannot find analog reader %q on board %q", name, config.AnalogReader, config.Board)
+	}
+	s := &Sensor{Name: name, config: config, reader: reader, logger: logger}
+	if config.Filter == nil {
+		return s, nil
+	}
+
+	s.filter, err = control.NewFilter(name, config.Filter)
+	if err != nil {
+		return nil, err
+	}
+	// the filter needs evenly spaced samples, so readings are taken in the background
+	// and Readings reports the most recent filtered value
+	s.lastErr = errors.New("no readings yet")
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.activeBackgroundWorkers.Add(1)
+	goutils.ManagedGo(func() { s.sample(cancelCtx) }, s.activeBackgroundWorkers.Done)
+	return s, nil
+}
+
+// Sensor reports the calibrated, and optionally filtered, value of an analog reader.
+type Sensor struct {
+	Name   string
+	config *AttrConfig
+	reader board.AnalogReader
+	filter control.Filter
+	logger golog.Logger
+
+	mu        sync.Mutex
+	raw       int
+	value     float64
+	lowAlarm  bool
+	highAlarm bool
+	lastErr   error
+
+	cancel                  func()
+	activeBackgroundWorkers sync.WaitGroup
+	generic.Unimplemented
+}
+
+func (s *Sensor) sample(ctx context.Context) {
+	interval := time.Second / time.Duration(s.config.SamplesPerSecond)
+	for {
+		start := time.Now()
+		raw, err := s.reader.Read(ctx, nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.logger.Debugw("error reading analog", "error", err)
+			s.mu.Lock()
+			s.lastErr = err
+			s.mu.Unlock()
+		} else {
+			value, ok := s.filter.Next(s.config.Calibration.Apply(float64(raw)))
+			// the outputs of a filter filling its history are not readings, and could raise alarms
+			if warming, isWarming := s.filter.(control.WarmingFilter); isWarming && !warming.Warm() {
+				ok = false
+			}
+			if ok {
+				s.update(raw, value)
+			}
+		}
+		if !goutils.SelectContextOrWait(ctx, interval-time.Since(start)) {
+			return
+		}
+	}
+}
+
+// update stores the latest reading and evaluates the alarms against it.
+func (s *Sensor) update(raw int, value float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.raw, s.value, s.lastErr = raw, value, nil
+	alarms := s.config.Alarms
+	if alarms == nil {
+		return
+	}
+	if alarms.Low != nil {
+		if s.lowAlarm {
+			s.lowAlarm = value <= *alarms.Low+alarms.Hysteresis
+		} else {
+			s.lowAlarm = value < *alarms.Low
+		}
+	}
+	if alarms.High != nil {
+		if s.highAlarm {
+			s.highAlarm = value >= *alarms.High-alarms.Hysteresis
+		} else {
+			s.highAlarm = value > *alarms.High
+		}
+	}
+}
+
+// Readings returns the value in engineering units along with the raw reading and alarm states.
+func (s *Sensor) Readings(ctx context.Context) (map[string]interface{}, error) {
+	if s.filter == nil {
+		raw, err := s.reader.Read(ctx, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "analog sensor %q", s.Name)
+		}
+		s.update(raw, s.config.Calibration.Apply(float64(raw)))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lastErr != nil {
+		return nil, errors.Wrapf(s.lastErr, "analog sensor %q", s.Name)
+	}
+	readings := map[string]interface{}{
+		"value": s.value,
+		"raw":   s.raw,
+	}
+	if s.config.Units != "" {
+		readings["units"] = s.config.Units
+	}
+	if alarms := s.config.Alarms; alarms != nil {
+		if alarms.Low != nil {
+			readings["low_alarm"] = s.lowAlarm
+		}
+		if alarms.High != nil {
+			readings["high_alarm"] = s.highAlarm
+		}
+	}
+	return readings, nil
+}
+
+// Close stops the background sampling, if any.
+func (s *Sensor) Close() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.activeBackgroundWorkers.Wait()
+	return nil
+}
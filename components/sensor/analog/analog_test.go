@@ -0,0 +1,237 @@
+package analog
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+
+	"go.viam.com/rdk/components/board"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+const (
+	testSensorName = "analog1"
+	testBoardName  = "board1"
+	testReaderName = "reader1"
+)
+
+func setupDependencies(reading *int64) registry.Dependencies {
+	reader := &inject.AnalogReader{}
+	reader.ReadFunc = func(ctx context.Context, extra map[string]interface{}) (int, error) {
+		return int(atomic.LoadInt64(reading)), nil
+	}
+	b := &inject.Board{}
+	b.AnalogReaderByNameFunc = func(name string) (board.AnalogReader, bool) {
+		return reader, name == testReaderName
+	}
+	return registry.Dependencies{board.Named(testBoardName): b}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestValidate(t *testing.T) {
+	cfg := &AttrConfig{}
+	err := cfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldContainSubstring, "error validating \"path\": \"board\" is required")
+
+	cfg.Board = testBoardName
+	err = cfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldContainSubstring, "error validating \"path\": \"analog_reader\" is required")
+
+	cfg.AnalogReader = testReaderName
+	test.That(t, cfg.Validate("path"), test.ShouldBeNil)
+
+	cfg.Calibration = &CalibrationConfig{Type: CalibrationTable, Points: []CalibrationPoint{{Raw: 1, Value: 2}}}
+	err = cfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldContainSubstring, "at least two points")
+	cfg.Calibration = &CalibrationConfig{Type: "cubic"}
+	err = cfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldContainSubstring, "unsupported calibration type")
+	cfg.Calibration = nil
+
+	cfg.Filter = config.AttributeMap{"type": "filterFIRMovingAverage", "filter_size": 5}
+	err = cfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldContainSubstring, "\"samples_per_second\" is required")
+	cfg.SamplesPerSecond = 100
+	test.That(t, cfg.Validate("path"), test.ShouldBeNil)
+	cfg.Filter = config.AttributeMap{"type": "filterIIRButterworth"}
+	err = cfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldContainSubstring, "should have a fs field")
+	cfg.Filter = nil
+
+	cfg.Alarms = &AlarmConfig{Low: floatPtr(10), High: floatPtr(5)}
+	err = cfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldContainSubstring, "low alarm must be below high alarm")
+	cfg.Alarms.High = floatPtr(20)
+	test.That(t, cfg.Validate("path"), test.ShouldBeNil)
+}
+
+func TestCalibration(t *testing.T) {
+	var cal *CalibrationConfig
+	test.That(t, cal.Apply(12), test.ShouldEqual, 12)
+
+	cal = &CalibrationConfig{Type: CalibrationLinear, Slope: 0.5, Offset: -10}
+	test.That(t, cal.Validate(), test.ShouldBeNil)
+	test.That(t, cal.Apply(100), test.ShouldAlmostEqual, 40)
+
+	cal = &CalibrationConfig{Type: CalibrationPolynomial, Coefficients: []float64{1, 2, 3}}
+	test.That(t, cal.Validate(), test.ShouldBeNil)
+	test.That(t, cal.Apply(2), test.ShouldAlmostEqual, 17)
+
+	cal = &CalibrationConfig{Type: CalibrationTable, Points: []CalibrationPoint{
+		{Raw: 100, Value: 10},
+		{Raw: 0, Value: 0},
+		{Raw: 200, Value: 40},
+	}}
+	test.That(t, cal.Validate(), test.ShouldBeNil)
+	test.That(t, cal.Apply(50), test.ShouldAlmostEqual, 5)
+	test.That(t, cal.Apply(150), test.ShouldAlmostEqual, 25)
+	test.That(t, cal.Apply(100), test.ShouldAlmostEqual, 10)
+	test.That(t, cal.Apply(-100), test.ShouldAlmostEqual, -10)
+	test.That(t, cal.Apply(300), test.ShouldAlmostEqual, 70)
+
+	cal.Points = append(cal.Points, CalibrationPoint{Raw: 100, Value: 11})
+	test.That(t, cal.Validate().Error(), test.ShouldContainSubstring, "duplicate raw value")
+}
+
+func TestReadings(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	reading := int64(100)
+	deps := setupDependencies(&reading)
+
+	_, err := newSensor(ctx, deps, testSensorName, &AttrConfig{Board: "other", AnalogReader: testReaderName}, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = newSensor(ctx, deps, testSensorName, &AttrConfig{Board: testBoardName, AnalogReader: "other"}, logger)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "cannot find analog reader \"other\"")
+
+	cfg := &AttrConfig{
+		Board:        testBoardName,
+		AnalogReader: testReaderName,
+		Calibration:  &CalibrationConfig{Type: CalibrationLinear, Slope: 0.1},
+		Units:        "kPa",
+		Alarms:       &AlarmConfig{Low: floatPtr(5), High: floatPtr(20), Hysteresis: 1},
+	}
+	s, err := newSensor(ctx, deps, testSensorName, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	readings, err := s.Readings(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, readings, test.ShouldResemble, map[string]interface{}{
+		"value": 10., "raw": 100, "units": "kPa", "low_alarm": false, "high_alarm": false,
+	})
+
+	expectAlarms := func(raw int64, low, high bool) {
+		t.Helper()
+		atomic.StoreInt64(&reading, raw)
+		readings, err := s.Readings(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, readings["low_alarm"], test.ShouldEqual, low)
+		test.That(t, readings["high_alarm"], test.ShouldEqual, high)
+	}
+	expectAlarms(210, false, true)
+	// within the hysteresis band the alarm stays set
+	expectAlarms(195, false, true)
+	expectAlarms(185, false, false)
+	expectAlarms(40, true, false)
+	expectAlarms(55, true, false)
+	expectAlarms(65, false, false)
+	test.That(t, s.(*Sensor).Close(), test.ShouldBeNil)
+}
+
+func TestFilteredReadings(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	reading := int64(100)
+	deps := setupDependencies(&reading)
+
+	cfg := &AttrConfig{
+		Board:            testBoardName,
+		AnalogReader:     testReaderName,
+		Calibration:      &CalibrationConfig{Type: CalibrationLinear, Slope: 2},
+		Filter:           config.AttributeMap{"type": "filterFIRMovingAverage", "filter_size": 4},
+		SamplesPerSecond: 200,
+	}
+	s, err := newSensor(ctx, deps, testSensorName, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, s.(*Sensor).Close(), test.ShouldBeNil)
+	}()
+
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		readings, err := s.Readings(ctx)
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, readings["value"], test.ShouldAlmostEqual, 200)
+	})
+
+	atomic.StoreInt64(&reading, 300)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		readings, err := s.Readings(ctx)
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, readings["value"], test.ShouldAlmostEqual, 600)
+		test.That(tb, readings["raw"], test.ShouldEqual, 300)
+	})
+}
+
+func TestFilterWarmUp(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+
+	// the reader blocks after the first two reads, before the window of the filter is full
+	var reads int64
+	release := make(chan struct{})
+	reader := &inject.AnalogReader{}
+	reader.ReadFunc = func(ctx context.Context, extra map[string]interface{}) (int, error) {
+		if atomic.AddInt64(&reads, 1) > 2 {
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+		return 100, nil
+	}
+	b := &inject.Board{}
+	b.AnalogReaderByNameFunc = func(name string) (board.AnalogReader, bool) {
+		return reader, true
+	}
+	deps := registry.Dependencies{board.Named(testBoardName): b}
+
+	cfg := &AttrConfig{
+		Board:            testBoardName,
+		AnalogReader:     testReaderName,
+		Filter:           config.AttributeMap{"type": "filterFIRMovingAverage", "filter_size": 4},
+		Alarms:           &AlarmConfig{Low: floatPtr(50)},
+		SamplesPerSecond: 200,
+	}
+	s, err := newSensor(ctx, deps, testSensorName, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, s.(*Sensor).Close(), test.ShouldBeNil)
+	}()
+
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, atomic.LoadInt64(&reads), test.ShouldEqual, 3)
+	})
+	_, err = s.Readings(ctx)
+	test.That(t, err, test.ShouldBeError, errors.New(`analog sensor "analog1": no readings yet`))
+
+	close(release)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		readings, err := s.Readings(ctx)
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, readings, test.ShouldResemble, map[string]interface{}{"value": 100., "raw": 100, "low_alarm": false})
+	})
+}
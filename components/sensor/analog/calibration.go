@@ -0,0 +1,99 @@
+package analog
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// The supported calibration curves.
+const (
+	CalibrationLinear     = "linear"
+	CalibrationPolynomial = "polynomial"
+	CalibrationTable      = "table"
+)
+
+// CalibrationPoint maps a raw analog reading to a value in engineering units.
+type CalibrationPoint struct {
+	Raw   float64 `json:"raw"`
+	Value float64 `json:"value"`
+}
+
+// CalibrationConfig describes how raw analog readings are converted to engineering units.
+// A linear curve computes slope*raw + offset. A polynomial curve evaluates the coefficients,
+// lowest order first. A table curve linearly interpolates between points sorted by raw value,
+// and extrapolates from the two closest points outside of the table.
+type CalibrationConfig struct {
+	Type         string             `json:"type"`
+	Slope        float64            `json:"slope,omitempty"`
+	Offset       float64            `json:"offset,omitempty"`
+	Coefficients []float64          `json:"coefficients,omitempty"`
+	Points       []CalibrationPoint `json:"points,omitempty"`
+}
+
+// Validate ensures the calibration curve is well defined.
+func (cal *CalibrationConfig) Validate() error {
+	switch cal.Type {
+	case CalibrationLinear:
+		if cal.Slope == 0 {
+			return errors.New("linear calibration must have a non-zero slope")
+		}
+	case CalibrationPolynomial:
+		if len(cal.Coefficients) == 0 {
+			return errors.New("polynomial calibration must have at least one coefficient")
+		}
+	case CalibrationTable:
+		if len(cal.Points) < 2 {
+			return errors.New("table calibration must have at least two points")
+		}
+		points := cal.sortedPoints()
+		for i := 1; i < len(points); i++ {
+			if points[i].Raw == points[i-1].Raw {
+				return errors.Errorf("table calibration has duplicate raw value %v", points[i].Raw)
+			}
+		}
+	default:
+		return errors.Errorf("unsupported calibration type %q", cal.Type)
+	}
+	return nil
+}
+
+// Apply converts a raw reading to engineering units. A nil calibration returns the raw reading.
+func (cal *CalibrationConfig) Apply(raw float64) float64 {
+	if cal == nil {
+		return raw
+	}
+	switch cal.Type {
+	case CalibrationLinear:
+		return cal.Slope*raw + cal.Offset
+	case CalibrationPolynomial:
+		value := 0.
+		for i := len(cal.Coefficients) - 1; i >= 0; i-- {
+			value = value*raw + cal.Coefficients[i]
+		}
+		return value
+	case CalibrationTable:
+		points := cal.sortedPoints()
+		i := sort.Search(len(points), func(i int) bool { return points[i].Raw >= raw })
+		switch {
+		case i == 0:
+			i = 1
+		case i == len(points):
+			i = len(points) - 1
+		}
+		lo, hi := points[i-1], points[i]
+		return lo.Value + (raw-lo.Raw)*(hi.Value-lo.Value)/(hi.Raw-lo.Raw)
+	default:
+		return raw
+	}
+}
+
+func (cal *CalibrationConfig) sortedPoints() []CalibrationPoint {
+	if sort.SliceIsSorted(cal.Points, func(i, j int) bool { return cal.Points[i].Raw < cal.Points[j].Raw }) {
+		return cal.Points
+	}
+	points := make([]CalibrationPoint, len(cal.Points))
+	copy(points, cal.Points)
+	sort.Slice(points, func(i, j int) bool { return points[i].Raw < points[j].Raw })
+	return points
+}
@@ -3,6 +3,7 @@ package register
 
 import (
 	// for Sensors.
+	_ "go.viam.com/rdk/components/sensor/analog"
 	_ "go.viam.com/rdk/components/sensor/bme280"
 	_ "go.viam.com/rdk/components/sensor/charge"
 	_ "go.viam.com/rdk/components/sensor/ds18b20"
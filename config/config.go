@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 	"go.viam.com/utils"
 	"go.viam.com/utils/pexec"
 	"go.viam.com/utils/rpc"
@@ -41,51 +42,43 @@ type Config struct {
 	// If false, it's for creating a robot via the RDK library. This is helpful for
 	// error messages that can indicate flags/config fields to use.
 	FromCommand bool `json:"-"`
+
+	// includedFiles are the files, other than ConfigFilePath, the config was composed from.
+	includedFiles []string
 }
 
-// Ensure ensures all parts of the config are valid.
+// Ensure ensures all parts of the config are valid. Every part is validated, and the errors of all
+// invalid parts are returned combined.
 func (c *Config) Ensure(fromCloud bool) error {
+	var errs error
 	if c.Cloud != nil {
-		if err := c.Cloud.Validate("cloud", fromCloud); err != nil {
-			return err
-		}
+		errs = multierr.Append(errs, c.Cloud.Validate("cloud", fromCloud))
 	}
 
 	for idx := 0; idx < len(c.Remotes); idx++ {
-		if err := c.Remotes[idx].Validate(fmt.Sprintf("%s.%d", "remotes", idx)); err != nil {
-			return err
-		}
+		errs = multierr.Append(errs, c.Remotes[idx].Validate(fmt.Sprintf("%s.%d", "remotes", idx)))
 	}
 
 	for idx := 0; idx < len(c.Components); idx++ {
 		dependsOn, err := c.Components[idx].Validate(fmt.Sprintf("%s.%d", "components", idx))
 		if err != nil {
-			return errors.Errorf("error validating component %s: %s", c.Components[idx].Name, err)
+			errs = multierr.Append(errs, errors.Errorf("error validating component %s: %s", c.Components[idx].Name, err))
+			continue
 		}
 		c.Components[idx].ImplicitDependsOn = dependsOn
 	}
 
 	for idx := 0; idx < len(c.Processes); idx++ {
-		if err := c.Processes[idx].Validate(fmt.Sprintf("%s.%d", "processes", idx)); err != nil {
-			return err
-		}
+		errs = multierr.Append(errs, c.Processes[idx].Validate(fmt.Sprintf("%s.%d", "processes", idx)))
 	}
 
 	for idx := 0; idx < len(c.Services); idx++ {
-		if err := c.Services[idx].Validate(fmt.Sprintf("%s.%d", "services", idx)); err != nil {
-			return err
-		}
-	}
-
-	if err := c.Network.Validate("network"); err != nil {
-		return err
-	}
-
-	if err := c.Auth.Validate("auth"); err != nil {
-		return err
+		errs = multierr.Append(errs, c.Services[idx].Validate(fmt.Sprintf("%s.%d", "services", idx)))
 	}
 
-	return nil
+	errs = multierr.Append(errs, c.Network.Validate("network"))
+	errs = multierr.Append(errs, c.Auth.Validate("auth"))
+	return errs
 }
 
 // FindComponent finds a particular component by name.
@@ -290,6 +283,55 @@ func (nc *NetworkConfig) Validate(path string) error {
 type AuthConfig struct {
 	Handlers        []AuthHandlerConfig `json:"handlers"`
 	TLSAuthEntities []string            `json:"tls_auth_entities"`
+
+	// Roles, RoleBindings and DefaultRole configure authorization of callers. If none of them
+	// are set, every caller may call every method. When there are no handlers, the web server
+	// does not authenticate callers and they only get the default role.
+	Roles        []RoleConfig        `json:"roles,omitempty"`
+	RoleBindings []RoleBindingConfig `json:"role_bindings,omitempty"`
+	DefaultRole  string              `json:"default_role,omitempty"`
+}
+
+// AuthorizationEnabled returns whether calls must be authorized against roles.
+func (config *AuthConfig) AuthorizationEnabled() bool {
+	return len(config.Roles) != 0 || len(config.RoleBindings) != 0 || config.DefaultRole != ""
+}
+
+// An AccessLevel is the broadest set of RPC methods a role may call.
+type AccessLevel string
+
+// The access levels, from least to most privileged. Each of them is also the name of a built-in role
+// giving that access to every resource.
+const (
+	// AccessReadOnly allows methods that only read state, such as Get* methods and ResourceNames.
+	AccessReadOnly = AccessLevel("read_only")
+	// AccessOperator allows every method except administrative ones, such as the shell service.
+	AccessOperator = AccessLevel("operator")
+	// AccessAdmin allows every method.
+	AccessAdmin = AccessLevel("admin")
+)
+
+// RoleConfig describes a role that callers can be bound to.
+type RoleConfig struct {
+	Name   string      `json:"name"`
+	Access AccessLevel `json:"access"`
+	// Resources restricts the role to the given resources, each either a resource name ("arm1"),
+	// a subtype ("rdk:component:arm") or a full resource name ("rdk:component:arm/arm1").
+	// An empty list allows every resource.
+	Resources []string `json:"resources,omitempty"`
+	// Methods further restricts the role to the given RPC methods, each either a full method
+	// ("/viam.component.arm.v1.ArmService/GetEndPosition"), a service wildcard
+	// ("/viam.component.arm.v1.ArmService/*") or a bare method name ("GetEndPosition").
+	// An empty list allows every method permitted by the access level.
+	Methods []string `json:"methods,omitempty"`
+}
+
+// RoleBindingConfig binds callers to a role, either by the API key they authenticated
+// with or by their authenticated entity (e.g. a TLS certificate DNS name).
+type RoleBindingConfig struct {
+	Role     string   `json:"role"`
+	APIKeys  []string `json:"api_keys,omitempty"`
+	Entities []string `json:"entities,omitempty"`
 }
 
 // AuthHandlerConfig describes the configuration for a particular auth handler.
@@ -311,6 +353,62 @@ func (config *AuthConfig) Validate(path string) error {
 			return err
 		}
 	}
+
+	if !config.AuthorizationEnabled() {
+		return nil
+	}
+	roles := map[string]struct{}{
+		string(AccessReadOnly): {},
+		string(AccessOperator): {},
+		string(AccessAdmin):    {},
+	}
+	for idx, role := range config.Roles {
+		rolePath := fmt.Sprintf("%s.%s.%d", path, "roles", idx)
+		if _, ok := roles[role.Name]; ok {
+			return utils.NewConfigValidationError(rolePath, errors.Errorf("duplicate role %q", role.Name))
+		}
+		if err := role.Validate(rolePath); err != nil {
+			return err
+		}
+		roles[role.Name] = struct{}{}
+	}
+	for idx, binding := range config.RoleBindings {
+		bindingPath := fmt.Sprintf("%s.%s.%d", path, "role_bindings", idx)
+		if _, ok := roles[binding.Role]; !ok {
+			return utils.NewConfigValidationError(bindingPath, errors.Errorf("unknown role %q", binding.Role))
+		}
+		if len(binding.APIKeys) == 0 && len(binding.Entities) == 0 {
+			return utils.NewConfigValidationError(bindingPath, errors.New("api_keys or entities is required"))
+		}
+	}
+	if _, ok := roles[config.DefaultRole]; config.DefaultRole != "" && !ok {
+		return utils.NewConfigValidationError(path, errors.Errorf("unknown default_role %q", config.DefaultRole))
+	}
+	return nil
+}
+
+// Validate ensures all parts of the config are valid.
+func (config *RoleConfig) Validate(path string) error {
+	if config.Name == "" {
+		return utils.NewConfigValidationFieldRequiredError(path, "name")
+	}
+	switch config.Access {
+	case AccessReadOnly, AccessOperator, AccessAdmin:
+	case "":
+		return utils.NewConfigValidationFieldRequiredError(path, "access")
+	default:
+		return utils.NewConfigValidationError(path, errors.Errorf("unknown access level %q", config.Access))
+	}
+	for _, res := range config.Resources {
+		if res == "" {
+			return utils.NewConfigValidationError(path, errors.New("resources cannot contain an empty resource"))
+		}
+	}
+	for _, method := range config.Methods {
+		if method == "" {
+			return utils.NewConfigValidationError(path, errors.New("methods cannot contain an empty method"))
+		}
+	}
 	return nil
 }
 
@@ -324,6 +422,17 @@ func (config *AuthHandlerConfig) Validate(path string) error {
 		if config.Config.String("key") == "" && len(config.Config.StringSlice("keys")) == 0 {
 			return utils.NewConfigValidationError(fmt.Sprintf("%s.config", path), errors.New("key or keys is required"))
 		}
+	case rutils.CredentialsTypeJWT:
+		if config.Config.String("audience") == "" {
+			return utils.NewConfigValidationFieldRequiredError(fmt.Sprintf("%s.config", path), "audience")
+		}
+		if config.Config.String("issuer") == "" && config.Config.String("jwks_file") == "" {
+			return utils.NewConfigValidationError(fmt.Sprintf("%s.config", path), errors.New("issuer or jwks_file is required"))
+		}
+	case rutils.CredentialsTypeMutualTLS:
+		if config.Config.String("ca_file") == "" {
+			return utils.NewConfigValidationFieldRequiredError(fmt.Sprintf("%s.config", path), "ca_file")
+		}
 	default:
 		return utils.NewConfigValidationError(path, errors.Errorf("do not know how to handle auth for %q", config.Type))
 	}
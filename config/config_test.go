@@ -13,6 +13,7 @@ import (
 	"github.com/golang/geo/r3"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 	"go.viam.com/test"
 	"go.viam.com/utils/pexec"
 	"go.viam.com/utils/rpc"
@@ -315,6 +316,76 @@ func TestConfigEnsure(t *testing.T) {
 	}
 
 	test.That(t, invalidAuthConfig.Ensure(false), test.ShouldBeNil)
+
+	invalidAuthConfig.Auth.Handlers = []config.AuthHandlerConfig{
+		validAPIKeyHandler,
+		{Type: rutils.CredentialsTypeJWT, Config: config.AttributeMap{"issuer": "https://idp.example.com"}},
+	}
+	err = invalidAuthConfig.Ensure(false)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `auth.handlers.1`)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `"audience" is required`)
+
+	invalidAuthConfig.Auth.Handlers[1].Config = config.AttributeMap{"audience": "rdk"}
+	err = invalidAuthConfig.Ensure(false)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `issuer or jwks_file is required`)
+
+	invalidAuthConfig.Auth.Handlers[1].Config = config.AttributeMap{"audience": "rdk", "jwks_file": "jwks.json"}
+	invalidAuthConfig.Auth.Handlers = append(invalidAuthConfig.Auth.Handlers, config.AuthHandlerConfig{Type: rutils.CredentialsTypeMutualTLS})
+	err = invalidAuthConfig.Ensure(false)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `auth.handlers.2`)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `"ca_file" is required`)
+
+	invalidAuthConfig.Auth.Handlers[2].Config = config.AttributeMap{"ca_file": "ca.pem"}
+	test.That(t, invalidAuthConfig.Ensure(false), test.ShouldBeNil)
+
+	invalidAuthConfig.Auth.Roles = []config.RoleConfig{{Name: "operator", Access: config.AccessReadOnly}}
+	err = invalidAuthConfig.Ensure(false)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `auth.roles.0`)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `duplicate role`)
+
+	invalidAuthConfig.Auth.Roles = []config.RoleConfig{{Name: "arm_operator", Access: "superuser"}}
+	err = invalidAuthConfig.Ensure(false)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `unknown access level`)
+
+	invalidAuthConfig.Auth.Roles = []config.RoleConfig{{Name: "arm_operator", Access: config.AccessOperator, Resources: []string{"arm1"}}}
+	invalidAuthConfig.Auth.RoleBindings = []config.RoleBindingConfig{{Role: "arm_driver", APIKeys: []string{"one"}}}
+	err = invalidAuthConfig.Ensure(false)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `auth.role_bindings.0`)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `unknown role`)
+
+	invalidAuthConfig.Auth.RoleBindings = []config.RoleBindingConfig{{Role: "arm_operator"}}
+	err = invalidAuthConfig.Ensure(false)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `api_keys or entities is required`)
+
+	invalidAuthConfig.Auth.RoleBindings = []config.RoleBindingConfig{{Role: "arm_operator", APIKeys: []string{"one"}}}
+	invalidAuthConfig.Auth.DefaultRole = "nobody"
+	err = invalidAuthConfig.Ensure(false)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `unknown default_role`)
+
+	invalidAuthConfig.Auth.DefaultRole = string(config.AccessReadOnly)
+	test.That(t, invalidAuthConfig.Ensure(false), test.ShouldBeNil)
+}
+
+func TestConfigEnsureCollectsErrors(t *testing.T) {
+	invalidConfig := config.Config{
+		Remotes:    []config.Remote{{Name: "foo"}},
+		Components: []config.Component{{}},
+		Processes:  []pexec.ProcessConfig{{ID: "bar"}},
+	}
+	err := invalidConfig.Ensure(false)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, multierr.Errors(err), test.ShouldHaveLength, 3)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `remotes.0`)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `components.0`)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `processes.0`)
 }
 
 func TestCopyOnlyPublicFields(t *testing.T) {
@@ -11,13 +11,16 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"runtime"
+	"strings"
 
 	"github.com/a8m/envsubst"
 	"github.com/edaniels/golog"
 	"github.com/mitchellh/copystructure"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 	apppb "go.viam.com/api/app/v1"
 	"go.viam.com/utils"
 	"go.viam.com/utils/artifact"
@@ -530,7 +533,7 @@ func Read(
 	filePath string,
 	logger golog.Logger,
 ) (*Config, error) {
-	buf, err := envsubst.ReadFile(filePath)
+	buf, err := readConfigFile(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -538,13 +541,19 @@ func Read(
 	return FromReader(ctx, filePath, bytes.NewReader(buf), logger)
 }
 
+// sourceFiles returns the files a config read from a file was read from, including those it
+// includes or substitutes.
+func (c *Config) sourceFiles() []string {
+	return append([]string{c.ConfigFilePath}, c.includedFiles...)
+}
+
 // ReadLocalConfig reads a config from the given file but does not fetch any config from the remote servers.
 func ReadLocalConfig(
 	ctx context.Context,
 	filePath string,
 	logger golog.Logger,
 ) (*Config, error) {
-	buf, err := envsubst.ReadFile(filePath)
+	buf, err := readConfigFile(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -552,6 +561,16 @@ func ReadLocalConfig(
 	return fromReader(ctx, filePath, bytes.NewReader(buf), logger, false)
 }
 
+// ReadUnprocessed reads a config from the given file without processing nor validating it, such as
+// to pass it to ValidateConfig.
+func ReadUnprocessed(filePath string) (*Config, error) {
+	buf, err := readConfigFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return decodeConfig(filePath, bytes.NewReader(buf))
+}
+
 // FromReader reads a config from the given reader and specifies
 // where, if applicable, the file the reader originated from.
 func FromReader(
@@ -573,14 +592,11 @@ func fromReader(
 	shouldReadFromCloud bool,
 ) (*Config, error) {
 	// First read and processes config from disk
-	unprocessedConfig := Config{
-		ConfigFilePath: originalPath,
-	}
-	err := json.NewDecoder(r).Decode(&unprocessedConfig)
+	unprocessedConfig, err := decodeConfig(originalPath, r)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to decode Config from json")
+		return nil, err
 	}
-	cfgFromDisk, err := processConfigLocalConfig(&unprocessedConfig)
+	cfgFromDisk, err := processConfigLocalConfig(unprocessedConfig)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to process Config")
 	}
@@ -593,6 +609,374 @@ func fromReader(
 	return cfgFromDisk, err
 }
 
+// The keys of a config file that compose it from other configs. They are resolved when the file is
+// read, so that the Config read holds the composed config only.
+const (
+	// includeKey lists the files, relative to the including one, whose configs are merged into the
+	// config. The lists of resources and processes are concatenated, included ones first, while
+	// other fields of the including config take precedence over included ones.
+	includeKey = "include"
+	// fragmentsKey defines named config fragments: lists of resources and processes, along with
+	// the default values of the "params" they use through ${param:name} substitutions. Fragments
+	// defined by included files can be used by the including one.
+	fragmentsKey = "fragments"
+	// useFragmentsKey lists the fragments merged into the config, each with the "params"
+	// overriding the defaults of the fragment.
+	useFragmentsKey = "use_fragments"
+	// fragmentParamsKey holds the parameters of fragments and fragment uses.
+	fragmentParamsKey = "params"
+)
+
+// composedListKeys are the keys of the config lists that are concatenated when composing configs.
+var composedListKeys = []string{"remotes", "components", "processes", "services"}
+
+// substitutionPattern matches the ${env:VAR}, ${file:path} and ${param:name} substitutions of
+// config strings. Files are read relative to the config file the substitution appears in and
+// parameters are those of the fragment the substitution appears in.
+var substitutionPattern = regexp.MustCompile(`\$\{(env|file|param):([^}]*)\}`)
+
+// readConfigFile reads the config file at path, substituting the environment variables it
+// references with envsubst but leaving the substitutions of config composition to decodeConfig.
+func readConfigFile(path string) ([]byte, error) {
+	//nolint:gosec
+	rd, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// $$ escapes $ for envsubst
+	rd = substitutionPattern.ReplaceAll(rd, []byte("$$$0"))
+	return envsubst.Bytes(rd)
+}
+
+// decodeConfig decodes the JSON config read from r, composing it with the files it includes and
+// the fragments it uses and performing its substitutions. path is the file the config was read
+// from, if any, against which relative paths are resolved.
+func decodeConfig(path string, r io.Reader) (*Config, error) {
+	var doc map[string]interface{}
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode Config from json")
+	}
+	c := &configComposer{visiting: map[string]bool{}}
+	if path != "" {
+		if abs, err := filepath.Abs(path); err == nil {
+			c.visiting[abs] = true
+		}
+	}
+	composed, err := c.compose(doc, path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compose Config")
+	}
+	// the fragments were only kept for includers, they are not part of the config
+	delete(composed, fragmentsKey)
+	md, err := json.Marshal(composed)
+	if err != nil {
+		return nil, err
+	}
+	cfg := Config{ConfigFilePath: path, includedFiles: c.files}
+	if err := json.Unmarshal(md, &cfg); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode Config from json")
+	}
+	return &cfg, nil
+}
+
+// A configComposer composes a config document from the files it includes and the fragments it uses.
+type configComposer struct {
+	// visiting holds the absolute paths of the files being included, to detect include cycles.
+	visiting map[string]bool
+	// files lists the files read while composing.
+	files []string
+}
+
+// compose returns the config document doc, read from the file at path, composed with the files it
+// includes and the fragments it uses, and with its substitutions performed. The fragments defined
+// along the way are kept in the returned document so that includers can use them.
+func (c *configComposer) compose(doc map[string]interface{}, path string) (map[string]interface{}, error) {
+	dir := filepath.Dir(path)
+	composed := map[string]interface{}{}
+	fragments := map[string]interface{}{}
+
+	includes, err := stringList(doc[includeKey], includeKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, include := range includes {
+		includePath := include
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(dir, includePath)
+		}
+		included, err := c.include(includePath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to include %q", include)
+		}
+		if err := mergeFragments(fragments, included[fragmentsKey]); err != nil {
+			return nil, err
+		}
+		delete(included, fragmentsKey)
+		mergeConfigDocument(composed, included)
+	}
+	if err := mergeFragments(fragments, doc[fragmentsKey]); err != nil {
+		return nil, err
+	}
+
+	uses, ok := doc[useFragmentsKey].([]interface{})
+	if !ok && doc[useFragmentsKey] != nil {
+		return nil, errors.Errorf("%q must be a list", useFragmentsKey)
+	}
+	for i, use := range uses {
+		instance, err := useFragment(fragments, use)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s.%d", useFragmentsKey, i)
+		}
+		mergeConfigDocument(composed, instance)
+	}
+
+	own := map[string]interface{}{}
+	for k, v := range doc {
+		switch k {
+		case includeKey, fragmentsKey, useFragmentsKey:
+		default:
+			own[k] = v
+		}
+	}
+	mergeConfigDocument(composed, own)
+
+	substituted, err := c.substitute(composed, dir)
+	if err != nil {
+		return nil, err
+	}
+	result := substituted.(map[string]interface{})
+	if len(fragments) != 0 {
+		result[fragmentsKey] = fragments
+	}
+	return result, nil
+}
+
+// include reads and composes the config file at path.
+func (c *configComposer) include(path string) (map[string]interface{}, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if c.visiting[abs] {
+		return nil, errors.New("include cycle")
+	}
+	c.visiting[abs] = true
+	defer delete(c.visiting, abs)
+	c.files = append(c.files, path)
+
+	rd, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(rd))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, errors.Wrap(err, "failed to decode json")
+	}
+	return c.compose(doc, path)
+}
+
+// substitute performs the ${env:VAR} and ${file:path} substitutions of the strings of v,
+// resolving file paths against dir. Fragment parameters must have been substituted already.
+func (c *configComposer) substitute(v interface{}, dir string) (interface{}, error) {
+	return mapStrings(v, func(match, kind, name string) (string, error) {
+		switch kind {
+		case "env":
+			value, ok := os.LookupEnv(name)
+			if !ok {
+				return "", errors.Errorf("environment variable %q is not set", name)
+			}
+			return value, nil
+		case "file":
+			path := name
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+			c.files = append(c.files, path)
+			//nolint:gosec
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimRight(string(content), "\r\n"), nil
+		default:
+			return "", errors.Errorf("parameter %q used outside of a fragment", name)
+		}
+	})
+}
+
+// mapStrings returns v with the substitutions of its strings replaced by what fn returns for them.
+func mapStrings(v interface{}, fn func(match, kind, name string) (string, error)) (interface{}, error) {
+	return mapStringValues(v, func(str string) (interface{}, error) {
+		return substituteString(str, fn)
+	})
+}
+
+// substituteString returns str with its substitutions replaced by what fn returns for them.
+func substituteString(str string, fn func(match, kind, name string) (string, error)) (string, error) {
+	var allErrs error
+	substituted := substitutionPattern.ReplaceAllStringFunc(str, func(match string) string {
+		parts := substitutionPattern.FindStringSubmatch(match)
+		value, err := fn(match, parts[1], parts[2])
+		allErrs = multierr.Combine(allErrs, err)
+		return value
+	})
+	return substituted, allErrs
+}
+
+// mapStringValues returns v with each of its strings replaced by the value fn returns for it.
+func mapStringValues(v interface{}, fn func(str string) (interface{}, error)) (interface{}, error) {
+	switch v := v.(type) {
+	case string:
+		return fn(v)
+	case []interface{}:
+		out := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			mapped, err := mapStringValues(item, fn)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, mapped)
+		}
+		return out, nil
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			mapped, err := mapStringValues(item, fn)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = mapped
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+// useFragment returns the config document of the fragment use, with its parameters substituted.
+func useFragment(fragments map[string]interface{}, use interface{}) (map[string]interface{}, error) {
+	useDoc, ok := use.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("fragment use must be an object")
+	}
+	name, ok := useDoc["fragment"].(string)
+	if !ok {
+		return nil, errors.New(`"fragment" is required`)
+	}
+	fragment, ok := fragments[name].(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("unknown fragment %q", name)
+	}
+	params := map[string]interface{}{}
+	if defaults, ok := fragment[fragmentParamsKey].(map[string]interface{}); ok {
+		for k, v := range defaults {
+			params[k] = v
+		}
+	}
+	if overrides, ok := useDoc[fragmentParamsKey].(map[string]interface{}); ok {
+		for k, v := range overrides {
+			params[k] = v
+		}
+	}
+	instance := map[string]interface{}{}
+	for _, key := range composedListKeys {
+		if list, ok := fragment[key]; ok {
+			instance[key] = list
+		}
+	}
+	// other substitutions are left to the using config, so file paths in fragments are relative
+	// to the file using them
+	lookup := func(param string) (interface{}, error) {
+		value, ok := params[param]
+		if !ok {
+			return nil, errors.Errorf("parameter %q is not set", param)
+		}
+		return value, nil
+	}
+	substituted, err := mapStringValues(instance, func(str string) (interface{}, error) {
+		// a string that is exactly one parameter takes its value as is, keeping numbers, booleans
+		// and objects typed; parameters within a longer string are interpolated into it
+		if parts := substitutionPattern.FindStringSubmatch(str); parts != nil && parts[0] == str && parts[1] == "param" {
+			return lookup(parts[2])
+		}
+		return substituteString(str, func(match, kind, param string) (string, error) {
+			if kind != "param" {
+				return match, nil
+			}
+			value, err := lookup(param)
+			return fmt.Sprint(value), err
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "fragment %q", name)
+	}
+	return substituted.(map[string]interface{}), nil
+}
+
+// mergeFragments adds the fragment definitions of defs to fragments, replacing those of the same name.
+func mergeFragments(fragments map[string]interface{}, defs interface{}) error {
+	if defs == nil {
+		return nil
+	}
+	defsMap, ok := defs.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("%q must be an object", fragmentsKey)
+	}
+	for name, def := range defsMap {
+		if _, ok := def.(map[string]interface{}); !ok {
+			return errors.Errorf("fragment %q must be an object", name)
+		}
+		fragments[name] = def
+	}
+	return nil
+}
+
+// mergeConfigDocument merges the config document src into dst: lists of resources and processes are
+// appended to, other fields replaced.
+func mergeConfigDocument(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if list, ok := v.([]interface{}); ok && isComposedListKey(k) {
+			existing, _ := dst[k].([]interface{})
+			dst[k] = append(existing, list...)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+func isComposedListKey(key string) bool {
+	for _, k := range composedListKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// stringList returns v as a list of strings, or an error naming key if it is not one.
+func stringList(v interface{}, key string) ([]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, errors.Errorf("%q must be a list of strings", key)
+	}
+	strs := make([]string, 0, len(list))
+	for _, item := range list {
+		str, ok := item.(string)
+		if !ok {
+			return nil, errors.Errorf("%q must be a list of strings", key)
+		}
+		strs = append(strs, str)
+	}
+	return strs, nil
+}
+
 // processConfigFromCloud returns a copy of the current config with all attributes parsed
 // and config validated with the assumption the config came from the cloud.
 // Returns an error if the unprocessedConfig is non-valid.
@@ -619,53 +1003,95 @@ func processConfig(unprocessedConfig *Config, fromCloud bool) (*Config, error) {
 
 	// Copy does not presve ConfigFilePath and we need to pass it along manually
 	cfg.ConfigFilePath = unprocessedConfig.ConfigFilePath
+	cfg.includedFiles = unprocessedConfig.includedFiles
 
-	for idx, c := range cfg.Components {
-		conv := findMapConverter(c.Type, c.Model)
-		// inner attributes may have their own converters
-		for k, v := range c.Attributes {
-			attrConv := findConverter(c.Type, c.Model, k)
-			if attrConv == nil {
-				continue
-			}
-
-			n, err := attrConv(v)
-			if err != nil {
-				return nil, errors.Wrapf(err, "error converting attribute for (%s, %s, %s)", c.Type, c.Model, k)
-			}
-			cfg.Components[idx].Attributes[k] = n
-		}
-		if conv == nil {
-			continue
+	for idx := range cfg.Components {
+		if err := convertComponentAttributes(&cfg.Components[idx]); err != nil {
+			return nil, err
 		}
+	}
 
-		converted, err := conv(c.Attributes)
-		if err != nil {
-			return nil, errors.Wrapf(err, "error converting attributes for (%s, %s)", c.Type, c.Model)
+	for idx := range cfg.Services {
+		if err := convertServiceAttributes(&cfg.Services[idx]); err != nil {
+			return nil, err
 		}
-		cfg.Components[idx].Attributes = nil
-		cfg.Components[idx].ConvertedAttributes = converted
 	}
 
-	for idx, c := range cfg.Services {
-		conv := findServiceMapConverter(c.Type)
-		if conv == nil {
+	if err := cfg.Ensure(fromCloud); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// ValidateConfig processes the unprocessed config like a config read from a local file, without
+// contacting the cloud. Instead of stopping at the first problem, every part of the config is
+// validated and all errors are returned combined. The processed config is returned even when it is
+// invalid, so that the changes it would cause can still be inspected.
+func ValidateConfig(unprocessedConfig *Config) (*Config, error) {
+	cfg, err := unprocessedConfig.CopyOnlyPublicFields()
+	if err != nil {
+		return nil, errors.Wrap(err, "error copying config")
+	}
+	cfg.ConfigFilePath = unprocessedConfig.ConfigFilePath
+	cfg.includedFiles = unprocessedConfig.includedFiles
+
+	var errs error
+	for idx := range cfg.Components {
+		errs = multierr.Append(errs, convertComponentAttributes(&cfg.Components[idx]))
+	}
+	for idx := range cfg.Services {
+		errs = multierr.Append(errs, convertServiceAttributes(&cfg.Services[idx]))
+	}
+	errs = multierr.Append(errs, cfg.Ensure(false))
+	return cfg, errs
+}
+
+// convertComponentAttributes replaces the attributes of the component with their converted
+// representation, using the converters registered for its type and model.
+func convertComponentAttributes(c *Component) error {
+	// inner attributes may have their own converters
+	for k, v := range c.Attributes {
+		attrConv := findConverter(c.Type, c.Model, k)
+		if attrConv == nil {
 			continue
 		}
 
-		converted, err := conv(c.Attributes)
+		n, err := attrConv(v)
 		if err != nil {
-			return nil, errors.Wrapf(err, "error converting attributes for %s", c.Type)
+			return errors.Wrapf(err, "error converting attribute for (%s, %s, %s)", c.Type, c.Model, k)
 		}
-		cfg.Services[idx].Attributes = nil
-		cfg.Services[idx].ConvertedAttributes = converted
+		c.Attributes[k] = n
+	}
+	conv := findMapConverter(c.Type, c.Model)
+	if conv == nil {
+		return nil
 	}
 
-	if err := cfg.Ensure(fromCloud); err != nil {
-		return nil, err
+	converted, err := conv(c.Attributes)
+	if err != nil {
+		return errors.Wrapf(err, "error converting attributes for (%s, %s)", c.Type, c.Model)
 	}
+	c.Attributes = nil
+	c.ConvertedAttributes = converted
+	return nil
+}
 
-	return cfg, nil
+// convertServiceAttributes replaces the attributes of the service with their converted
+// representation, using the converter registered for its type.
+func convertServiceAttributes(s *Service) error {
+	conv := findServiceMapConverter(s.Type)
+	if conv == nil {
+		return nil
+	}
+
+	converted, err := conv(s.Attributes)
+	if err != nil {
+		return errors.Wrapf(err, "error converting attributes for %s", s.Type)
+	}
+	s.Attributes = nil
+	s.ConvertedAttributes = converted
+	return nil
 }
 
 // getFromCloudOrCache returns the config from either the legacy HTTP endpoint or gRPC endpoint depending if the original config
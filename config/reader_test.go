@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -105,3 +106,111 @@ func TestProcessConfig(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, *cfg, test.ShouldResemble, unprocessedConfig)
 }
+
+func TestReadComposedConfig(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		test.That(t, os.WriteFile(path, []byte(content), 0o600), test.ShouldBeNil)
+		return path
+	}
+	writeFile("serial.txt", "SN-1234\n")
+	commonPath := writeFile("common.json", `{
+		"fragments": {
+			"wheel": {
+				"params": {"board": "board1", "rpm": 100, "flip": false},
+				"components": [{
+					"name": "${param:side}_motor",
+					"type": "gizmo",
+					"model": "fake",
+					"attributes": {
+						"board": "${param:board}",
+						"serial_path": "${param:port}",
+						"max_rpm": "${param:rpm}",
+						"dir_flip": "${param:flip}",
+						"label": "${param:side} at ${param:rpm} rpm"
+					}
+				}]
+			}
+		},
+		"components": [{"name": "board1", "type": "gizmo", "model": "fake"}],
+		"network": {"bind_address": "localhost:9090"}
+	}`)
+	t.Setenv("TEST_COMPOSED_ARM_MODEL", "fake")
+	robotJSON := func(rightPort string) string {
+		return `{
+			"include": ["common.json"],
+			"use_fragments": [
+				{"fragment": "wheel", "params": {"side": "left", "port": "/dev/ttyUSB0"}},
+				{"fragment": "wheel", "params": {"side": "right", "port": "` + rightPort + `", "board": "board2", "rpm": 150.5, "flip": true}}
+			],
+			"components": [{
+				"name": "arm1",
+				"type": "gizmo",
+				"model": "${env:TEST_COMPOSED_ARM_MODEL}",
+				"attributes": {"serial": "${file:serial.txt}", "home": "$HOME"}
+			}]
+		}`
+	}
+	robotPath := writeFile("robot.json", robotJSON("/dev/ttyUSB1"))
+
+	cfg, err := Read(context.Background(), robotPath, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cfg.ConfigFilePath, test.ShouldEqual, robotPath)
+	test.That(t, cfg.Network.BindAddress, test.ShouldEqual, "localhost:9090")
+	test.That(t, cfg.sourceFiles(), test.ShouldResemble, []string{robotPath, commonPath, filepath.Join(dir, "serial.txt")})
+
+	names := make([]string, 0, len(cfg.Components))
+	for _, c := range cfg.Components {
+		names = append(names, c.Name)
+	}
+	test.That(t, names, test.ShouldResemble, []string{"board1", "left_motor", "right_motor", "arm1"})
+	// parameters used as a whole value keep their type
+	test.That(t, cfg.Components[1].Attributes, test.ShouldResemble, AttributeMap{
+		"board": "board1", "serial_path": "/dev/ttyUSB0", "max_rpm": 100., "dir_flip": false, "label": "left at 100 rpm",
+	})
+	test.That(t, cfg.Components[2].Attributes, test.ShouldResemble, AttributeMap{
+		"board": "board2", "serial_path": "/dev/ttyUSB1", "max_rpm": 150.5, "dir_flip": true, "label": "right at 150.5 rpm",
+	})
+	test.That(t, cfg.Components[3].Model, test.ShouldEqual, "fake")
+	test.That(t, cfg.Components[3].Attributes, test.ShouldResemble, AttributeMap{
+		"serial": "SN-1234", "home": os.Getenv("HOME"),
+	})
+
+	// the resolved configs compare like any other
+	cpy, err := cfg.CopyOnlyPublicFields()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cpy.Components, test.ShouldResemble, cfg.Components)
+
+	writeFile("robot.json", robotJSON("/dev/ttyUSB2"))
+	newCfg, err := Read(context.Background(), robotPath, logger)
+	test.That(t, err, test.ShouldBeNil)
+	diff, err := DiffConfigs(*cfg, *newCfg, true)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, diff.Added.Components, test.ShouldBeEmpty)
+	test.That(t, diff.Removed.Components, test.ShouldBeEmpty)
+	test.That(t, diff.Modified.Components, test.ShouldHaveLength, 1)
+	test.That(t, diff.Modified.Components[0].Name, test.ShouldEqual, "right_motor")
+
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"include cycle", `{"include": ["robot.json"]}`, "include cycle"},
+		{"missing include", `{"include": ["nope.json"]}`, "nope.json"},
+		{"unknown fragment", `{"use_fragments": [{"fragment": "nope"}]}`, `unknown fragment "nope"`},
+		{"missing param", `{"include": ["common.json"], "use_fragments": [{"fragment": "wheel"}]}`, `fragment "wheel": parameter`},
+		{"param outside fragment", `{"components": [{"name": "${param:name}"}]}`, "used outside of a fragment"},
+		{"unset env", `{"components": [{"name": "${env:TEST_COMPOSED_UNSET}"}]}`, `"TEST_COMPOSED_UNSET" is not set`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			writeFile("robot.json", tc.content)
+			_, err := Read(context.Background(), robotPath, logger)
+			test.That(t, err, test.ShouldNotBeNil)
+			test.That(t, err.Error(), test.ShouldContainSubstring, tc.err)
+		})
+	}
+}
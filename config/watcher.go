@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/fsnotify/fsnotify"
+	"go.uber.org/multierr"
 	"go.viam.com/utils"
 )
 
@@ -28,7 +30,7 @@ func NewWatcher(ctx context.Context, config *Config, logger golog.Logger) (Watch
 		return newCloudWatcher(ctx, config, logger), nil
 	}
 	if config.ConfigFilePath != "" {
-		return newFSWatcher(ctx, config.ConfigFilePath, logger)
+		return newFSWatcher(ctx, config, logger)
 	}
 	return noopWatcher{}, nil
 }
@@ -100,7 +102,12 @@ func (w *cloudWatcher) Close() {
 	<-w.watcherDoneCh
 }
 
-// A fsConfigWatcher fetches new configs from an underlying file when written to.
+// fsDebounceInterval is how long the files of a config must go unchanged after a change before the
+// config is read again, so that an editor saving a file in several steps causes one reload.
+const fsDebounceInterval = 250 * time.Millisecond
+
+// A fsConfigWatcher fetches new configs from an underlying file, and the files it includes, when
+// they change.
 type fsConfigWatcher struct {
 	fsWatcher     *fsnotify.Watcher
 	configCh      chan *Config
@@ -108,20 +115,49 @@ type fsConfigWatcher struct {
 	cancel        func()
 }
 
-// newFSWatcher returns a new v that will fetch new configs
-// as soon as the underlying file is written to.
-func newFSWatcher(ctx context.Context, configPath string, logger golog.Logger) (*fsConfigWatcher, error) {
+// newFSWatcher returns a new v that will fetch new configs once the files making up the config
+// settle after a change, starting from the files the given config was read from. Invalid configs
+// are logged and skipped.
+func newFSWatcher(ctx context.Context, config *Config, logger golog.Logger) (*fsConfigWatcher, error) {
 	fsWatcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		return nil, err
 	}
-	if err := fsWatcher.Add(configPath); err != nil {
-		return nil, err
+	configPath := filepath.Clean(config.ConfigFilePath)
+	// directories are watched rather than files since editors commonly save a file by replacing it,
+	// after which a watch on the file itself would be lost
+	watched := map[string]bool{}
+	watchedDirs := map[string]bool{}
+	watch := func(paths []string) error {
+		watched = map[string]bool{}
+		for _, path := range paths {
+			path = filepath.Clean(path)
+			watched[path] = true
+			dir := filepath.Dir(path)
+			if watchedDirs[dir] {
+				continue
+			}
+			if err := fsWatcher.Add(dir); err != nil {
+				return err
+			}
+			watchedDirs[dir] = true
+		}
+		return nil
+	}
+	files := config.sourceFiles()
+	if err := watch(files); err != nil {
+		return nil, multierr.Combine(err, fsWatcher.Close())
+	}
+	lastRd, err := readFiles(files)
+	if err != nil {
+		return nil, multierr.Combine(err, fsWatcher.Close())
 	}
+
 	configCh := make(chan *Config)
 	watcherDoneCh := make(chan struct{})
 	cancelCtx, cancel := context.WithCancel(ctx)
-	var lastRd []byte
+	debounce := time.NewTimer(fsDebounceInterval)
+	debounce.Stop()
 	utils.ManagedGo(func() {
 		for {
 			if cancelCtx.Err() != nil {
@@ -130,32 +166,52 @@ func newFSWatcher(ctx context.Context, configPath string, logger golog.Logger) (
 			select {
 			case <-cancelCtx.Done():
 				return
-			case event := <-fsWatcher.Events:
-				if event.Op&fsnotify.Write == fsnotify.Write {
-					//nolint:gosec
-					rd, err := os.ReadFile(configPath)
-					if err != nil {
-						logger.Errorw("error reading config file after write", "error", err)
-						continue
-					}
-					if bytes.Equal(rd, lastRd) {
-						continue
-					}
-					lastRd = rd
-					newConfig, err := FromReader(cancelCtx, configPath, bytes.NewReader(rd), logger)
-					if err != nil {
-						logger.Errorw("error reading config after write", "error", err)
-						continue
-					}
-					select {
-					case <-cancelCtx.Done():
-						return
-					case configCh <- newConfig:
-					}
+			case err, ok := <-fsWatcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Errorw("error watching config files", "error", err)
+				continue
+			case event, ok := <-fsWatcher.Events:
+				if !ok {
+					return
+				}
+				if watched[filepath.Clean(event.Name)] && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+					debounce.Reset(fsDebounceInterval)
 				}
+				continue
+			case <-debounce.C:
+			}
+
+			rd, err := readFiles(files)
+			if err != nil {
+				logger.Errorw("error reading config files after change", "error", err)
+				continue
+			}
+			if bytes.Equal(rd, lastRd) {
+				continue
+			}
+			lastRd = rd
+			newConfig, err := Read(cancelCtx, configPath, logger)
+			if err != nil {
+				logger.Errorw("config is invalid after change, ignoring it", "error", err)
+				continue
+			}
+			files = newConfig.sourceFiles()
+			if err := watch(files); err != nil {
+				logger.Errorw("error watching config files", "error", err)
+			}
+			if rd, err := readFiles(files); err == nil {
+				lastRd = rd
+			}
+			select {
+			case <-cancelCtx.Done():
+				return
+			case configCh <- newConfig:
 			}
 		}
 	}, func() {
+		debounce.Stop()
 		close(watcherDoneCh)
 	})
 	return &fsConfigWatcher{
@@ -166,6 +222,20 @@ func newFSWatcher(ctx context.Context, configPath string, logger golog.Logger) (
 	}, nil
 }
 
+// readFiles returns the contents of the given files, one after the other.
+func readFiles(paths []string) ([]byte, error) {
+	var rd []byte
+	for _, path := range paths {
+		//nolint:gosec
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		rd = append(rd, content...)
+	}
+	return rd, nil
+}
+
 func (w *fsConfigWatcher) Config() <-chan *Config {
 	return w.configCh
 }
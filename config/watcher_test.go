@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -155,6 +156,89 @@ func TestNewWatcherFile(t *testing.T) {
 	test.That(t, utils.TryClose(context.Background(), watcher), test.ShouldBeNil)
 }
 
+func TestNewWatcherFileReplaced(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "robot.json")
+	test.That(t, os.WriteFile(configPath, []byte("{}"), 0o600), test.ShouldBeNil)
+
+	watcher, err := NewWatcher(context.Background(), &Config{ConfigFilePath: configPath}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, utils.TryClose(context.Background(), watcher), test.ShouldBeNil)
+	}()
+
+	confWithComponent := func(name string) *Config {
+		return &Config{
+			ConfigFilePath: configPath,
+			Components:     []Component{{Namespace: resource.ResourceNamespaceRDK, Name: name}},
+			Network:        NetworkConfig{NetworkConfigData: NetworkConfigData{BindAddress: "localhost:8080"}},
+		}
+	}
+
+	// saved the way editors do, by replacing the file
+	md, err := json.Marshal(confWithComponent("hello"))
+	test.That(t, err, test.ShouldBeNil)
+	tempPath := filepath.Join(dir, "robot.json.tmp")
+	test.That(t, os.WriteFile(tempPath, md, 0o600), test.ShouldBeNil)
+	test.That(t, os.Rename(tempPath, configPath), test.ShouldBeNil)
+
+	newConf := <-watcher.Config()
+	test.That(t, newConf, test.ShouldResemble, confWithComponent("hello"))
+
+	// bursts of writes are debounced into one reload of the final content
+	for _, name := range []string{"a", "b", "c"} {
+		md, err := json.Marshal(confWithComponent(name))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, os.WriteFile(configPath, md, 0o600), test.ShouldBeNil)
+	}
+	newConf = <-watcher.Config()
+	test.That(t, newConf, test.ShouldResemble, confWithComponent("c"))
+
+	// invalid configs are not delivered
+	test.That(t, os.WriteFile(configPath, []byte(`{"components": [{"name": ""}]}`), 0o600), test.ShouldBeNil)
+	timer := time.NewTimer(time.Second)
+	defer timer.Stop()
+	select {
+	case c := <-watcher.Config():
+		t.Fatalf("unexpected config %+v", c)
+	case <-timer.C:
+	}
+}
+
+func TestNewWatcherFileIncludes(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "robot.json")
+	partPath := filepath.Join(dir, "part.json")
+	writePart := func(name string) {
+		t.Helper()
+		content := fmt.Sprintf(`{"components": [{"namespace": "rdk", "name": %q}]}`, name)
+		test.That(t, os.WriteFile(partPath, []byte(content), 0o600), test.ShouldBeNil)
+	}
+	writePart("hello")
+	test.That(t, os.WriteFile(configPath, []byte(`{}`), 0o600), test.ShouldBeNil)
+
+	watcher, err := NewWatcher(context.Background(), &Config{ConfigFilePath: configPath}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, utils.TryClose(context.Background(), watcher), test.ShouldBeNil)
+	}()
+
+	test.That(t, os.WriteFile(configPath, []byte(`{"include": ["part.json"]}`), 0o600), test.ShouldBeNil)
+	newConf := <-watcher.Config()
+	test.That(t, newConf.Components, test.ShouldHaveLength, 1)
+	test.That(t, newConf.Components[0].Name, test.ShouldEqual, "hello")
+
+	// the included file is watched from then on
+	writePart("world")
+	newConf = <-watcher.Config()
+	test.That(t, newConf.Components, test.ShouldHaveLength, 1)
+	test.That(t, newConf.Components[0].Name, test.ShouldEqual, "world")
+}
+
 func TestNewWatcherCloud(t *testing.T) {
 	logger := golog.NewTestLogger(t)
 
@@ -335,3 +419,33 @@ func TestNewWatcherCloud(t *testing.T) {
 	test.That(t, httpServer.Shutdown(context.Background()), test.ShouldBeNil)
 	<-serveDone
 }
+
+func TestNewWatcherStartupIncludes(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "robot.json")
+	partPath := filepath.Join(dir, "part.json")
+	writePart := func(name string) {
+		t.Helper()
+		content := fmt.Sprintf(`{"components": [{"namespace": "rdk", "name": %q}]}`, name)
+		test.That(t, os.WriteFile(partPath, []byte(content), 0o600), test.ShouldBeNil)
+	}
+	writePart("hello")
+	test.That(t, os.WriteFile(configPath, []byte(`{"include": ["part.json"]}`), 0o600), test.ShouldBeNil)
+
+	startCfg, err := Read(context.Background(), configPath, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	watcher, err := NewWatcher(context.Background(), startCfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, utils.TryClose(context.Background(), watcher), test.ShouldBeNil)
+	}()
+
+	// the files the startup config includes are watched before the config file itself changes
+	writePart("world")
+	newConf := <-watcher.Config()
+	test.That(t, newConf.Components, test.ShouldHaveLength, 1)
+	test.That(t, newConf.Components[0].Name, test.ShouldEqual, "world")
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/edaniels/golog"
 	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/config"
 )
 
 type filterType string
@@ -18,15 +20,25 @@ const (
 	filterIIRChebyshevTypeI filterType = "filterIIRChebyshevTypeI"
 )
 
-type filter interface {
+// A Filter is a single input, single output discrete time filter.
+type Filter interface {
+	// Reset clears the filter history.
 	Reset() error
+	// Next feeds the next sample through the filter and returns the filtered value.
 	Next(x float64) (float64, bool)
 }
 
+// A WarmingFilter is a Filter whose first outputs, while its history fills up, are not filtered values.
+type WarmingFilter interface {
+	Filter
+	// Warm reports whether the filter has seen enough samples for its outputs to be filtered values.
+	Warm() bool
+}
+
 type filterStruct struct {
 	mu     sync.Mutex
 	cfg    BlockConfig
-	filter filter
+	filter Filter
 	y      []Signal
 	logger golog.Logger
 }
@@ -39,6 +51,16 @@ func newFilter(config BlockConfig, logger golog.Logger) (Block, error) {
 	return f, nil
 }
 
+// NewFilter creates a standalone filter, for use outside of a control loop, from the same attributes
+// as a filter block. The name is only used in error messages.
+func NewFilter(name string, attributes config.AttributeMap) (Filter, error) {
+	f := &filterStruct{cfg: BlockConfig{Name: name, Type: blockFilter, Attribute: attributes}}
+	if err := f.initFilter(); err != nil {
+		return nil, err
+	}
+	return f.filter, nil
+}
+
 func (f *filterStruct) initFilter() error {
 	if !f.cfg.Attribute.Has("type") {
 		return errors.Errorf("filter %s config should have a type field", f.cfg.Name)
@@ -24,7 +24,8 @@ func (f *movingAverageFilter) filterSample(x float64) float64 {
 		}
 		return 0.0
 	}
-	f.accumulator += (x - f.x[0]) / float64(f.smpCount)
+	// the window is x[1:], x[0] is the sample that already dropped out of it
+	f.accumulator += (x - f.x[1]) / float64(f.smpCount)
 	f.x = f.x[1:]
 	f.x = append(f.x, x)
 	return f.accumulator
@@ -37,6 +38,11 @@ func (f *movingAverageFilter) Reset() error {
 	return nil
 }
 
+// Warm reports whether the window of the filter is full, before which its outputs are not averages.
+func (f *movingAverageFilter) Warm() bool {
+	return f.smpCount >= f.filterSize-1
+}
+
 func (f *movingAverageFilter) Next(x float64) (float64, bool) {
 	y := f.filterSample(x)
 	return y, !math.IsNaN(y)
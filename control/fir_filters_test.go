@@ -10,6 +10,28 @@ func TestFIRFilterMovingAverage(t *testing.T) {
 	firFlt := movingAverageFilter{filterSize: 20}
 	firFlt.Reset()
 	test.That(t, len(firFlt.x), test.ShouldEqual, firFlt.filterSize)
+
+	// the average of the last filterSize-1 samples
+	firFlt = movingAverageFilter{filterSize: 4}
+	firFlt.Reset()
+	for i := 0; i < 3; i++ {
+		test.That(t, firFlt.Warm(), test.ShouldBeFalse)
+		y, ok := firFlt.Next(100)
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, firFlt.Warm(), test.ShouldEqual, i == 2)
+		if i == 2 {
+			test.That(t, y, test.ShouldAlmostEqual, 100)
+		}
+	}
+	for i, expected := range []float64{100, 100, 400. / 3, 500. / 3, 200, 200} {
+		x := 100.
+		if i >= 2 {
+			x = 200
+		}
+		y, ok := firFlt.Next(x)
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, y, test.ShouldAlmostEqual, expected)
+	}
 }
 
 func TestFIRFilterSinc(t *testing.T) {
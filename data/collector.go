@@ -13,6 +13,7 @@ import (
 	"github.com/edaniels/golog"
 	"github.com/matttproud/golang_protobuf_extensions/pbutil"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.opencensus.io/trace"
 	v1 "go.viam.com/api/app/datasync/v1"
 	"go.viam.com/utils"
@@ -20,6 +21,7 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"go.viam.com/rdk/metrics"
 	"go.viam.com/rdk/resource"
 )
 
@@ -60,6 +62,9 @@ type collector struct {
 	cancel            context.CancelFunc
 	capturer          Capturer
 	closed            bool
+	queueDepth        prometheus.Gauge
+	componentName     string
+	methodName        string
 }
 
 // SetTarget updates the file being written to by the collector.
@@ -93,6 +98,7 @@ func (c *collector) Close() {
 	if err := c.writer.Flush(); err != nil {
 		c.logger.Errorw("failed to flush writer to disk", "error", err)
 	}
+	metrics.CollectorQueueDepth.DeleteLabelValues(c.componentName, c.methodName)
 	c.closed = true
 }
 
@@ -200,6 +206,7 @@ func (c *collector) getAndPushNextReading() {
 			return
 		}
 		c.logger.Errorw("error while capturing data", "error", err)
+		c.dropReading("capture_error")
 		return
 	}
 
@@ -220,6 +227,7 @@ func (c *collector) getAndPushNextReading() {
 		pbReading, err := protoutils.StructToStructPb(reading)
 		if err != nil {
 			c.logger.Errorw("error while converting reading to structpb.Struct", "error", err)
+			c.dropReading("conversion_error")
 			return
 		}
 
@@ -240,10 +248,16 @@ func (c *collector) getAndPushNextReading() {
 	case <-c.cancelCtx.Done():
 		return
 	case c.queue <- &msg:
+		c.queueDepth.Inc()
 		return
 	}
 }
 
+// dropReading counts a reading the collector failed to capture or convert for the given reason.
+func (c *collector) dropReading(reason string) {
+	metrics.CollectorDroppedReadings.WithLabelValues(c.componentName, c.methodName, reason).Inc()
+}
+
 // NewCollector returns a new Collector with the passed capturer and configuration options. It calls capturer at the
 // specified Interval, and appends the resulting reading to target.
 func NewCollector(capturer Capturer, params CollectorParams) (Collector, error) {
@@ -265,11 +279,15 @@ func NewCollector(capturer Capturer, params CollectorParams) (Collector, error)
 		backgroundWorkers: sync.WaitGroup{},
 		capturer:          capturer,
 		closed:            false,
+		queueDepth:        metrics.CollectorQueueDepth.WithLabelValues(params.ComponentName, params.MethodName),
+		componentName:     params.ComponentName,
+		methodName:        params.MethodName,
 	}, nil
 }
 
 func (c *collector) write() error {
 	for msg := range c.queue {
+		c.queueDepth.Dec()
 		if err := c.appendMessage(msg); err != nil {
 			return err
 		}
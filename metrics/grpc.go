@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// otherResource labels the RPC calls naming a resource the robot does not have, so that callers
+// cannot grow the number of label values without bound.
+const otherResource = "other"
+
+// An RPCObserver counts and times the RPC calls served, labelled by the resource they name among
+// the resources of the robot.
+type RPCObserver struct {
+	mu        sync.RWMutex
+	resources map[string]bool
+}
+
+// NewRPCObserver returns an RPCObserver that knows of no resource yet.
+func NewRPCObserver() *RPCObserver {
+	return &RPCObserver{resources: map[string]bool{}}
+}
+
+// SetResources sets the names of the resources of the robot, the only ones calls are labelled with.
+func (o *RPCObserver) SetResources(names []string) {
+	resources := make(map[string]bool, len(names))
+	for _, name := range names {
+		resources[name] = true
+	}
+	o.mu.Lock()
+	o.resources = resources
+	o.mu.Unlock()
+}
+
+// UnaryServerInterceptor counts and times every unary RPC call served.
+func (o *RPCObserver) UnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	o.observe(info.FullMethod, req, time.Since(start), err)
+	return resp, err
+}
+
+// StreamServerInterceptor counts and times every streaming RPC call served, once the stream ends.
+// The resource of the call is taken from the first message received from the client.
+func (o *RPCObserver) StreamServerInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	wrapped := &firstMessageServerStream{ServerStream: ss}
+	err := handler(srv, wrapped)
+	o.observe(info.FullMethod, wrapped.first, time.Since(start), err)
+	return err
+}
+
+type firstMessageServerStream struct {
+	grpc.ServerStream
+	first interface{}
+}
+
+func (s *firstMessageServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.first == nil {
+		s.first = m
+	}
+	return err
+}
+
+func (o *RPCObserver) observe(fullMethod string, req interface{}, duration time.Duration, err error) {
+	service, method := splitMethod(fullMethod)
+	resource := o.resourceLabel(req)
+	RPCCalls.WithLabelValues(service, method, resource).Inc()
+	RPCDuration.WithLabelValues(service, method, resource).Observe(duration.Seconds())
+	if err != nil {
+		RPCErrors.WithLabelValues(service, method, resource, status.Code(err).String()).Inc()
+	}
+}
+
+// resourceLabel returns the resource label of a call: the name of the resource it names if the
+// robot has it, empty if it names none.
+func (o *RPCObserver) resourceLabel(req interface{}) string {
+	named, ok := req.(interface{ GetName() string })
+	if !ok || named.GetName() == "" {
+		return ""
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if !o.resources[named.GetName()] {
+		return otherResource
+	}
+	return named.GetName()
+}
+
+// splitMethod splits a full gRPC method name of the form /package.Service/Method.
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
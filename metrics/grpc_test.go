@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pb "go.viam.com/api/component/arm/v1"
+	"go.viam.com/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/viam.component.arm.v1.ArmService/Stop"}
+	calls := RPCCalls.WithLabelValues("viam.component.arm.v1.ArmService", "Stop", "arm1")
+	errs := RPCErrors.WithLabelValues("viam.component.arm.v1.ArmService", "Stop", "arm1", codes.Unavailable.String())
+	callsBefore, errsBefore := testutil.ToFloat64(calls), testutil.ToFloat64(errs)
+
+	observer := NewRPCObserver()
+	observer.SetResources([]string{"arm1"})
+	_, err := observer.UnaryServerInterceptor(context.Background(), &pb.StopRequest{Name: "arm1"}, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &pb.StopResponse{}, nil
+		})
+	test.That(t, err, test.ShouldBeNil)
+	_, err = observer.UnaryServerInterceptor(context.Background(), &pb.StopRequest{Name: "arm1"}, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.Unavailable, "arm is unplugged")
+		})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	test.That(t, testutil.ToFloat64(calls), test.ShouldEqual, callsBefore+2)
+	test.That(t, testutil.ToFloat64(errs), test.ShouldEqual, errsBefore+1)
+
+	// calls naming resources the robot does not have share one label
+	other := RPCCalls.WithLabelValues("viam.component.arm.v1.ArmService", "Stop", otherResource)
+	otherBefore := testutil.ToFloat64(other)
+	for _, name := range []string{"arm2", "arm3"} {
+		_, err = observer.UnaryServerInterceptor(context.Background(), &pb.StopRequest{Name: name}, info,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &pb.StopResponse{}, nil
+			})
+		test.That(t, err, test.ShouldBeNil)
+	}
+	test.That(t, testutil.ToFloat64(other), test.ShouldEqual, otherBefore+2)
+	test.That(t, testutil.CollectAndCount(RPCCalls, "rdk_rpc_calls_total"), test.ShouldEqual, 2)
+}
+
+func TestSplitMethod(t *testing.T) {
+	service, method := splitMethod("/viam.robot.v1.RobotService/GetStatus")
+	test.That(t, service, test.ShouldEqual, "viam.robot.v1.RobotService")
+	test.That(t, method, test.ShouldEqual, "GetStatus")
+
+	service, method = splitMethod("GetStatus")
+	test.That(t, service, test.ShouldEqual, "unknown")
+	test.That(t, method, test.ShouldEqual, "GetStatus")
+}
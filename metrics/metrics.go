@@ -0,0 +1,124 @@
+// Package metrics defines the Prometheus metrics of a robot, exported by its web server at /metrics.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "rdk"
+
+// Registry holds the metrics of the robot along with those of the Go runtime and the process. It
+// is kept apart from the default Prometheus registry so that libraries can not add to it.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// RPCCalls counts the RPC calls served, by service, method and resource.
+	RPCCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_calls_total",
+		Help:      "RPC calls served.",
+	}, []string{"service", "method", "resource"})
+
+	// RPCErrors counts the RPC calls served that returned an error, by service, method, resource
+	// and gRPC status code.
+	RPCErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_errors_total",
+		Help:      "RPC calls served that returned an error.",
+	}, []string{"service", "method", "resource", "code"})
+
+	// RPCDuration observes how long serving RPC calls took, by service, method and resource.
+	RPCDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rpc_duration_seconds",
+		Help:      "Time taken to serve RPC calls.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method", "resource"})
+
+	// CollectorQueueDepth is the number of readings a data collector captured but did not write
+	// yet, by component and method.
+	CollectorQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "data_collector_queue_depth",
+		Help:      "Readings captured by a data collector and waiting to be written.",
+	}, []string{"component", "method"})
+
+	// CollectorDroppedReadings counts the readings a data collector dropped, by component, method
+	// and reason.
+	CollectorDroppedReadings = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "data_collector_dropped_readings_total",
+		Help:      "Readings a data collector failed to capture or convert.",
+	}, []string{"component", "method", "reason"})
+
+	// StreamFrames counts the frames encoded for a stream. Its rate is the frame rate of the stream.
+	StreamFrames = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "stream_frames_total",
+		Help:      "Video frames or audio chunks encoded for a stream.",
+	}, []string{"stream", "kind"})
+
+	// StreamBytes counts the encoded bytes of a stream. Its rate is the bitrate of the stream, in bytes.
+	StreamBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "stream_bytes_total",
+		Help:      "Encoded bytes of a stream.",
+	}, []string{"stream", "kind"})
+
+	// Reconfigurations counts the reconfigurations of the robot, by outcome.
+	Reconfigurations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconfigurations_total",
+		Help:      "Reconfigurations of the robot by outcome.",
+	}, []string{"state"})
+
+	// RemoteConnected is 1 for the remotes of the robot that are connected and 0 for the others.
+	RemoteConnected = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "remote_connected",
+		Help:      "Whether a remote of the robot is connected.",
+	}, []string{"remote"})
+
+	// ProcessRestarts counts the restarts of the processes managed by the robot after they exited
+	// unexpectedly.
+	ProcessRestarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "process_restarts_total",
+		Help:      "Restarts of managed processes that exited unexpectedly.",
+	}, []string{"process"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		RPCCalls,
+		RPCErrors,
+		RPCDuration,
+		CollectorQueueDepth,
+		CollectorDroppedReadings,
+		StreamFrames,
+		StreamBytes,
+		Reconfigurations,
+		RemoteConnected,
+		ProcessRestarts,
+	)
+}
+
+// Handler returns an HTTP handler serving the metrics of the registry in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// SetRemoteConnected sets whether the remote of the given name is connected.
+func SetRemoteConnected(remote string, connected bool) {
+	var value float64
+	if connected {
+		value = 1
+	}
+	RemoteConnected.WithLabelValues(remote).Set(value)
+}
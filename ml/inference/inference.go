@@ -21,6 +21,12 @@ type MLModel interface {
 	Close() error
 }
 
+// Tensor is the flattened data of a tensor, as a slice of its data type, along with its shape.
+type Tensor struct {
+	Shape []int64     `json:"shape"`
+	Data  interface{} `json:"data"`
+}
+
 // FailedToLoadError is the default error message for when expected resources for inference fail to load.
 func FailedToLoadError(name string) error {
 	return errors.Errorf("failed to load %s", name)
@@ -0,0 +1,140 @@
+package inference
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/config"
+)
+
+// ONNX models are only supported by builds with the onnx build tag, which requires Go 1.19 for
+// onnxruntime_go, and need the onnxruntime shared library at runtime.
+
+// ErrONNXNotSupported is returned when loading an ONNX model with a build lacking the onnx tag.
+var ErrONNXNotSupported = errors.New("ONNX models are not supported by this build, rebuild it with the onnx build tag")
+
+// ONNXRuntimeLibraryPathEnv is the environment variable holding the path of the onnxruntime shared
+// library, which is loaded when the first ONNX model is.
+const ONNXRuntimeLibraryPathEnv = "ONNXRUNTIME_LIBRARY_PATH"
+
+// ONNXTensorInfo describes an input or output tensor of an ONNX model. Dynamic dimensions of its
+// shape are -1.
+type ONNXTensorInfo struct {
+	Name     string  `json:"name"`
+	Shape    []int64 `json:"shape"`
+	DataType string  `json:"data_type"`
+}
+
+// ONNXInfo holds the input and output tensors of an ONNX model, in the order the model lists them.
+type ONNXInfo struct {
+	Inputs  []ONNXTensorInfo `json:"inputs"`
+	Outputs []ONNXTensorInfo `json:"outputs"`
+}
+
+// ONNXMetadata is the metadata an ONNX model was exported with.
+type ONNXMetadata struct {
+	ProducerName   string            `json:"producer_name"`
+	GraphName      string            `json:"graph_name"`
+	Domain         string            `json:"domain"`
+	Description    string            `json:"description"`
+	Version        int64             `json:"version"`
+	CustomMetadata map[string]string `json:"custom_metadata"`
+}
+
+var _ MLModel = (*ONNXStruct)(nil)
+
+// ONNXModelLoader loads ONNX models to run on the CPU.
+type ONNXModelLoader struct {
+	numThreads int
+}
+
+// NewDefaultONNXModelLoader returns the default loader of ONNX models, using as many threads as
+// there are CPUs.
+func NewDefaultONNXModelLoader() (*ONNXModelLoader, error) {
+	return NewONNXModelLoader(runtime.NumCPU())
+}
+
+// NewONNXModelLoader returns a loader of ONNX models running inferences with the given number of
+// threads.
+func NewONNXModelLoader(numThreads int) (*ONNXModelLoader, error) {
+	if numThreads <= 0 {
+		return nil, errors.New("numThreads must be a positive integer")
+	}
+	return &ONNXModelLoader{numThreads: numThreads}, nil
+}
+
+func tensorNames(infos []ONNXTensorInfo) []string {
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, info.Name)
+	}
+	return names
+}
+
+// Infer takes the flattened data of the only input tensor of the model, as a slice of its data
+// type, or a map from the names of the input tensors to their data, and returns the flattened data
+// of the output tensors by name. The dynamic dimension of an input, if any, is sized to fit its
+// data.
+func (model *ONNXStruct) Infer(inputTensor interface{}) (config.AttributeMap, error) {
+	inputs := make(map[string]Tensor, len(model.Info.Inputs))
+	switch in := inputTensor.(type) {
+	case map[string]interface{}:
+		for name, data := range in {
+			inputs[name] = Tensor{Data: data}
+		}
+	case config.AttributeMap:
+		for name, data := range in {
+			inputs[name] = Tensor{Data: data}
+		}
+	default:
+		if len(model.Info.Inputs) != 1 {
+			return nil, errors.Errorf("model has %d input tensors, they must be given by name", len(model.Info.Inputs))
+		}
+		inputs[model.Info.Inputs[0].Name] = Tensor{Data: inputTensor}
+	}
+	outputs, err := model.InferTensors(inputs)
+	if err != nil {
+		return nil, err
+	}
+	out := make(config.AttributeMap, len(outputs))
+	for name, tensor := range outputs {
+		out[name] = tensor.Data
+	}
+	return out, nil
+}
+
+// resolveShape returns the shape of the tensor holding size elements, sizing its dynamic dimension.
+func resolveShape(info ONNXTensorInfo, size int) ([]int64, error) {
+	shape := append([]int64(nil), info.Shape...)
+	known, dynamic := int64(1), -1
+	for i, dim := range shape {
+		if dim >= 0 {
+			known *= dim
+			continue
+		}
+		if dynamic >= 0 {
+			return nil, errors.Errorf("input tensor %q has more than one dynamic dimension %v, its shape must be given", info.Name, info.Shape)
+		}
+		dynamic = i
+	}
+	switch {
+	case dynamic < 0 && known != int64(size):
+		return nil, errors.Errorf("input tensor %q of shape %v needs %d values, got %d", info.Name, info.Shape, known, size)
+	case dynamic >= 0 && (known == 0 || int64(size)%known != 0):
+		return nil, errors.Errorf("%d values do not fit input tensor %q of shape %v", size, info.Name, info.Shape)
+	case dynamic >= 0:
+		shape[dynamic] = int64(size) / known
+	}
+	return shape, nil
+}
+
+// dataLen returns the number of elements of a slice of tensor data, or -1 if it is not one.
+func dataLen(data interface{}) int {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice {
+		return -1
+	}
+	return v.Len()
+}
@@ -0,0 +1,28 @@
+//go:build !onnx
+
+package inference
+
+// ONNXStruct holds information and the inference session of an ONNX model.
+type ONNXStruct struct {
+	Info *ONNXInfo
+}
+
+// Load returns an error, as ONNX models are not supported by this build.
+func (loader ONNXModelLoader) Load(modelPath string) (*ONNXStruct, error) {
+	return nil, ErrONNXNotSupported
+}
+
+// InferTensors returns an error, as ONNX models are not supported by this build.
+func (model *ONNXStruct) InferTensors(inputs map[string]Tensor) (map[string]Tensor, error) {
+	return nil, ErrONNXNotSupported
+}
+
+// Metadata returns an error, as ONNX models are not supported by this build.
+func (model *ONNXStruct) Metadata() (interface{}, error) {
+	return nil, ErrONNXNotSupported
+}
+
+// Close does nothing, as ONNX models are not supported by this build.
+func (model *ONNXStruct) Close() error {
+	return nil
+}
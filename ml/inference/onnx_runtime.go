@@ -0,0 +1,282 @@
+//go:build onnx
+
+package inference
+
+import (
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+// onnxRuntimeMu guards the initialization of the onnxruntime environment.
+var onnxRuntimeMu sync.Mutex
+
+// initializeONNXRuntime loads the onnxruntime shared library once for the lifetime of the process.
+func initializeONNXRuntime() error {
+	onnxRuntimeMu.Lock()
+	defer onnxRuntimeMu.Unlock()
+	if ort.IsInitialized() {
+		return nil
+	}
+	libraryPath := os.Getenv(ONNXRuntimeLibraryPathEnv)
+	if libraryPath == "" {
+		switch runtime.GOOS {
+		case "darwin":
+			libraryPath = "libonnxruntime.dylib"
+		case "windows":
+			libraryPath = "onnxruntime.dll"
+		default:
+			libraryPath = "libonnxruntime.so"
+		}
+	}
+	ort.SetSharedLibraryPath(libraryPath)
+	if err := ort.InitializeEnvironment(); err != nil {
+		return errors.Wrapf(err, "could not load the onnxruntime library %q, set %s to its path", libraryPath, ONNXRuntimeLibraryPathEnv)
+	}
+	return nil
+}
+
+// ONNXStruct holds information and the inference session of an ONNX model.
+type ONNXStruct struct {
+	session  *ort.DynamicAdvancedSession
+	Info     *ONNXInfo
+	metadata *ONNXMetadata
+}
+
+// Load returns an ONNX struct that is ready to be used for inferences.
+func (loader ONNXModelLoader) Load(modelPath string) (*ONNXStruct, error) {
+	if err := initializeONNXRuntime(); err != nil {
+		return nil, err
+	}
+	inputs, outputs, err := ort.GetInputOutputInfo(modelPath)
+	if err != nil {
+		return nil, errors.Wrap(err, FailedToLoadError("model").Error())
+	}
+	info := &ONNXInfo{Inputs: tensorInfos(inputs), Outputs: tensorInfos(outputs)}
+
+	options, err := ort.NewSessionOptions()
+	if err != nil {
+		return nil, err
+	}
+	//nolint:errcheck
+	defer options.Destroy()
+	if err := options.SetIntraOpNumThreads(loader.numThreads); err != nil {
+		return nil, err
+	}
+	session, err := ort.NewDynamicAdvancedSession(modelPath, tensorNames(info.Inputs), tensorNames(info.Outputs), options)
+	if err != nil {
+		return nil, errors.Wrap(err, FailedToLoadError("session").Error())
+	}
+	metadata, err := sessionMetadata(session)
+	if err != nil {
+		//nolint:errcheck
+		session.Destroy()
+		return nil, err
+	}
+	return &ONNXStruct{session: session, Info: info, metadata: metadata}, nil
+}
+
+func tensorInfos(infos []ort.InputOutputInfo) []ONNXTensorInfo {
+	out := make([]ONNXTensorInfo, 0, len(infos))
+	for _, info := range infos {
+		out = append(out, ONNXTensorInfo{
+			Name:     info.Name,
+			Shape:    append([]int64(nil), info.Dimensions...),
+			DataType: onnxDataTypeName(info.DataType),
+		})
+	}
+	return out
+}
+
+// onnxDataTypeName returns the name of the Go type of the elements of a tensor, e.g. float32.
+func onnxDataTypeName(dataType ort.TensorElementDataType) string {
+	switch dataType {
+	case ort.TensorElementDataTypeFloat:
+		return "float32"
+	case ort.TensorElementDataTypeDouble:
+		return "float64"
+	case ort.TensorElementDataTypeUint8:
+		return "uint8"
+	case ort.TensorElementDataTypeInt8:
+		return "int8"
+	case ort.TensorElementDataTypeUint16:
+		return "uint16"
+	case ort.TensorElementDataTypeInt16:
+		return "int16"
+	case ort.TensorElementDataTypeUint32:
+		return "uint32"
+	case ort.TensorElementDataTypeInt32:
+		return "int32"
+	case ort.TensorElementDataTypeUint64:
+		return "uint64"
+	case ort.TensorElementDataTypeInt64:
+		return "int64"
+	default:
+		return dataType.String()
+	}
+}
+
+func sessionMetadata(session *ort.DynamicAdvancedSession) (*ONNXMetadata, error) {
+	m, err := session.GetModelMetadata()
+	if err != nil {
+		return nil, errors.Wrap(err, FailedToGetError("metadata").Error())
+	}
+	//nolint:errcheck
+	defer m.Destroy()
+	var metadata ONNXMetadata
+	if metadata.ProducerName, err = m.GetProducerName(); err != nil {
+		return nil, err
+	}
+	if metadata.GraphName, err = m.GetGraphName(); err != nil {
+		return nil, err
+	}
+	if metadata.Domain, err = m.GetDomain(); err != nil {
+		return nil, err
+	}
+	if metadata.Description, err = m.GetDescription(); err != nil {
+		return nil, err
+	}
+	if metadata.Version, err = m.GetVersion(); err != nil {
+		return nil, err
+	}
+	keys, err := m.GetCustomMetadataMapKeys()
+	if err != nil {
+		return nil, err
+	}
+	metadata.CustomMetadata = make(map[string]string, len(keys))
+	for _, key := range keys {
+		value, _, err := m.LookupCustomMetadataMap(key)
+		if err != nil {
+			return nil, err
+		}
+		metadata.CustomMetadata[key] = value
+	}
+	return &metadata, nil
+}
+
+// InferTensors runs the model on the named input tensors and returns its output tensors by name.
+// The shape of an input tensor may be left empty if the model has at most one dynamic dimension
+// for it.
+func (model *ONNXStruct) InferTensors(inputs map[string]Tensor) (map[string]Tensor, error) {
+	ortInputs := make([]ort.ArbitraryTensor, 0, len(model.Info.Inputs))
+	defer func() {
+		for _, t := range ortInputs {
+			//nolint:errcheck
+			t.Destroy()
+		}
+	}()
+	for _, info := range model.Info.Inputs {
+		in, ok := inputs[info.Name]
+		if !ok {
+			return nil, errors.Errorf("missing input tensor %q", info.Name)
+		}
+		shape := in.Shape
+		if len(shape) == 0 {
+			var err error
+			if shape, err = resolveShape(info, dataLen(in.Data)); err != nil {
+				return nil, err
+			}
+		}
+		t, err := newORTTensor(ort.NewShape(shape...), in.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "input tensor %q", info.Name)
+		}
+		ortInputs = append(ortInputs, t)
+	}
+
+	ortOutputs := make([]ort.ArbitraryTensor, len(model.Info.Outputs))
+	defer func() {
+		for _, t := range ortOutputs {
+			if t != nil {
+				//nolint:errcheck
+				t.Destroy()
+			}
+		}
+	}()
+	if err := model.session.Run(ortInputs, ortOutputs); err != nil {
+		return nil, errors.Wrap(err, "invoke failed")
+	}
+	outputs := make(map[string]Tensor, len(ortOutputs))
+	for i, t := range ortOutputs {
+		data, err := ortTensorData(t)
+		if err != nil {
+			return nil, errors.Wrapf(err, "output tensor %q", model.Info.Outputs[i].Name)
+		}
+		outputs[model.Info.Outputs[i].Name] = Tensor{Shape: append([]int64(nil), t.GetShape()...), Data: data}
+	}
+	return outputs, nil
+}
+
+// Metadata returns the metadata the model was exported with as an *ONNXMetadata.
+func (model *ONNXStruct) Metadata() (interface{}, error) {
+	return model.metadata, nil
+}
+
+// Close destroys the inference session of the model.
+func (model *ONNXStruct) Close() error {
+	return model.session.Destroy()
+}
+
+func newORTTensor(shape ort.Shape, data interface{}) (ort.ArbitraryTensor, error) {
+	switch d := data.(type) {
+	case []float32:
+		return ort.NewTensor(shape, d)
+	case []float64:
+		return ort.NewTensor(shape, d)
+	case []uint8:
+		return ort.NewTensor(shape, d)
+	case []int8:
+		return ort.NewTensor(shape, d)
+	case []uint16:
+		return ort.NewTensor(shape, d)
+	case []int16:
+		return ort.NewTensor(shape, d)
+	case []uint32:
+		return ort.NewTensor(shape, d)
+	case []int32:
+		return ort.NewTensor(shape, d)
+	case []uint64:
+		return ort.NewTensor(shape, d)
+	case []int64:
+		return ort.NewTensor(shape, d)
+	default:
+		return nil, errors.Errorf("unsupported tensor data type %T", data)
+	}
+}
+
+// ortTensorData returns a copy of the data of the tensor, which is freed along with the tensor.
+func ortTensorData(t ort.ArbitraryTensor) (interface{}, error) {
+	switch tt := t.(type) {
+	case *ort.Tensor[float32]:
+		return copyData(tt.GetData()), nil
+	case *ort.Tensor[float64]:
+		return copyData(tt.GetData()), nil
+	case *ort.Tensor[uint8]:
+		return copyData(tt.GetData()), nil
+	case *ort.Tensor[int8]:
+		return copyData(tt.GetData()), nil
+	case *ort.Tensor[uint16]:
+		return copyData(tt.GetData()), nil
+	case *ort.Tensor[int16]:
+		return copyData(tt.GetData()), nil
+	case *ort.Tensor[uint32]:
+		return copyData(tt.GetData()), nil
+	case *ort.Tensor[int32]:
+		return copyData(tt.GetData()), nil
+	case *ort.Tensor[uint64]:
+		return copyData(tt.GetData()), nil
+	case *ort.Tensor[int64]:
+		return copyData(tt.GetData()), nil
+	case *ort.CustomDataTensor:
+		return copyData(tt.GetData()), nil
+	default:
+		return nil, FailedToGetError("output tensor type")
+	}
+}
+
+func copyData[T any](data []T) []T {
+	return append([]T(nil), data...)
+}
@@ -0,0 +1,80 @@
+package inference
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go.viam.com/test"
+)
+
+// loadONNXModel loads the test ONNX model, skipping the test if ONNX models are not supported by the
+// build or the onnxruntime library is missing.
+func loadONNXModel(t *testing.T) *ONNXStruct {
+	t.Helper()
+	loader, err := NewONNXModelLoader(1)
+	test.That(t, err, test.ShouldBeNil)
+	model, err := loader.Load(basePath + "/testing_files/relu.onnx")
+	if errors.Is(err, ErrONNXNotSupported) {
+		t.Skip(err)
+	}
+	if err != nil && strings.Contains(err.Error(), "could not load the onnxruntime library") {
+		t.Skipf("onnxruntime is not available: %v", err)
+	}
+	test.That(t, err, test.ShouldBeNil)
+	return model
+}
+
+func TestONNXModel(t *testing.T) {
+	_, err := NewONNXModelLoader(0)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	model := loadONNXModel(t)
+	defer func() {
+		test.That(t, model.Close(), test.ShouldBeNil)
+	}()
+	test.That(t, model.Info.Inputs, test.ShouldResemble, []ONNXTensorInfo{{Name: "x", Shape: []int64{-1, 4}, DataType: "float32"}})
+	test.That(t, model.Info.Outputs, test.ShouldResemble, []ONNXTensorInfo{{Name: "y", Shape: []int64{-1, 4}, DataType: "float32"}})
+	metadata, err := model.Metadata()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, metadata, test.ShouldResemble, &ONNXMetadata{
+		ProducerName:   "rdk",
+		GraphName:      "relu_graph",
+		Domain:         "com.viam",
+		Description:    "relu of 4 values",
+		Version:        3,
+		CustomMetadata: map[string]string{"labels": "a,b,c,d"},
+	})
+
+	out, err := model.Infer([]float32{-1, 2, -3, 4, 5, -6, 7, -8})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out["y"], test.ShouldResemble, []float32{0, 2, 0, 4, 5, 0, 7, 0})
+
+	tensors, err := model.InferTensors(map[string]Tensor{"x": {Shape: []int64{1, 4}, Data: []float32{1, -1, 1, -1}}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, tensors["y"], test.ShouldResemble, Tensor{Shape: []int64{1, 4}, Data: []float32{1, 0, 1, 0}})
+
+	_, err = model.Infer([]float32{1, 2, 3})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = model.Infer(map[string]interface{}{"z": []float32{1, 2, 3, 4}})
+	test.That(t, err.Error(), test.ShouldContainSubstring, "missing input tensor")
+}
+
+func TestResolveShape(t *testing.T) {
+	info := ONNXTensorInfo{Name: "x", Shape: []int64{-1, 3, 2, 2}}
+	shape, err := resolveShape(info, 24)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, shape, test.ShouldResemble, []int64{2, 3, 2, 2})
+	test.That(t, info.Shape[0], test.ShouldEqual, -1)
+
+	_, err = resolveShape(info, 25)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	shape, err = resolveShape(ONNXTensorInfo{Name: "x", Shape: []int64{1, 4}}, 4)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, shape, test.ShouldResemble, []int64{1, 4})
+	_, err = resolveShape(ONNXTensorInfo{Name: "x", Shape: []int64{1, 4}}, 8)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = resolveShape(ONNXTensorInfo{Name: "x", Shape: []int64{1, 3, -1, -1}}, 12)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "more than one dynamic dimension")
+}
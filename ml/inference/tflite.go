@@ -139,14 +139,15 @@ const (
 
 // TFLiteInfo holds information about a model that are useful for creating input tensors bytes.
 type TFLiteInfo struct {
-	InputHeight       int
-	InputWidth        int
-	InputChannels     int
-	InputShape        []int
-	InputTensorType   InTensorType
-	InputTensorCount  int
-	OutputTensorCount int
-	OutputTensorTypes []string
+	InputHeight        int
+	InputWidth         int
+	InputChannels      int
+	InputShape         []int
+	InputTensorType    InTensorType
+	InputTensorCount   int
+	OutputTensorCount  int
+	OutputTensorTypes  []string
+	OutputTensorShapes [][]int
 }
 
 // getInfo provides some input and output tensor information based on a tflite interpreter.
@@ -155,19 +156,22 @@ func getInfo(inter Interpreter) *TFLiteInfo {
 
 	numOut := inter.GetOutputTensorCount()
 	var outTypes []string
+	var outShapes [][]int
 	for i := 0; i < numOut; i++ {
 		outTypes = append(outTypes, inter.GetOutputTensor(i).Type().String())
+		outShapes = append(outShapes, inter.GetOutputTensor(i).Shape())
 	}
 
 	info := &TFLiteInfo{
-		InputHeight:       input.Dim(1),
-		InputWidth:        input.Dim(2),
-		InputChannels:     input.Dim(3),
-		InputShape:        input.Shape(),
-		InputTensorType:   InTensorType(input.Type().String()),
-		InputTensorCount:  inter.GetInputTensorCount(),
-		OutputTensorCount: numOut,
-		OutputTensorTypes: outTypes,
+		InputHeight:        input.Dim(1),
+		InputWidth:         input.Dim(2),
+		InputChannels:      input.Dim(3),
+		InputShape:         input.Shape(),
+		InputTensorType:    InTensorType(input.Type().String()),
+		InputTensorCount:   inter.GetInputTensorCount(),
+		OutputTensorCount:  numOut,
+		OutputTensorTypes:  outTypes,
+		OutputTensorShapes: outShapes,
 	}
 	return info
 }
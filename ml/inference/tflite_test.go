@@ -73,6 +73,7 @@ func TestLoadModel(t *testing.T) {
 	test.That(t, structInfo.InputTensorCount, test.ShouldEqual, 1)
 	test.That(t, structInfo.OutputTensorCount, test.ShouldEqual, 4)
 	test.That(t, structInfo.OutputTensorTypes, test.ShouldResemble, []string{"Float32", "Float32", "Float32", "Float32"})
+	test.That(t, structInfo.OutputTensorShapes, test.ShouldHaveLength, 4)
 
 	buf := make([]float32, c*h*w)
 	outTensors, err := tfliteStruct.Infer(buf)
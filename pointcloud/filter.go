@@ -0,0 +1,233 @@
+package pointcloud
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+// A Filter returns a new point cloud made from the points of a point cloud, such as the points
+// that are not noise, or fewer points standing for them.
+type Filter func(PointCloud) (PointCloud, error)
+
+// ChainFilters returns a filter applying the given filters one after the other.
+func ChainFilters(filters ...Filter) Filter {
+	return func(pc PointCloud) (PointCloud, error) {
+		var err error
+		for _, filter := range filters {
+			if pc, err = filter(pc); err != nil {
+				return nil, err
+			}
+		}
+		return pc, nil
+	}
+}
+
+// selectPoints returns the point cloud of the points of pc for which keep returns true.
+func selectPoints(pc PointCloud, keep func(p r3.Vector, d Data) bool) (PointCloud, error) {
+	filtered := New()
+	var err error
+	pc.Iterate(0, 0, func(p r3.Vector, d Data) bool {
+		if keep(p, d) {
+			err = filtered.Set(p, d)
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filtered, nil
+}
+
+// VoxelGridFilter downsamples a point cloud by replacing the points of each voxel of a VoxelGrid,
+// of side voxelSize, by their barycenter. The color and normal of the barycenter are the average
+// colors and normals of the points.
+func VoxelGridFilter(voxelSize float64) (Filter, error) {
+	if voxelSize <= 0.0 {
+		return nil, errors.Errorf("argument voxelSize must be a positive float, got %.2f", voxelSize)
+	}
+	return func(pc PointCloud) (PointCloud, error) {
+		if pc.Size() == 0 {
+			return New(), nil
+		}
+		vg := NewVoxelGridFromPointCloud(pc, voxelSize, 1.0)
+		filtered := NewWithPrealloc(len(vg.Voxels))
+		for _, vox := range vg.Voxels {
+			if len(vox.Points) == 0 {
+				continue
+			}
+			if err := filtered.Set(vox.Center, averageData(vox.Points)); err != nil {
+				return nil, err
+			}
+		}
+		return filtered, nil
+	}, nil
+}
+
+// averageData returns the average color and normal of the data of the points.
+func averageData(points map[r3.Vector]Data) Data {
+	var r, g, b, nColors float64
+	var normal r3.Vector
+	hasNormal := false
+	for _, d := range points {
+		if d == nil {
+			continue
+		}
+		if d.HasColor() {
+			cr, cg, cb := d.RGB255()
+			r, g, b = r+float64(cr), g+float64(cg), b+float64(cb)
+			nColors++
+		}
+		if d.HasNormal() {
+			normal = normal.Add(d.Normal())
+			hasNormal = true
+		}
+	}
+	avg := NewBasicData()
+	if nColors > 0 {
+		avg.SetColor(color.NRGBA{
+			uint8(math.Round(r / nColors)),
+			uint8(math.Round(g / nColors)),
+			uint8(math.Round(b / nColors)),
+			255,
+		})
+	}
+	if hasNormal && normal.Norm() > 0 {
+		avg.SetNormal(normal.Normalize())
+	}
+	return avg
+}
+
+// RadiusOutlierFilter removes the points that have fewer than minNeighbors other points within
+// the given radius.
+// https://pcl.readthedocs.io/projects/tutorials/en/latest/remove_outliers.html
+func RadiusOutlierFilter(radius float64, minNeighbors int) (Filter, error) {
+	if radius <= 0.0 {
+		return nil, errors.Errorf("argument radius must be a positive float, got %.2f", radius)
+	}
+	if minNeighbors <= 0 {
+		return nil, errors.Errorf("argument minNeighbors must be a positive int, got %d", minNeighbors)
+	}
+	return func(pc PointCloud) (PointCloud, error) {
+		kd := ToKDTree(pc)
+		return selectPoints(kd, func(p r3.Vector, d Data) bool {
+			return len(kd.RadiusNearestNeighbors(p, radius, false)) >= minNeighbors
+		})
+	}, nil
+}
+
+// CropBoxFilter keeps the points inside the axis-aligned box spanning from minPt to maxPt.
+func CropBoxFilter(minPt, maxPt r3.Vector) (Filter, error) {
+	if minPt.X > maxPt.X || minPt.Y > maxPt.Y || minPt.Z > maxPt.Z {
+		return nil, errors.Errorf("crop box min %v must not be above its max %v", minPt, maxPt)
+	}
+	return func(pc PointCloud) (PointCloud, error) {
+		return selectPoints(pc, func(p r3.Vector, d Data) bool {
+			return p.X >= minPt.X && p.X <= maxPt.X && p.Y >= minPt.Y && p.Y <= maxPt.Y && p.Z >= minPt.Z && p.Z <= maxPt.Z
+		})
+	}, nil
+}
+
+// OrientedCropBoxFilter keeps the points inside the box of the given dimensions, centered on the
+// pose and rotated by it.
+func OrientedCropBoxFilter(pose spatialmath.Pose, dims r3.Vector) (Filter, error) {
+	if dims.X < 0 || dims.Y < 0 || dims.Z < 0 {
+		return nil, errors.Errorf("crop box dimensions %v must not be negative", dims)
+	}
+	toBox := spatialmath.PoseInverse(pose)
+	halfSize := dims.Mul(0.5)
+	return func(pc PointCloud) (PointCloud, error) {
+		return selectPoints(pc, func(p r3.Vector, d Data) bool {
+			local := spatialmath.Compose(toBox, spatialmath.NewPoseFromPoint(p)).Point()
+			return math.Abs(local.X) <= halfSize.X && math.Abs(local.Y) <= halfSize.Y && math.Abs(local.Z) <= halfSize.Z
+		})
+	}, nil
+}
+
+// PassThroughFilter keeps the points whose coordinate along the axis, "x", "y" or "z", is within
+// [minValue, maxValue].
+func PassThroughFilter(axis string, minValue, maxValue float64) (Filter, error) {
+	var coordinate func(p r3.Vector) float64
+	switch axis {
+	case "x":
+		coordinate = func(p r3.Vector) float64 { return p.X }
+	case "y":
+		coordinate = func(p r3.Vector) float64 { return p.Y }
+	case "z":
+		coordinate = func(p r3.Vector) float64 { return p.Z }
+	default:
+		return nil, errors.Errorf("pass through axis must be x, y or z, got %q", axis)
+	}
+	if minValue > maxValue {
+		return nil, errors.Errorf("pass through min %.2f must not be above its max %.2f", minValue, maxValue)
+	}
+	return func(pc PointCloud) (PointCloud, error) {
+		return selectPoints(pc, func(p r3.Vector, d Data) bool {
+			c := coordinate(p)
+			return c >= minValue && c <= maxValue
+		})
+	}, nil
+}
+
+// NormalEstimationFilter returns a filter that keeps all the points but sets their normals, see EstimateNormals.
+func NormalEstimationFilter(k int, viewpoint r3.Vector) (Filter, error) {
+	if k < 3 {
+		return nil, errors.Errorf("argument k must be at least 3, got %d", k)
+	}
+	return func(pc PointCloud) (PointCloud, error) {
+		return EstimateNormals(pc, k, viewpoint)
+	}, nil
+}
+
+// EstimateNormals returns a copy of the point cloud where each point has the normal of the plane
+// fitting its k nearest neighbors, pointing towards the viewpoint, usually the origin of the
+// camera. Points with fewer than 3 neighbors to fit a plane to are left without a normal.
+func EstimateNormals(pc PointCloud, k int, viewpoint r3.Vector) (PointCloud, error) {
+	kd := ToKDTree(pc)
+	withNormals := NewWithPrealloc(kd.Size())
+	var err error
+	kd.Iterate(0, 0, func(p r3.Vector, d Data) bool {
+		nd := copyData(d)
+		neighbors := kd.KNearestNeighbors(p, k, true)
+		if len(neighbors) >= 3 {
+			positions := make([]r3.Vector, 0, len(neighbors))
+			for _, n := range neighbors {
+				positions = append(positions, n.P)
+			}
+			normal := estimatePlaneNormalFromPoints(positions)
+			if normal.Dot(viewpoint.Sub(p)) < 0 {
+				normal = normal.Mul(-1)
+			}
+			nd.SetNormal(normal)
+		}
+		err = withNormals.Set(p, nd)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return withNormals, nil
+}
+
+// copyData returns a copy of the data that can be modified without modifying the original.
+func copyData(d Data) Data {
+	c := NewBasicData()
+	if d == nil {
+		return c
+	}
+	if d.HasColor() {
+		r, g, b := d.RGB255()
+		c.SetColor(color.NRGBA{r, g, b, 255})
+	}
+	if d.HasValue() {
+		c.SetValue(d.Value())
+	}
+	if d.HasNormal() {
+		c.SetNormal(d.Normal())
+	}
+	return c.SetIntensity(d.Intensity())
+}
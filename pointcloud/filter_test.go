@@ -0,0 +1,132 @@
+package pointcloud
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+// makeGridCloud returns a 10x10 grid of points 1mm apart on the z=0 plane, plus an outlier far above it.
+func makeGridCloud(t *testing.T) PointCloud {
+	t.Helper()
+	pc := New()
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			test.That(t, pc.Set(NewVector(float64(x), float64(y), 0), NewColoredData(color.NRGBA{uint8(10 * x), 0, 0, 255})), test.ShouldBeNil)
+		}
+	}
+	test.That(t, pc.Set(NewVector(5, 5, 100), NewColoredData(color.NRGBA{255, 255, 255, 255})), test.ShouldBeNil)
+	return pc
+}
+
+func TestVoxelGridFilter(t *testing.T) {
+	_, err := VoxelGridFilter(0)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	filter, err := VoxelGridFilter(2)
+	test.That(t, err, test.ShouldBeNil)
+	filtered, err := filter(makeGridCloud(t))
+	test.That(t, err, test.ShouldBeNil)
+	// 5x5 voxels on the grid, and the outlier
+	test.That(t, filtered.Size(), test.ShouldEqual, 26)
+	d, got := filtered.At(0.5, 0.5, 0)
+	test.That(t, got, test.ShouldBeTrue)
+	r, _, _ := d.RGB255()
+	test.That(t, r, test.ShouldEqual, 5)
+	_, got = filtered.At(5, 5, 100)
+	test.That(t, got, test.ShouldBeTrue)
+
+	filtered, err = filter(New())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, filtered.Size(), test.ShouldEqual, 0)
+}
+
+func TestRadiusOutlierFilter(t *testing.T) {
+	_, err := RadiusOutlierFilter(0, 1)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = RadiusOutlierFilter(1, 0)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	filter, err := RadiusOutlierFilter(1.5, 3)
+	test.That(t, err, test.ShouldBeNil)
+	filtered, err := filter(makeGridCloud(t))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, filtered.Size(), test.ShouldEqual, 100)
+	test.That(t, CloudContains(filtered, 5, 5, 100), test.ShouldBeFalse)
+	test.That(t, CloudContains(filtered, 0, 0, 0), test.ShouldBeTrue)
+
+	// corners have only 3 neighbors within 1.5mm
+	filter, err = RadiusOutlierFilter(1.5, 4)
+	test.That(t, err, test.ShouldBeNil)
+	filtered, err = filter(makeGridCloud(t))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, filtered.Size(), test.ShouldEqual, 96)
+	test.That(t, CloudContains(filtered, 0, 0, 0), test.ShouldBeFalse)
+}
+
+func TestCropFilters(t *testing.T) {
+	_, err := CropBoxFilter(r3.Vector{1, 0, 0}, r3.Vector{0, 1, 1})
+	test.That(t, err, test.ShouldNotBeNil)
+	filter, err := CropBoxFilter(r3.Vector{-1, -1, -1}, r3.Vector{2, 2, 200})
+	test.That(t, err, test.ShouldBeNil)
+	filtered, err := filter(makeGridCloud(t))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, filtered.Size(), test.ShouldEqual, 9)
+
+	_, err = OrientedCropBoxFilter(spatialmath.NewZeroPose(), r3.Vector{-1, 1, 1})
+	test.That(t, err, test.ShouldNotBeNil)
+	// a 3x1 box along the diagonal of the grid
+	pose := spatialmath.NewPoseFromOrientation(r3.Vector{5, 5, 0}, &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: 45})
+	filter, err = OrientedCropBoxFilter(pose, r3.Vector{3 * math.Sqrt2, 1, 1})
+	test.That(t, err, test.ShouldBeNil)
+	filtered, err = filter(makeGridCloud(t))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, filtered.Size(), test.ShouldEqual, 3)
+	test.That(t, CloudContains(filtered, 4, 4, 0), test.ShouldBeTrue)
+	test.That(t, CloudContains(filtered, 6, 6, 0), test.ShouldBeTrue)
+	test.That(t, CloudContains(filtered, 6, 4, 0), test.ShouldBeFalse)
+
+	_, err = PassThroughFilter("w", 0, 1)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = PassThroughFilter("x", 1, 0)
+	test.That(t, err, test.ShouldNotBeNil)
+	filter, err = PassThroughFilter("z", -1, 1)
+	test.That(t, err, test.ShouldBeNil)
+	pass, err := PassThroughFilter("x", 8, 20)
+	test.That(t, err, test.ShouldBeNil)
+	filtered, err = ChainFilters(filter, pass)(makeGridCloud(t))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, filtered.Size(), test.ShouldEqual, 20)
+}
+
+func TestEstimateNormals(t *testing.T) {
+	_, err := NormalEstimationFilter(2, r3.Vector{})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	pc := makeGridCloud(t)
+	withNormals, err := EstimateNormals(pc, 8, r3.Vector{0, 0, -10})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, withNormals.Size(), test.ShouldEqual, pc.Size())
+	test.That(t, withNormals.MetaData().HasNormal, test.ShouldBeTrue)
+	d, got := withNormals.At(3, 4, 0)
+	test.That(t, got, test.ShouldBeTrue)
+	test.That(t, d.HasNormal(), test.ShouldBeTrue)
+	test.That(t, d.Normal().Z, test.ShouldAlmostEqual, -1, 1e-6)
+	r, _, _ := d.RGB255()
+	test.That(t, r, test.ShouldEqual, 30)
+	// the original points are left untouched
+	d, _ = pc.At(3, 4, 0)
+	test.That(t, d.HasNormal(), test.ShouldBeFalse)
+
+	filter, err := NormalEstimationFilter(8, r3.Vector{0, 0, 10})
+	test.That(t, err, test.ShouldBeNil)
+	withNormals, err = filter(pc)
+	test.That(t, err, test.ShouldBeNil)
+	d, _ = withNormals.At(3, 4, 0)
+	test.That(t, d.Normal().Z, test.ShouldAlmostEqual, 1, 1e-6)
+}
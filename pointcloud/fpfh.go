@@ -0,0 +1,148 @@
+package pointcloud
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+)
+
+// fpfhBins is the number of bins of the histogram of each of the 3 angular features of an FPFH.
+const fpfhBins = 11
+
+// FPFH is a Fast Point Feature Histogram, a descriptor of the geometry of the surface around a point
+// made of the histograms of the angles between the normals of the point and of its neighbors,
+// which does not change when the point cloud moves. See Rusu et al., "Fast Point Feature
+// Histograms (FPFH) for 3D Registration", ICRA 2009.
+type FPFH [3 * fpfhBins]float64
+
+// Distance returns the euclidean distance between the two descriptors.
+func (f *FPFH) Distance(other *FPFH) float64 {
+	sum := 0.
+	for i := range f {
+		d := f[i] - other[i]
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+// ComputeFPFH returns the points of the point cloud that have a normal, along with their FPFH
+// computed from their neighbors within the radius. The radius should be a few times the distance
+// between neighboring points, and larger than the radius used to estimate the normals.
+func ComputeFPFH(pc PointCloud, radius float64) ([]r3.Vector, []FPFH, error) {
+	if radius <= 0 {
+		return nil, nil, errors.Errorf("radius must be positive, got %v", radius)
+	}
+	withNormals := NewKDTree()
+	var err error
+	pc.Iterate(0, 0, func(p r3.Vector, d Data) bool {
+		if d != nil && d.HasNormal() {
+			err = withNormals.Set(p, d)
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if withNormals.Size() == 0 {
+		return nil, nil, errors.New("cannot compute FPFH of a point cloud without normals")
+	}
+
+	points := make([]r3.Vector, 0, withNormals.Size())
+	normals := make([]r3.Vector, 0, withNormals.Size())
+	indices := make(map[r3.Vector]int, withNormals.Size())
+	withNormals.Iterate(0, 0, func(p r3.Vector, d Data) bool {
+		indices[p] = len(points)
+		points = append(points, p)
+		normals = append(normals, d.Normal())
+		return true
+	})
+
+	// the simplified point feature histogram of each point, from its neighbors only
+	neighbors := make([][]*PointAndData, len(points))
+	spfh := make([]FPFH, len(points))
+	for i, p := range points {
+		neighbors[i] = withNormals.RadiusNearestNeighbors(p, radius, false)
+		for _, n := range neighbors[i] {
+			alpha, phi, theta, ok := pairFeatures(p, normals[i], n.P, n.D.Normal())
+			if !ok {
+				continue
+			}
+			spfh[i][featureBin(alpha, -1, 1)]++
+			spfh[i][fpfhBins+featureBin(phi, -1, 1)]++
+			spfh[i][2*fpfhBins+featureBin(theta, -math.Pi, math.Pi)]++
+		}
+		normalizeHistograms(&spfh[i], len(neighbors[i]))
+	}
+
+	// weighted by the inverse of the distance to the neighbors
+	features := make([]FPFH, len(points))
+	for i, p := range points {
+		features[i] = spfh[i]
+		for _, n := range neighbors[i] {
+			dist := p.Distance(n.P)
+			if dist == 0 {
+				continue
+			}
+			weight := 1 / (dist * float64(len(neighbors[i])))
+			for b, v := range spfh[indices[n.P]] {
+				features[i][b] += weight * v
+			}
+		}
+		sums := [3]float64{}
+		for b, v := range features[i] {
+			sums[b/fpfhBins] += v
+		}
+		for b := range features[i] {
+			if s := sums[b/fpfhBins]; s > 0 {
+				features[i][b] *= 100 / s
+			}
+		}
+	}
+	return points, features, nil
+}
+
+// pairFeatures returns the angular features of a pair of oriented points, in the Darboux frame of
+// the point whose normal is the most aligned with the line between them.
+func pairFeatures(p1, n1, p2, n2 r3.Vector) (float64, float64, float64, bool) {
+	d := p2.Sub(p1)
+	dist := d.Norm()
+	if dist == 0 {
+		return 0, 0, 0, false
+	}
+	d = d.Mul(1 / dist)
+	if math.Abs(n1.Dot(d)) < math.Abs(n2.Dot(d)) {
+		n1, n2 = n2, n1
+		d = d.Mul(-1)
+	}
+	u := n1
+	v := u.Cross(d)
+	if v.Norm() == 0 {
+		return 0, 0, 0, false
+	}
+	v = v.Normalize()
+	w := u.Cross(v)
+	return v.Dot(n2), u.Dot(d), math.Atan2(w.Dot(n2), u.Dot(n2)), true
+}
+
+// featureBin returns the bin of the histogram of a feature between minValue and maxValue.
+func featureBin(f, minValue, maxValue float64) int {
+	bin := int(math.Floor((f - minValue) / (maxValue - minValue) * fpfhBins))
+	if bin < 0 {
+		return 0
+	}
+	if bin >= fpfhBins {
+		return fpfhBins - 1
+	}
+	return bin
+}
+
+// normalizeHistograms scales the histograms made of n pairs so that each adds up to 100.
+func normalizeHistograms(h *FPFH, n int) {
+	if n == 0 {
+		return
+	}
+	for b := range h {
+		h[b] *= 100 / float64(n)
+	}
+}
@@ -39,12 +39,13 @@ func (v treeComparableR3Vector) Dims() int {
 	return 3
 }
 
+// Distance returns the squared distance between the vectors, which the kd tree expects to prune its search.
 func (v treeComparableR3Vector) Distance(c kdtree.Comparable) float64 {
 	v2, ok := c.(treeComparableR3Vector)
 	if !ok {
 		panic("treeComparableR3Vector Distance got wrong data")
 	}
-	return v.vec.Distance(v2.vec)
+	return v.vec.Sub(v2.vec).Norm2()
 }
 
 type kdValues []treeComparableR3Vector
@@ -182,7 +183,7 @@ func (kd *KDTree) NearestNeighbor(p r3.Vector) (r3.Vector, Data, float64, bool)
 	if !ok {
 		panic("Mismatch between tree and point storage.")
 	}
-	return p2.vec, d, dist, true
+	return p2.vec, d, math.Sqrt(dist), true
 }
 
 func keeperToArray(heap kdtree.Heap, points storage, p r3.Vector, includeSelf bool, max int) []*PointAndData {
@@ -227,9 +228,17 @@ func (kd *KDTree) KNearestNeighbors(p r3.Vector, k int, includeSelf bool) []*Poi
 // If includeSelf is true and if the point p is in the point cloud, point p will also be returned in the slice
 // as the first element with distance 0.
 func (kd *KDTree) RadiusNearestNeighbors(p r3.Vector, r float64, includeSelf bool) []*PointAndData {
-	keep := kdtree.NewDistKeeper(r)
+	// the squared radius is padded against rounding errors, then the points are kept by their distance
+	keep := kdtree.NewDistKeeper(r * r * (1 + 1e-9))
 	kd.tree.NearestSet(keep, &treeComparableR3Vector{p})
-	return keeperToArray(keep.Heap, kd.points, p, includeSelf, math.MaxInt)
+	neighbors := keeperToArray(keep.Heap, kd.points, p, includeSelf, math.MaxInt)
+	inRadius := neighbors[:0]
+	for _, n := range neighbors {
+		if n.P.Distance(p) <= r {
+			inRadius = append(inRadius, n)
+		}
+	}
+	return inRadius
 }
 
 // Iterate iterates over all points in the cloud.
@@ -3,6 +3,8 @@ package pointcloud
 import (
 	"errors"
 	"math"
+	"math/rand"
+	"sort"
 	"testing"
 
 	"github.com/golang/geo/r3"
@@ -147,6 +149,47 @@ func TestRadiusNearestNeighor(t *testing.T) {
 	test.That(t, nns, test.ShouldHaveLength, 0)
 }
 
+func TestNeighborsMatchBruteForce(t *testing.T) {
+	// far enough apart that the search must not prune the branches of the tree holding neighbors
+	rnd := rand.New(rand.NewSource(1))
+	kd := NewKDTree()
+	var points []r3.Vector
+	for i := 0; i < 500; i++ {
+		p := r3.Vector{rnd.Float64() * 100, rnd.Float64() * 100, rnd.Float64() * 100}
+		points = append(points, p)
+		test.That(t, kd.Set(p, nil), test.ShouldBeNil)
+	}
+	for i := 0; i < 20; i++ {
+		query := r3.Vector{rnd.Float64() * 100, rnd.Float64() * 100, rnd.Float64() * 100}
+		inRadius := 0
+		nearest := math.Inf(1)
+		dists := make([]float64, 0, len(points))
+		for _, p := range points {
+			if query.Distance(p) <= 20 {
+				inRadius++
+			}
+			nearest = math.Min(nearest, query.Distance(p))
+			dists = append(dists, query.Distance(p))
+		}
+		test.That(t, kd.RadiusNearestNeighbors(query, 20, true), test.ShouldHaveLength, inRadius)
+		_, _, dist, _ := kd.NearestNeighbor(query)
+		test.That(t, dist, test.ShouldAlmostEqual, nearest)
+
+		// the neighbors come back in heap order, so compare them by their sorted distances
+		sort.Float64s(dists)
+		kNearest := kd.KNearestNeighbors(query, 5, true)
+		test.That(t, kNearest, test.ShouldHaveLength, 5)
+		got := make([]float64, 0, len(kNearest))
+		for _, n := range kNearest {
+			got = append(got, query.Distance(n.P))
+		}
+		sort.Float64s(got)
+		for j := range got {
+			test.That(t, got[j], test.ShouldAlmostEqual, dists[j])
+		}
+	}
+}
+
 func TestNewEmptyKDtree(t *testing.T) {
 	pt0 := r3.Vector{0, 0, 0}
 	pt1 := r3.Vector{0, 0, 1}
@@ -0,0 +1,123 @@
+package pointcloud
+
+import (
+	"github.com/pkg/errors"
+)
+
+// The limits of the LZF format, which compresses the data of binary_compressed PCD files.
+const (
+	lzfHashLog    = 14
+	lzfMaxLiteral = 1 << 5
+	lzfMaxOffset  = 1 << 13
+	lzfMaxRef     = (1 << 8) + (1 << 3)
+	// lzfMaxExpansion is the most bytes a byte of LZF data decompresses into, reached by the back
+	// references of the maximum length, which take 3 bytes.
+	lzfMaxExpansion = (lzfMaxRef + 1) / 3
+)
+
+// lzfMaxCompressedLen returns the largest size of n bytes compressed in the LZF format, for data
+// that cannot be compressed and takes a control byte every lzfMaxLiteral bytes.
+func lzfMaxCompressedLen(n int) int {
+	return n + n/lzfMaxLiteral + 1
+}
+
+// lzfCompress compresses the data in the LZF format of liblzf. The output is a series of chunks,
+// each starting with a control byte: below 32 it is followed by that many literal bytes plus one,
+// otherwise it is a back reference whose length and offset it holds along with one or two more bytes.
+func lzfCompress(in []byte) []byte {
+	out := make([]byte, 0, lzfMaxCompressedLen(len(in)))
+	// table holds the position plus one of the last occurrence of the hash of 3 bytes
+	var table [1 << lzfHashLog]int
+	hash := func(i int) int {
+		v := uint32(in[i])<<16 | uint32(in[i+1])<<8 | uint32(in[i+2])
+		return int((v * 2654435761) >> (32 - lzfHashLog))
+	}
+	literalStart := 0
+	flushLiterals := func(end int) {
+		for literalStart < end {
+			n := end - literalStart
+			if n > lzfMaxLiteral {
+				n = lzfMaxLiteral
+			}
+			out = append(out, byte(n-1))
+			out = append(out, in[literalStart:literalStart+n]...)
+			literalStart += n
+		}
+	}
+	ip := 0
+	for ip+2 < len(in) {
+		h := hash(ip)
+		ref := table[h] - 1
+		table[h] = ip + 1
+		if ref < 0 || ip-ref-1 >= lzfMaxOffset || in[ref] != in[ip] || in[ref+1] != in[ip+1] || in[ref+2] != in[ip+2] {
+			ip++
+			continue
+		}
+		maxLen := len(in) - ip
+		if maxLen > lzfMaxRef {
+			maxLen = lzfMaxRef
+		}
+		length := 3
+		for length < maxLen && in[ref+length] == in[ip+length] {
+			length++
+		}
+		flushLiterals(ip)
+		offset := ip - ref - 1
+		if l := length - 2; l < 7 {
+			out = append(out, byte(l<<5|offset>>8))
+		} else {
+			out = append(out, byte(7<<5|offset>>8), byte(l-7))
+		}
+		out = append(out, byte(offset))
+		ip += length
+		literalStart = ip
+	}
+	flushLiterals(len(in))
+	return out
+}
+
+// lzfDecompress decompresses LZF data into outLen bytes.
+func lzfDecompress(in []byte, outLen int) ([]byte, error) {
+	if outLen < 0 || outLen > len(in)*lzfMaxExpansion {
+		return nil, errors.Errorf("corrupt lzf data: %d bytes cannot decompress into %d", len(in), outLen)
+	}
+	out := make([]byte, 0, outLen)
+	for ip := 0; ip < len(in); {
+		ctrl := int(in[ip])
+		ip++
+		if ctrl < lzfMaxLiteral {
+			n := ctrl + 1
+			if ip+n > len(in) || len(out)+n > outLen {
+				return nil, errors.New("corrupt lzf data: literal run out of bounds")
+			}
+			out = append(out, in[ip:ip+n]...)
+			ip += n
+			continue
+		}
+		length := ctrl >> 5
+		if length == 7 {
+			if ip >= len(in) {
+				return nil, errors.New("corrupt lzf data: truncated back reference")
+			}
+			length += int(in[ip])
+			ip++
+		}
+		if ip >= len(in) {
+			return nil, errors.New("corrupt lzf data: truncated back reference")
+		}
+		ref := len(out) - (ctrl&0x1f)<<8 - int(in[ip]) - 1
+		ip++
+		length += 2
+		if ref < 0 || len(out)+length > outLen {
+			return nil, errors.New("corrupt lzf data: back reference out of bounds")
+		}
+		// the reference may overlap the bytes it produces
+		for i := 0; i < length; i++ {
+			out = append(out, out[ref+i])
+		}
+	}
+	if len(out) != outLen {
+		return nil, errors.Errorf("corrupt lzf data: decompressed %d bytes instead of %d", len(out), outLen)
+	}
+	return out, nil
+}
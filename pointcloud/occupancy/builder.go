@@ -0,0 +1,77 @@
+package occupancy
+
+import (
+	"context"
+	"math"
+
+	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/movementsensor"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// A PoseSource returns the current pose of a camera in the frame of a grid.
+type PoseSource func(ctx context.Context) (spatialmath.Pose, error)
+
+// FrameSystemPoseSource returns the pose of the camera in the given frame of the frame system of the robot.
+func FrameSystemPoseSource(r robot.Robot, cameraName, frame string) PoseSource {
+	return func(ctx context.Context) (spatialmath.Pose, error) {
+		origin := referenceframe.NewPoseInFrame(cameraName, spatialmath.NewZeroPose())
+		pose, err := r.TransformPose(ctx, origin, frame, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not transform %q into %q", cameraName, frame)
+		}
+		return pose.Pose(), nil
+	}
+}
+
+// MovementSensorPoseSource returns the pose of a camera mounted on a robot moving on the ground,
+// from the position and compass heading of a movement sensor of the robot. The grid is in the
+// frame whose x axis points east and y axis points north from the origin, on the ground. The pose
+// of the sensor has its x axis along its heading, and the camera is at the given offset from it.
+func MovementSensorPoseSource(ms movementsensor.MovementSensor, origin *geo.Point, cameraOffset spatialmath.Pose) PoseSource {
+	return func(ctx context.Context) (spatialmath.Pose, error) {
+		position, _, err := ms.Position(ctx)
+		if err != nil {
+			return nil, err
+		}
+		heading, err := ms.CompassHeading(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return spatialmath.Compose(sensorPose(origin, position, heading), cameraOffset), nil
+	}
+}
+
+// sensorPose returns the pose of a sensor at the position, with the compass heading in degrees,
+// in the frame whose x axis points east and y axis points north from the origin.
+func sensorPose(origin, position *geo.Point, heading float64) spatialmath.Pose {
+	// the distance is in km and the bearing in degrees clockwise from the north
+	dist := origin.GreatCircleDistance(position) * 1e6
+	bearing := origin.BearingTo(position) * math.Pi / 180
+	point := r3.Vector{X: dist * math.Sin(bearing), Y: dist * math.Cos(bearing)}
+	return spatialmath.NewPoseFromOrientation(point, &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: 90 - heading})
+}
+
+// Update inserts the next point cloud of the camera in the grid, taken from the current pose of
+// the camera.
+func (g *Grid) Update(ctx context.Context, cam camera.PointCloudSource, poseSource PoseSource) error {
+	ctx, span := trace.StartSpan(ctx, "occupancy::Grid::Update")
+	defer span.End()
+	pose, err := poseSource(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get the pose of the camera")
+	}
+	pc, err := cam.NextPointCloud(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get the next point cloud")
+	}
+	g.InsertPointCloud(pc, pose)
+	return nil
+}
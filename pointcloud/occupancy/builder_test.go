@@ -0,0 +1,83 @@
+package occupancy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+	commonpb "go.viam.com/api/common/v1"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+func TestUpdate(t *testing.T) {
+	r := &inject.Robot{}
+	r.TransformPoseFunc = func(
+		ctx context.Context, pose *referenceframe.PoseInFrame, dst string, additionalTransforms []*commonpb.Transform,
+	) (*referenceframe.PoseInFrame, error) {
+		test.That(t, pose.FrameName(), test.ShouldEqual, "cam")
+		test.That(t, dst, test.ShouldEqual, referenceframe.World)
+		return referenceframe.NewPoseInFrame(dst, spatialmath.NewPoseFromPoint(r3.Vector{0, 0, 500})), nil
+	}
+	cam := &inject.Camera{}
+	cam.NextPointCloudFunc = func(ctx context.Context) (pointcloud.PointCloud, error) {
+		return wallCloud(t), nil
+	}
+
+	g, err := NewGrid(testConfig())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, g.Update(context.Background(), cam, FrameSystemPoseSource(r, "cam", referenceframe.World)), test.ShouldBeNil)
+	test.That(t, g.State(550, 0), test.ShouldEqual, Occupied)
+
+	cam.NextPointCloudFunc = func(ctx context.Context) (pointcloud.PointCloud, error) {
+		return nil, errors.New("no cloud")
+	}
+	err = g.Update(context.Background(), cam, FrameSystemPoseSource(r, "cam", referenceframe.World))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "no cloud")
+
+	r.TransformPoseFunc = func(
+		ctx context.Context, pose *referenceframe.PoseInFrame, dst string, additionalTransforms []*commonpb.Transform,
+	) (*referenceframe.PoseInFrame, error) {
+		return nil, errors.New("no frame")
+	}
+	err = g.Update(context.Background(), cam, FrameSystemPoseSource(r, "cam", referenceframe.World))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "no frame")
+}
+
+func TestMovementSensorPoseSource(t *testing.T) {
+	origin := geo.NewPoint(40.7, -74)
+	ms := &inject.MovementSensor{}
+	ms.PositionFunc = func(ctx context.Context) (*geo.Point, float64, error) {
+		// 10m to the east of the origin
+		return origin.PointAtDistanceAndBearing(0.01, 90), 0, nil
+	}
+	ms.CompassHeadingFunc = func(ctx context.Context) (float64, error) {
+		return 0, nil
+	}
+	offset := spatialmath.NewPoseFromPoint(r3.Vector{100, 0, 500})
+	pose, err := MovementSensorPoseSource(ms, origin, offset)(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	// heading north, the front of the robot is along y
+	test.That(t, pose.Point().X, test.ShouldAlmostEqual, 10000, 1)
+	test.That(t, pose.Point().Y, test.ShouldAlmostEqual, 100, 1)
+	test.That(t, pose.Point().Z, test.ShouldAlmostEqual, 500)
+
+	pose = sensorPose(origin, origin.PointAtDistanceAndBearing(0.002, 0), 90)
+	test.That(t, pose.Point().X, test.ShouldAlmostEqual, 0, 1)
+	test.That(t, pose.Point().Y, test.ShouldAlmostEqual, 2000, 1)
+	test.That(t, spatialmath.OrientationAlmostEqual(pose.Orientation(), spatialmath.NewZeroOrientation()), test.ShouldBeTrue)
+
+	ms.CompassHeadingFunc = func(ctx context.Context) (float64, error) {
+		return 0, errors.New("no heading")
+	}
+	_, err = MovementSensorPoseSource(ms, origin, offset)(context.Background())
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -0,0 +1,145 @@
+package occupancy
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v2"
+
+	"go.viam.com/rdk/utils"
+)
+
+// The gray levels of the cells in map images, as map_server of ROS reads them.
+const (
+	occupiedGray = 0
+	unknownGray  = 205
+	freeGray     = 254
+)
+
+// The thresholds of map_server on the darkness of the pixels of map images, (255 - gray) / 255,
+// above which cells are occupied and below which they are free. They tell apart the gray levels
+// of the image, whose darkness is 1, 0.196 and 0.004, so they do not depend on the thresholds of
+// the grid.
+const (
+	occupiedThresh = 0.65
+	freeThresh     = 0.196
+)
+
+// MapMetadata is the YAML metadata of a map image, in the format of map_server of ROS. Distances
+// are in meters.
+type MapMetadata struct {
+	Image          string     `yaml:"image"`
+	Resolution     float64    `yaml:"resolution"`
+	Origin         [3]float64 `yaml:"origin,flow"`
+	Negate         int        `yaml:"negate"`
+	OccupiedThresh float64    `yaml:"occupied_thresh"`
+	FreeThresh     float64    `yaml:"free_thresh"`
+}
+
+// Image returns the grid as a gray image whose pixels are black for occupied cells, white for
+// free cells and gray for unknown cells. The first row of the image is the row of the grid whose
+// y is the highest, so that the image is seen from above.
+func (g *Grid) Image() *image.Gray {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	img := image.NewGray(image.Rect(0, 0, g.cfg.Width, g.cfg.Height))
+	for j := 0; j < g.cfg.Height; j++ {
+		for i := 0; i < g.cfg.Width; i++ {
+			gray := uint8(unknownGray)
+			switch g.state(j*g.cfg.Width + i) {
+			case Occupied:
+				gray = occupiedGray
+			case Free:
+				gray = freeGray
+			case Unknown:
+			}
+			img.SetGray(i, g.cfg.Height-1-j, color.Gray{gray})
+		}
+	}
+	return img
+}
+
+// WritePGM writes the image of the grid as a binary PGM.
+func (g *Grid) WritePGM(out io.Writer) error {
+	img := g.Image()
+	w := bufio.NewWriter(out)
+	if _, err := fmt.Fprintf(w, "P5\n# resolution %v mm\n%d %d\n255\n", g.cfg.Resolution, g.cfg.Width, g.cfg.Height); err != nil {
+		return err
+	}
+	if _, err := w.Write(img.Pix); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// WritePNG writes the image of the grid as a PNG.
+func (g *Grid) WritePNG(out io.Writer) error {
+	return png.Encode(out, g.Image())
+}
+
+// Metadata returns the metadata of the image of the grid saved as imageName. Its origin is the
+// pose of the lower left pixel of the image: the position and yaw, in radians, of the grid.
+func (g *Grid) Metadata(imageName string) MapMetadata {
+	return MapMetadata{
+		Image:          imageName,
+		Resolution:     g.cfg.Resolution / 1000,
+		Origin:         [3]float64{g.cfg.Origin.X / 1000, g.cfg.Origin.Y / 1000, utils.DegToRad(g.cfg.Yaw)},
+		Negate:         0,
+		OccupiedThresh: occupiedThresh,
+		FreeThresh:     freeThresh,
+	}
+}
+
+// WriteYAML writes the metadata of the image of the grid saved as imageName.
+func (g *Grid) WriteYAML(out io.Writer, imageName string) error {
+	data, err := yaml.Marshal(g.Metadata(imageName))
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+// Save saves the image of the grid, as a PGM or PNG depending on the extension of the path, and
+// its metadata in a YAML file of the same name.
+func (g *Grid) Save(imagePath string) error {
+	ext := strings.ToLower(filepath.Ext(imagePath))
+	var writeImage func(io.Writer) error
+	switch ext {
+	case ".pgm":
+		writeImage = g.WritePGM
+	case ".png":
+		writeImage = g.WritePNG
+	default:
+		return errors.Errorf("cannot save an occupancy grid as %q, only as .pgm or .png", ext)
+	}
+	if err := writeFile(imagePath, writeImage); err != nil {
+		return err
+	}
+	yamlPath := strings.TrimSuffix(imagePath, filepath.Ext(imagePath)) + ".yaml"
+	return writeFile(yamlPath, func(w io.Writer) error {
+		return g.WriteYAML(w, filepath.Base(imagePath))
+	})
+}
+
+// writeFile creates the file and writes it.
+func writeFile(fn string, write func(io.Writer) error) (err error) {
+	//nolint:gosec
+	f, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Combine(err, f.Close())
+	}()
+	return write(f)
+}
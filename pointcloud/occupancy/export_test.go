@@ -0,0 +1,93 @@
+package occupancy
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+	"gopkg.in/yaml.v2"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+func TestExport(t *testing.T) {
+	g, err := NewGrid(testConfig())
+	test.That(t, err, test.ShouldBeNil)
+	for i := 0; i < 5; i++ {
+		g.InsertPointCloud(wallCloud(t), spatialmath.NewPoseFromPoint(r3.Vector{0, 0, 500}))
+	}
+
+	img := g.Image()
+	test.That(t, img.Bounds().Dx(), test.ShouldEqual, 20)
+	test.That(t, img.Bounds().Dy(), test.ShouldEqual, 20)
+	// the cell (15, 10) is on the row 20-1-10 of the image
+	test.That(t, img.GrayAt(15, 9).Y, test.ShouldEqual, occupiedGray)
+	test.That(t, img.GrayAt(12, 9).Y, test.ShouldEqual, freeGray)
+	test.That(t, img.GrayAt(17, 9).Y, test.ShouldEqual, unknownGray)
+
+	var buf bytes.Buffer
+	test.That(t, g.WritePGM(&buf), test.ShouldBeNil)
+	header := "P5\n# resolution 100 mm\n20 20\n255\n"
+	test.That(t, buf.String()[:len(header)], test.ShouldEqual, header)
+	test.That(t, buf.Bytes()[len(header):], test.ShouldResemble, img.Pix)
+
+	buf.Reset()
+	test.That(t, g.WritePNG(&buf), test.ShouldBeNil)
+	decoded, err := png.Decode(&buf)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, decoded.Bounds(), test.ShouldResemble, img.Bounds())
+
+	buf.Reset()
+	test.That(t, g.WriteYAML(&buf, "map.pgm"), test.ShouldBeNil)
+	var metadata MapMetadata
+	test.That(t, yaml.Unmarshal(buf.Bytes(), &metadata), test.ShouldBeNil)
+	test.That(t, metadata, test.ShouldResemble, MapMetadata{
+		Image:          "map.pgm",
+		Resolution:     0.1,
+		Origin:         [3]float64{-1, -1, 0},
+		OccupiedThresh: 0.65,
+		FreeThresh:     0.196,
+	})
+	// map_server reads the gray of unknown cells as neither occupied nor free
+	unknownDarkness := float64(255-unknownGray) / 255
+	test.That(t, unknownDarkness, test.ShouldBeGreaterThan, metadata.FreeThresh)
+	test.That(t, unknownDarkness, test.ShouldBeLessThan, metadata.OccupiedThresh)
+
+	// the thresholds of the image do not depend on the thresholds of the grid, and the origin
+	// holds the yaw of the grid
+	cfg := testConfig()
+	cfg.FreeThreshold = 0.3
+	cfg.OccupiedThreshold = 0.8
+	cfg.Origin = r3.Vector{1000, -1000, 0}
+	cfg.Yaw = 90
+	g, err = NewGrid(cfg)
+	test.That(t, err, test.ShouldBeNil)
+	metadata = g.Metadata("map.png")
+	test.That(t, metadata.OccupiedThresh, test.ShouldEqual, 0.65)
+	test.That(t, metadata.FreeThresh, test.ShouldEqual, 0.196)
+	test.That(t, metadata.Origin[:2], test.ShouldResemble, []float64{1, -1})
+	test.That(t, metadata.Origin[2], test.ShouldAlmostEqual, math.Pi/2)
+}
+
+func TestSave(t *testing.T) {
+	g, err := NewGrid(testConfig())
+	test.That(t, err, test.ShouldBeNil)
+	dir := t.TempDir()
+	for _, name := range []string{"map.pgm", "map2.PNG"} {
+		test.That(t, g.Save(filepath.Join(dir, name)), test.ShouldBeNil)
+		_, err = os.Stat(filepath.Join(dir, name))
+		test.That(t, err, test.ShouldBeNil)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "map2.yaml"))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, string(data), test.ShouldContainSubstring, "image: map2.PNG")
+	_, err = os.Stat(filepath.Join(dir, "map.yaml"))
+	test.That(t, err, test.ShouldBeNil)
+
+	test.That(t, g.Save(filepath.Join(dir, "map.jpg")), test.ShouldNotBeNil)
+}
@@ -0,0 +1,445 @@
+// Package occupancy builds occupancy grids, 2D maps of which places are free or occupied by
+// obstacles, from point clouds and the poses of the cameras that took them.
+package occupancy
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+// CellState is whether a cell of the grid is known to be free or occupied.
+type CellState int
+
+// The states of cells.
+const (
+	Unknown CellState = iota
+	Free
+	Occupied
+)
+
+// Config describes the area an occupancy grid covers and how it is updated. Distances are in mm.
+type Config struct {
+	// Frame the grid is in. Its cells cover the xy plane of the frame. Defaults to the world frame.
+	Frame string `json:"frame,omitempty"`
+	// Resolution is the side of the cells.
+	Resolution float64 `json:"resolution_mm"`
+	// Width and Height are the number of cells along x and y.
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	// Origin is the position of the corner of the first cell, whose coordinates are the lowest.
+	Origin r3.Vector `json:"origin_mm"`
+	// Yaw is the angle in degrees, counterclockwise about z, from the x axis of the frame to the
+	// rows of the grid, which turn around Origin.
+	Yaw float64 `json:"yaw_deg,omitempty"`
+	// Points are only obstacles when their height (z) is between MinHeight and MaxHeight, which
+	// leaves out the floor and ceiling. Points below MinHeight are seen floor, free of obstacles.
+	// Ignored unless MaxHeight is above MinHeight.
+	MinHeight float64 `json:"min_height_mm,omitempty"`
+	MaxHeight float64 `json:"max_height_mm,omitempty"`
+	// MaxRange is the distance beyond which points are not trusted: the space towards them is free
+	// up to MaxRange, but they are not obstacles. Ignored unless positive.
+	MaxRange float64 `json:"max_range_mm,omitempty"`
+	// The probabilities that a cell is occupied when a point hits it, when a ray towards a point
+	// crosses it, and the bounds of the probability of occupancy of cells. Default to 0.7, 0.4,
+	// 0.12 and 0.97.
+	HitProbability  float64 `json:"hit_probability,omitempty"`
+	MissProbability float64 `json:"miss_probability,omitempty"`
+	MinProbability  float64 `json:"min_probability,omitempty"`
+	MaxProbability  float64 `json:"max_probability,omitempty"`
+	// The probabilities above which cells are occupied and below which they are free. Default to
+	// 0.65 and 0.196.
+	OccupiedThreshold float64 `json:"occupied_threshold,omitempty"`
+	FreeThreshold     float64 `json:"free_threshold,omitempty"`
+}
+
+// setDefaults fills the unset fields of the config with their defaults.
+func (cfg *Config) setDefaults() {
+	if cfg.Frame == "" {
+		cfg.Frame = referenceframe.World
+	}
+	setDefault := func(v *float64, def float64) {
+		if *v == 0 {
+			*v = def
+		}
+	}
+	setDefault(&cfg.HitProbability, 0.7)
+	setDefault(&cfg.MissProbability, 0.4)
+	setDefault(&cfg.MinProbability, 0.12)
+	setDefault(&cfg.MaxProbability, 0.97)
+	setDefault(&cfg.OccupiedThreshold, 0.65)
+	setDefault(&cfg.FreeThreshold, 0.196)
+}
+
+// validate returns an error if the config does not describe a grid.
+func (cfg *Config) validate() error {
+	if cfg.Resolution <= 0 {
+		return errors.Errorf("resolution must be positive, got %v", cfg.Resolution)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return errors.Errorf("width and height must be positive, got %dx%d", cfg.Width, cfg.Height)
+	}
+	for name, p := range map[string]float64{
+		"hit_probability":    cfg.HitProbability,
+		"miss_probability":   cfg.MissProbability,
+		"min_probability":    cfg.MinProbability,
+		"max_probability":    cfg.MaxProbability,
+		"occupied_threshold": cfg.OccupiedThreshold,
+		"free_threshold":     cfg.FreeThreshold,
+	} {
+		if p <= 0 || p >= 1 {
+			return errors.Errorf("%s must be between 0 and 1 exclusive, got %v", name, p)
+		}
+	}
+	if cfg.HitProbability <= 0.5 || cfg.MissProbability >= 0.5 {
+		return errors.New("hit_probability must be above 0.5 and miss_probability below it")
+	}
+	if cfg.MinProbability >= cfg.MaxProbability || cfg.FreeThreshold >= cfg.OccupiedThreshold {
+		return errors.New("min_probability and free_threshold must be below max_probability and occupied_threshold")
+	}
+	return nil
+}
+
+// logOdds returns the log-odds of the probability.
+func logOdds(p float64) float64 {
+	return math.Log(p / (1 - p))
+}
+
+// probability returns the probability of the log-odds.
+func probability(l float64) float64 {
+	return 1 - 1/(1+math.Exp(l))
+}
+
+// Grid is an occupancy grid: each of its cells holds the log-odds of the probability it is
+// occupied, which the point clouds inserted in it update, starting from 0 (a probability of 0.5)
+// for unknown cells. It also holds the height of the highest obstacle seen in each cell. It is
+// safe to query a grid while point clouds are inserted in it.
+type Grid struct {
+	mu      sync.RWMutex
+	cfg     Config
+	logOdds []float64
+	heights []float64
+
+	hit, miss, minLogOdds, maxLogOdds, occupied, free float64
+	// the sine and cosine of the yaw
+	sin, cos float64
+}
+
+// NewGrid returns an occupancy grid whose cells are all unknown.
+func NewGrid(cfg Config) (*Grid, error) {
+	cfg.setDefaults()
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	g := &Grid{
+		cfg:        cfg,
+		logOdds:    make([]float64, cfg.Width*cfg.Height),
+		heights:    make([]float64, cfg.Width*cfg.Height),
+		hit:        logOdds(cfg.HitProbability),
+		miss:       logOdds(cfg.MissProbability),
+		minLogOdds: logOdds(cfg.MinProbability),
+		maxLogOdds: logOdds(cfg.MaxProbability),
+		occupied:   logOdds(cfg.OccupiedThreshold),
+		free:       logOdds(cfg.FreeThreshold),
+	}
+	g.sin, g.cos = math.Sincos(utils.DegToRad(cfg.Yaw))
+	for i := range g.heights {
+		g.heights[i] = math.NaN()
+	}
+	return g, nil
+}
+
+// Config returns the config of the grid, with its defaults filled.
+func (g *Grid) Config() Config {
+	return g.cfg
+}
+
+// local returns the position of the point along the rows and columns of the grid, in cells.
+func (g *Grid) local(x, y float64) (float64, float64) {
+	dx, dy := x-g.cfg.Origin.X, y-g.cfg.Origin.Y
+	return (g.cos*dx + g.sin*dy) / g.cfg.Resolution, (g.cos*dy - g.sin*dx) / g.cfg.Resolution
+}
+
+// cell returns the column and row of the cell containing the point, which may be outside of the grid.
+func (g *Grid) cell(x, y float64) (int, int) {
+	u, v := g.local(x, y)
+	return int(math.Floor(u)), int(math.Floor(v))
+}
+
+// index returns the index of the cell in the grid, or false if it is outside of the grid.
+func (g *Grid) index(i, j int) (int, bool) {
+	if i < 0 || j < 0 || i >= g.cfg.Width || j >= g.cfg.Height {
+		return 0, false
+	}
+	return j*g.cfg.Width + i, true
+}
+
+// CellCenter returns the center of the cell at the given column and row.
+func (g *Grid) CellCenter(i, j int) r3.Vector {
+	u, v := (float64(i)+0.5)*g.cfg.Resolution, (float64(j)+0.5)*g.cfg.Resolution
+	return r3.Vector{
+		X: g.cfg.Origin.X + g.cos*u - g.sin*v,
+		Y: g.cfg.Origin.Y + g.sin*u + g.cos*v,
+		Z: g.cfg.Origin.Z,
+	}
+}
+
+// InsertPointCloud updates the grid with a point cloud taken from the given pose in the frame of
+// the grid. The cells the points hit become more likely to be occupied, and the cells crossed by
+// the rays from the camera to the points more likely to be free. A cell hit by a point is not
+// made free by the rays of the same point cloud crossing it.
+func (g *Grid) InsertPointCloud(pc pointcloud.PointCloud, pose spatialmath.Pose) {
+	origin := pose.Point()
+	useBand := g.cfg.MaxHeight > g.cfg.MinHeight
+	hits := map[int]float64{}
+	misses := map[int]bool{}
+	markMiss := func(i, j int) {
+		idx, _ := g.index(i, j)
+		misses[idx] = true
+	}
+	pc.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+		p = spatialmath.Compose(pose, spatialmath.NewPoseFromPoint(p)).Point()
+		if useBand && p.Z > g.cfg.MaxHeight {
+			// rays towards the ceiling cross over the obstacles below it
+			return true
+		}
+		isHit := !useBand || p.Z >= g.cfg.MinHeight
+		end := p
+		if dist := p.Sub(origin).Norm(); g.cfg.MaxRange > 0 && dist > g.cfg.MaxRange {
+			end = origin.Add(p.Sub(origin).Mul(g.cfg.MaxRange / dist))
+			isHit = false
+		}
+		g.traverse(origin, end, markMiss)
+		i, j := g.cell(end.X, end.Y)
+		idx, ok := g.index(i, j)
+		switch {
+		case !ok:
+		case isHit:
+			if h, seen := hits[idx]; !seen || p.Z > h {
+				hits[idx] = p.Z
+			}
+		case useBand && p.Z < g.cfg.MinHeight:
+			// the floor is seen there
+			misses[idx] = true
+		}
+		return true
+	})
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for idx := range misses {
+		if _, ok := hits[idx]; !ok {
+			g.logOdds[idx] = math.Max(g.minLogOdds, g.logOdds[idx]+g.miss)
+		}
+	}
+	for idx, h := range hits {
+		g.logOdds[idx] = math.Min(g.maxLogOdds, g.logOdds[idx]+g.hit)
+		if math.IsNaN(g.heights[idx]) || h > g.heights[idx] {
+			g.heights[idx] = h
+		}
+	}
+}
+
+// traverse calls visit on the cells the segment from a to b crosses on the xy plane, in order,
+// except the cell of b, that are in the grid. Only the part of the segment over the grid is walked,
+// so that far points cost no more than the cells of the grid.
+// http://www.cse.yorku.ca/~amana/research/grid.pdf
+func (g *Grid) traverse(a, b r3.Vector, visit func(i, j int)) {
+	x0, y0 := g.local(a.X, a.Y)
+	x1, y1 := g.local(b.X, b.Y)
+	tEnter, tExit, ok := clipSegment(x0, y0, x1, y1, float64(g.cfg.Width), float64(g.cfg.Height))
+	if !ok {
+		return
+	}
+	xs, ys := x0+tEnter*(x1-x0), y0+tEnter*(y1-y0)
+	xe, ye := x0+tExit*(x1-x0), y0+tExit*(y1-y0)
+	i, j := int(math.Floor(xs)), int(math.Floor(ys))
+	endI, endJ := int(math.Floor(x1)), int(math.Floor(y1))
+	step := func(from, delta float64) (int, float64, float64) {
+		switch {
+		case delta > 0:
+			return 1, (math.Floor(from) + 1 - from) / delta, 1 / delta
+		case delta < 0:
+			return -1, (from - math.Floor(from)) / -delta, -1 / delta
+		default:
+			return 0, math.Inf(1), math.Inf(1)
+		}
+	}
+	stepI, tMaxI, tDeltaI := step(xs, x1-x0)
+	stepJ, tMaxJ, tDeltaJ := step(ys, y1-y0)
+	// bounds the walk to the cells over the grid, and in case of rounding errors
+	remaining := absInt(int(math.Floor(xe))-i) + absInt(int(math.Floor(ye))-j) + 1
+	for (i != endI || j != endJ) && remaining >= 0 {
+		if _, ok := g.index(i, j); ok {
+			visit(i, j)
+		}
+		if tMaxI < tMaxJ {
+			tMaxI += tDeltaI
+			i += stepI
+		} else {
+			tMaxJ += tDeltaJ
+			j += stepJ
+		}
+		remaining--
+	}
+}
+
+// clipSegment returns the parameters, between 0 and 1, at which the segment from (x0, y0) to
+// (x1, y1) enters and exits the rectangle from (0, 0) to (width, height), or false if it misses it.
+// https://en.wikipedia.org/wiki/Liang%E2%80%93Barsky_algorithm
+func clipSegment(x0, y0, x1, y1, width, height float64) (float64, float64, bool) {
+	tEnter, tExit := 0., 1.
+	dx, dy := x1-x0, y1-y0
+	for _, edge := range [][2]float64{{-dx, x0}, {dx, width - x0}, {-dy, y0}, {dy, height - y0}} {
+		p, q := edge[0], edge[1]
+		if p == 0 {
+			if q < 0 {
+				return 0, 0, false
+			}
+			continue
+		}
+		t := q / p
+		if p < 0 {
+			tEnter = math.Max(tEnter, t)
+		} else {
+			tExit = math.Min(tExit, t)
+		}
+	}
+	if tEnter > tExit {
+		return 0, 0, false
+	}
+	return tEnter, tExit, true
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// Probability returns the probability that the cell containing the point (x, y) is occupied, or
+// false if the point is outside of the grid.
+func (g *Grid) Probability(x, y float64) (float64, bool) {
+	idx, ok := g.index(g.cell(x, y))
+	if !ok {
+		return 0, false
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return probability(g.logOdds[idx]), true
+}
+
+// State returns whether the cell containing the point (x, y) is free or occupied. Points outside
+// of the grid are unknown.
+func (g *Grid) State(x, y float64) CellState {
+	idx, ok := g.index(g.cell(x, y))
+	if !ok {
+		return Unknown
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.state(idx)
+}
+
+func (g *Grid) state(idx int) CellState {
+	switch l := g.logOdds[idx]; {
+	case l >= g.occupied:
+		return Occupied
+	case l <= g.free:
+		return Free
+	default:
+		return Unknown
+	}
+}
+
+// IsOccupied returns whether the point is in an occupied cell, below the highest obstacle seen in it.
+func (g *Grid) IsOccupied(p r3.Vector) bool {
+	idx, ok := g.index(g.cell(p.X, p.Y))
+	if !ok {
+		return false
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.state(idx) != Occupied {
+		return false
+	}
+	return math.IsNaN(g.heights[idx]) || p.Z <= g.heights[idx]
+}
+
+// Height returns the height of the highest obstacle seen in the cell containing the point (x, y),
+// or false if none was.
+func (g *Grid) Height(x, y float64) (float64, bool) {
+	idx, ok := g.index(g.cell(x, y))
+	if !ok {
+		return 0, false
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	h := g.heights[idx]
+	return h, !math.IsNaN(h)
+}
+
+// Obstacles returns a box for each occupied cell, in the frame of the grid, which can be passed to
+// the motion service as obstacles of a world state. The boxes rise from MinHeight to the highest
+// obstacle seen in their cell.
+func (g *Grid) Obstacles() (*referenceframe.GeometriesInFrame, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	obstacles := map[string]spatialmath.Geometry{}
+	for j := 0; j < g.cfg.Height; j++ {
+		for i := 0; i < g.cfg.Width; i++ {
+			idx := j*g.cfg.Width + i
+			if g.state(idx) != Occupied {
+				continue
+			}
+			box, err := g.cellBox(i, j, g.heights[idx])
+			if err != nil {
+				return nil, err
+			}
+			obstacles[box.Label()] = box
+		}
+	}
+	return referenceframe.NewGeometriesInFrame(g.cfg.Frame, obstacles), nil
+}
+
+// cellBox returns the box of an occupied cell, from the floor to the given height.
+func (g *Grid) cellBox(i, j int, height float64) (spatialmath.Geometry, error) {
+	floor := g.cfg.MinHeight
+	if math.IsNaN(height) || height-floor < g.cfg.Resolution {
+		height = floor + g.cfg.Resolution
+	}
+	center := g.CellCenter(i, j)
+	center.Z = (floor + height) / 2
+	return spatialmath.NewBox(
+		spatialmath.NewPoseFromOrientation(center, &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: g.cfg.Yaw}),
+		r3.Vector{X: g.cfg.Resolution, Y: g.cfg.Resolution, Z: height - floor},
+		fmt.Sprintf("cell_%d_%d", i, j),
+	)
+}
+
+// CollidesWith returns whether the geometry, in the frame of the grid, collides with an occupied cell.
+func (g *Grid) CollidesWith(geometry spatialmath.Geometry) (bool, error) {
+	obstacles, err := g.Obstacles()
+	if err != nil {
+		return false, err
+	}
+	for _, obstacle := range obstacles.Geometries() {
+		collides, err := geometry.CollidesWith(obstacle)
+		if err != nil {
+			return false, err
+		}
+		if collides {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,229 @@
+package occupancy
+
+import (
+	"image"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// testConfig returns the config of a 2m x 2m grid of 10cm cells centered on the origin, where
+// obstacles are between 5cm and 2m high.
+func testConfig() Config {
+	return Config{
+		Resolution: 100,
+		Width:      20,
+		Height:     20,
+		Origin:     r3.Vector{-1000, -1000, 0},
+		MinHeight:  50,
+		MaxHeight:  2000,
+	}
+}
+
+// wallCloud returns the point cloud of a camera 50cm above the ground, seeing a wall 50cm in front
+// of it along x, the floor before the wall, and the ceiling behind it.
+func wallCloud(t *testing.T) pointcloud.PointCloud {
+	t.Helper()
+	pc := pointcloud.New()
+	for y := -300.; y <= 300; y += 50 {
+		for z := -400.; z <= 500; z += 100 {
+			test.That(t, pc.Set(r3.Vector{550, y, z}, nil), test.ShouldBeNil)
+		}
+	}
+	test.That(t, pc.Set(r3.Vector{350, 0, -500}, nil), test.ShouldBeNil)
+	test.That(t, pc.Set(r3.Vector{-450, 0, 2500}, nil), test.ShouldBeNil)
+	return pc
+}
+
+func TestNewGrid(t *testing.T) {
+	g, err := NewGrid(testConfig())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, g.Config().Frame, test.ShouldEqual, referenceframe.World)
+	test.That(t, g.Config().HitProbability, test.ShouldEqual, 0.7)
+	test.That(t, g.State(0, 0), test.ShouldEqual, Unknown)
+	p, ok := g.Probability(0, 0)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, p, test.ShouldAlmostEqual, 0.5)
+	_, ok = g.Probability(1000, 0)
+	test.That(t, ok, test.ShouldBeFalse)
+	test.That(t, g.CellCenter(10, 10), test.ShouldResemble, r3.Vector{50, 50, 0})
+
+	for _, cfg := range []Config{
+		{Resolution: 0, Width: 1, Height: 1},
+		{Resolution: 1, Width: 0, Height: 1},
+		{Resolution: 1, Width: 1, Height: 1, HitProbability: 0.3},
+		{Resolution: 1, Width: 1, Height: 1, MissProbability: 1.5},
+		{Resolution: 1, Width: 1, Height: 1, FreeThreshold: 0.9},
+	} {
+		_, err := NewGrid(cfg)
+		test.That(t, err, test.ShouldNotBeNil)
+	}
+}
+
+func TestInsertPointCloud(t *testing.T) {
+	g, err := NewGrid(testConfig())
+	test.That(t, err, test.ShouldBeNil)
+	pose := spatialmath.NewPoseFromPoint(r3.Vector{0, 0, 500})
+	g.InsertPointCloud(wallCloud(t), pose)
+	test.That(t, g.State(550, 0), test.ShouldEqual, Occupied)
+	test.That(t, g.State(550, 450), test.ShouldEqual, Unknown)
+	// a single miss is not enough to be sure a cell is free
+	test.That(t, g.State(250, 0), test.ShouldEqual, Unknown)
+	p, _ := g.Probability(250, 0)
+	test.That(t, p, test.ShouldAlmostEqual, 0.4)
+
+	for i := 0; i < 4; i++ {
+		g.InsertPointCloud(wallCloud(t), pose)
+	}
+	test.That(t, g.State(250, 0), test.ShouldEqual, Free)
+	test.That(t, g.State(50, 0), test.ShouldEqual, Free)
+	// the floor before the wall is seen
+	test.That(t, g.State(350, 0), test.ShouldEqual, Free)
+	// behind the wall, and under the ceiling
+	test.That(t, g.State(750, 0), test.ShouldEqual, Unknown)
+	test.That(t, g.State(-450, 0), test.ShouldEqual, Unknown)
+	p, _ = g.Probability(550, 0)
+	test.That(t, p, test.ShouldAlmostEqual, 0.97)
+
+	h, ok := g.Height(550, 0)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, h, test.ShouldEqual, 1000)
+	_, ok = g.Height(250, 0)
+	test.That(t, ok, test.ShouldBeFalse)
+	test.That(t, g.IsOccupied(r3.Vector{550, 0, 500}), test.ShouldBeTrue)
+	test.That(t, g.IsOccupied(r3.Vector{550, 0, 1500}), test.ShouldBeFalse)
+	test.That(t, g.IsOccupied(r3.Vector{250, 0, 500}), test.ShouldBeFalse)
+	test.That(t, g.IsOccupied(r3.Vector{5000, 0, 500}), test.ShouldBeFalse)
+
+	// a camera turned around sees the wall behind it
+	g, err = NewGrid(testConfig())
+	test.That(t, err, test.ShouldBeNil)
+	turned := spatialmath.NewPoseFromOrientation(r3.Vector{0, 0, 500}, &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: 180})
+	g.InsertPointCloud(wallCloud(t), turned)
+	test.That(t, g.State(-550, 0), test.ShouldEqual, Occupied)
+	test.That(t, g.State(550, 0), test.ShouldEqual, Unknown)
+}
+
+func TestMaxRange(t *testing.T) {
+	cfg := testConfig()
+	cfg.MaxRange = 300
+	g, err := NewGrid(cfg)
+	test.That(t, err, test.ShouldBeNil)
+	for i := 0; i < 5; i++ {
+		g.InsertPointCloud(wallCloud(t), spatialmath.NewPoseFromPoint(r3.Vector{0, 0, 500}))
+	}
+	test.That(t, g.State(550, 0), test.ShouldEqual, Unknown)
+	test.That(t, g.State(150, 0), test.ShouldEqual, Free)
+	test.That(t, g.State(450, 0), test.ShouldEqual, Unknown)
+}
+
+func TestFarPoints(t *testing.T) {
+	// with no max range, rays towards far points free the cells up to the edge of the grid
+	g, err := NewGrid(testConfig())
+	test.That(t, err, test.ShouldBeNil)
+	pc := pointcloud.New()
+	test.That(t, pc.Set(r3.Vector{5e9, 0, 0}, nil), test.ShouldBeNil)
+	for i := 0; i < 5; i++ {
+		g.InsertPointCloud(pc, spatialmath.NewPoseFromPoint(r3.Vector{0, 0, 500}))
+	}
+	test.That(t, g.State(50, 0), test.ShouldEqual, Free)
+	test.That(t, g.State(950, 0), test.ShouldEqual, Free)
+	test.That(t, g.State(-50, 0), test.ShouldEqual, Unknown)
+}
+
+func TestYaw(t *testing.T) {
+	// the rows of the grid go along y, and its columns along -x, over the same area as testConfig
+	cfg := testConfig()
+	cfg.Origin = r3.Vector{1000, -1000, 0}
+	cfg.Yaw = 90
+	g, err := NewGrid(cfg)
+	test.That(t, err, test.ShouldBeNil)
+	for i := 0; i < 5; i++ {
+		g.InsertPointCloud(wallCloud(t), spatialmath.NewPoseFromPoint(r3.Vector{0, 0, 500}))
+	}
+	test.That(t, g.State(550, 0), test.ShouldEqual, Occupied)
+	test.That(t, g.State(250, 0), test.ShouldEqual, Free)
+	test.That(t, g.State(750, 0), test.ShouldEqual, Unknown)
+	i, j := g.cell(550, 0)
+	test.That(t, image.Pt(i, j), test.ShouldResemble, image.Pt(10, 4))
+	center := g.CellCenter(10, 4)
+	test.That(t, center.X, test.ShouldAlmostEqual, 550)
+	test.That(t, center.Y, test.ShouldAlmostEqual, 50)
+
+	obstacles, err := g.Obstacles()
+	test.That(t, err, test.ShouldBeNil)
+	box, ok := obstacles.Geometries()["cell_10_4"]
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, spatialmath.OrientationAlmostEqual(
+		box.Pose().Orientation(), &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: 90},
+	), test.ShouldBeTrue)
+}
+
+func TestTraverse(t *testing.T) {
+	g, err := NewGrid(Config{Resolution: 1, Width: 10, Height: 10})
+	test.That(t, err, test.ShouldBeNil)
+	var cells []image.Point
+	visit := func(i, j int) { cells = append(cells, image.Pt(i, j)) }
+	g.traverse(r3.Vector{0.5, 0.5, 0}, r3.Vector{3.5, 0.5, 0}, visit)
+	test.That(t, cells, test.ShouldResemble, []image.Point{{0, 0}, {1, 0}, {2, 0}})
+
+	cells = nil
+	g.traverse(r3.Vector{0.5, 0.2, 0}, r3.Vector{2.5, 1.8, 0}, visit)
+	test.That(t, cells, test.ShouldResemble, []image.Point{{0, 0}, {1, 0}, {1, 1}})
+
+	cells = nil
+	g.traverse(r3.Vector{2.5, 2.5, 0}, r3.Vector{2.5, -0.5, 0}, visit)
+	test.That(t, cells, test.ShouldResemble, []image.Point{{2, 2}, {2, 1}, {2, 0}})
+
+	cells = nil
+	g.traverse(r3.Vector{2.5, 2.5, 0}, r3.Vector{2.7, 2.1, 0}, visit)
+	test.That(t, cells, test.ShouldBeEmpty)
+
+	// only the cells in the grid are walked, however far the ends of the segment are
+	cells = nil
+	g.traverse(r3.Vector{-1e12, 2.5, 0}, r3.Vector{1e12, 2.5, 0}, visit)
+	test.That(t, cells, test.ShouldHaveLength, 10)
+	test.That(t, cells[0], test.ShouldResemble, image.Pt(0, 2))
+	test.That(t, cells[9], test.ShouldResemble, image.Pt(9, 2))
+
+	cells = nil
+	g.traverse(r3.Vector{8.5, 8.5, 0}, r3.Vector{2e12, 1e12, 0}, visit)
+	test.That(t, cells, test.ShouldResemble, []image.Point{{8, 8}, {9, 8}, {9, 9}})
+
+	cells = nil
+	g.traverse(r3.Vector{-5, -5, 0}, r3.Vector{-1e12, 1e12, 0}, visit)
+	test.That(t, cells, test.ShouldBeEmpty)
+}
+
+func TestObstacles(t *testing.T) {
+	g, err := NewGrid(testConfig())
+	test.That(t, err, test.ShouldBeNil)
+	g.InsertPointCloud(wallCloud(t), spatialmath.NewPoseFromPoint(r3.Vector{0, 0, 500}))
+
+	obstacles, err := g.Obstacles()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, obstacles.FrameName(), test.ShouldEqual, referenceframe.World)
+	// the wall spans 7 cells, from y=-300 to y=300
+	test.That(t, obstacles.Geometries(), test.ShouldHaveLength, 7)
+	cell, ok := obstacles.Geometries()["cell_15_10"]
+	test.That(t, ok, test.ShouldBeTrue)
+	expected, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{550, 50, 525}), r3.Vector{100, 100, 950}, "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cell.AlmostEqual(expected), test.ShouldBeTrue)
+
+	robotBox, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{450, 0, 300}), r3.Vector{300, 300, 300}, "")
+	test.That(t, err, test.ShouldBeNil)
+	collides, err := g.CollidesWith(robotBox)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, collides, test.ShouldBeTrue)
+	robotBox, err = spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{0, 0, 300}), r3.Vector{300, 300, 300}, "")
+	test.That(t, err, test.ShouldBeNil)
+	collides, err = g.CollidesWith(robotBox)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, collides, test.ShouldBeFalse)
+}
@@ -0,0 +1,422 @@
+package pointcloud
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+)
+
+// PLYFormat is the format of a ply file.
+type PLYFormat int
+
+const (
+	// PLYAscii ascii format for ply.
+	PLYAscii PLYFormat = iota
+	// PLYBinaryLittleEndian little endian binary format for ply.
+	PLYBinaryLittleEndian
+	// PLYBinaryBigEndian big endian binary format for ply.
+	PLYBinaryBigEndian
+)
+
+var plyFormatNames = map[PLYFormat]string{
+	PLYAscii:              "ascii",
+	PLYBinaryLittleEndian: "binary_little_endian",
+	PLYBinaryBigEndian:    "binary_big_endian",
+}
+
+// plyMaxPrealloc is the most points preallocated for the vertices of a ply file; clouds with more
+// grow as they are read.
+const plyMaxPrealloc = 1 << 20
+
+// plyType is the type of a ply property.
+type plyType string
+
+// the sizes in bytes of the ply property types, under both their names.
+var plyTypeSizes = map[plyType]int{
+	"char": 1, "int8": 1,
+	"uchar": 1, "uint8": 1,
+	"short": 2, "int16": 2,
+	"ushort": 2, "uint16": 2,
+	"int": 4, "int32": 4,
+	"uint": 4, "uint32": 4,
+	"float": 4, "float32": 4,
+	"double": 8, "float64": 8,
+}
+
+func (t plyType) isFloat() bool {
+	return t == "float" || t == "float32" || t.isDouble()
+}
+
+func (t plyType) isDouble() bool {
+	return t == "double" || t == "float64"
+}
+
+// decode returns the value of the property type held by buf.
+func (t plyType) decode(buf []byte, order binary.ByteOrder) float64 {
+	switch t {
+	case "char", "int8":
+		return float64(int8(buf[0]))
+	case "uchar", "uint8":
+		return float64(buf[0])
+	case "short", "int16":
+		return float64(int16(order.Uint16(buf)))
+	case "ushort", "uint16":
+		return float64(order.Uint16(buf))
+	case "int", "int32":
+		return float64(int32(order.Uint32(buf)))
+	case "uint", "uint32":
+		return float64(order.Uint32(buf))
+	case "float", "float32":
+		return float64(math.Float32frombits(order.Uint32(buf)))
+	default:
+		return math.Float64frombits(order.Uint64(buf))
+	}
+}
+
+// plyProperty is a property of a ply element. List properties hold a count of type countType
+// followed by that many values of type valueType.
+type plyProperty struct {
+	name      string
+	valueType plyType
+	countType plyType
+}
+
+type plyElement struct {
+	name       string
+	count      int
+	properties []plyProperty
+}
+
+type plyHeader struct {
+	format   PLYFormat
+	elements []plyElement
+}
+
+// parsePLYHeader reads the header of a ply file, up to and including its end_header line.
+func parsePLYHeader(in *bufio.Reader) (*plyHeader, error) {
+	readLine := func() (string, error) {
+		line, err := in.ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf("error reading ply header: %w", err)
+		}
+		return strings.TrimSpace(line), nil
+	}
+	magic, err := readLine()
+	if err != nil {
+		return nil, err
+	}
+	if magic != "ply" {
+		return nil, errors.New("ply file must start with ply")
+	}
+	header := &plyHeader{format: -1}
+	for {
+		line, err := readLine()
+		if err != nil {
+			return nil, err
+		}
+		tokens := strings.Fields(line)
+		if len(tokens) == 0 {
+			continue
+		}
+		switch tokens[0] {
+		case "end_header":
+			if header.format < 0 {
+				return nil, errors.New("ply header has no format line")
+			}
+			return header, nil
+		case "comment", "obj_info":
+		case "format":
+			if len(tokens) != 3 || tokens[2] != "1.0" {
+				return nil, fmt.Errorf("unsupported ply format line %q", line)
+			}
+			for format, name := range plyFormatNames {
+				if tokens[1] == name {
+					header.format = format
+				}
+			}
+			if header.format < 0 {
+				return nil, fmt.Errorf("unsupported ply format %s", tokens[1])
+			}
+		case "element":
+			if len(tokens) != 3 {
+				return nil, fmt.Errorf("invalid ply element line %q", line)
+			}
+			count, err := strconv.Atoi(tokens[2])
+			if err != nil || count < 0 {
+				return nil, fmt.Errorf("invalid ply element count %s", tokens[2])
+			}
+			header.elements = append(header.elements, plyElement{name: tokens[1], count: count})
+		case "property":
+			if len(header.elements) == 0 {
+				return nil, fmt.Errorf("ply property %q is not part of an element", line)
+			}
+			var prop plyProperty
+			switch {
+			case len(tokens) == 3:
+				prop = plyProperty{name: tokens[2], valueType: plyType(tokens[1])}
+			case len(tokens) == 5 && tokens[1] == "list":
+				prop = plyProperty{name: tokens[4], valueType: plyType(tokens[3]), countType: plyType(tokens[2])}
+				if _, ok := plyTypeSizes[prop.countType]; !ok || prop.countType.isFloat() {
+					return nil, fmt.Errorf("invalid ply list count type %s", prop.countType)
+				}
+			default:
+				return nil, fmt.Errorf("invalid ply property line %q", line)
+			}
+			if _, ok := plyTypeSizes[prop.valueType]; !ok {
+				return nil, fmt.Errorf("unsupported ply property type %s", prop.valueType)
+			}
+			element := &header.elements[len(header.elements)-1]
+			element.properties = append(element.properties, prop)
+		default:
+			return nil, fmt.Errorf("unexpected ply header line %q", line)
+		}
+	}
+}
+
+// plyElementReader reads the elements of the body of a ply file one at a time.
+type plyElementReader struct {
+	in     *bufio.Reader
+	format PLYFormat
+	order  binary.ByteOrder
+	buf    []byte
+}
+
+func newPLYElementReader(in *bufio.Reader, format PLYFormat) *plyElementReader {
+	var order binary.ByteOrder = binary.LittleEndian
+	if format == PLYBinaryBigEndian {
+		order = binary.BigEndian
+	}
+	return &plyElementReader{in: in, format: format, order: order, buf: make([]byte, 8)}
+}
+
+// read reads an element into values, which holds the value of each of its scalar properties. The
+// values of list properties are read but not kept.
+func (r *plyElementReader) read(element *plyElement, values []float64) error {
+	if r.format == PLYAscii {
+		line, err := r.in.ReadString('\n')
+		if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+			return err
+		}
+		tokens := strings.Fields(line)
+		for i, prop := range element.properties {
+			if len(tokens) == 0 {
+				return fmt.Errorf("missing ply %s property %s", element.name, prop.name)
+			}
+			if prop.countType != "" {
+				count, err := strconv.Atoi(tokens[0])
+				if err != nil || count < 0 || count >= len(tokens) {
+					return fmt.Errorf("invalid ply list count %s", tokens[0])
+				}
+				tokens = tokens[count+1:]
+				continue
+			}
+			values[i], err = strconv.ParseFloat(tokens[0], 64)
+			if err != nil {
+				return fmt.Errorf("invalid ply %s property %s: %w", element.name, prop.name, err)
+			}
+			tokens = tokens[1:]
+		}
+		return nil
+	}
+	readValue := func(t plyType) (float64, error) {
+		buf := r.buf[:plyTypeSizes[t]]
+		if _, err := io.ReadFull(r.in, buf); err != nil {
+			return 0, err
+		}
+		return t.decode(buf, r.order), nil
+	}
+	for i, prop := range element.properties {
+		if prop.countType != "" {
+			count, err := readValue(prop.countType)
+			if err != nil {
+				return err
+			}
+			if _, err := r.in.Discard(int(count) * plyTypeSizes[prop.valueType]); err != nil {
+				return err
+			}
+			continue
+		}
+		value, err := readValue(prop.valueType)
+		if err != nil {
+			return err
+		}
+		values[i] = value
+	}
+	return nil
+}
+
+// ReadPLY reads the vertices of a ply file into a pointcloud, along with their colors (the red,
+// green and blue properties) and normals (the nx, ny and nz properties) if they have any. The
+// vertices are in meters, and the points of the cloud in millimeters.
+func ReadPLY(inRaw io.Reader) (PointCloud, error) {
+	in := bufio.NewReader(inRaw)
+	header, err := parsePLYHeader(in)
+	if err != nil {
+		return nil, err
+	}
+	reader := newPLYElementReader(in, header.format)
+	for i := range header.elements {
+		element := &header.elements[i]
+		values := make([]float64, len(element.properties))
+		if element.name != "vertex" {
+			// skip the elements before the vertices, such as the faces of meshes
+			for j := 0; j < element.count; j++ {
+				if err := reader.read(element, values); err != nil {
+					return nil, fmt.Errorf("error reading ply %s %d: %w", element.name, j, err)
+				}
+			}
+			continue
+		}
+		return readPLYVertices(reader, element, values)
+	}
+	return nil, errors.New("ply file has no vertex element")
+}
+
+func readPLYVertices(reader *plyElementReader, element *plyElement, values []float64) (PointCloud, error) {
+	index := map[string]int{}
+	for i, prop := range element.properties {
+		if prop.countType == "" {
+			index[prop.name] = i
+		}
+	}
+	lookup := func(names ...string) ([]int, bool) {
+		indices := make([]int, 0, len(names))
+		for _, name := range names {
+			i, ok := index[name]
+			if !ok {
+				return nil, false
+			}
+			indices = append(indices, i)
+		}
+		return indices, true
+	}
+	position, ok := lookup("x", "y", "z")
+	if !ok {
+		return nil, errors.New("ply vertices must have x, y and z properties")
+	}
+	colors, hasColor := lookup("red", "green", "blue")
+	// colors of float type range from 0 to 1
+	colorScale := 1.
+	if hasColor && element.properties[colors[0]].valueType.isFloat() {
+		colorScale = 255
+	}
+	normals, hasNormal := lookup("nx", "ny", "nz")
+
+	// the count comes from the header, so a file claiming more vertices than it holds must not
+	// allocate for all of them up front
+	prealloc := element.count
+	if prealloc > plyMaxPrealloc {
+		prealloc = plyMaxPrealloc
+	}
+	pc := NewWithPrealloc(prealloc)
+	for i := 0; i < element.count; i++ {
+		if err := reader.read(element, values); err != nil {
+			return nil, fmt.Errorf("error reading ply vertex %d: %w", i, err)
+		}
+		coord := func(j int) float64 {
+			v := values[position[j]]
+			// single precision coordinates are rounded like those of pcd files
+			if !element.properties[position[j]].valueType.isDouble() {
+				v = math.Round(v*10000) / 10000
+			}
+			// multiply by 1000 as RDK uses millimeters and ply files usually hold meters
+			return 1000. * v
+		}
+		point := r3.Vector{X: coord(0), Y: coord(1), Z: coord(2)}
+		d := NewBasicData()
+		if hasColor {
+			channel := func(j int) uint8 {
+				return uint8(math.Round(math.Max(0, math.Min(255, colorScale*values[colors[j]]))))
+			}
+			d.SetColor(color.NRGBA{channel(0), channel(1), channel(2), 255})
+		}
+		if hasNormal {
+			d.SetNormal(r3.Vector{X: values[normals[0]], Y: values[normals[1]], Z: values[normals[2]]})
+		}
+		if err := pc.Set(point, d); err != nil {
+			return nil, err
+		}
+	}
+	return pc, nil
+}
+
+// ToPLY writes out a point cloud to a ply file of the specified format, point by point. The
+// vertices hold the position of the points in meters, along with their normals and colors if the
+// cloud has any.
+func ToPLY(cloud PointCloud, out io.Writer, format PLYFormat) error {
+	formatName, ok := plyFormatNames[format]
+	if !ok {
+		return fmt.Errorf("unsupported ply format %d", format)
+	}
+	meta := cloud.MetaData()
+	w := bufio.NewWriter(out)
+	header := fmt.Sprintf("ply\nformat %s 1.0\nelement vertex %d\nproperty float x\nproperty float y\nproperty float z\n",
+		formatName, cloud.Size())
+	if meta.HasNormal {
+		header += "property float nx\nproperty float ny\nproperty float nz\n"
+	}
+	if meta.HasColor {
+		header += "property uchar red\nproperty uchar green\nproperty uchar blue\n"
+	}
+	if _, err := w.WriteString(header + "end_header\n"); err != nil {
+		return err
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if format == PLYBinaryBigEndian {
+		order = binary.BigEndian
+	}
+	buf := make([]byte, 0, 27)
+	scratch := make([]byte, 4)
+	var err error
+	cloud.Iterate(0, 0, func(pos r3.Vector, d Data) bool {
+		// divide by 1000 as rdk uses millimeters and ply files usually hold meters
+		floats := []float64{pos.X / 1000., pos.Y / 1000., pos.Z / 1000.}
+		if meta.HasNormal {
+			var n r3.Vector
+			if d != nil && d.HasNormal() {
+				n = d.Normal()
+			}
+			floats = append(floats, n.X, n.Y, n.Z)
+		}
+		var rgb []uint8
+		if meta.HasColor {
+			red, green, blue := uint8(255), uint8(255), uint8(255)
+			if d != nil && d.HasColor() {
+				red, green, blue = d.RGB255()
+			}
+			rgb = []uint8{red, green, blue}
+		}
+		if format == PLYAscii {
+			tokens := make([]string, 0, len(floats)+len(rgb))
+			for _, f := range floats {
+				tokens = append(tokens, strconv.FormatFloat(f, 'f', -1, 32))
+			}
+			for _, c := range rgb {
+				tokens = append(tokens, strconv.Itoa(int(c)))
+			}
+			_, err = w.WriteString(strings.Join(tokens, " ") + "\n")
+			return err == nil
+		}
+		buf = buf[:0]
+		for _, f := range floats {
+			order.PutUint32(scratch, math.Float32bits(float32(f)))
+			buf = append(buf, scratch...)
+		}
+		buf = append(buf, rgb...)
+		_, err = w.Write(buf)
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,149 @@
+package pointcloud
+
+import (
+	"bytes"
+	"image/color"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+)
+
+func testPLYCloud(t *testing.T) PointCloud {
+	t.Helper()
+	cloud := New()
+	test.That(t, cloud.Set(NewVector(-1, -2, 5), NewColoredData(color.NRGBA{255, 1, 2, 255}).SetNormal(r3.Vector{Z: 1})), test.ShouldBeNil)
+	test.That(t, cloud.Set(NewVector(582, 12, 0), NewColoredData(color.NRGBA{0, 10, 20, 255}).SetNormal(r3.Vector{X: 1})), test.ShouldBeNil)
+	test.That(t, cloud.Set(NewVector(7, 6, 1), NewBasicData()), test.ShouldBeNil)
+	return cloud
+}
+
+func TestPLYRoundTrip(t *testing.T) {
+	cloud := testPLYCloud(t)
+	for _, format := range []PLYFormat{PLYAscii, PLYBinaryLittleEndian, PLYBinaryBigEndian} {
+		var buf bytes.Buffer
+		test.That(t, ToPLY(cloud, &buf, format), test.ShouldBeNil)
+		test.That(t, buf.String(), test.ShouldStartWith, "ply\nformat "+plyFormatNames[format]+" 1.0\nelement vertex 3\n")
+
+		cloud2, err := ReadPLY(&buf)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, cloud2.Size(), test.ShouldEqual, 3)
+		test.That(t, cloud2.MetaData().HasColor, test.ShouldBeTrue)
+		test.That(t, cloud2.MetaData().HasNormal, test.ShouldBeTrue)
+		d, ok := cloud2.At(-1, -2, 5)
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, d.Color(), test.ShouldResemble, &color.NRGBA{255, 1, 2, 255})
+		test.That(t, d.Normal(), test.ShouldResemble, r3.Vector{Z: 1})
+		// points without color or normal are written white without normal
+		d, ok = cloud2.At(7, 6, 1)
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, d.Color(), test.ShouldResemble, &color.NRGBA{255, 255, 255, 255})
+		test.That(t, d.Normal(), test.ShouldResemble, r3.Vector{})
+	}
+
+	plain := New()
+	test.That(t, plain.Set(NewVector(1, 2, 3), NewBasicData()), test.ShouldBeNil)
+	var buf bytes.Buffer
+	test.That(t, ToPLY(plain, &buf, PLYAscii), test.ShouldBeNil)
+	test.That(t, buf.String(), test.ShouldEqual,
+		"ply\nformat ascii 1.0\nelement vertex 1\nproperty float x\nproperty float y\nproperty float z\nend_header\n0.001 0.002 0.003\n")
+	test.That(t, ToPLY(plain, &buf, PLYFormat(7)), test.ShouldNotBeNil)
+}
+
+func TestReadPLY(t *testing.T) {
+	// a mesh whose faces come before its vertices, with float colors and extra properties
+	ply := "ply\n" +
+		"format ascii 1.0\n" +
+		"comment made by hand\n" +
+		"element face 2\n" +
+		"property list uchar int vertex_indices\n" +
+		"element vertex 3\n" +
+		"property double x\n" +
+		"property double y\n" +
+		"property double z\n" +
+		"property float intensity\n" +
+		"property float red\n" +
+		"property float green\n" +
+		"property float blue\n" +
+		"end_header\n" +
+		"3 0 1 2\n" +
+		"3 2 1 0\n" +
+		"0 0 0 0.5 1 0 0\n" +
+		"0.001 0 0 0.5 0 1 0\n" +
+		"0 0.002 0 0.5 0 0 0.5"
+	cloud, err := ReadPLY(strings.NewReader(ply))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cloud.Size(), test.ShouldEqual, 3)
+	test.That(t, cloud.MetaData().HasNormal, test.ShouldBeFalse)
+	d, ok := cloud.At(0, 2, 0)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, d.Color(), test.ShouldResemble, &color.NRGBA{0, 0, 128, 255})
+
+	for _, bad := range []string{
+		"not a ply\n",
+		"ply\nelement vertex 1\nproperty float x\nend_header\n",
+		"ply\nformat binary_middle_endian 1.0\nend_header\n",
+		"ply\nformat ascii 1.0\nproperty float x\nend_header\n",
+		"ply\nformat ascii 1.0\nelement vertex 1\nproperty quad x\nend_header\n",
+		"ply\nformat ascii 1.0\nelement vertex 1\nproperty float x\nend_header\n1\n",
+		"ply\nformat ascii 1.0\nelement vertex 2\nproperty float x\nproperty float y\nproperty float z\nend_header\n1 2 3\n",
+		"ply\nformat ascii 1.0\nelement face 1\nproperty list uchar int vertex_indices\nend_header\n3 1\n",
+		// a count far beyond the vertices of the file fails on reading them, not on allocating them
+		"ply\nformat ascii 1.0\nelement vertex 2000000000\nproperty float x\nproperty float y\nproperty float z\nend_header\n1 2 3\n",
+	} {
+		_, err := ReadPLY(strings.NewReader(bad))
+		test.That(t, err, test.ShouldNotBeNil)
+	}
+}
+
+func TestPLYNewFromFile(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	cloud := testPLYCloud(t)
+	for ext, write := range map[string]func(*os.File) error{
+		"*.ply": func(f *os.File) error { return ToPLY(cloud, f, PLYBinaryLittleEndian) },
+		"*.pcd": func(f *os.File) error { return ToPCD(cloud, f, PCDCompressed) },
+	} {
+		temp, err := os.CreateTemp(t.TempDir(), ext)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, write(temp), test.ShouldBeNil)
+		test.That(t, temp.Close(), test.ShouldBeNil)
+
+		cloud2, err := NewFromFile(temp.Name(), logger)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, cloud2.Size(), test.ShouldEqual, cloud.Size())
+		test.That(t, CloudContains(cloud2, 582, 12, 0), test.ShouldBeTrue)
+	}
+}
+
+func TestLZF(t *testing.T) {
+	//nolint:gosec
+	r := rand.New(rand.NewSource(1))
+	random := make([]byte, 5000)
+	r.Read(random)
+	repetitive := bytes.Repeat([]byte("abcabcabd"), 1000)
+	for _, data := range [][]byte{nil, []byte("a"), []byte("aaaa"), random, repetitive, append(repetitive, random...)} {
+		compressed := lzfCompress(data)
+		decompressed, err := lzfDecompress(compressed, len(data))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, bytes.Equal(decompressed, data), test.ShouldBeTrue)
+	}
+	test.That(t, len(lzfCompress(repetitive)), test.ShouldBeLessThan, len(repetitive)/10)
+
+	compressed := lzfCompress(repetitive)
+	_, err := lzfDecompress(compressed, len(repetitive)-1)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = lzfDecompress(compressed[:len(compressed)-1], len(repetitive))
+	test.That(t, err, test.ShouldNotBeNil)
+	// a back reference before the start of the data
+	_, err = lzfDecompress([]byte{0x20, 0x05}, 3)
+	test.That(t, err, test.ShouldNotBeNil)
+	// more bytes than the data can decompress into
+	_, err = lzfDecompress([]byte{0x00, 'a'}, 1<<30)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, len(compressed), test.ShouldBeLessThanOrEqualTo, lzfMaxCompressedLen(len(repetitive)))
+	test.That(t, len(lzfCompress(random)), test.ShouldBeLessThanOrEqualTo, lzfMaxCompressedLen(len(random)))
+}
@@ -8,6 +8,7 @@ import (
 	"image/color"
 	"io"
 	"math"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -40,11 +41,26 @@ func NewFromFile(fn string, logger golog.Logger) (PointCloud, error) {
 	switch filepath.Ext(fn) {
 	case ".las":
 		return NewFromLASFile(fn, logger)
+	case ".pcd":
+		return newFromReaderFile(fn, ReadPCD)
+	case ".ply":
+		return newFromReaderFile(fn, ReadPLY)
 	default:
 		return nil, errors.Errorf("do not know how to read file %q", fn)
 	}
 }
 
+// newFromReaderFile returns the pointcloud read from the file by read.
+func newFromReaderFile(fn string, read func(io.Reader) (PointCloud, error)) (PointCloud, error) {
+	//nolint:gosec
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.UncheckedErrorFunc(f.Close)
+	return read(f)
+}
+
 // pointValueDataTag encodes if the point has value data.
 const pointValueDataTag = "rc|pv"
 
@@ -278,11 +294,11 @@ func ToPCD(cloud PointCloud, out io.Writer, outputType PCDType) error {
 			return err
 		}
 	case PCDCompressed:
-		// _, err = fmt.Fprintf(out, "DATA binary_compressed\n")
-		// if err != nil {
-		// 	return err
-		// }
-		return errors.New("compressed PCD not yet implemented")
+		_, err = fmt.Fprintf(out, "DATA binary_compressed\n")
+		if err != nil {
+			return err
+		}
+		return writePCDCompressed(cloud, out)
 	}
 	err = writePCDData(cloud, out, outputType)
 	if err != nil {
@@ -310,8 +326,6 @@ func writePCDData(cloud PointCloud, out io.Writer, pcdtype PCDType) error {
 				_, err = out.Write(buf)
 			case PCDAscii:
 				_, err = fmt.Fprintf(out, "%f %f %f %d\n", x, y, z, c)
-			case PCDCompressed:
-				return false // TODO(aidanglickman): Implement compressed PCD
 			default:
 				return false
 			}
@@ -325,8 +339,6 @@ func writePCDData(cloud PointCloud, out io.Writer, pcdtype PCDType) error {
 				_, err = out.Write(buf)
 			case PCDAscii:
 				_, err = fmt.Fprintf(out, "%f %f %f\n", x, y, z)
-			case PCDCompressed:
-				return false // TODO(aidanglickman): Implement compressed PCD
 			default:
 				return false
 			}
@@ -336,6 +348,39 @@ func writePCDData(cloud PointCloud, out io.Writer, pcdtype PCDType) error {
 	return nil
 }
 
+// writePCDCompressed writes the data of a binary_compressed PCD file: the compressed and
+// uncompressed sizes followed by the LZF compressed values, stored field by field rather than
+// point by point so that they compress better.
+func writePCDCompressed(cloud PointCloud, out io.Writer) error {
+	fields := int(pcdPointOnly)
+	if cloud.MetaData().HasColor {
+		fields = int(pcdPointColor)
+	}
+	n := cloud.Size()
+	data := make([]byte, 4*fields*n)
+	i := 0
+	cloud.Iterate(0, 0, func(pos r3.Vector, d Data) bool {
+		// divide by 1000 as rdk uses millimeters and PCD expects meters
+		binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(float32(pos.X/1000.)))
+		binary.LittleEndian.PutUint32(data[4*(n+i):], math.Float32bits(float32(pos.Y/1000.)))
+		binary.LittleEndian.PutUint32(data[4*(2*n+i):], math.Float32bits(float32(pos.Z/1000.)))
+		if fields == int(pcdPointColor) {
+			binary.LittleEndian.PutUint32(data[4*(3*n+i):], uint32(_colorToPCDInt(d)))
+		}
+		i++
+		return i < n
+	})
+	compressed := lzfCompress(data)
+	sizes := make([]byte, 8)
+	binary.LittleEndian.PutUint32(sizes, uint32(len(compressed)))
+	binary.LittleEndian.PutUint32(sizes[4:], uint32(len(data)))
+	if _, err := out.Write(sizes); err != nil {
+		return err
+	}
+	_, err := out.Write(compressed)
+	return err
+}
+
 func readFloat(n uint32) float64 {
 	f := float64(math.Float32frombits(n))
 	return math.Round(f*10000) / 10000
@@ -516,6 +561,13 @@ func readPCDHelper(inRaw io.Reader, pctype PCType) (PointCloud, error) {
 		}
 		headerLineCount++
 	}
+	var compressed []byte
+	if header.data == PCDCompressed {
+		// the compressed data is checked against the header before allocating its points
+		if compressed, err = readPCDCompressedData(in, header); err != nil {
+			return nil, err
+		}
+	}
 	var pc PointCloud
 	switch pctype {
 	case BasicType:
@@ -531,8 +583,7 @@ func readPCDHelper(inRaw io.Reader, pctype PCType) (PointCloud, error) {
 	case PCDBinary:
 		return readPCDBinary(in, header, pc)
 	case PCDCompressed:
-		// return readPCDCompressed(in, header)
-		return nil, errors.New("compressed pcd not yet supported")
+		return readPCDCompressed(compressed, header, pc)
 	default:
 		return nil, fmt.Errorf("unsupported pcd data type %v", header.data)
 	}
@@ -601,6 +652,60 @@ func readPCDBinary(in *bufio.Reader, header pcdHeader, pc PointCloud) (PointClou
 	return pc, nil
 }
 
+// readPCDCompressedData returns the decompressed data of a binary_compressed PCD file.
+func readPCDCompressedData(in *bufio.Reader, header pcdHeader) ([]byte, error) {
+	for j := 0; j < int(header.fields); j++ {
+		if header.size[j] != 4 || (header.count != nil && header.count[j] != 1) {
+			return nil, fmt.Errorf("unsupported compressed field of size %d", header.size[j])
+		}
+	}
+	// the sizes come from the file, so they are checked against the header before allocating anything
+	size := 4 * uint64(header.fields)
+	if header.points > math.MaxUint32/size {
+		return nil, fmt.Errorf("too many points %d in compressed data", header.points)
+	}
+	size *= header.points
+	sizes := make([]byte, 8)
+	if _, err := io.ReadFull(in, sizes); err != nil {
+		return nil, fmt.Errorf("error reading compressed data sizes: %w", err)
+	}
+	compressedSize, uncompressedSize := binary.LittleEndian.Uint32(sizes), binary.LittleEndian.Uint32(sizes[4:])
+	if uint64(uncompressedSize) != size {
+		return nil, fmt.Errorf("compressed data holds %d bytes but %d points need %d", uncompressedSize, header.points, size)
+	}
+	if int(compressedSize) > lzfMaxCompressedLen(int(size)) {
+		return nil, fmt.Errorf("compressed data of %d bytes is larger than the %d bytes it holds", compressedSize, size)
+	}
+	var compressed bytes.Buffer
+	if _, err := io.CopyN(&compressed, in, int64(compressedSize)); err != nil {
+		return nil, fmt.Errorf("error reading compressed data: %w", err)
+	}
+	return lzfDecompress(compressed.Bytes(), int(size))
+}
+
+func readPCDCompressed(data []byte, header pcdHeader, pc PointCloud) (PointCloud, error) {
+	// the values are stored field by field, each field holding the values of all the points
+	points := int(header.points)
+	offsets := make([]int, header.fields)
+	for j := range offsets {
+		offsets[j] = 4 * points * j
+	}
+	value := func(field, i int) uint32 {
+		return binary.LittleEndian.Uint32(data[offsets[field]+4*i:])
+	}
+	for i := 0; i < points; i++ {
+		point := r3.Vector{X: 1000. * readFloat(value(0, i)), Y: 1000. * readFloat(value(1, i)), Z: 1000. * readFloat(value(2, i))}
+		d := NewBasicData()
+		if header.fields == pcdPointColor {
+			d = NewColoredData(_pcdIntToColor(int(value(3, i))))
+		}
+		if err := pc.Set(point, d); err != nil {
+			return nil, err
+		}
+	}
+	return pc, nil
+}
+
 // reads a specified amount of bytes from a buffer. The number of bytes specified is defined from the pcd.
 func readBuffer(in *bufio.Reader, header pcdHeader, index int) ([]byte, error) {
 	buf := make([]byte, header.size[index])
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
 	"go.viam.com/test"
 	"go.viam.com/utils/artifact"
 )
@@ -66,6 +67,7 @@ func TestPCD(t *testing.T) {
 	testPCDHeaders(t)
 	testASCIIRoundTrip(t, cloud)
 	testBinaryRoundTrip(t, cloud)
+	testCompressedRoundTrip(t, cloud)
 }
 
 func testPCDHeaders(t *testing.T) {
@@ -158,7 +160,9 @@ func TestPCDNoColor(t *testing.T) {
 
 	testNoColorASCIIRoundTrip(t, cloud)
 	testNoColorBinaryRoundTrip(t, cloud)
+	testCompressedRoundTrip(t, cloud)
 	testLargeBinaryNoError(t)
+	testLargeCompressedRoundTrip(t)
 }
 
 func testNoColorASCIIRoundTrip(t *testing.T, cloud PointCloud) {
@@ -254,6 +258,70 @@ func testBinaryRoundTrip(t *testing.T, cloud PointCloud) {
 	test.That(t, b, test.ShouldEqual, 2)
 }
 
+func testCompressedRoundTrip(t *testing.T, cloud PointCloud) {
+	t.Helper()
+	var buf bytes.Buffer
+	err := ToPCD(cloud, &buf, PCDCompressed)
+	test.That(t, err, test.ShouldBeNil)
+	gotPCD := buf.String()
+	test.That(t, gotPCD, test.ShouldContainSubstring, "POINTS 3\n")
+	test.That(t, gotPCD, test.ShouldContainSubstring, "DATA binary_compressed\n")
+
+	cloud2, err := ReadPCD(strings.NewReader(gotPCD))
+	test.That(t, err, test.ShouldBeNil)
+	testPCDOutput(t, cloud2)
+	test.That(t, cloud2.MetaData().HasColor, test.ShouldEqual, cloud.MetaData().HasColor)
+	cloud.Iterate(0, 0, func(p r3.Vector, d Data) bool {
+		d2, ok := cloud2.At(p.X, p.Y, p.Z)
+		test.That(t, ok, test.ShouldBeTrue)
+		if d.HasColor() {
+			test.That(t, d2.Color(), test.ShouldResemble, d.Color())
+		}
+		return true
+	})
+
+	// truncated data
+	_, err = ReadPCD(strings.NewReader(gotPCD[:len(gotPCD)-4]))
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// sizes that do not match the header are rejected before allocating them
+	dataStart := strings.Index(gotPCD, "DATA binary_compressed\n") + len("DATA binary_compressed\n")
+	withSizes := func(compressed, uncompressed uint32) string {
+		sizes := make([]byte, 8)
+		binary.LittleEndian.PutUint32(sizes, compressed)
+		binary.LittleEndian.PutUint32(sizes[4:], uncompressed)
+		return gotPCD[:dataStart] + string(sizes) + gotPCD[dataStart+8:]
+	}
+	uncompressedSize := binary.LittleEndian.Uint32([]byte(gotPCD[dataStart+4 : dataStart+8]))
+	_, err = ReadPCD(strings.NewReader(withSizes(10, math.MaxUint32)))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "points need")
+	_, err = ReadPCD(strings.NewReader(withSizes(math.MaxUint32, uncompressedSize)))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "larger than")
+	tooManyPoints := strings.Replace(gotPCD, "WIDTH 3\n", "WIDTH 4294967295\n", 1)
+	tooManyPoints = strings.Replace(tooManyPoints, "POINTS 3\n", "POINTS 4294967295\n", 1)
+	_, err = ReadPCD(strings.NewReader(tooManyPoints))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "too many points")
+}
+
+func testLargeCompressedRoundTrip(t *testing.T) {
+	t.Helper()
+	var buf bytes.Buffer
+	largeCloud := newBigPC()
+	err := ToPCD(largeCloud, &buf, PCDCompressed)
+	test.That(t, err, test.ShouldBeNil)
+	var binaryBuf bytes.Buffer
+	test.That(t, ToPCD(largeCloud, &binaryBuf, PCDBinary), test.ShouldBeNil)
+	test.That(t, buf.Len(), test.ShouldBeLessThan, binaryBuf.Len())
+
+	readPointCloud, err := ReadPCD(&buf)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, readPointCloud.Size(), test.ShouldEqual, largeCloud.Size())
+	test.That(t, CloudContains(readPointCloud, 10, 50, 30), test.ShouldBeTrue)
+}
+
 func testLargeBinaryNoError(t *testing.T) {
 	// This tests whether large pointclouds that exceed the usual buffered page size for a file error on reads
 	t.Helper()
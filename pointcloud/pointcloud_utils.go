@@ -51,11 +51,32 @@ func PrunePointClouds(clouds []PointCloud, nMin int) []PointCloud {
 	return pruned
 }
 
+// ApplyOffset returns a copy of the point cloud with every point moved by the pose, such as the pose
+// of the frame of the point cloud in another frame. The normals of the points are rotated with them.
+func ApplyOffset(pc PointCloud, offset spatialmath.Pose) (PointCloud, error) {
+	rotation := spatialmath.NewPoseFromOrientation(r3.Vector{}, offset.Orientation())
+	moved := NewWithPrealloc(pc.Size())
+	var err error
+	pc.Iterate(0, 0, func(p r3.Vector, d Data) bool {
+		p = spatialmath.Compose(offset, spatialmath.NewPoseFromPoint(p)).Point()
+		if d != nil && d.HasNormal() {
+			d = copyData(d)
+			d.SetNormal(spatialmath.Compose(rotation, spatialmath.NewPoseFromPoint(d.Normal())).Point())
+		}
+		err = moved.Set(p, d)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return moved, nil
+}
+
 // StatisticalOutlierFilter implements the function from PCL to remove noisy points from a point cloud.
 // https://pcl.readthedocs.io/projects/tutorials/en/latest/statistical_outlier.html
 // This returns a function that can be used to filter on point clouds.
 // NOTE(bh): Returns a new point cloud, but could be modified to filter and change the original point cloud.
-func StatisticalOutlierFilter(meanK int, stdDevThresh float64) (func(PointCloud) (PointCloud, error), error) {
+func StatisticalOutlierFilter(meanK int, stdDevThresh float64) (Filter, error) {
 	if meanK <= 0 {
 		return nil, errors.Errorf("argument meanK must be a positive int, got %d", meanK)
 	}
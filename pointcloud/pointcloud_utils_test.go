@@ -70,3 +70,29 @@ func TestPrune(t *testing.T) {
 	test.That(t, len(clouds), test.ShouldEqual, 1)
 	test.That(t, clouds[0].Size(), test.ShouldEqual, 5)
 }
+
+func TestApplyOffset(t *testing.T) {
+	pc := New()
+	test.That(t, pc.Set(NewVector(1, 0, 0), NewBasicData().SetNormal(r3.Vector{1, 0, 0})), test.ShouldBeNil)
+	test.That(t, pc.Set(NewVector(0, 2, 0), nil), test.ShouldBeNil)
+	// a quarter turn around z, then 10mm up
+	offset := spatialmath.NewPoseFromOrientation(r3.Vector{0, 0, 10}, &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: 90})
+	moved, err := ApplyOffset(pc, offset)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, moved.Size(), test.ShouldEqual, 2)
+	var points []r3.Vector
+	moved.Iterate(0, 0, func(p r3.Vector, d Data) bool {
+		points = append(points, p)
+		if d != nil && d.HasNormal() {
+			test.That(t, spatialmath.R3VectorAlmostEqual(d.Normal(), r3.Vector{0, 1, 0}, 1e-9), test.ShouldBeTrue)
+			test.That(t, spatialmath.R3VectorAlmostEqual(p, r3.Vector{0, 1, 10}, 1e-9), test.ShouldBeTrue)
+		} else {
+			test.That(t, spatialmath.R3VectorAlmostEqual(p, r3.Vector{-2, 0, 10}, 1e-9), test.ShouldBeTrue)
+		}
+		return true
+	})
+	test.That(t, points, test.ShouldHaveLength, 2)
+	// the original normal is unchanged
+	d, _ := pc.At(1, 0, 0)
+	test.That(t, d.Normal(), test.ShouldResemble, r3.Vector{1, 0, 0})
+}
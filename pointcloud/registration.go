@@ -0,0 +1,367 @@
+package pointcloud
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+// Default values of the RegistrationConfig.
+const (
+	defaultRegistrationLevels     = 3
+	defaultRANSACIterations       = 20000
+	defaultICPIterations          = 30
+	registrationNormalNeighbors   = 10
+	registrationFeatureRadius     = 5.
+	registrationMaxCorrespondence = 1.5
+	ransacEdgeSimilarity          = 0.9
+	icpConvergence                = 1e-6
+)
+
+// RegistrationConfig configures the global registration of a point cloud to another. The point
+// clouds are downsampled to voxels twice as large at each level, from the finest level whose voxel
+// size is VoxelSize. The transform is first found from the FPFH of the coarsest level, then refined
+// by point-to-plane ICP from the coarsest level to the finest one.
+type RegistrationConfig struct {
+	VoxelSize        float64 `json:"voxel_size_mm"`
+	Levels           int     `json:"levels"`
+	RANSACIterations int     `json:"ransac_iterations"`
+	ICPIterations    int     `json:"icp_iterations"`
+}
+
+// setDefaults fills the unset values of the config.
+func (cfg *RegistrationConfig) setDefaults() {
+	if cfg.Levels == 0 {
+		cfg.Levels = defaultRegistrationLevels
+	}
+	if cfg.RANSACIterations == 0 {
+		cfg.RANSACIterations = defaultRANSACIterations
+	}
+	if cfg.ICPIterations == 0 {
+		cfg.ICPIterations = defaultICPIterations
+	}
+}
+
+// Validate ensures all parts of the config are valid.
+func (cfg *RegistrationConfig) Validate() error {
+	if cfg.VoxelSize <= 0 {
+		return errors.Errorf("voxel_size_mm must be positive, got %v", cfg.VoxelSize)
+	}
+	if cfg.Levels < 0 || cfg.RANSACIterations < 0 || cfg.ICPIterations < 0 {
+		return errors.New("levels, ransac_iterations and icp_iterations cannot be negative")
+	}
+	return nil
+}
+
+// RegistrationResult is the result of registering a source point cloud to a target point cloud.
+type RegistrationResult struct {
+	// Pose is the pose of the source point cloud in the frame of the target point cloud, which
+	// moves the points of the source onto the target.
+	Pose spatialmath.Pose
+	// Fitness is the fraction of the source points that have a corresponding target point.
+	Fitness float64
+	// RMSE is the root mean square distance between the corresponding points.
+	RMSE float64
+}
+
+// RegisterPointCloudGlobal registers a source point cloud to a target point cloud without any
+// initial guess of the transform between them, which needs them to overlap enough. The point
+// clouds are expected in the frames of the cameras that took them, as the normals that are not
+// already set are estimated to point towards the origin.
+func RegisterPointCloudGlobal(source, target PointCloud, cfg RegistrationConfig) (RegistrationResult, error) {
+	if err := cfg.Validate(); err != nil {
+		return RegistrationResult{}, err
+	}
+	cfg.setDefaults()
+	sources := make([]PointCloud, cfg.Levels)
+	targets := make([]PointCloud, cfg.Levels)
+	for level := 0; level < cfg.Levels; level++ {
+		voxelSize := levelVoxelSize(cfg, level)
+		var err error
+		if sources[level], err = downsampleWithNormals(source, voxelSize); err != nil {
+			return RegistrationResult{}, err
+		}
+		if targets[level], err = downsampleWithNormals(target, voxelSize); err != nil {
+			return RegistrationResult{}, err
+		}
+	}
+
+	coarsest := cfg.Levels - 1
+	voxelSize := levelVoxelSize(cfg, coarsest)
+	srcPoints, srcFeatures, err := ComputeFPFH(sources[coarsest], registrationFeatureRadius*voxelSize)
+	if err != nil {
+		return RegistrationResult{}, errors.Wrap(err, "source")
+	}
+	tgtPoints, tgtFeatures, err := ComputeFPFH(targets[coarsest], registrationFeatureRadius*voxelSize)
+	if err != nil {
+		return RegistrationResult{}, errors.Wrap(err, "target")
+	}
+	result, err := RegisterFeaturesRANSAC(srcPoints, srcFeatures, tgtPoints, tgtFeatures,
+		registrationMaxCorrespondence*voxelSize, cfg.RANSACIterations, rand.New(rand.NewSource(1)))
+	if err != nil {
+		return RegistrationResult{}, err
+	}
+	for level := coarsest; level >= 0; level-- {
+		result, err = RegisterPointCloudPointToPlane(sources[level], ToKDTree(targets[level]), result.Pose,
+			registrationMaxCorrespondence*levelVoxelSize(cfg, level), cfg.ICPIterations)
+		if err != nil {
+			return RegistrationResult{}, err
+		}
+	}
+	return result, nil
+}
+
+// levelVoxelSize returns the voxel size of the level, doubled at each level.
+func levelVoxelSize(cfg RegistrationConfig, level int) float64 {
+	return cfg.VoxelSize * math.Pow(2, float64(level))
+}
+
+// downsampleWithNormals returns the point cloud downsampled to the voxel size, with normals
+// pointing towards the origin.
+func downsampleWithNormals(pc PointCloud, voxelSize float64) (PointCloud, error) {
+	voxelFilter, err := VoxelGridFilter(voxelSize)
+	if err != nil {
+		return nil, err
+	}
+	normalFilter, err := NormalEstimationFilter(registrationNormalNeighbors, r3.Vector{})
+	if err != nil {
+		return nil, err
+	}
+	return ChainFilters(voxelFilter, normalFilter)(pc)
+}
+
+// RegisterFeaturesRANSAC registers source points to target points from their descriptors, by
+// matching each source point to the target point with the closest descriptor, then finding the
+// transform that brings the most matched points within maxDist of each other from random samples
+// of 3 matches.
+func RegisterFeaturesRANSAC(
+	srcPoints []r3.Vector, srcFeatures []FPFH,
+	tgtPoints []r3.Vector, tgtFeatures []FPFH,
+	maxDist float64, iterations int, rnd *rand.Rand,
+) (RegistrationResult, error) {
+	if len(srcPoints) != len(srcFeatures) || len(tgtPoints) != len(tgtFeatures) {
+		return RegistrationResult{}, errors.New("there must be one feature per point")
+	}
+	if len(srcPoints) < 3 || len(tgtPoints) < 3 {
+		return RegistrationResult{}, errors.New("need at least 3 source and target points to register them")
+	}
+	matches := make([]int, len(srcPoints))
+	for i := range srcFeatures {
+		best := math.Inf(1)
+		for j := range tgtFeatures {
+			if d := srcFeatures[i].Distance(&tgtFeatures[j]); d < best {
+				best = d
+				matches[i] = j
+			}
+		}
+	}
+
+	inliersOf := func(pose spatialmath.Pose) []int {
+		var inliers []int
+		for i, j := range matches {
+			if transformPoint(pose, srcPoints[i]).Distance(tgtPoints[j]) < maxDist {
+				inliers = append(inliers, i)
+			}
+		}
+		return inliers
+	}
+
+	var bestInliers []int
+	for it := 0; it < iterations; it++ {
+		sample := [3]int{rnd.Intn(len(matches)), rnd.Intn(len(matches)), rnd.Intn(len(matches))}
+		if sample[0] == sample[1] || sample[1] == sample[2] || sample[0] == sample[2] {
+			continue
+		}
+		// the distances between the points of both samples should be about the same
+		similar := true
+		for k := 0; k < 3 && similar; k++ {
+			a, b := sample[k], sample[(k+1)%3]
+			srcEdge := srcPoints[a].Distance(srcPoints[b])
+			tgtEdge := tgtPoints[matches[a]].Distance(tgtPoints[matches[b]])
+			similar = math.Min(srcEdge, tgtEdge) > ransacEdgeSimilarity*math.Max(srcEdge, tgtEdge)
+		}
+		if !similar {
+			continue
+		}
+		src := make([]r3.Vector, 0, 3)
+		tgt := make([]r3.Vector, 0, 3)
+		for _, i := range sample {
+			src = append(src, srcPoints[i])
+			tgt = append(tgt, tgtPoints[matches[i]])
+		}
+		pose, err := estimateRigidTransform(src, tgt)
+		if err != nil {
+			continue
+		}
+		if inliers := inliersOf(pose); len(inliers) > len(bestInliers) {
+			bestInliers = inliers
+		}
+	}
+	if len(bestInliers) < 3 {
+		return RegistrationResult{}, errors.New("could not find a transform matching the features of the point clouds")
+	}
+
+	src := make([]r3.Vector, 0, len(bestInliers))
+	tgt := make([]r3.Vector, 0, len(bestInliers))
+	for _, i := range bestInliers {
+		src = append(src, srcPoints[i])
+		tgt = append(tgt, tgtPoints[matches[i]])
+	}
+	pose, err := estimateRigidTransform(src, tgt)
+	if err != nil {
+		return RegistrationResult{}, err
+	}
+	inliers := inliersOf(pose)
+	sum := 0.
+	for _, i := range inliers {
+		d := transformPoint(pose, srcPoints[i]).Distance(tgtPoints[matches[i]])
+		sum += d * d
+	}
+	result := RegistrationResult{Pose: pose, Fitness: float64(len(inliers)) / float64(len(srcPoints))}
+	if len(inliers) > 0 {
+		result.RMSE = math.Sqrt(sum / float64(len(inliers)))
+	}
+	return result, nil
+}
+
+// estimateRigidTransform returns the pose that moves the source points the closest to the
+// corresponding target points in the least squares sense, with the Kabsch algorithm.
+func estimateRigidTransform(src, tgt []r3.Vector) (spatialmath.Pose, error) {
+	if len(src) != len(tgt) || len(src) < 3 {
+		return nil, errors.New("need at least 3 pairs of points to estimate a rigid transform")
+	}
+	var srcCentroid, tgtCentroid r3.Vector
+	for i := range src {
+		srcCentroid = srcCentroid.Add(src[i])
+		tgtCentroid = tgtCentroid.Add(tgt[i])
+	}
+	srcCentroid = srcCentroid.Mul(1 / float64(len(src)))
+	tgtCentroid = tgtCentroid.Mul(1 / float64(len(tgt)))
+
+	cov := mat.NewDense(3, 3, nil)
+	for i := range src {
+		s := src[i].Sub(srcCentroid)
+		t := tgt[i].Sub(tgtCentroid)
+		var outer mat.Dense
+		outer.Outer(1, mat.NewVecDense(3, []float64{s.X, s.Y, s.Z}), mat.NewVecDense(3, []float64{t.X, t.Y, t.Z}))
+		cov.Add(cov, &outer)
+	}
+	var svd mat.SVD
+	if ok := svd.Factorize(cov, mat.SVDFull); !ok {
+		return nil, errors.New("failed to factorize the covariance of the points")
+	}
+	if vals := svd.Values(nil); vals[1] < 1e-9*vals[0] {
+		return nil, errors.New("the points are collinear, the rotation is not observable")
+	}
+	var u, v, rot mat.Dense
+	svd.UTo(&u)
+	svd.VTo(&v)
+	rot.Mul(&v, u.T())
+	if mat.Det(&rot) < 0 {
+		rot.Product(&v, mat.NewDiagDense(3, []float64{1, 1, -1}), u.T())
+	}
+	return poseFromRotation(&rot, tgtCentroid, srcCentroid)
+}
+
+// poseFromRotation returns the pose with the rotation that moves the source centroid onto the
+// target centroid.
+func poseFromRotation(rot mat.Matrix, tgtCentroid, srcCentroid r3.Vector) (spatialmath.Pose, error) {
+	// spatialmath.RotationMatrix converts to quaternions from the values in column major order
+	values := make([]float64, 0, 9)
+	for c := 0; c < 3; c++ {
+		for r := 0; r < 3; r++ {
+			values = append(values, rot.At(r, c))
+		}
+	}
+	rm, err := spatialmath.NewRotationMatrix(values)
+	if err != nil {
+		return nil, err
+	}
+	var rotated mat.VecDense
+	rotated.MulVec(rot, mat.NewVecDense(3, []float64{srcCentroid.X, srcCentroid.Y, srcCentroid.Z}))
+	translation := tgtCentroid.Sub(r3.Vector{X: rotated.AtVec(0), Y: rotated.AtVec(1), Z: rotated.AtVec(2)})
+	return spatialmath.NewPoseFromOrientation(translation, rm), nil
+}
+
+// transformPoint returns the point moved by the pose.
+func transformPoint(pose spatialmath.Pose, p r3.Vector) r3.Vector {
+	return spatialmath.Compose(pose, spatialmath.NewPoseFromPoint(p)).Point()
+}
+
+// RegisterPointCloudPointToPlane refines the pose of a source point cloud in the frame of a target
+// point cloud with normals, starting from the guess, with ICP minimizing the distances between the
+// source points and the planes of the closest target points within maxDist.
+func RegisterPointCloudPointToPlane(
+	source PointCloud, target *KDTree, guess spatialmath.Pose, maxDist float64, iterations int,
+) (RegistrationResult, error) {
+	if maxDist <= 0 {
+		return RegistrationResult{}, errors.Errorf("maximum correspondence distance must be positive, got %v", maxDist)
+	}
+	points := make([]r3.Vector, 0, source.Size())
+	source.Iterate(0, 0, func(p r3.Vector, d Data) bool {
+		points = append(points, p)
+		return true
+	})
+	if len(points) == 0 {
+		return RegistrationResult{}, errors.New("cannot register an empty point cloud")
+	}
+
+	pose := guess
+	for it := 0; it < iterations; it++ {
+		// linearized for a small rotation w and translation t, the distance of the moved point q to
+		// the plane (x, n) is (q - x).n + (q x n).w + n.t
+		ata := mat.NewSymDense(6, nil)
+		atb := mat.NewVecDense(6, nil)
+		correspondences := 0
+		for _, p := range points {
+			q := transformPoint(pose, p)
+			nearest, d, dist, ok := target.NearestNeighbor(q)
+			if !ok || dist > maxDist || d == nil || !d.HasNormal() {
+				continue
+			}
+			n := d.Normal()
+			c := q.Cross(n)
+			row := mat.NewVecDense(6, []float64{c.X, c.Y, c.Z, n.X, n.Y, n.Z})
+			ata.SymRankOne(ata, 1, row)
+			atb.AddScaledVec(atb, -q.Sub(nearest).Dot(n), row)
+			correspondences++
+		}
+		if correspondences < 6 {
+			return RegistrationResult{}, errors.New("not enough corresponding points to register the point clouds")
+		}
+		var x mat.VecDense
+		if err := x.SolveVec(ata, atb); err != nil {
+			return RegistrationResult{}, errors.Wrap(err, "could not solve for the point-to-plane transform")
+		}
+		rotation := r3.Vector{X: x.AtVec(0), Y: x.AtVec(1), Z: x.AtVec(2)}
+		translation := r3.Vector{X: x.AtVec(3), Y: x.AtVec(4), Z: x.AtVec(5)}
+		step := spatialmath.NewPoseFromPoint(translation)
+		if rotation.Norm() > 0 {
+			step = spatialmath.NewPoseFromOrientation(translation, spatialmath.R3ToR4(rotation))
+		}
+		pose = spatialmath.Compose(step, pose)
+		if rotation.Norm() < icpConvergence && translation.Norm() < icpConvergence*maxDist {
+			break
+		}
+	}
+
+	inliers := 0
+	sum := 0.
+	for _, p := range points {
+		q := transformPoint(pose, p)
+		if _, _, dist, ok := target.NearestNeighbor(q); ok && dist <= maxDist {
+			inliers++
+			sum += dist * dist
+		}
+	}
+	result := RegistrationResult{Pose: pose, Fitness: float64(inliers) / float64(len(points))}
+	if inliers > 0 {
+		result.RMSE = math.Sqrt(sum / float64(inliers))
+	}
+	return result, nil
+}
@@ -0,0 +1,178 @@
+package pointcloud
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+// makeCornerScene returns the points 20mm apart of the corner of a room, made of a floor and two
+// walls of different sizes, with a box on the floor, as seen from somewhere in the room.
+func makeCornerScene(t *testing.T) PointCloud {
+	t.Helper()
+	pc := New()
+	set := func(x, y, z float64) {
+		test.That(t, pc.Set(NewVector(x, y, z), nil), test.ShouldBeNil)
+	}
+	for x := 0.; x <= 1000; x += 20 {
+		for y := 0.; y <= 600; y += 20 {
+			// the floor, except under the box
+			if x < 600 || x > 800 || y < 200 || y > 400 {
+				set(x, y, 0)
+			}
+		}
+		for z := 20.; z <= 300; z += 20 {
+			set(x, 0, z)
+		}
+	}
+	for y := 20.; y <= 600; y += 20 {
+		for z := 20.; z <= 400; z += 20 {
+			set(0, y, z)
+		}
+	}
+	for u := 0.; u <= 200; u += 20 {
+		for v := 0.; v <= 200; v += 20 {
+			set(600+u, 200+v, 200)
+			set(800, 200+u, v)
+			set(600+u, 400, v)
+		}
+	}
+	return pc
+}
+
+// seenFrom returns the scene in the frame of a camera at the pose.
+func seenFrom(t *testing.T, scene PointCloud, pose spatialmath.Pose) PointCloud {
+	t.Helper()
+	pc, err := ApplyOffset(scene, spatialmath.PoseInverse(pose))
+	test.That(t, err, test.ShouldBeNil)
+	return pc
+}
+
+// poseError returns the distance and the angle in degrees between two poses.
+func poseError(a, b spatialmath.Pose) (float64, float64) {
+	delta := spatialmath.PoseDelta(a, b)
+	return delta.Point().Norm(), spatialmath.QuatToR3AA(delta.Orientation().Quaternion()).Norm() * 180 / math.Pi
+}
+
+func TestEstimateRigidTransform(t *testing.T) {
+	pose := spatialmath.NewPoseFromOrientation(r3.Vector{10, -20, 30}, &spatialmath.OrientationVectorDegrees{OX: 1, OY: 1, Theta: 40})
+	rnd := rand.New(rand.NewSource(1))
+	src := make([]r3.Vector, 10)
+	tgt := make([]r3.Vector, 10)
+	for i := range src {
+		src[i] = r3.Vector{rnd.Float64() * 100, rnd.Float64() * 100, rnd.Float64() * 100}
+		tgt[i] = spatialmath.Compose(pose, spatialmath.NewPoseFromPoint(src[i])).Point()
+	}
+	estimated, err := estimateRigidTransform(src, tgt)
+	test.That(t, err, test.ShouldBeNil)
+	dist, angle := poseError(estimated, pose)
+	test.That(t, dist, test.ShouldBeLessThan, 1e-6)
+	test.That(t, angle, test.ShouldBeLessThan, 1e-6)
+
+	_, err = estimateRigidTransform(src[:2], tgt[:2])
+	test.That(t, err, test.ShouldNotBeNil)
+	line := []r3.Vector{{0, 0, 0}, {1, 1, 1}, {2, 2, 2}}
+	_, err = estimateRigidTransform(line, line)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestComputeFPFH(t *testing.T) {
+	_, _, err := ComputeFPFH(makeCornerScene(t), 100)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// the points of the grid are moved a little, or rounding errors would decide the order of the
+	// many pairs of points at the same angle to both normals
+	rnd := rand.New(rand.NewSource(1))
+	noisy := New()
+	makeCornerScene(t).Iterate(0, 0, func(p r3.Vector, d Data) bool {
+		noise := r3.Vector{rnd.Float64() - 0.5, rnd.Float64() - 0.5, rnd.Float64() - 0.5}
+		test.That(t, noisy.Set(p.Add(noise), d), test.ShouldBeNil)
+		return true
+	})
+	camera := spatialmath.NewPoseFromPoint(r3.Vector{1000, 800, 600})
+	withNormals, err := EstimateNormals(seenFrom(t, noisy, camera), 10, r3.Vector{})
+	test.That(t, err, test.ShouldBeNil)
+	_, _, err = ComputeFPFH(withNormals, 0)
+	test.That(t, err, test.ShouldNotBeNil)
+	points, features, err := ComputeFPFH(withNormals, 90)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, points, test.ShouldHaveLength, withNormals.Size())
+	test.That(t, features, test.ShouldHaveLength, withNormals.Size())
+	for b := 0; b < 3; b++ {
+		sum := 0.
+		for _, v := range features[0][b*fpfhBins : (b+1)*fpfhBins] {
+			sum += v
+		}
+		test.That(t, sum, test.ShouldAlmostEqual, 100)
+	}
+
+	// the features do not change when the camera moves
+	moved := spatialmath.NewPoseFromOrientation(r3.Vector{-300, 500, 900}, &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: 120})
+	movedNormals, err := ApplyOffset(withNormals, moved)
+	test.That(t, err, test.ShouldBeNil)
+	movedPoints, movedFeatures, err := ComputeFPFH(movedNormals, 90)
+	test.That(t, err, test.ShouldBeNil)
+	movedTree := NewKDTree()
+	for i, p := range movedPoints {
+		test.That(t, movedTree.Set(p, NewValueData(i)), test.ShouldBeNil)
+	}
+	for i, p := range points {
+		_, d, dist, _ := movedTree.NearestNeighbor(spatialmath.Compose(moved, spatialmath.NewPoseFromPoint(p)).Point())
+		test.That(t, dist, test.ShouldBeLessThan, 1e-6)
+		test.That(t, features[i].Distance(&movedFeatures[d.Value()]), test.ShouldBeLessThan, 1e-6)
+	}
+}
+
+func TestRegisterPointCloudPointToPlane(t *testing.T) {
+	scene := makeCornerScene(t)
+	targetPose := spatialmath.NewPoseFromPoint(r3.Vector{1000, 800, 600})
+	target, err := EstimateNormals(seenFrom(t, scene, targetPose), 10, r3.Vector{})
+	test.That(t, err, test.ShouldBeNil)
+	sourcePose := spatialmath.NewPoseFromOrientation(r3.Vector{900, 850, 650}, &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: 5})
+	source := seenFrom(t, scene, sourcePose)
+	expected := spatialmath.Compose(spatialmath.PoseInverse(targetPose), sourcePose)
+
+	guess := spatialmath.Compose(expected, spatialmath.NewPoseFromOrientation(r3.Vector{15, -10, 10}, &spatialmath.R4AA{0.03, 0, 0, 1}))
+	result, err := RegisterPointCloudPointToPlane(source, ToKDTree(target), guess, 50, 30)
+	test.That(t, err, test.ShouldBeNil)
+	dist, angle := poseError(result.Pose, expected)
+	test.That(t, dist, test.ShouldBeLessThan, 1e-3)
+	test.That(t, angle, test.ShouldBeLessThan, 1e-3)
+	test.That(t, result.Fitness, test.ShouldAlmostEqual, 1)
+	test.That(t, result.RMSE, test.ShouldBeLessThan, 1e-3)
+
+	_, err = RegisterPointCloudPointToPlane(source, ToKDTree(target), guess, 0, 30)
+	test.That(t, err, test.ShouldNotBeNil)
+	// too far from the target
+	_, err = RegisterPointCloudPointToPlane(source, ToKDTree(target), spatialmath.NewPoseFromPoint(r3.Vector{0, 0, 10000}), 50, 30)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestRegisterPointCloudGlobal(t *testing.T) {
+	scene := makeCornerScene(t)
+	targetPose := spatialmath.NewPoseFromOrientation(r3.Vector{1000, 800, 600}, &spatialmath.OrientationVectorDegrees{OX: -1, OY: -1, OZ: -1})
+	sourcePose := spatialmath.NewPoseFromOrientation(
+		r3.Vector{700, 1100, 900},
+		&spatialmath.OrientationVectorDegrees{OX: -0.5, OY: -1, OZ: -1, Theta: 30},
+	)
+	target := seenFrom(t, scene, targetPose)
+	source := seenFrom(t, scene, sourcePose)
+	expected := spatialmath.Compose(spatialmath.PoseInverse(targetPose), sourcePose)
+
+	result, err := RegisterPointCloudGlobal(source, target, RegistrationConfig{VoxelSize: 20, Levels: 2})
+	test.That(t, err, test.ShouldBeNil)
+	dist, angle := poseError(result.Pose, expected)
+	test.That(t, dist, test.ShouldBeLessThan, 10)
+	test.That(t, angle, test.ShouldBeLessThan, 1)
+	test.That(t, result.Fitness, test.ShouldBeGreaterThan, 0.9)
+
+	_, err = RegisterPointCloudGlobal(source, target, RegistrationConfig{})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = RegisterPointCloudGlobal(source, New(), RegistrationConfig{VoxelSize: 20})
+	test.That(t, err, test.ShouldNotBeNil)
+}
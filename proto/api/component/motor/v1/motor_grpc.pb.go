@@ -0,0 +1,151 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: proto/api/component/motor/v1/motor.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ExtendedMotorServiceClient is the client API for ExtendedMotorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExtendedMotorServiceClient interface {
+	// GetProperties returns a message of booleans indicating which optional features the motor
+	// supports, the telemetry features included
+	GetProperties(ctx context.Context, in *GetPropertiesRequest, opts ...grpc.CallOption) (*GetPropertiesResponse, error)
+	// GetTelemetry returns the current draw, driver temperature, bus voltage and active faults of
+	// the motor
+	// This method will return an error if the motor does not report telemetry
+	GetTelemetry(ctx context.Context, in *GetTelemetryRequest, opts ...grpc.CallOption) (*GetTelemetryResponse, error)
+}
+
+type extendedMotorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExtendedMotorServiceClient(cc grpc.ClientConnInterface) ExtendedMotorServiceClient {
+	return &extendedMotorServiceClient{cc}
+}
+
+func (c *extendedMotorServiceClient) GetProperties(ctx context.Context, in *GetPropertiesRequest, opts ...grpc.CallOption) (*GetPropertiesResponse, error) {
+	out := new(GetPropertiesResponse)
+	err := c.cc.Invoke(ctx, "/proto.api.component.motor.v1.ExtendedMotorService/GetProperties", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedMotorServiceClient) GetTelemetry(ctx context.Context, in *GetTelemetryRequest, opts ...grpc.CallOption) (*GetTelemetryResponse, error) {
+	out := new(GetTelemetryResponse)
+	err := c.cc.Invoke(ctx, "/proto.api.component.motor.v1.ExtendedMotorService/GetTelemetry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExtendedMotorServiceServer is the server API for ExtendedMotorService service.
+// All implementations must embed UnimplementedExtendedMotorServiceServer
+// for forward compatibility
+type ExtendedMotorServiceServer interface {
+	// GetProperties returns a message of booleans indicating which optional features the motor
+	// supports, the telemetry features included
+	GetProperties(context.Context, *GetPropertiesRequest) (*GetPropertiesResponse, error)
+	// GetTelemetry returns the current draw, driver temperature, bus voltage and active faults of
+	// the motor
+	// This method will return an error if the motor does not report telemetry
+	GetTelemetry(context.Context, *GetTelemetryRequest) (*GetTelemetryResponse, error)
+	mustEmbedUnimplementedExtendedMotorServiceServer()
+}
+
+// UnimplementedExtendedMotorServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedExtendedMotorServiceServer struct {
+}
+
+func (UnimplementedExtendedMotorServiceServer) GetProperties(context.Context, *GetPropertiesRequest) (*GetPropertiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProperties not implemented")
+}
+func (UnimplementedExtendedMotorServiceServer) GetTelemetry(context.Context, *GetTelemetryRequest) (*GetTelemetryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTelemetry not implemented")
+}
+func (UnimplementedExtendedMotorServiceServer) mustEmbedUnimplementedExtendedMotorServiceServer() {}
+
+// UnsafeExtendedMotorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExtendedMotorServiceServer will
+// result in compilation errors.
+type UnsafeExtendedMotorServiceServer interface {
+	mustEmbedUnimplementedExtendedMotorServiceServer()
+}
+
+func RegisterExtendedMotorServiceServer(s grpc.ServiceRegistrar, srv ExtendedMotorServiceServer) {
+	s.RegisterService(&ExtendedMotorService_ServiceDesc, srv)
+}
+
+func _ExtendedMotorService_GetProperties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPropertiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedMotorServiceServer).GetProperties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.api.component.motor.v1.ExtendedMotorService/GetProperties",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedMotorServiceServer).GetProperties(ctx, req.(*GetPropertiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedMotorService_GetTelemetry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTelemetryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedMotorServiceServer).GetTelemetry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.api.component.motor.v1.ExtendedMotorService/GetTelemetry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedMotorServiceServer).GetTelemetry(ctx, req.(*GetTelemetryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExtendedMotorService_ServiceDesc is the grpc.ServiceDesc for ExtendedMotorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExtendedMotorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.api.component.motor.v1.ExtendedMotorService",
+	HandlerType: (*ExtendedMotorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetProperties",
+			Handler:    _ExtendedMotorService_GetProperties_Handler,
+		},
+		{
+			MethodName: "GetTelemetry",
+			Handler:    _ExtendedMotorService_GetTelemetry_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/api/component/motor/v1/motor.proto",
+}
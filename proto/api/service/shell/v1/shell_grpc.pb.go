@@ -0,0 +1,318 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: proto/api/service/shell/v1/shell.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ExtendedShellServiceClient is the client API for ExtendedShellService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExtendedShellServiceClient interface {
+	// StatFile returns the info of a file of the robot
+	StatFile(ctx context.Context, in *StatFileRequest, opts ...grpc.CallOption) (*StatFileResponse, error)
+	// UploadFile writes the data of the stream to a file of the robot, from an offset on so that an
+	// interrupted upload can be resumed
+	// The first message of the stream holds the metadata of the upload, the following ones its data
+	UploadFile(ctx context.Context, opts ...grpc.CallOption) (ExtendedShellService_UploadFileClient, error)
+	// DownloadFile streams the content of a file of the robot from an offset on, followed by the
+	// info of the file
+	DownloadFile(ctx context.Context, in *DownloadFileRequest, opts ...grpc.CallOption) (ExtendedShellService_DownloadFileClient, error)
+	// Exec runs a command to completion, streaming its output as it is written, followed by its
+	// exit code
+	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (ExtendedShellService_ExecClient, error)
+}
+
+type extendedShellServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExtendedShellServiceClient(cc grpc.ClientConnInterface) ExtendedShellServiceClient {
+	return &extendedShellServiceClient{cc}
+}
+
+func (c *extendedShellServiceClient) StatFile(ctx context.Context, in *StatFileRequest, opts ...grpc.CallOption) (*StatFileResponse, error) {
+	out := new(StatFileResponse)
+	err := c.cc.Invoke(ctx, "/proto.api.service.shell.v1.ExtendedShellService/StatFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedShellServiceClient) UploadFile(ctx context.Context, opts ...grpc.CallOption) (ExtendedShellService_UploadFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExtendedShellService_ServiceDesc.Streams[0], "/proto.api.service.shell.v1.ExtendedShellService/UploadFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &extendedShellServiceUploadFileClient{stream}
+	return x, nil
+}
+
+type ExtendedShellService_UploadFileClient interface {
+	Send(*UploadFileRequest) error
+	CloseAndRecv() (*UploadFileResponse, error)
+	grpc.ClientStream
+}
+
+type extendedShellServiceUploadFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *extendedShellServiceUploadFileClient) Send(m *UploadFileRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *extendedShellServiceUploadFileClient) CloseAndRecv() (*UploadFileResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadFileResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *extendedShellServiceClient) DownloadFile(ctx context.Context, in *DownloadFileRequest, opts ...grpc.CallOption) (ExtendedShellService_DownloadFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExtendedShellService_ServiceDesc.Streams[1], "/proto.api.service.shell.v1.ExtendedShellService/DownloadFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &extendedShellServiceDownloadFileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExtendedShellService_DownloadFileClient interface {
+	Recv() (*DownloadFileResponse, error)
+	grpc.ClientStream
+}
+
+type extendedShellServiceDownloadFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *extendedShellServiceDownloadFileClient) Recv() (*DownloadFileResponse, error) {
+	m := new(DownloadFileResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *extendedShellServiceClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (ExtendedShellService_ExecClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExtendedShellService_ServiceDesc.Streams[2], "/proto.api.service.shell.v1.ExtendedShellService/Exec", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &extendedShellServiceExecClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExtendedShellService_ExecClient interface {
+	Recv() (*ExecResponse, error)
+	grpc.ClientStream
+}
+
+type extendedShellServiceExecClient struct {
+	grpc.ClientStream
+}
+
+func (x *extendedShellServiceExecClient) Recv() (*ExecResponse, error) {
+	m := new(ExecResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ExtendedShellServiceServer is the server API for ExtendedShellService service.
+// All implementations must embed UnimplementedExtendedShellServiceServer
+// for forward compatibility
+type ExtendedShellServiceServer interface {
+	// StatFile returns the info of a file of the robot
+	StatFile(context.Context, *StatFileRequest) (*StatFileResponse, error)
+	// UploadFile writes the data of the stream to a file of the robot, from an offset on so that an
+	// interrupted upload can be resumed
+	// The first message of the stream holds the metadata of the upload, the following ones its data
+	UploadFile(ExtendedShellService_UploadFileServer) error
+	// DownloadFile streams the content of a file of the robot from an offset on, followed by the
+	// info of the file
+	DownloadFile(*DownloadFileRequest, ExtendedShellService_DownloadFileServer) error
+	// Exec runs a command to completion, streaming its output as it is written, followed by its
+	// exit code
+	Exec(*ExecRequest, ExtendedShellService_ExecServer) error
+	mustEmbedUnimplementedExtendedShellServiceServer()
+}
+
+// UnimplementedExtendedShellServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedExtendedShellServiceServer struct {
+}
+
+func (UnimplementedExtendedShellServiceServer) StatFile(context.Context, *StatFileRequest) (*StatFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StatFile not implemented")
+}
+func (UnimplementedExtendedShellServiceServer) UploadFile(ExtendedShellService_UploadFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadFile not implemented")
+}
+func (UnimplementedExtendedShellServiceServer) DownloadFile(*DownloadFileRequest, ExtendedShellService_DownloadFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadFile not implemented")
+}
+func (UnimplementedExtendedShellServiceServer) Exec(*ExecRequest, ExtendedShellService_ExecServer) error {
+	return status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedExtendedShellServiceServer) mustEmbedUnimplementedExtendedShellServiceServer() {}
+
+// UnsafeExtendedShellServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExtendedShellServiceServer will
+// result in compilation errors.
+type UnsafeExtendedShellServiceServer interface {
+	mustEmbedUnimplementedExtendedShellServiceServer()
+}
+
+func RegisterExtendedShellServiceServer(s grpc.ServiceRegistrar, srv ExtendedShellServiceServer) {
+	s.RegisterService(&ExtendedShellService_ServiceDesc, srv)
+}
+
+func _ExtendedShellService_StatFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedShellServiceServer).StatFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.api.service.shell.v1.ExtendedShellService/StatFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedShellServiceServer).StatFile(ctx, req.(*StatFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedShellService_UploadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ExtendedShellServiceServer).UploadFile(&extendedShellServiceUploadFileServer{stream})
+}
+
+type ExtendedShellService_UploadFileServer interface {
+	SendAndClose(*UploadFileResponse) error
+	Recv() (*UploadFileRequest, error)
+	grpc.ServerStream
+}
+
+type extendedShellServiceUploadFileServer struct {
+	grpc.ServerStream
+}
+
+func (x *extendedShellServiceUploadFileServer) SendAndClose(m *UploadFileResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *extendedShellServiceUploadFileServer) Recv() (*UploadFileRequest, error) {
+	m := new(UploadFileRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ExtendedShellService_DownloadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExtendedShellServiceServer).DownloadFile(m, &extendedShellServiceDownloadFileServer{stream})
+}
+
+type ExtendedShellService_DownloadFileServer interface {
+	Send(*DownloadFileResponse) error
+	grpc.ServerStream
+}
+
+type extendedShellServiceDownloadFileServer struct {
+	grpc.ServerStream
+}
+
+func (x *extendedShellServiceDownloadFileServer) Send(m *DownloadFileResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ExtendedShellService_Exec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExtendedShellServiceServer).Exec(m, &extendedShellServiceExecServer{stream})
+}
+
+type ExtendedShellService_ExecServer interface {
+	Send(*ExecResponse) error
+	grpc.ServerStream
+}
+
+type extendedShellServiceExecServer struct {
+	grpc.ServerStream
+}
+
+func (x *extendedShellServiceExecServer) Send(m *ExecResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ExtendedShellService_ServiceDesc is the grpc.ServiceDesc for ExtendedShellService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExtendedShellService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.api.service.shell.v1.ExtendedShellService",
+	HandlerType: (*ExtendedShellServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StatFile",
+			Handler:    _ExtendedShellService_StatFile_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadFile",
+			Handler:       _ExtendedShellService_UploadFile_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadFile",
+			Handler:       _ExtendedShellService_DownloadFile_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Exec",
+			Handler:       _ExtendedShellService_Exec_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/api/service/shell/v1/shell.proto",
+}
@@ -0,0 +1,189 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: proto/api/service/vision/v1/vision.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ExtendedVisionServiceClient is the client API for ExtendedVisionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExtendedVisionServiceClient interface {
+	// GetSegmentationMask returns the per-pixel classes and instances a segmenter finds in an image,
+	// or in the next image of a camera if one is named
+	GetSegmentationMask(ctx context.Context, in *GetSegmentationMaskRequest, opts ...grpc.CallOption) (*GetSegmentationMaskResponse, error)
+	// GetTracks returns the objects a tracker follows in the images of a camera, after tracking
+	// them in its next image
+	GetTracks(ctx context.Context, in *GetTracksRequest, opts ...grpc.CallOption) (*GetTracksResponse, error)
+	// GetTrackEvents returns the latest events of the objects a tracker follows in the images of a
+	// camera
+	GetTrackEvents(ctx context.Context, in *GetTrackEventsRequest, opts ...grpc.CallOption) (*GetTrackEventsResponse, error)
+}
+
+type extendedVisionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExtendedVisionServiceClient(cc grpc.ClientConnInterface) ExtendedVisionServiceClient {
+	return &extendedVisionServiceClient{cc}
+}
+
+func (c *extendedVisionServiceClient) GetSegmentationMask(ctx context.Context, in *GetSegmentationMaskRequest, opts ...grpc.CallOption) (*GetSegmentationMaskResponse, error) {
+	out := new(GetSegmentationMaskResponse)
+	err := c.cc.Invoke(ctx, "/proto.api.service.vision.v1.ExtendedVisionService/GetSegmentationMask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedVisionServiceClient) GetTracks(ctx context.Context, in *GetTracksRequest, opts ...grpc.CallOption) (*GetTracksResponse, error) {
+	out := new(GetTracksResponse)
+	err := c.cc.Invoke(ctx, "/proto.api.service.vision.v1.ExtendedVisionService/GetTracks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedVisionServiceClient) GetTrackEvents(ctx context.Context, in *GetTrackEventsRequest, opts ...grpc.CallOption) (*GetTrackEventsResponse, error) {
+	out := new(GetTrackEventsResponse)
+	err := c.cc.Invoke(ctx, "/proto.api.service.vision.v1.ExtendedVisionService/GetTrackEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExtendedVisionServiceServer is the server API for ExtendedVisionService service.
+// All implementations must embed UnimplementedExtendedVisionServiceServer
+// for forward compatibility
+type ExtendedVisionServiceServer interface {
+	// GetSegmentationMask returns the per-pixel classes and instances a segmenter finds in an image,
+	// or in the next image of a camera if one is named
+	GetSegmentationMask(context.Context, *GetSegmentationMaskRequest) (*GetSegmentationMaskResponse, error)
+	// GetTracks returns the objects a tracker follows in the images of a camera, after tracking
+	// them in its next image
+	GetTracks(context.Context, *GetTracksRequest) (*GetTracksResponse, error)
+	// GetTrackEvents returns the latest events of the objects a tracker follows in the images of a
+	// camera
+	GetTrackEvents(context.Context, *GetTrackEventsRequest) (*GetTrackEventsResponse, error)
+	mustEmbedUnimplementedExtendedVisionServiceServer()
+}
+
+// UnimplementedExtendedVisionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedExtendedVisionServiceServer struct {
+}
+
+func (UnimplementedExtendedVisionServiceServer) GetSegmentationMask(context.Context, *GetSegmentationMaskRequest) (*GetSegmentationMaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSegmentationMask not implemented")
+}
+func (UnimplementedExtendedVisionServiceServer) GetTracks(context.Context, *GetTracksRequest) (*GetTracksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTracks not implemented")
+}
+func (UnimplementedExtendedVisionServiceServer) GetTrackEvents(context.Context, *GetTrackEventsRequest) (*GetTrackEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrackEvents not implemented")
+}
+func (UnimplementedExtendedVisionServiceServer) mustEmbedUnimplementedExtendedVisionServiceServer() {}
+
+// UnsafeExtendedVisionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExtendedVisionServiceServer will
+// result in compilation errors.
+type UnsafeExtendedVisionServiceServer interface {
+	mustEmbedUnimplementedExtendedVisionServiceServer()
+}
+
+func RegisterExtendedVisionServiceServer(s grpc.ServiceRegistrar, srv ExtendedVisionServiceServer) {
+	s.RegisterService(&ExtendedVisionService_ServiceDesc, srv)
+}
+
+func _ExtendedVisionService_GetSegmentationMask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSegmentationMaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedVisionServiceServer).GetSegmentationMask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.api.service.vision.v1.ExtendedVisionService/GetSegmentationMask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedVisionServiceServer).GetSegmentationMask(ctx, req.(*GetSegmentationMaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedVisionService_GetTracks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTracksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedVisionServiceServer).GetTracks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.api.service.vision.v1.ExtendedVisionService/GetTracks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedVisionServiceServer).GetTracks(ctx, req.(*GetTracksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedVisionService_GetTrackEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrackEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedVisionServiceServer).GetTrackEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.api.service.vision.v1.ExtendedVisionService/GetTrackEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedVisionServiceServer).GetTrackEvents(ctx, req.(*GetTrackEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExtendedVisionService_ServiceDesc is the grpc.ServiceDesc for ExtendedVisionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExtendedVisionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.api.service.vision.v1.ExtendedVisionService",
+	HandlerType: (*ExtendedVisionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSegmentationMask",
+			Handler:    _ExtendedVisionService_GetSegmentationMask_Handler,
+		},
+		{
+			MethodName: "GetTracks",
+			Handler:    _ExtendedVisionService_GetTracks_Handler,
+		},
+		{
+			MethodName: "GetTrackEvents",
+			Handler:    _ExtendedVisionService_GetTrackEvents_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/api/service/vision/v1/vision.proto",
+}
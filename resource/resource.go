@@ -301,6 +301,13 @@ type Updateable interface {
 	Update(context.Context, map[Name]interface{}) error
 }
 
+// HealthChecker is implemented when a resource of a robot can check its own health. A robot
+// restarts the local components whose health checks keep failing.
+type HealthChecker interface {
+	// CheckHealth returns an error if the resource is unhealthy
+	CheckHealth(context.Context) error
+}
+
 // MovingCheckable is implemented when a resource of a robot returns whether it is moving or not.
 type MovingCheckable interface {
 	// IsMoving returns whether the resource is moving or not
@@ -111,6 +111,19 @@ func (g *Graph) Clone() *Graph {
 	return g.clone()
 }
 
+// Restore replaces the nodes and dependencies of the graph with a copy of those of other.
+func (g *Graph) Restore(other *Graph) {
+	other.mu.Lock()
+	restored := other.clone()
+	other.mu.Unlock()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.nodes = restored.nodes
+	g.children = restored.children
+	g.parents = restored.parents
+	g.transitiveClosureMatrix = restored.transitiveClosureMatrix
+}
+
 func (g *Graph) clone() *Graph {
 	return &Graph{
 		children:                copyNodeMap(g.children),
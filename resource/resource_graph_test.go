@@ -805,6 +805,36 @@ func TestCopyNodeAndChildren(t *testing.T) {
 	}...))
 }
 
+func TestGraphRestore(t *testing.T) {
+	g := NewGraph()
+	for _, component := range commonCfg {
+		g.AddNode(component.Name, component.Name.Name)
+		for _, dep := range component.DependsOn {
+			test.That(t, g.AddChildren(component.Name, dep), test.ShouldBeNil)
+		}
+	}
+	snapshot := g.Clone()
+	b := NewName("namespace", "atype", "asubtype", "B")
+	h := NewName("namespace", "atype", "asubtype", "H")
+	g.AddNode(b, "replaced")
+	g.AddNode(h, "H")
+	test.That(t, g.AddChildren(h, b), test.ShouldBeNil)
+	g.Remove(NewName("namespace", "atype", "asubtype", "G"))
+
+	g.Restore(snapshot)
+	test.That(t, newResourceNameSet(g.Names()...), test.ShouldResemble, newResourceNameSet(snapshot.Names()...))
+	node, ok := g.Node(b)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, node, test.ShouldEqual, "B")
+	test.That(t, newResourceNameSet(g.GetAllChildrenOf(b)...), test.ShouldResemble,
+		newResourceNameSet(snapshot.GetAllChildrenOf(b)...))
+
+	// the graph does not share its state with the snapshot
+	g.AddNode(b, "replaced")
+	node, _ = snapshot.Node(b)
+	test.That(t, node, test.ShouldEqual, "B")
+}
+
 func TestRenameNode(t *testing.T) {
 	g := NewGraph()
 	test.That(t, g, test.ShouldNotBeNil)
@@ -0,0 +1,274 @@
+package transform
+
+import (
+	"math"
+
+	"github.com/golang/geo/r2"
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/diff/fd"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/num/quat"
+	"gonum.org/v1/gonum/optimize"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+// HandEyeSetup describes where the camera is mounted relative to the arm during hand-eye calibration.
+type HandEyeSetup string
+
+const (
+	// EyeInHand is a camera mounted on the end effector of the arm looking at a fixed target.
+	// The calibration finds the pose of the camera relative to the end effector.
+	EyeInHand = HandEyeSetup("eye_in_hand")
+	// EyeToHand is a fixed camera looking at a target held by the end effector.
+	// The calibration finds the pose of the camera relative to the base of the arm.
+	EyeToHand = HandEyeSetup("eye_to_hand")
+)
+
+// HandEyeSample is one station of a hand-eye calibration: where the arm's end effector was, relative
+// to the arm's base, and where the calibration target was seen, relative to the camera.
+type HandEyeSample struct {
+	ArmPose    spatialmath.Pose
+	TargetPose spatialmath.Pose
+}
+
+// HandEyeResult is the solution of a hand-eye calibration. Pose is the camera pose in the end effector
+// frame for EyeInHand, and in the arm base frame for EyeToHand. The errors are the root mean square
+// of the residuals of AX=XB over every pair of samples, in degrees and mm.
+type HandEyeResult struct {
+	Pose             spatialmath.Pose
+	RotationError    float64
+	TranslationError float64
+}
+
+// SolveHandEye solves the AX=XB hand-eye calibration problem for the given samples, where A is the
+// relative motion of the end effector and B is the corresponding relative motion of the target seen
+// by the camera. Rotation is solved in closed form on the rotation vectors of every pair of samples
+// (Park and Martin, 1994), then translation is solved by linear least squares. The samples should
+// cover rotations about at least two non-parallel axes.
+func SolveHandEye(samples []HandEyeSample, setup HandEyeSetup) (*HandEyeResult, error) {
+	if len(samples) < 3 {
+		return nil, errors.Errorf("need at least 3 samples to solve hand-eye calibration, only have %d", len(samples))
+	}
+	armPoses := make([]spatialmath.Pose, len(samples))
+	for i, s := range samples {
+		if s.ArmPose == nil || s.TargetPose == nil {
+			return nil, errors.Errorf("sample %d is missing a pose", i)
+		}
+		switch setup {
+		case EyeInHand:
+			armPoses[i] = s.ArmPose
+		case EyeToHand:
+			// a camera fixed to the world sees the inverse of the end effector motion
+			armPoses[i] = spatialmath.PoseInverse(s.ArmPose)
+		default:
+			return nil, errors.Errorf("unknown hand-eye setup %q", setup)
+		}
+	}
+
+	type motion struct{ a, b spatialmath.Pose }
+	var motions []motion
+	for i := 0; i < len(samples); i++ {
+		for j := i + 1; j < len(samples); j++ {
+			a := spatialmath.Compose(spatialmath.PoseInverse(armPoses[j]), armPoses[i])
+			b := spatialmath.Compose(samples[j].TargetPose, spatialmath.PoseInverse(samples[i].TargetPose))
+			motions = append(motions, motion{a, b})
+		}
+	}
+
+	// rotation: find R minimizing sum |R*beta - alpha|^2 over the rotation vectors of A and B
+	h := mat.NewDense(3, 3, nil)
+	usable := 0
+	for _, m := range motions {
+		alpha := spatialmath.QuatToR3AA(m.a.Orientation().Quaternion())
+		beta := spatialmath.QuatToR3AA(m.b.Orientation().Quaternion())
+		if alpha.Norm() < 1e-3 || beta.Norm() < 1e-3 {
+			continue
+		}
+		usable++
+		var outer mat.Dense
+		outer.Outer(1, mat.NewVecDense(3, []float64{beta.X, beta.Y, beta.Z}), mat.NewVecDense(3, []float64{alpha.X, alpha.Y, alpha.Z}))
+		h.Add(h, &outer)
+	}
+	if usable < 2 {
+		return nil, errors.New("not enough rotation between samples to solve hand-eye calibration")
+	}
+	var svd mat.SVD
+	if ok := svd.Factorize(h, mat.SVDFull); !ok {
+		return nil, errors.New("failed to factorize hand-eye rotation system")
+	}
+	if vals := svd.Values(nil); vals[1] < 1e-6*vals[0] {
+		return nil, errors.New("sample rotations are all about the same axis, hand-eye rotation is not observable")
+	}
+	var u, v mat.Dense
+	svd.UTo(&u)
+	svd.VTo(&v)
+	var rx mat.Dense
+	rx.Mul(&v, u.T())
+	if mat.Det(&rx) < 0 {
+		d := mat.NewDiagDense(3, []float64{1, 1, -1})
+		rx.Product(&v, d, u.T())
+	}
+	// spatialmath stores rotation matrices transposed, with the rotated basis vectors as rows
+	rotXMat, err := spatialmath.NewRotationMatrix(mat.DenseCopyOf(rx.T()).RawMatrix().Data)
+	if err != nil {
+		return nil, err
+	}
+	rotX := rotXMat.Quaternion()
+
+	// translation: (R_A - I) t_X = R_X t_B - t_A
+	lhs := mat.NewDense(3*len(motions), 3, nil)
+	rhs := mat.NewVecDense(3*len(motions), nil)
+	for k, m := range motions {
+		ra := spatialmath.QuatToRotationMatrix(m.a.Orientation().Quaternion())
+		ta := m.a.Point()
+		rtb := rotateByQuat(rotX, m.b.Point())
+		for r := 0; r < 3; r++ {
+			for c := 0; c < 3; c++ {
+				val := ra.At(c, r)
+				if r == c {
+					val--
+				}
+				lhs.Set(3*k+r, c, val)
+			}
+		}
+		rhs.SetVec(3*k, rtb.X-ta.X)
+		rhs.SetVec(3*k+1, rtb.Y-ta.Y)
+		rhs.SetVec(3*k+2, rtb.Z-ta.Z)
+	}
+	var tx mat.VecDense
+	if err := tx.SolveVec(lhs, rhs); err != nil {
+		return nil, errors.Wrap(err, "failed to solve hand-eye translation")
+	}
+	x := spatialmath.NewPoseFromOrientation(r3.Vector{tx.AtVec(0), tx.AtVec(1), tx.AtVec(2)}, (*spatialmath.Quaternion)(&rotX))
+
+	// residuals of AX = XB
+	var rotSq, transSq float64
+	for _, m := range motions {
+		ax := spatialmath.Compose(m.a, x)
+		xb := spatialmath.Compose(x, m.b)
+		diff := spatialmath.PoseBetween(xb, ax)
+		angle := spatialmath.QuatToR3AA(diff.Orientation().Quaternion()).Norm() * 180 / math.Pi
+		rotSq += angle * angle
+		transSq += ax.Point().Sub(xb.Point()).Norm2()
+	}
+	return &HandEyeResult{
+		Pose:             x,
+		RotationError:    math.Sqrt(rotSq / float64(len(motions))),
+		TranslationError: math.Sqrt(transSq / float64(len(motions))),
+	}, nil
+}
+
+// EstimatePlanarTargetPose finds the pose of a planar target (all object points with Z=0, in mm) relative
+// to a camera with the given intrinsics, from the pixel locations of the object points in an undistorted
+// image. The initial estimate comes from decomposing the plane-to-image homography and is then refined
+// by minimizing the reprojection error. It returns the pose and the root mean square reprojection error
+// in pixels.
+func EstimatePlanarTargetPose(
+	intrinsics *PinholeCameraIntrinsics,
+	objectPoints []r3.Vector,
+	imagePoints []r2.Point,
+) (spatialmath.Pose, float64, error) {
+	if err := intrinsics.CheckValid(); err != nil {
+		return nil, 0, err
+	}
+	if len(objectPoints) != len(imagePoints) {
+		return nil, 0, errors.Errorf("number of object points (%d) does not equal number of image points (%d)",
+			len(objectPoints), len(imagePoints))
+	}
+	planePts := make([]r2.Point, len(objectPoints))
+	normPts := make([]r2.Point, len(imagePoints))
+	for i, p := range objectPoints {
+		if p.Z != 0 {
+			return nil, 0, errors.Errorf("object point %d is not on the Z=0 plane", i)
+		}
+		planePts[i] = r2.Point{p.X, p.Y}
+		normPts[i] = r2.Point{(imagePoints[i].X - intrinsics.Ppx) / intrinsics.Fx, (imagePoints[i].Y - intrinsics.Ppy) / intrinsics.Fy}
+	}
+	h, err := EstimateLeastSquaresHomography(pointsToMat(planePts), pointsToMat(normPts))
+	if err != nil {
+		return nil, 0, err
+	}
+	rot, t, err := decomposePlanarHomography(h)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	project := func(q quat.Number, t r3.Vector, p r3.Vector) r2.Point {
+		c := rotateByQuat(q, p).Add(t)
+		return r2.Point{c.X/c.Z*intrinsics.Fx + intrinsics.Ppx, c.Y/c.Z*intrinsics.Fy + intrinsics.Ppy}
+	}
+	// refine rotation (as a rotation vector) and translation on the reprojection error
+	toQuat := func(p []float64) quat.Number {
+		// R3ToR4 is undefined for the zero rotation
+		if rv := (r3.Vector{p[0], p[1], p[2]}); rv.Norm2() > 0 {
+			return spatialmath.R3ToR4(rv).ToQuat()
+		}
+		return quat.Number{Real: 1}
+	}
+	rv := spatialmath.QuatToR3AA(rot)
+	params := []float64{rv.X, rv.Y, rv.Z, t.X, t.Y, t.Z}
+	cost := func(p []float64) float64 {
+		q := toQuat(p)
+		tt := r3.Vector{p[3], p[4], p[5]}
+		sse := 0.
+		for i, op := range objectPoints {
+			d := project(q, tt, op).Sub(imagePoints[i])
+			sse += d.Dot(d)
+		}
+		return sse
+	}
+	res, err := optimize.Minimize(optimize.Problem{
+		Func: cost,
+		Grad: func(grad, x []float64) { fd.Gradient(grad, cost, x, nil) },
+	}, params, &optimize.Settings{
+		GradientThreshold: 1e-10,
+		Converger:         &optimize.FunctionConverge{Relative: 1e-10, Absolute: 1e-12, Iterations: 50},
+	}, &optimize.BFGS{})
+	if err == nil && res.F < cost(params) {
+		params = res.X
+	}
+	rot = toQuat(params)
+	t = r3.Vector{params[3], params[4], params[5]}
+	rms := math.Sqrt(cost(params) / float64(len(objectPoints)))
+	return spatialmath.NewPoseFromOrientation(t, (*spatialmath.Quaternion)(&rot)), rms, nil
+}
+
+// decomposePlanarHomography recovers the rotation and translation of the Z=0 plane from the homography
+// mapping plane coordinates to normalized image coordinates, H = [r1 r2 t] up to scale.
+func decomposePlanarHomography(h *Homography) (quat.Number, r3.Vector, error) {
+	h1 := r3.Vector{h.At(0, 0), h.At(1, 0), h.At(2, 0)}
+	h2 := r3.Vector{h.At(0, 1), h.At(1, 1), h.At(2, 1)}
+	h3 := r3.Vector{h.At(0, 2), h.At(1, 2), h.At(2, 2)}
+	lambda := 2 / (h1.Norm() + h2.Norm())
+	// the target must be in front of the camera
+	if h3.Z < 0 {
+		lambda = -lambda
+	}
+	r1, r2 := h1.Mul(lambda), h2.Mul(lambda)
+	r3v := r1.Cross(r2)
+	approx := mat.NewDense(3, 3, []float64{
+		r1.X, r2.X, r3v.X,
+		r1.Y, r2.Y, r3v.Y,
+		r1.Z, r2.Z, r3v.Z,
+	})
+	// closest rotation matrix to the approximation
+	var svd mat.SVD
+	svd.Factorize(approx, mat.SVDFull)
+	var u, v, rot mat.Dense
+	svd.UTo(&u)
+	svd.VTo(&v)
+	rot.Mul(&u, v.T())
+	rotMat, err := spatialmath.NewRotationMatrix(mat.DenseCopyOf(rot.T()).RawMatrix().Data)
+	if err != nil {
+		return quat.Number{}, r3.Vector{}, err
+	}
+	return rotMat.Quaternion(), h3.Mul(lambda), nil
+}
+
+// rotateByQuat rotates a vector by a unit quaternion.
+func rotateByQuat(q quat.Number, v r3.Vector) r3.Vector {
+	p := quat.Mul(quat.Mul(q, quat.Number{Imag: v.X, Jmag: v.Y, Kmag: v.Z}), quat.Conj(q))
+	return r3.Vector{p.Imag, p.Jmag, p.Kmag}
+}
@@ -0,0 +1,94 @@
+package transform
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+func randomPose(rnd *rand.Rand, maxTranslation float64) spatialmath.Pose {
+	return spatialmath.NewPoseFromOrientation(
+		r3.Vector{(rnd.Float64() - 0.5) * maxTranslation, (rnd.Float64() - 0.5) * maxTranslation, (rnd.Float64() - 0.5) * maxTranslation},
+		&spatialmath.EulerAngles{Roll: rnd.Float64() - 0.5, Pitch: rnd.Float64() - 0.5, Yaw: 2 * (rnd.Float64() - 0.5)},
+	)
+}
+
+func TestSolveHandEye(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	x := spatialmath.NewPoseFromOrientation(r3.Vector{30, -20, 80}, &spatialmath.EulerAngles{Roll: 0.2, Pitch: -0.1, Yaw: 1.5})
+	// the target pose in the arm base frame for eye-in-hand, in the end effector frame for eye-to-hand
+	fixed := spatialmath.NewPoseFromOrientation(r3.Vector{400, 100, -50}, &spatialmath.EulerAngles{Roll: 3, Pitch: 0.1})
+
+	eyeInHand := make([]HandEyeSample, 0, 8)
+	eyeToHand := make([]HandEyeSample, 0, 8)
+	for i := 0; i < 8; i++ {
+		arm := spatialmath.Compose(spatialmath.NewPoseFromPoint(r3.Vector{300, 0, 400}), randomPose(rnd, 200))
+		eyeInHand = append(eyeInHand, HandEyeSample{
+			ArmPose: arm,
+			TargetPose: spatialmath.Compose(
+				spatialmath.PoseInverse(x),
+				spatialmath.Compose(spatialmath.PoseInverse(arm), fixed),
+			),
+		})
+		eyeToHand = append(eyeToHand, HandEyeSample{
+			ArmPose:    arm,
+			TargetPose: spatialmath.Compose(spatialmath.PoseInverse(x), spatialmath.Compose(arm, fixed)),
+		})
+	}
+
+	for setup, samples := range map[HandEyeSetup][]HandEyeSample{EyeInHand: eyeInHand, EyeToHand: eyeToHand} {
+		res, err := SolveHandEye(samples, setup)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, spatialmath.PoseAlmostEqual(res.Pose, x), test.ShouldBeTrue)
+		test.That(t, res.RotationError, test.ShouldBeLessThan, 1e-6)
+		test.That(t, res.TranslationError, test.ShouldBeLessThan, 1e-6)
+	}
+
+	_, err := SolveHandEye(eyeInHand[:2], EyeInHand)
+	test.That(t, err, test.ShouldBeError)
+	_, err = SolveHandEye(eyeInHand, HandEyeSetup("sideways"))
+	test.That(t, err, test.ShouldBeError)
+
+	// rotating about a single axis leaves the calibration unobservable
+	singleAxis := make([]HandEyeSample, 0, 4)
+	for i := 0; i < 4; i++ {
+		arm := spatialmath.NewPoseFromOrientation(r3.Vector{float64(i) * 10, 0, 400}, &spatialmath.EulerAngles{Yaw: float64(i) * 0.3})
+		singleAxis = append(singleAxis, HandEyeSample{
+			ArmPose: arm,
+			TargetPose: spatialmath.Compose(
+				spatialmath.PoseInverse(x),
+				spatialmath.Compose(spatialmath.PoseInverse(arm), fixed),
+			),
+		})
+	}
+	_, err = SolveHandEye(singleAxis, EyeInHand)
+	test.That(t, err, test.ShouldBeError)
+}
+
+func TestEstimatePlanarTargetPose(t *testing.T) {
+	pose := spatialmath.NewPoseFromOrientation(r3.Vector{-80, -40, 520}, &spatialmath.EulerAngles{Roll: 0.3, Pitch: -0.25, Yaw: 0.4})
+	objPts := testCheckerboard.ObjectPoints()
+	imgPts := projectPoints(testCalibIntrinsics, pose, objPts)
+	got, rms, err := EstimatePlanarTargetPose(testCalibIntrinsics, objPts, imgPts)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rms, test.ShouldBeLessThan, 1e-3)
+	test.That(t, spatialmath.PoseAlmostCoincidentEps(got, pose, 1e-3), test.ShouldBeTrue)
+
+	// end to end through the corner detector
+	img := renderCheckerboard(testCalibIntrinsics, testCheckerboard, pose)
+	corners, err := testCheckerboard.FindCorners(img)
+	test.That(t, err, test.ShouldBeNil)
+	got, rms, err = EstimatePlanarTargetPose(testCalibIntrinsics, objPts, corners)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rms, test.ShouldBeLessThan, 0.3)
+	test.That(t, spatialmath.PoseAlmostCoincidentEps(got, pose, 2), test.ShouldBeTrue)
+
+	_, _, err = EstimatePlanarTargetPose(testCalibIntrinsics, objPts[:5], imgPts)
+	test.That(t, err, test.ShouldBeError)
+	_, _, err = EstimatePlanarTargetPose(&PinholeCameraIntrinsics{}, objPts, imgPts)
+	test.That(t, err, test.ShouldBeError)
+}
@@ -0,0 +1,265 @@
+package transform
+
+import (
+	"math"
+
+	"github.com/golang/geo/r2"
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/num/quat"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+// IntrinsicCalibration is the result of an intrinsic camera calibration. It marshals to the same
+// intrinsic_parameters/distortion_parameters format used by camera configs. ReprojectionError is the
+// root mean square distance in pixels between the detected corners and the corners projected through
+// the calibrated model, and ViewErrors breaks it down per image.
+type IntrinsicCalibration struct {
+	Intrinsics        *PinholeCameraIntrinsics `json:"intrinsic_parameters"`
+	Distortion        *BrownConrady            `json:"distortion_parameters"`
+	ReprojectionError float64                  `json:"reprojection_error_px"`
+	ViewErrors        []float64                `json:"view_errors_px"`
+}
+
+// CalibrateIntrinsics estimates the focal lengths, principal point and Brown-Conrady distortion of a camera
+// from the checkerboard corners found in several images of the board (see FindCheckerboardCorners) taken
+// with the same camera at different orientations. The closed form solution of Zhang (2000) provides the
+// initial pinhole parameters and board poses, which are then refined jointly with the distortion
+// coefficients by Levenberg-Marquardt minimization of the reprojection error.
+func CalibrateIntrinsics(cb *CheckerboardConfig, views [][]r2.Point, width, height int) (*IntrinsicCalibration, error) {
+	if err := cb.CheckValid(); err != nil {
+		return nil, err
+	}
+	if width <= 0 || height <= 0 {
+		return nil, errors.Errorf("invalid image size (%d, %d)", width, height)
+	}
+	if len(views) < 3 {
+		return nil, errors.Errorf("need at least 3 views of the checkerboard to calibrate, only have %d", len(views))
+	}
+	objectPoints := cb.ObjectPoints()
+	planePts := make([]r2.Point, len(objectPoints))
+	for i, p := range objectPoints {
+		planePts[i] = r2.Point{p.X, p.Y}
+	}
+	homographies := make([]*mat.Dense, len(views))
+	for i, corners := range views {
+		if len(corners) != len(objectPoints) {
+			return nil, errors.Errorf("view %d has %d corners, expected %d", i, len(corners), len(objectPoints))
+		}
+		h, err := EstimateLeastSquaresHomography(pointsToMat(planePts), pointsToMat(corners))
+		if err != nil {
+			return nil, errors.Wrapf(err, "view %d", i)
+		}
+		homographies[i] = h.matrix
+	}
+
+	k, err := intrinsicsFromHomographies(homographies, width, height)
+	if err != nil {
+		return nil, err
+	}
+	var kInv mat.Dense
+	if err := kInv.Inverse(k); err != nil {
+		return nil, errors.Wrap(err, "camera matrix is singular")
+	}
+
+	// parameters: fx, fy, ppx, ppy, k1, k2, k3, p1, p2, then a rotation vector and translation per view
+	const nIntrinsic = 9
+	params := make([]float64, nIntrinsic+6*len(views))
+	params[0], params[1], params[2], params[3] = k.At(0, 0), k.At(1, 1), k.At(0, 2), k.At(1, 2)
+	for i, h := range homographies {
+		var hn mat.Dense
+		hn.Mul(&kInv, h)
+		rot, t, err := decomposePlanarHomography(&Homography{&hn})
+		if err != nil {
+			return nil, err
+		}
+		rv := spatialmath.QuatToR3AA(rot)
+		copy(params[nIntrinsic+6*i:], []float64{rv.X, rv.Y, rv.Z, t.X, t.Y, t.Z})
+	}
+
+	residuals := func(p []float64) []float64 {
+		intr := &PinholeCameraIntrinsics{Fx: p[0], Fy: p[1], Ppx: p[2], Ppy: p[3]}
+		dist := &BrownConrady{RadialK1: p[4], RadialK2: p[5], RadialK3: p[6], TangentialP1: p[7], TangentialP2: p[8]}
+		res := make([]float64, 0, 2*len(views)*len(objectPoints))
+		for i, corners := range views {
+			vp := p[nIntrinsic+6*i:]
+			q := quat.Number{Real: 1}
+			// R3ToR4 is undefined for the zero rotation
+			if rv := (r3.Vector{vp[0], vp[1], vp[2]}); rv.Norm2() > 0 {
+				q = spatialmath.R3ToR4(rv).ToQuat()
+			}
+			t := r3.Vector{vp[3], vp[4], vp[5]}
+			for j, op := range objectPoints {
+				px := projectWithDistortion(intr, dist, rotateByQuat(q, op).Add(t))
+				res = append(res, px.X-corners[j].X, px.Y-corners[j].Y)
+			}
+		}
+		return res
+	}
+	params = levenbergMarquardt(residuals, params, 100)
+
+	final := residuals(params)
+	result := &IntrinsicCalibration{
+		Intrinsics: &PinholeCameraIntrinsics{
+			Width: width, Height: height,
+			Fx: params[0], Fy: params[1], Ppx: params[2], Ppy: params[3],
+		},
+		Distortion: &BrownConrady{
+			RadialK1: params[4], RadialK2: params[5], RadialK3: params[6],
+			TangentialP1: params[7], TangentialP2: params[8],
+		},
+		ViewErrors: make([]float64, len(views)),
+	}
+	perView := 2 * len(objectPoints)
+	total := 0.
+	for i := range views {
+		sse := 0.
+		for _, r := range final[i*perView : (i+1)*perView] {
+			sse += r * r
+		}
+		total += sse
+		result.ViewErrors[i] = math.Sqrt(sse / float64(len(objectPoints)))
+	}
+	result.ReprojectionError = math.Sqrt(total / float64(len(views)*len(objectPoints)))
+	if err := result.Intrinsics.CheckValid(); err != nil {
+		return nil, errors.Wrap(err, "calibration did not converge to valid intrinsics")
+	}
+	return result, nil
+}
+
+// intrinsicsFromHomographies solves for the camera matrix, assuming zero skew, from the constraints each
+// plane-to-image homography puts on the image of the absolute conic B = K^-T K^-1. Pixels are first
+// scaled to [-1, 1] to keep the linear system well conditioned.
+func intrinsicsFromHomographies(homographies []*mat.Dense, width, height int) (*mat.Dense, error) {
+	sx, sy := 2/float64(width), 2/float64(height)
+	norm := mat.NewDense(3, 3, []float64{sx, 0, -1, 0, sy, -1, 0, 0, 1})
+	v := func(h *mat.Dense, i, j int) []float64 {
+		return []float64{
+			h.At(0, i) * h.At(0, j),
+			h.At(0, i)*h.At(1, j) + h.At(1, i)*h.At(0, j),
+			h.At(1, i) * h.At(1, j),
+			h.At(2, i)*h.At(0, j) + h.At(0, i)*h.At(2, j),
+			h.At(2, i)*h.At(1, j) + h.At(1, i)*h.At(2, j),
+			h.At(2, i) * h.At(2, j),
+		}
+	}
+	system := mat.NewDense(2*len(homographies)+1, 6, nil)
+	for k, h := range homographies {
+		var hn mat.Dense
+		hn.Mul(norm, h)
+		hn.Scale(1/mat.Norm(&hn, 2), &hn)
+		v11, v12, v22 := v(&hn, 0, 0), v(&hn, 0, 1), v(&hn, 1, 1)
+		diff := make([]float64, 6)
+		for i := range diff {
+			diff[i] = v11[i] - v22[i]
+		}
+		system.SetRow(2*k, v12)
+		system.SetRow(2*k+1, diff)
+	}
+	// zero skew
+	system.SetRow(2*len(homographies), []float64{0, 1, 0, 0, 0, 0})
+
+	var svd mat.SVD
+	if ok := svd.Factorize(system, mat.SVDFull); !ok {
+		return nil, errors.New("failed to factorize intrinsic calibration system")
+	}
+	var vt mat.Dense
+	svd.VTo(&vt)
+	b := mat.Col(nil, 5, &vt)
+	b11, b12, b22, b13, b23, b33 := b[0], b[1], b[2], b[3], b[4], b[5]
+	den := b11*b22 - b12*b12
+	if den == 0 || b11 == 0 {
+		return nil, errors.New("degenerate views, the board must be seen from several different orientations")
+	}
+	v0 := (b12*b13 - b11*b23) / den
+	lambda := b33 - (b13*b13+v0*(b12*b13-b11*b23))/b11
+	alpha2, beta2 := lambda/b11, lambda*b11/den
+	if alpha2 <= 0 || beta2 <= 0 {
+		return nil, errors.New("degenerate views, the board must be seen from several different orientations")
+	}
+	alpha, beta := math.Sqrt(alpha2), math.Sqrt(beta2)
+	u0 := -b13 * alpha2 / lambda
+
+	// undo the pixel normalization
+	return mat.NewDense(3, 3, []float64{
+		alpha / sx, 0, (u0 + 1) / sx,
+		0, beta / sy, (v0 + 1) / sy,
+		0, 0, 1,
+	}), nil
+}
+
+// projectWithDistortion projects a point in the camera frame to a pixel through the distortion model.
+func projectWithDistortion(intrinsics *PinholeCameraIntrinsics, distortion Distorter, pt r3.Vector) r2.Point {
+	x, y := distortion.Transform(pt.X/pt.Z, pt.Y/pt.Z)
+	return r2.Point{x*intrinsics.Fx + intrinsics.Ppx, y*intrinsics.Fy + intrinsics.Ppy}
+}
+
+// levenbergMarquardt minimizes the sum of squares of the residuals, starting from params, using a forward
+// difference Jacobian. It returns the best parameters found.
+func levenbergMarquardt(residuals func([]float64) []float64, params []float64, maxIterations int) []float64 {
+	sumSq := func(r []float64) float64 {
+		s := 0.
+		for _, v := range r {
+			s += v * v
+		}
+		return s
+	}
+	p := make([]float64, len(params))
+	copy(p, params)
+	r := residuals(p)
+	cost := sumSq(r)
+	lambda := 1e-3
+	n := len(p)
+	for iter := 0; iter < maxIterations; iter++ {
+		jac := mat.NewDense(len(r), n, nil)
+		for j := 0; j < n; j++ {
+			step := 1e-6 * math.Max(math.Abs(p[j]), 1e-2)
+			orig := p[j]
+			p[j] = orig + step
+			rj := residuals(p)
+			p[j] = orig
+			for i := range rj {
+				jac.Set(i, j, (rj[i]-r[i])/step)
+			}
+		}
+		var jtj mat.Dense
+		jtj.Mul(jac.T(), jac)
+		var jtr mat.VecDense
+		jtr.MulVec(jac.T(), mat.NewVecDense(len(r), r))
+
+		improved := false
+		for attempt := 0; attempt < 10; attempt++ {
+			damped := mat.DenseCopyOf(&jtj)
+			for j := 0; j < n; j++ {
+				damped.Set(j, j, jtj.At(j, j)*(1+lambda)+1e-12)
+			}
+			var delta mat.VecDense
+			if err := delta.SolveVec(damped, &jtr); err != nil {
+				lambda *= 10
+				continue
+			}
+			candidate := make([]float64, n)
+			for j := range candidate {
+				candidate[j] = p[j] - delta.AtVec(j)
+			}
+			rc := residuals(candidate)
+			if c := sumSq(rc); c < cost {
+				converged := (cost-c) < 1e-12*cost || cost-c < 1e-16
+				p, r, cost = candidate, rc, c
+				lambda = math.Max(lambda/10, 1e-12)
+				improved = true
+				if converged {
+					return p
+				}
+				break
+			}
+			lambda *= 10
+		}
+		if !improved {
+			break
+		}
+	}
+	return p
+}
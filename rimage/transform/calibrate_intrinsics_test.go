@@ -0,0 +1,91 @@
+package transform
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/golang/geo/r2"
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+func calibrationViewPoses() []spatialmath.Pose {
+	return []spatialmath.Pose{
+		spatialmath.NewPoseFromOrientation(r3.Vector{-100, -60, 450}, &spatialmath.EulerAngles{Roll: 0.3, Pitch: -0.2, Yaw: 0.05}),
+		spatialmath.NewPoseFromOrientation(r3.Vector{-60, -80, 520}, &spatialmath.EulerAngles{Roll: -0.35, Pitch: 0.1, Yaw: -0.1}),
+		spatialmath.NewPoseFromOrientation(r3.Vector{-120, -40, 480}, &spatialmath.EulerAngles{Roll: 0.1, Pitch: 0.4, Yaw: 0.2}),
+		spatialmath.NewPoseFromOrientation(r3.Vector{-80, -70, 430}, &spatialmath.EulerAngles{Roll: -0.1, Pitch: -0.4, Yaw: -0.15}),
+		spatialmath.NewPoseFromOrientation(r3.Vector{-20, -100, 560}, &spatialmath.EulerAngles{Roll: 0.25, Pitch: 0.25, Yaw: 0.3}),
+		spatialmath.NewPoseFromOrientation(r3.Vector{-160, -10, 500}, &spatialmath.EulerAngles{Roll: -0.25, Pitch: -0.25, Yaw: -0.3}),
+	}
+}
+
+func TestCalibrateIntrinsicsFromPoints(t *testing.T) {
+	model := &PinholeCameraModel{
+		PinholeCameraIntrinsics: &PinholeCameraIntrinsics{Width: 640, Height: 480, Fx: 610, Fy: 605, Ppx: 322, Ppy: 236},
+		Distortion:              &BrownConrady{RadialK1: -0.12, RadialK2: 0.05, TangentialP1: 0.001, TangentialP2: -0.002},
+	}
+	rnd := rand.New(rand.NewSource(2))
+	var views [][]r2.Point
+	for _, pose := range calibrationViewPoses() {
+		corners := make([]r2.Point, 0, testCheckerboard.Rows*testCheckerboard.Cols)
+		for _, p := range testCheckerboard.ObjectPoints() {
+			c := spatialmath.Compose(pose, spatialmath.NewPoseFromPoint(p)).Point()
+			px := projectWithDistortion(model.PinholeCameraIntrinsics, model.Distortion, c)
+			corners = append(corners, px.Add(r2.Point{rnd.NormFloat64() * 0.05, rnd.NormFloat64() * 0.05}))
+		}
+		views = append(views, corners)
+	}
+	calib, err := CalibrateIntrinsics(testCheckerboard, views, 640, 480)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, calib.ReprojectionError, test.ShouldBeLessThan, 0.1)
+	test.That(t, calib.ViewErrors, test.ShouldHaveLength, len(views))
+	test.That(t, calib.Intrinsics.Width, test.ShouldEqual, 640)
+	test.That(t, calib.Intrinsics.Fx, test.ShouldAlmostEqual, 610, 2)
+	test.That(t, calib.Intrinsics.Fy, test.ShouldAlmostEqual, 605, 2)
+	test.That(t, calib.Intrinsics.Ppx, test.ShouldAlmostEqual, 322, 2)
+	test.That(t, calib.Intrinsics.Ppy, test.ShouldAlmostEqual, 236, 2)
+	test.That(t, calib.Distortion.RadialK1, test.ShouldAlmostEqual, -0.12, 0.02)
+	test.That(t, calib.Distortion.TangentialP1, test.ShouldAlmostEqual, 0.001, 0.001)
+	test.That(t, calib.Distortion.TangentialP2, test.ShouldAlmostEqual, -0.002, 0.001)
+
+	_, err = CalibrateIntrinsics(testCheckerboard, views[:2], 640, 480)
+	test.That(t, err, test.ShouldBeError)
+	_, err = CalibrateIntrinsics(testCheckerboard, [][]r2.Point{views[0], views[1], views[2][:4]}, 640, 480)
+	test.That(t, err, test.ShouldBeError)
+	_, err = CalibrateIntrinsics(testCheckerboard, views, 0, 480)
+	test.That(t, err, test.ShouldBeError)
+}
+
+func TestCalibrateIntrinsicsFromImages(t *testing.T) {
+	model := &PinholeCameraModel{
+		PinholeCameraIntrinsics: &PinholeCameraIntrinsics{Width: 640, Height: 480, Fx: 600, Fy: 600, Ppx: 318, Ppy: 242},
+		Distortion:              &BrownConrady{RadialK1: -0.08},
+	}
+	var views [][]r2.Point
+	for _, pose := range calibrationViewPoses() {
+		img := renderDistortedCheckerboard(model, testCheckerboard, pose)
+		corners, err := testCheckerboard.FindCorners(img)
+		test.That(t, err, test.ShouldBeNil)
+		views = append(views, corners)
+	}
+	calib, err := CalibrateIntrinsics(testCheckerboard, views, 640, 480)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, calib.ReprojectionError, test.ShouldBeLessThan, 0.3)
+	test.That(t, calib.Intrinsics.Fx, test.ShouldAlmostEqual, 600, 6)
+	test.That(t, calib.Intrinsics.Fy, test.ShouldAlmostEqual, 600, 6)
+	test.That(t, calib.Intrinsics.Ppx, test.ShouldAlmostEqual, 318, 6)
+	test.That(t, calib.Intrinsics.Ppy, test.ShouldAlmostEqual, 242, 6)
+	test.That(t, calib.Distortion.RadialK1, test.ShouldAlmostEqual, -0.08, 0.03)
+
+	// undistorting a distorted corner brings it back onto the pinhole projection
+	c := spatialmath.Compose(calibrationViewPoses()[0], spatialmath.NewPoseFromPoint(r3.Vector{100, 50, 0})).Point()
+	distorted := projectWithDistortion(model.PinholeCameraIntrinsics, model.Distortion, c)
+	undistorted := model.UndistortPoint(distorted)
+	test.That(t, undistorted.X, test.ShouldAlmostEqual, c.X/c.Z*600+318, 1e-6)
+	test.That(t, undistorted.Y, test.ShouldAlmostEqual, c.Y/c.Z*600+242, 1e-6)
+	test.That(t, math.IsNaN(undistorted.X), test.ShouldBeFalse)
+}
@@ -0,0 +1,386 @@
+package transform
+
+import (
+	"image"
+	"math"
+	"sort"
+
+	"github.com/golang/geo/r2"
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/utils"
+	"go.viam.com/rdk/vision/delaunay"
+)
+
+// ErrCheckerboardNotFound is returned when the requested checkerboard pattern cannot be located in an image.
+var ErrCheckerboardNotFound = errors.New("checkerboard pattern not found in image")
+
+// CheckerboardConfig describes a planar checkerboard calibration target. Rows and Cols are the number of
+// inner corners (where four squares meet) along each side of the board, and SquareSize is the side length
+// of one square in mm. Boards where Rows and Cols differ in parity (e.g. 6x9) have an unambiguous
+// orientation, which is required when the target pose must be consistent across views.
+type CheckerboardConfig struct {
+	Rows       int     `json:"rows"`
+	Cols       int     `json:"cols"`
+	SquareSize float64 `json:"square_size_mm"`
+}
+
+// CheckValid checks if the fields for CheckerboardConfig have valid inputs.
+func (cb *CheckerboardConfig) CheckValid() error {
+	if cb == nil {
+		return errors.New("checkerboard config does not exist")
+	}
+	if cb.Rows < 2 || cb.Cols < 2 {
+		return errors.Errorf("checkerboard needs at least 2x2 inner corners, got %dx%d", cb.Rows, cb.Cols)
+	}
+	if cb.SquareSize <= 0 {
+		return errors.Errorf("invalid checkerboard square size %v", cb.SquareSize)
+	}
+	return nil
+}
+
+// ObjectPoints returns the inner corners of the board in the board's own frame, in mm, in row major
+// order. The board lies in the Z=0 plane, with X along the columns and Y along the rows.
+func (cb *CheckerboardConfig) ObjectPoints() []r3.Vector {
+	pts := make([]r3.Vector, 0, cb.Rows*cb.Cols)
+	for r := 0; r < cb.Rows; r++ {
+		for c := 0; c < cb.Cols; c++ {
+			pts = append(pts, r3.Vector{float64(c) * cb.SquareSize, float64(r) * cb.SquareSize, 0})
+		}
+	}
+	return pts
+}
+
+// FindCorners locates the inner corners of the checkerboard in the image. See FindCheckerboardCorners.
+func (cb *CheckerboardConfig) FindCorners(img image.Image) ([]r2.Point, error) {
+	if err := cb.CheckValid(); err != nil {
+		return nil, err
+	}
+	return FindCheckerboardCorners(img, cb.Rows, cb.Cols)
+}
+
+// FindCheckerboardCorners finds the rows x cols inner corners of a checkerboard in an image, refined
+// to sub-pixel accuracy. The corners are returned in row major order, matching the order of
+// CheckerboardConfig.ObjectPoints. Corner (0,0) is chosen so that the square diagonally outside of
+// it is dark. The whole board should be visible in the image and be the dominant corner-like pattern.
+func FindCheckerboardCorners(img image.Image, rows, cols int) ([]r2.Point, error) {
+	if rows < 2 || cols < 2 {
+		return nil, errors.Errorf("checkerboard needs at least 2x2 inner corners, got %dx%d", rows, cols)
+	}
+	gray, err := blurredLuminance(img)
+	if err != nil {
+		return nil, err
+	}
+	candidates := saddleCandidates(gray, 4)
+	if len(candidates) < rows*cols {
+		return nil, errors.Wrapf(ErrCheckerboardNotFound, "only %d corner candidates for %dx%d board", len(candidates), rows, cols)
+	}
+	pts := make([]r2.Point, len(candidates))
+	for i, c := range candidates {
+		pts[i] = c.pt
+	}
+	// the strongest candidates are assumed to belong to the board
+	hullPts := make([]delaunay.Point, rows*cols)
+	for i, pt := range pts[:rows*cols] {
+		hullPts[i] = delaunay.Point(pt)
+	}
+	hull := make([]r2.Point, 0, len(hullPts))
+	for _, pt := range delaunay.ConvexHull(hullPts) {
+		hull = append(hull, r2.Point(pt))
+	}
+	quad := largestQuadrilateral(hull)
+	if quad == nil {
+		return nil, errors.Wrap(ErrCheckerboardNotFound, "could not find the outline of the board")
+	}
+
+	// try every assignment of the board corners to the outline and keep the ones that explain all
+	// the inner corners, preferring the one with a dark square outside of corner (0,0).
+	var best []r2.Point
+	bestDarkness := math.Inf(1)
+	for shift := 0; shift < 4; shift++ {
+		grid, h := matchGridToQuad(quad, shift, rows, cols, pts)
+		if grid == nil {
+			continue
+		}
+		outside := h.Apply(r2.Point{-0.5, -0.5})
+		darkness := grayBilinear(gray, outside.X, outside.Y)
+		if darkness < bestDarkness {
+			best = grid
+			bestDarkness = darkness
+		}
+	}
+	if best == nil {
+		return nil, errors.Wrapf(ErrCheckerboardNotFound, "could not match a %dx%d grid to the corners", rows, cols)
+	}
+
+	spacing := math.Inf(1)
+	for r := 0; r < rows; r++ {
+		for c := 0; c+1 < cols; c++ {
+			spacing = math.Min(spacing, best[r*cols+c].Sub(best[r*cols+c+1]).Norm())
+		}
+	}
+	halfWin := int(math.Max(2, math.Min(8, spacing/4)))
+	for i, pt := range best {
+		best[i] = refineCorner(gray, pt, halfWin)
+	}
+	return best, nil
+}
+
+// matchGridToQuad maps the four corners of a rows x cols grid onto the quadrilateral starting at the
+// given shift and looks for a corner candidate near every projected grid point. It returns the matched
+// points in row major order and the homography from grid coordinates to the image, or nil if any grid
+// point is unmatched.
+func matchGridToQuad(quad []r2.Point, shift, rows, cols int, candidates []r2.Point) ([]r2.Point, *Homography) {
+	gridCorners := []r2.Point{{0, 0}, {float64(cols - 1), 0}, {float64(cols - 1), float64(rows - 1)}, {0, float64(rows - 1)}}
+	imgCorners := make([]r2.Point, 4)
+	for i := range imgCorners {
+		imgCorners[i] = quad[(i+shift)%4]
+	}
+	h, err := EstimateLeastSquaresHomography(pointsToMat(gridCorners), pointsToMat(imgCorners))
+	if err != nil {
+		return nil, nil
+	}
+	gridPts := make([]r2.Point, 0, rows*cols)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			gridPts = append(gridPts, r2.Point{float64(c), float64(r)})
+		}
+	}
+	var matched []r2.Point
+	// refit once with every matched corner to absorb the error in the outline
+	for iter := 0; iter < 2; iter++ {
+		matched = make([]r2.Point, len(gridPts))
+		for i, g := range gridPts {
+			p := h.Apply(g)
+			right := h.Apply(r2.Point{g.X + 1, g.Y})
+			down := h.Apply(r2.Point{g.X, g.Y + 1})
+			tol := 0.3 * math.Min(p.Sub(right).Norm(), p.Sub(down).Norm())
+			nearest, dist := -1, math.Inf(1)
+			for j, cand := range candidates {
+				if d := p.Sub(cand).Norm(); d < dist {
+					nearest, dist = j, d
+				}
+			}
+			if nearest < 0 || dist > tol {
+				return nil, nil
+			}
+			matched[i] = candidates[nearest]
+		}
+		if h, err = EstimateLeastSquaresHomography(pointsToMat(gridPts), pointsToMat(matched)); err != nil {
+			return nil, nil
+		}
+	}
+	return matched, h
+}
+
+// cornerCandidate is a possible inner corner of a checkerboard and the strength of its saddle response.
+type cornerCandidate struct {
+	pt       r2.Point
+	response float64
+}
+
+// checkerboardBlurPasses is how many times the image is smoothed by the 3x3 gaussian kernel before
+// looking for corners, which amounts to a gaussian blur with a sigma of about 1.4 pixels.
+const checkerboardBlurPasses = 4
+
+// blurredLuminance returns the luminance of the image, between 0 and 255, smoothed by the gaussian kernel.
+func blurredLuminance(img image.Image) (*mat.Dense, error) {
+	gray := rimage.ConvertColorImageToLuminanceFloat(rimage.ConvertImage(img))
+	kernel := rimage.GetGaussian3()
+	var err error
+	for i := 0; i < checkerboardBlurPasses; i++ {
+		if gray, err = rimage.ConvolveGrayFloat64(gray, kernel.Normalize()); err != nil {
+			return nil, err
+		}
+	}
+	return gray, nil
+}
+
+// grayAt returns the intensity of the image at a pixel, clamped at the borders.
+func grayAt(gray *mat.Dense, x, y int) float64 {
+	h, w := gray.Dims()
+	x, y = int(utils.Clamp(float64(x), 0, float64(w-1))), int(utils.Clamp(float64(y), 0, float64(h-1)))
+	return gray.At(y, x)
+}
+
+// grayBilinear returns the interpolated intensity of the image at a sub-pixel location, clamped at the borders.
+func grayBilinear(gray *mat.Dense, x, y float64) float64 {
+	x0, y0 := math.Floor(x), math.Floor(y)
+	dx, dy := x-x0, y-y0
+	ix, iy := int(x0), int(y0)
+	return (1-dx)*(1-dy)*grayAt(gray, ix, iy) + dx*(1-dy)*grayAt(gray, ix+1, iy) +
+		(1-dx)*dy*grayAt(gray, ix, iy+1) + dx*dy*grayAt(gray, ix+1, iy+1)
+}
+
+// saddleCandidates finds the local maxima of the saddle response Ixy^2 - Ixx*Iyy of the image that
+// look like the meeting point of four alternating squares, sorted strongest first.
+func saddleCandidates(gray *mat.Dense, ringRadius int) []cornerCandidate {
+	height, width := gray.Dims()
+	response := make([]float64, width*height)
+	maxResponse := 0.
+	for y := 1; y < height-1; y++ {
+		for x := 1; x < width-1; x++ {
+			c := grayAt(gray, x, y)
+			ixx := grayAt(gray, x+1, y) - 2*c + grayAt(gray, x-1, y)
+			iyy := grayAt(gray, x, y+1) - 2*c + grayAt(gray, x, y-1)
+			ixy := (grayAt(gray, x+1, y+1) - grayAt(gray, x+1, y-1) - grayAt(gray, x-1, y+1) + grayAt(gray, x-1, y-1)) / 4
+			r := ixy*ixy - ixx*iyy
+			if r > 0 {
+				response[y*width+x] = r
+				maxResponse = math.Max(maxResponse, r)
+			}
+		}
+	}
+	if maxResponse == 0 {
+		return nil
+	}
+	threshold := 0.01 * maxResponse
+	var candidates []cornerCandidate
+	for y := ringRadius; y < height-ringRadius; y++ {
+		for x := ringRadius; x < width-ringRadius; x++ {
+			r := response[y*width+x]
+			if r < threshold {
+				continue
+			}
+			isMax := true
+			for dy := -ringRadius; dy <= ringRadius && isMax; dy++ {
+				for dx := -ringRadius; dx <= ringRadius; dx++ {
+					other := response[(y+dy)*width+x+dx]
+					// break ties towards the top left so plateaus produce a single candidate
+					if other > r || (other == r && (dy < 0 || (dy == 0 && dx < 0))) {
+						isMax = false
+						break
+					}
+				}
+			}
+			if isMax && isXCorner(gray, x, y, ringRadius) {
+				candidates = append(candidates, cornerCandidate{r2.Point{float64(x), float64(y)}, r})
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].response > candidates[j].response })
+	return candidates
+}
+
+// isXCorner samples a ring around the point and checks that it crosses exactly four dark/light
+// boundaries, which separates the inner corners of a checkerboard from L-shaped outer corners and edges.
+func isXCorner(gray *mat.Dense, x, y, radius int) bool {
+	const samples = 32
+	ring := make([]float64, samples)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i := range ring {
+		theta := 2 * math.Pi * float64(i) / samples
+		ring[i] = grayBilinear(gray, float64(x)+float64(radius)*math.Cos(theta), float64(y)+float64(radius)*math.Sin(theta))
+		lo, hi = math.Min(lo, ring[i]), math.Max(hi, ring[i])
+	}
+	if hi-lo < 25 {
+		return false
+	}
+	mid, hysteresis := (lo+hi)/2, (hi-lo)/6
+	// find a starting state that is clearly dark or light
+	state, start := 0, 0
+	for i, v := range ring {
+		if v > mid+hysteresis {
+			state, start = 1, i
+			break
+		} else if v < mid-hysteresis {
+			state, start = -1, i
+			break
+		}
+	}
+	transitions := 0
+	for i := start; i <= start+samples; i++ {
+		v := ring[i%samples]
+		switch {
+		case state < 0 && v > mid+hysteresis:
+			state = 1
+			transitions++
+		case state > 0 && v < mid-hysteresis:
+			state = -1
+			transitions++
+		}
+	}
+	return transitions == 4
+}
+
+// refineCorner moves a corner estimate to the point where the image gradients in the surrounding
+// window are orthogonal to the vector towards the corner, the approach of OpenCV's cornerSubPix.
+func refineCorner(gray *mat.Dense, pt r2.Point, halfWin int) r2.Point {
+	sigma := float64(halfWin)
+	for iter := 0; iter < 20; iter++ {
+		cx, cy := int(math.Round(pt.X)), int(math.Round(pt.Y))
+		var a, b, c, bx, by float64
+		for dy := -halfWin; dy <= halfWin; dy++ {
+			for dx := -halfWin; dx <= halfWin; dx++ {
+				x, y := cx+dx, cy+dy
+				gx := (grayAt(gray, x+1, y) - grayAt(gray, x-1, y)) / 2
+				gy := (grayAt(gray, x, y+1) - grayAt(gray, x, y-1)) / 2
+				w := math.Exp(-float64(dx*dx+dy*dy) / (2 * sigma * sigma))
+				gxx, gxy, gyy := w*gx*gx, w*gx*gy, w*gy*gy
+				a += gxx
+				b += gxy
+				c += gyy
+				bx += gxx*float64(x) + gxy*float64(y)
+				by += gxy*float64(x) + gyy*float64(y)
+			}
+		}
+		det := a*c - b*b
+		if math.Abs(det) < 1e-12 {
+			return pt
+		}
+		next := r2.Point{(c*bx - b*by) / det, (a*by - b*bx) / det}
+		if next.Sub(pt).Norm() > float64(halfWin) {
+			// the estimate ran away, keep the previous one
+			return pt
+		}
+		converged := next.Sub(pt).Norm() < 0.01
+		pt = next
+		if converged {
+			break
+		}
+	}
+	return pt
+}
+
+// largestQuadrilateral picks the four hull vertices spanning the largest area, keeping the hull order.
+func largestQuadrilateral(hull []r2.Point) []r2.Point {
+	n := len(hull)
+	if n < 4 {
+		return nil
+	}
+	area := func(q ...r2.Point) float64 {
+		s := 0.
+		for i := range q {
+			s += q[i].Cross(q[(i+1)%len(q)])
+		}
+		return math.Abs(s) / 2
+	}
+	var best []r2.Point
+	bestArea := 0.
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			for k := j + 1; k < n; k++ {
+				for l := k + 1; l < n; l++ {
+					if a := area(hull[i], hull[j], hull[k], hull[l]); a > bestArea {
+						bestArea = a
+						best = []r2.Point{hull[i], hull[j], hull[k], hull[l]}
+					}
+				}
+			}
+		}
+	}
+	return best
+}
+
+// pointsToMat returns the points as the rows of an n x 2 matrix.
+func pointsToMat(pts []r2.Point) *mat.Dense {
+	m := mat.NewDense(len(pts), 2, nil)
+	for i, p := range pts {
+		m.Set(i, 0, p.X)
+		m.Set(i, 1, p.Y)
+	}
+	return m
+}
@@ -0,0 +1,108 @@
+package transform
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r2"
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+var testCheckerboard = &CheckerboardConfig{Rows: 6, Cols: 9, SquareSize: 25}
+
+var testCalibIntrinsics = &PinholeCameraIntrinsics{Width: 640, Height: 480, Fx: 600, Fy: 600, Ppx: 320, Ppy: 240}
+
+// renderCheckerboard draws the board, with a one square white margin, as seen by a pinhole camera
+// from the given board pose in the camera frame. Pixels are supersampled to anti-alias edges.
+func renderCheckerboard(intrinsics *PinholeCameraIntrinsics, cb *CheckerboardConfig, boardPose spatialmath.Pose) *image.Gray {
+	return renderDistortedCheckerboard(&PinholeCameraModel{intrinsics, &NoDistortion{}}, cb, boardPose)
+}
+
+// renderDistortedCheckerboard draws the board as seen through a camera with lens distortion.
+func renderDistortedCheckerboard(model *PinholeCameraModel, cb *CheckerboardConfig, boardPose spatialmath.Pose) *image.Gray {
+	intrinsics := model.PinholeCameraIntrinsics
+	img := image.NewGray(image.Rect(0, 0, intrinsics.Width, intrinsics.Height))
+	q := boardPose.Orientation().Quaternion()
+	// the rows of the spatialmath rotation matrix are the rotated axes of the board
+	rot := spatialmath.QuatToRotationMatrix(q)
+	origin := boardPose.Point()
+	normal := rot.Row(2)
+	xAxis := rot.Row(0)
+	yAxis := rot.Row(1)
+	const sub = 4
+	for v := 0; v < intrinsics.Height; v++ {
+		for u := 0; u < intrinsics.Width; u++ {
+			sum := 0.
+			for sv := 0; sv < sub; sv++ {
+				for su := 0; su < sub; su++ {
+					px := model.UndistortPoint(r2.Point{
+						float64(u) + (float64(su)+0.5)/sub - 0.5,
+						float64(v) + (float64(sv)+0.5)/sub - 0.5,
+					})
+					ray := r3.Vector{(px.X - intrinsics.Ppx) / intrinsics.Fx, (px.Y - intrinsics.Ppy) / intrinsics.Fy, 1}
+					s := origin.Dot(normal) / ray.Dot(normal)
+					p := ray.Mul(s).Sub(origin)
+					bx, by := p.Dot(xAxis)/cb.SquareSize, p.Dot(yAxis)/cb.SquareSize
+					sum += checkerboardIntensity(cb, bx, by)
+				}
+			}
+			img.SetGray(u, v, color.Gray{uint8(40 + 180*sum/(sub*sub))})
+		}
+	}
+	return img
+}
+
+// checkerboardIntensity is 1 for white and 0 for black at board coordinates given in squares,
+// with the first inner corner at the origin and the square outside of it black.
+func checkerboardIntensity(cb *CheckerboardConfig, bx, by float64) float64 {
+	if bx < -2 || by < -2 || bx > float64(cb.Cols)+1 || by > float64(cb.Rows)+1 {
+		return 0.5
+	}
+	if bx < -1 || by < -1 || bx > float64(cb.Cols) || by > float64(cb.Rows) {
+		return 1
+	}
+	if (int(math.Floor(bx))+int(math.Floor(by)))%2 == 0 {
+		return 0
+	}
+	return 1
+}
+
+func projectPoints(intrinsics *PinholeCameraIntrinsics, pose spatialmath.Pose, pts []r3.Vector) []r2.Point {
+	out := make([]r2.Point, len(pts))
+	for i, p := range pts {
+		c := spatialmath.Compose(pose, spatialmath.NewPoseFromPoint(p)).Point()
+		out[i] = r2.Point{c.X/c.Z*intrinsics.Fx + intrinsics.Ppx, c.Y/c.Z*intrinsics.Fy + intrinsics.Ppy}
+	}
+	return out
+}
+
+func TestFindCheckerboardCorners(t *testing.T) {
+	poses := []spatialmath.Pose{
+		spatialmath.NewPoseFromOrientation(r3.Vector{-100, -60, 450}, &spatialmath.EulerAngles{Roll: 0.1, Pitch: -0.2, Yaw: 0.05}),
+		spatialmath.NewPoseFromOrientation(r3.Vector{20, -90, 500}, &spatialmath.EulerAngles{Roll: -0.3, Pitch: 0.2, Yaw: 1.2}),
+		// upside down board, corner (0,0) must still be the one next to the dark outer square
+		spatialmath.NewPoseFromOrientation(r3.Vector{100, 60, 480}, &spatialmath.EulerAngles{Roll: 0.2, Pitch: 0.1, Yaw: math.Pi}),
+	}
+	for _, pose := range poses {
+		img := renderCheckerboard(testCalibIntrinsics, testCheckerboard, pose)
+		expected := projectPoints(testCalibIntrinsics, pose, testCheckerboard.ObjectPoints())
+		corners, err := testCheckerboard.FindCorners(img)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, corners, test.ShouldHaveLength, len(expected))
+		for i := range corners {
+			test.That(t, corners[i].Sub(expected[i]).Norm(), test.ShouldBeLessThan, 0.3)
+		}
+	}
+
+	blank := image.NewGray(image.Rect(0, 0, 100, 100))
+	_, err := FindCheckerboardCorners(blank, 6, 9)
+	test.That(t, err, test.ShouldBeError)
+
+	_, err = (&CheckerboardConfig{Rows: 1, Cols: 9, SquareSize: 10}).FindCorners(blank)
+	test.That(t, err, test.ShouldBeError)
+}
@@ -0,0 +1,196 @@
+// Moves an arm through a list of joint positions while a camera looks at a checkerboard, and solves
+// the hand-eye calibration (AX=XB) between the arm and the camera. The result is printed as a frame
+// config for the camera, along with the residual error of the solution.
+// For an eye-in-hand setup the camera is mounted on the arm and the board is fixed; for an eye-to-hand
+// setup the camera is fixed and the board is held by the arm. Corners are undistorted with the camera's
+// distortion parameters, if any.
+// rimage/transform/data/example_hand_eye_calib.json has an example input file.
+// $./hand_eye_calibration -conf=/path/to/input/file
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+	pb "go.viam.com/api/component/arm/v1"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/robot/client"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// handEyeConfig describes the robot and stations used for a hand-eye calibration.
+type handEyeConfig struct {
+	Address        string                             `json:"address"`
+	Arm            string                             `json:"arm"`
+	Camera         string                             `json:"camera"`
+	Setup          transform.HandEyeSetup             `json:"setup"`
+	Checkerboard   *transform.CheckerboardConfig      `json:"checkerboard"`
+	Intrinsics     *transform.PinholeCameraIntrinsics `json:"intrinsic_parameters,omitempty"`
+	Distortion     *transform.BrownConrady            `json:"distortion_parameters,omitempty"`
+	JointPositions [][]float64                        `json:"joint_positions"`
+	SettleTimeMs   int                                `json:"settle_time_ms"`
+}
+
+func main() {
+	confPtr := flag.String("conf", "", "path of configuration for hand-eye calibration")
+	flag.Parse()
+	logger := golog.NewLogger("hand_eye_calibration")
+	ctx := context.Background()
+
+	cfg, err := readConfig(*confPtr)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	robotClient, err := client.New(ctx, cfg.Address, logger)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	defer utils.UncheckedErrorFunc(func() error { return robotClient.Close(ctx) })
+
+	frame, res, err := calibrate(ctx, robotClient, cfg, logger)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	logger.Infof("rotation error: %.3f deg, translation error: %.3f mm", res.RotationError, res.TranslationError)
+	out, err := json.MarshalIndent(map[string]interface{}{cfg.Camera: frame}, "", "  ")
+	if err != nil {
+		logger.Fatal(err)
+	}
+	fmt.Println(string(out))
+}
+
+// calibrate visits every station, collects the arm and target poses, and returns the frame config of the camera.
+func calibrate(
+	ctx context.Context,
+	r robot.Robot,
+	cfg *handEyeConfig,
+	logger golog.Logger,
+) (*config.Frame, *transform.HandEyeResult, error) {
+	theArm, err := arm.FromRobot(r, cfg.Arm)
+	if err != nil {
+		return nil, nil, err
+	}
+	cam, err := camera.FromRobot(r, cfg.Camera)
+	if err != nil {
+		return nil, nil, err
+	}
+	props, err := cam.Properties(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	model := &transform.PinholeCameraModel{PinholeCameraIntrinsics: cfg.Intrinsics, Distortion: props.DistortionParams}
+	if model.PinholeCameraIntrinsics == nil {
+		model.PinholeCameraIntrinsics = props.IntrinsicParams
+	}
+	if cfg.Distortion != nil {
+		model.Distortion = cfg.Distortion
+	}
+	if err := model.CheckValid(); err != nil {
+		return nil, nil, errors.Wrap(err, "camera intrinsics are required for hand-eye calibration")
+	}
+	objectPoints := cfg.Checkerboard.ObjectPoints()
+
+	samples := make([]transform.HandEyeSample, 0, len(cfg.JointPositions))
+	for i, joints := range cfg.JointPositions {
+		if err := theArm.MoveToJointPositions(ctx, &pb.JointPositions{Values: joints}, nil); err != nil {
+			return nil, nil, errors.Wrapf(err, "station %d", i)
+		}
+		if !utils.SelectContextOrWait(ctx, time.Duration(cfg.SettleTimeMs)*time.Millisecond) {
+			return nil, nil, ctx.Err()
+		}
+		armPose, err := theArm.EndPosition(ctx, nil)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "station %d", i)
+		}
+		img, release, err := camera.ReadImage(ctx, cam)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "station %d", i)
+		}
+		corners, err := cfg.Checkerboard.FindCorners(img)
+		release()
+		if err != nil {
+			logger.Warnf("skipping station %d: %v", i, err)
+			continue
+		}
+		for j, c := range corners {
+			corners[j] = model.UndistortPoint(c)
+		}
+		targetPose, rms, err := transform.EstimatePlanarTargetPose(model.PinholeCameraIntrinsics, objectPoints, corners)
+		if err != nil {
+			logger.Warnf("skipping station %d: %v", i, err)
+			continue
+		}
+		logger.Debugf("station %d: reprojection error %.3f px", i, rms)
+		samples = append(samples, transform.HandEyeSample{
+			ArmPose:    spatialmath.NewPoseFromProtobuf(armPose),
+			TargetPose: targetPose,
+		})
+	}
+
+	res, err := transform.SolveHandEye(samples, cfg.Setup)
+	if err != nil {
+		return nil, nil, err
+	}
+	pose, parent := res.Pose, cfg.Arm
+	if cfg.Setup == transform.EyeToHand {
+		// the solution is relative to the arm base, which sits at the arm's own frame offset
+		parts, err := r.FrameSystemConfig(ctx, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		var armFrame *config.Frame
+		for _, part := range parts {
+			if part.Name == cfg.Arm {
+				armFrame = part.FrameConfig
+				break
+			}
+		}
+		if armFrame == nil {
+			return nil, nil, errors.Errorf("arm %q is not part of the frame system", cfg.Arm)
+		}
+		pose, parent = spatialmath.Compose(armFrame.Pose(), pose), armFrame.Parent
+	}
+	return &config.Frame{
+		Parent:      parent,
+		Translation: pose.Point(),
+		Orientation: pose.Orientation(),
+	}, res, nil
+}
+
+func readConfig(cfgPath string) (*handEyeConfig, error) {
+	f, err := os.Open(cfgPath) //nolint:gosec
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("path=%q", cfgPath))
+	}
+	defer utils.UncheckedErrorFunc(f.Close)
+
+	byteJSON, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := &handEyeConfig{}
+	if err := json.Unmarshal(byteJSON, conf); err != nil {
+		return nil, errors.Wrap(err, "error parsing byte array ")
+	}
+	if err := conf.Checkerboard.CheckValid(); err != nil {
+		return nil, err
+	}
+	if conf.Setup == "" {
+		conf.Setup = transform.EyeInHand
+	}
+	return conf, nil
+}
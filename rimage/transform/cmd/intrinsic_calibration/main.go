@@ -0,0 +1,149 @@
+// Estimates the intrinsic parameters and Brown-Conrady distortion of a camera from images of a checkerboard.
+// The images either come from files, or are captured live from a camera on a running robot. The result is
+// written in the intrinsic_parameters/distortion_parameters format of the camera config.
+// Move the board around between captures so it is seen at several different orientations and covers
+// the whole image.
+// $./intrinsic_calibration -rows=6 -cols=9 -square=25 -images="/path/to/images/*.png" -out=intrinsics.json
+// $./intrinsic_calibration -rows=6 -cols=9 -square=25 -address=localhost:8080 -camera=cam1 -frames=20
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r2"
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/robot/client"
+)
+
+func main() {
+	rows := flag.Int("rows", 6, "number of inner corners along the rows of the checkerboard")
+	cols := flag.Int("cols", 9, "number of inner corners along the columns of the checkerboard")
+	square := flag.Float64("square", 25, "side length of a checkerboard square in mm")
+	images := flag.String("images", "", "glob of checkerboard image files")
+	address := flag.String("address", "", "address of the robot to capture images from")
+	cameraName := flag.String("camera", "", "name of the camera to capture images from")
+	frames := flag.Int("frames", 15, "number of checkerboard frames to capture from the camera")
+	interval := flag.Duration("interval", 2*time.Second, "time between captures from the camera")
+	out := flag.String("out", "", "path to write the calibration to, defaults to stdout")
+	flag.Parse()
+	logger := golog.NewLogger("intrinsic_calibration")
+	ctx := context.Background()
+
+	cb := &transform.CheckerboardConfig{Rows: *rows, Cols: *cols, SquareSize: *square}
+	var views [][]r2.Point
+	var bounds image.Rectangle
+	var err error
+	switch {
+	case *images != "":
+		views, bounds, err = cornersFromFiles(cb, *images, logger)
+	case *address != "" && *cameraName != "":
+		views, bounds, err = cornersFromCamera(ctx, cb, *address, *cameraName, *frames, *interval, logger)
+	default:
+		err = errors.New("either -images or -address and -camera must be set")
+	}
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	calib, err := transform.CalibrateIntrinsics(cb, views, bounds.Dx(), bounds.Dy())
+	if err != nil {
+		logger.Fatal(err)
+	}
+	logger.Infof("reprojection error: %.3f px over %d views", calib.ReprojectionError, len(views))
+	b, err := json.MarshalIndent(calib, "", "  ")
+	if err != nil {
+		logger.Fatal(err)
+	}
+	if *out == "" {
+		fmt.Println(string(b))
+		return
+	}
+	if err := os.WriteFile(*out, b, 0o600); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+// cornersFromFiles finds the checkerboard in every image matching the glob. Images where the board is
+// not found are skipped, and all images must be the same size.
+func cornersFromFiles(cb *transform.CheckerboardConfig, glob string, logger golog.Logger) ([][]r2.Point, image.Rectangle, error) {
+	files, err := filepath.Glob(glob)
+	if err != nil {
+		return nil, image.Rectangle{}, err
+	}
+	var views [][]r2.Point
+	var bounds image.Rectangle
+	for _, fn := range files {
+		img, err := rimage.NewImageFromFile(fn)
+		if err != nil {
+			return nil, image.Rectangle{}, err
+		}
+		if bounds.Empty() {
+			bounds = img.Bounds()
+		} else if img.Bounds().Size() != bounds.Size() {
+			return nil, image.Rectangle{}, errors.Errorf("image %q is %v, expected %v", fn, img.Bounds().Size(), bounds.Size())
+		}
+		corners, err := cb.FindCorners(img)
+		if err != nil {
+			logger.Warnf("skipping %q: %v", fn, err)
+			continue
+		}
+		views = append(views, corners)
+	}
+	return views, bounds, nil
+}
+
+// cornersFromCamera captures frames from a camera on a robot until the checkerboard has been found in
+// the requested number of them.
+func cornersFromCamera(
+	ctx context.Context,
+	cb *transform.CheckerboardConfig,
+	address, cameraName string,
+	frames int,
+	interval time.Duration,
+	logger golog.Logger,
+) ([][]r2.Point, image.Rectangle, error) {
+	robotClient, err := client.New(ctx, address, logger)
+	if err != nil {
+		return nil, image.Rectangle{}, err
+	}
+	defer utils.UncheckedErrorFunc(func() error { return robotClient.Close(ctx) })
+	cam, err := camera.FromRobot(robotClient, cameraName)
+	if err != nil {
+		return nil, image.Rectangle{}, err
+	}
+
+	var views [][]r2.Point
+	var bounds image.Rectangle
+	for len(views) < frames {
+		if !utils.SelectContextOrWait(ctx, interval) {
+			return nil, image.Rectangle{}, ctx.Err()
+		}
+		img, release, err := camera.ReadImage(ctx, cam)
+		if err != nil {
+			return nil, image.Rectangle{}, err
+		}
+		bounds = img.Bounds()
+		corners, err := cb.FindCorners(img)
+		release()
+		if err != nil {
+			logger.Infof("checkerboard not found, move it fully into view: %v", err)
+			continue
+		}
+		views = append(views, corners)
+		logger.Infof("captured frame %d/%d, move the board to a new orientation", len(views), frames)
+	}
+	return views, bounds, nil
+}
@@ -210,7 +210,9 @@ func EstimateHomographyRANSAC(pts1, pts2 []r2.Point, thresh float64, nMaxIterati
 	return &Homography{finalH}, maxInliers, nil
 }
 
-// EstimateLeastSquaresHomography estimates an homography from 2 sets of corresponding points.
+// EstimateLeastSquaresHomography estimates an homography from 2 sets of corresponding points. The
+// homography maps the points of pts1 onto those of pts2. It is solved for on the points normalized
+// by N1 and N2, so it maps N1*pts1 onto N2*pts2 and is returned as N2^-1 * Hn * N1.
 func EstimateLeastSquaresHomography(pts1, pts2 *mat.Dense) (*Homography, error) {
 	nPoints1, _ := pts1.Dims()
 	if nPoints1 < 4 {
@@ -269,18 +271,13 @@ func EstimateLeastSquaresHomography(pts1, pts2 *mat.Dense) (*Homography, error)
 	var l mat.VecDense
 	l.CloneFromVec(L)
 	H := mat.NewDense(3, 3, l.RawVector().Data)
-	invNorm1 := mat.NewDense(3, 3, nil)
-	err := invNorm1.Inverse(normalizationMat1)
-	if err != nil {
-		return nil, err
-	}
 	invNorm2 := mat.NewDense(3, 3, nil)
-	err = invNorm2.Inverse(normalizationMat2)
+	err := invNorm2.Inverse(normalizationMat2)
 	if err != nil {
 		return nil, err
 	}
-	m1.Mul(normalizationMat1, H)
-	m2.Mul(&m1, invNorm2)
+	m1.Mul(invNorm2, H)
+	m2.Mul(&m1, normalizationMat1)
 	m3.Scale(1./m2.At(2, 2), &m2)
 
 	return &Homography{&m3}, nil
@@ -104,9 +104,34 @@ func TestEstimateLeastSquaresHomography(t *testing.T) {
 	test.That(t, estH2.At(0, 1), test.ShouldBeLessThanOrEqualTo, 0.001)
 	test.That(t, estH2.At(1, 0), test.ShouldBeLessThanOrEqualTo, 0.001)
 	test.That(t, estH2.At(1, 1), test.ShouldAlmostEqual, 1.0, 0.001)
-	// check that translation terms are close to sqrt(2) / 4. (from homography decomposition formula)
-	test.That(t, estH2.At(0, 2), test.ShouldAlmostEqual, 0.3535533905932738, 0.01)
-	test.That(t, estH2.At(1, 2), test.ShouldAlmostEqual, 0.3535533905932738, 0.01)
+	// check that translation terms are the translation of the points
+	test.That(t, estH2.At(0, 2), test.ShouldAlmostEqual, 2, 0.01)
+	test.That(t, estH2.At(1, 2), test.ShouldAlmostEqual, 2, 0.01)
 	// test that translation terms are equal tx = ty
 	test.That(t, estH2.At(1, 2), test.ShouldAlmostEqual, estH2.At(0, 2), 0.01)
 }
+
+func TestEstimateLeastSquaresHomographyMapsPoints(t *testing.T) {
+	// a homography with rotation, scale, translation and perspective terms
+	h := mat.NewDense(3, 3, []float64{
+		0.9, -0.2, 30,
+		0.15, 1.1, -12,
+		0.0004, -0.0003, 1,
+	})
+	x := make([]float64, 5)
+	floats.Span(x, 0, 200)
+	grid := utils.Single(2, x)
+	pts1 := mat.NewDense(len(grid), 2, nil)
+	pts2 := mat.NewDense(len(grid), 2, nil)
+	for i, pt := range grid {
+		pts1.SetRow(i, pt)
+		var p mat.VecDense
+		p.MulVec(h, mat.NewVecDense(3, []float64{pt[0], pt[1], 1}))
+		pts2.SetRow(i, []float64{p.AtVec(0) / p.AtVec(2), p.AtVec(1) / p.AtVec(2)})
+	}
+	estH, err := EstimateLeastSquaresHomography(pts1, pts2)
+	test.That(t, err, test.ShouldBeNil)
+	// denormalizing as N1 * Hn * N2^-1 instead of N2^-1 * Hn * N1 breaks as soon as the homography
+	// is more than a rotation around the origin
+	test.That(t, mat.EqualApprox(estH.matrix, h, 1e-6), test.ShouldBeTrue)
+}
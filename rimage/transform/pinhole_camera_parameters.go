@@ -46,6 +46,26 @@ func (params *PinholeCameraModel) DistortionMap() func(u, v float64) (float64, f
 	}
 }
 
+// UndistortPoint maps a pixel of the distorted image to where it would be in the undistorted image, by
+// iteratively inverting the distortion model.
+func (params *PinholeCameraModel) UndistortPoint(pt r2.Point) r2.Point {
+	if params.Distortion == nil {
+		return pt
+	}
+	xd := (pt.X - params.Ppx) / params.Fx
+	yd := (pt.Y - params.Ppy) / params.Fy
+	x, y := xd, yd
+	for i := 0; i < 20; i++ {
+		dx, dy := params.Distortion.Transform(x, y)
+		ex, ey := xd-dx, yd-dy
+		x, y = x+ex, y+ey
+		if ex*ex+ey*ey < 1e-20 {
+			break
+		}
+	}
+	return r2.Point{x*params.Fx + params.Ppx, y*params.Fy + params.Ppy}
+}
+
 // UndistortImage takes an input image and creates a new image the same size with the same camera parameters
 // as the original image, but undistorted according to the distortion model in PinholeCameraModel. A bilinear
 // interpolation is used to interpolate values between image pixels.
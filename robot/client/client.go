@@ -27,12 +27,15 @@ import (
 	"go.viam.com/rdk/discovery"
 	"go.viam.com/rdk/grpc"
 	"go.viam.com/rdk/operation"
+	adminpb "go.viam.com/rdk/proto/api/robot/v1"
 	rprotoutils "go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/registry"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
 	framesystemparts "go.viam.com/rdk/robot/framesystem/parts"
+	"go.viam.com/rdk/robot/journal"
+	grpcserver "go.viam.com/rdk/robot/server"
 )
 
 var (
@@ -50,6 +53,7 @@ type RobotClient struct {
 	address         string
 	conn            rpc.ClientConn
 	client          pb.RobotServiceClient
+	adminClient     adminpb.AdminServiceClient
 	refClient       *grpcreflect.Client
 	dialOptions     []rpc.DialOption
 	resourceClients map[resource.Name]interface{}
@@ -189,6 +193,7 @@ func (rc *RobotClient) connect(ctx context.Context) error {
 
 	rc.conn = conn
 	rc.client = client
+	rc.adminClient = adminpb.NewAdminServiceClient(conn)
 	rc.refClient = refClient
 	rc.connected = true
 	if len(rc.resourceClients) != 0 {
@@ -667,6 +672,65 @@ func (rc *RobotClient) Status(ctx context.Context, resourceNames []resource.Name
 	return statuses, nil
 }
 
+// ValidateConfig validates the given unprocessed config against the remote robot and returns the
+// changes reconfiguring the robot with it would make, without applying it.
+func (rc *RobotClient) ValidateConfig(ctx context.Context, cfg *config.Config) (*robot.ReconfigurationPlan, error) {
+	cfgpb, err := grpcserver.ConfigToProto(cfg)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := rc.adminClient.ValidateConfig(ctx, &adminpb.ValidateConfigRequest{Config: cfgpb})
+	if err != nil {
+		return nil, err
+	}
+	return &robot.ReconfigurationPlan{
+		Added:      resp.Added,
+		Modified:   resp.Modified,
+		Removed:    resp.Removed,
+		BuildOrder: resp.BuildOrder,
+		Warnings:   resp.Warnings,
+		Errors:     resp.Errors,
+	}, nil
+}
+
+// Health returns the health of the local resources of the remote robot of the given names, or of
+// all of them if no names are given.
+func (rc *RobotClient) Health(ctx context.Context, resourceNames []resource.Name) ([]robot.ResourceHealth, error) {
+	names := make([]string, 0, len(resourceNames))
+	for _, name := range resourceNames {
+		names = append(names, name.String())
+	}
+	resp, err := rc.adminClient.GetHealth(ctx, &adminpb.GetHealthRequest{Names: names})
+	if err != nil {
+		return nil, err
+	}
+	healths := make([]robot.ResourceHealth, 0, len(resp.Resources))
+	for _, h := range resp.Resources {
+		healths = append(healths, robot.ResourceHealth{
+			Name:      h.Name,
+			State:     robot.HealthState(h.State),
+			Since:     h.Since,
+			LastError: h.LastError,
+			Retries:   int(h.Retries),
+			NextRetry: h.NextRetry,
+		})
+	}
+	return healths, nil
+}
+
+// Events returns the events of the journal of the remote robot matching the filter, oldest first.
+func (rc *RobotClient) Events(ctx context.Context, filter journal.Filter) ([]journal.Event, error) {
+	resp, err := rc.adminClient.ListEvents(ctx, grpcserver.FilterToProto(filter))
+	if err != nil {
+		return nil, err
+	}
+	events := make([]journal.Event, 0, len(resp.Events))
+	for _, e := range resp.Events {
+		events = append(events, grpcserver.EventFromProto(e))
+	}
+	return events, nil
+}
+
 // StopAll cancels all current and outstanding operations for the robot and stops all actuators and movement.
 func (rc *RobotClient) StopAll(ctx context.Context, extra map[resource.Name]map[string]interface{}) error {
 	e := []*pb.StopExtraParameters{}
@@ -53,12 +53,14 @@ import (
 	"go.viam.com/rdk/discovery"
 	rgrpc "go.viam.com/rdk/grpc"
 	"go.viam.com/rdk/operation"
+	adminpb "go.viam.com/rdk/proto/api/robot/v1"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/registry"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/rimage"
 	"go.viam.com/rdk/robot"
 	framesystemparts "go.viam.com/rdk/robot/framesystem/parts"
+	"go.viam.com/rdk/robot/journal"
 	"go.viam.com/rdk/robot/server"
 	"go.viam.com/rdk/spatialmath"
 	"go.viam.com/rdk/subtype"
@@ -1427,6 +1429,155 @@ func TestClientStopAll(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 }
 
+func TestClientValidateConfig(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	listener1, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	gServer1 := grpc.NewServer()
+	var received *config.Config
+	injectRobot1 := &inject.Robot{
+		ResourceNamesFunc:       func() []resource.Name { return []resource.Name{} },
+		ResourceRPCSubtypesFunc: func() []resource.RPCSubtype { return nil },
+		ValidateConfigFunc: func(ctx context.Context, cfg *config.Config) (*robot.ReconfigurationPlan, error) {
+			received = cfg
+			return &robot.ReconfigurationPlan{
+				Added:      []string{arm.Named("arm1").String()},
+				BuildOrder: []string{arm.Named("arm1").String()},
+				Errors:     []string{"something is wrong"},
+			}, nil
+		},
+	}
+	pb.RegisterRobotServiceServer(gServer1, server.New(injectRobot1))
+	adminpb.RegisterAdminServiceServer(gServer1, server.NewAdmin(injectRobot1))
+
+	go gServer1.Serve(listener1)
+	defer gServer1.Stop()
+
+	client, err := New(context.Background(), listener1.Addr().String(), logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	plan, err := client.ValidateConfig(context.Background(), &config.Config{
+		Components: []config.Component{
+			{Name: "arm1", Type: arm.SubtypeName, Model: "fake", Attributes: config.AttributeMap{"one": 1.}},
+		},
+	})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, plan.Valid(), test.ShouldBeFalse)
+	test.That(t, plan.Added, test.ShouldResemble, []string{arm.Named("arm1").String()})
+	test.That(t, plan.Errors, test.ShouldResemble, []string{"something is wrong"})
+	test.That(t, received.Components, test.ShouldHaveLength, 1)
+	test.That(t, received.Components[0].Name, test.ShouldEqual, "arm1")
+	test.That(t, received.Components[0].Attributes, test.ShouldResemble, config.AttributeMap{"one": 1.})
+
+	err = client.Close(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestClientHealth(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	listener1, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	gServer1 := grpc.NewServer()
+	var received []resource.Name
+	injectRobot1 := &inject.Robot{
+		ResourceNamesFunc:       func() []resource.Name { return []resource.Name{} },
+		ResourceRPCSubtypesFunc: func() []resource.RPCSubtype { return nil },
+		HealthFunc: func(ctx context.Context, resourceNames []resource.Name) ([]robot.ResourceHealth, error) {
+			received = resourceNames
+			return []robot.ResourceHealth{
+				{Name: arm.Named("arm1").String(), State: robot.HealthReady},
+				{Name: arm.Named("arm2").String(), State: robot.HealthFailed, LastError: "no power", Retries: 2},
+			}, nil
+		},
+	}
+	pb.RegisterRobotServiceServer(gServer1, server.New(injectRobot1))
+	adminpb.RegisterAdminServiceServer(gServer1, server.NewAdmin(injectRobot1))
+
+	go gServer1.Serve(listener1)
+	defer gServer1.Stop()
+
+	client, err := New(context.Background(), listener1.Addr().String(), logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	healths, err := client.Health(context.Background(), []resource.Name{arm.Named("arm1"), arm.Named("arm2")})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, received, test.ShouldResemble, []resource.Name{arm.Named("arm1"), arm.Named("arm2")})
+	test.That(t, healths, test.ShouldHaveLength, 2)
+	test.That(t, healths[0].State, test.ShouldEqual, robot.HealthReady)
+	test.That(t, healths[1], test.ShouldResemble, robot.ResourceHealth{
+		Name:      arm.Named("arm2").String(),
+		State:     robot.HealthFailed,
+		LastError: "no power",
+		Retries:   2,
+	})
+
+	err = client.Close(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestClientEvents(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	listener1, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	gServer1 := grpc.NewServer()
+	since := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	var received journal.Filter
+	injectRobot1 := &inject.Robot{
+		ResourceNamesFunc:       func() []resource.Name { return []resource.Name{} },
+		ResourceRPCSubtypesFunc: func() []resource.RPCSubtype { return nil },
+		EventsFunc: func(ctx context.Context, filter journal.Filter) ([]journal.Event, error) {
+			received = filter
+			return []journal.Event{
+				{
+					Time:    since.Add(time.Minute),
+					Kind:    journal.KindReconfigure,
+					Details: map[string]interface{}{"version": 3, "added": []string{arm.Named("arm1").String()}},
+				},
+				{
+					Time:       since.Add(2 * time.Minute),
+					Kind:       journal.KindRPC,
+					Method:     "/viam.component.arm.v1.ArmService/Stop",
+					DurationMs: 1.5,
+					Error:      "no power",
+				},
+			}, nil
+		},
+	}
+	pb.RegisterRobotServiceServer(gServer1, server.New(injectRobot1))
+	adminpb.RegisterAdminServiceServer(gServer1, server.NewAdmin(injectRobot1))
+
+	go gServer1.Serve(listener1)
+	defer gServer1.Stop()
+
+	client, err := New(context.Background(), listener1.Addr().String(), logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	filter := journal.Filter{
+		Kinds:      []journal.Kind{journal.KindReconfigure, journal.KindRPC},
+		Method:     "Stop",
+		Since:      since,
+		ErrorsOnly: true,
+		Limit:      10,
+	}
+	events, err := client.Events(context.Background(), filter)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, received, test.ShouldResemble, filter)
+	test.That(t, events, test.ShouldHaveLength, 2)
+	test.That(t, events[0].Time.Equal(since.Add(time.Minute)), test.ShouldBeTrue)
+	test.That(t, events[0].Details, test.ShouldResemble, map[string]interface{}{
+		"version": 3.,
+		"added":   []interface{}{arm.Named("arm1").String()},
+	})
+	test.That(t, events[1].Kind, test.ShouldEqual, journal.KindRPC)
+	test.That(t, events[1].Method, test.ShouldEqual, "/viam.component.arm.v1.ArmService/Stop")
+	test.That(t, events[1].DurationMs, test.ShouldEqual, 1.5)
+	test.That(t, events[1].Error, test.ShouldEqual, "no power")
+	test.That(t, events[1].Details, test.ShouldBeNil)
+
+	err = client.Close(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+}
+
 func TestRemoteClientMatch(t *testing.T) {
 	logger := golog.NewTestLogger(t)
 	listener1, err := net.Listen("tcp", "localhost:0")
@@ -0,0 +1,93 @@
+package robotimpl
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+)
+
+// maxConfigHistory is the number of config events a robot keeps.
+const maxConfigHistory = 50
+
+// configHistory numbers the config versions a robot is reconfigured with and keeps the events of
+// the latest ones.
+type configHistory struct {
+	mu      sync.Mutex
+	version int
+	current int
+	events  []robot.ConfigEvent
+}
+
+// record adds the event of the next config version, of which diff describes the changes, failures
+// the resources that failed to build and err the other errors gathered.
+func (h *configHistory) record(
+	state robot.ConfigState,
+	diff *config.Diff,
+	failures map[resource.Name]error,
+	err error,
+) robot.ConfigEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.version++
+	if state == robot.ConfigApplied {
+		h.current = h.version
+	}
+	event := robot.ConfigEvent{
+		Version:  h.version,
+		State:    state,
+		Time:     time.Now().UTC().Format(time.RFC3339Nano),
+		Added:    planNames(diff.Added.Remotes, diff.Added.Components, diff.Added.Services, diff.Added.Processes),
+		Modified: planNames(diff.Modified.Remotes, diff.Modified.Components, diff.Modified.Services, diff.Modified.Processes),
+		Removed:  planNames(diff.Removed.Remotes, diff.Removed.Components, diff.Removed.Services, diff.Removed.Processes),
+	}
+	names := make([]resource.Name, 0, len(failures))
+	for name := range failures {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i].String() < names[j].String() })
+	for _, name := range names {
+		event.Failed = append(event.Failed, name.String())
+		err = multierr.Combine(err, errors.Wrapf(failures[name], "failed to build %s", name))
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	h.events = append(h.events, event)
+	if len(h.events) > maxConfigHistory {
+		h.events = h.events[len(h.events)-maxConfigHistory:]
+	}
+	return event
+}
+
+// rollbackFailed marks the event of the given version as not fully rolled back because of err and
+// returns it.
+func (h *configHistory) rollbackFailed(version int, err error) robot.ConfigEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i := range h.events {
+		if h.events[i].Version != version {
+			continue
+		}
+		h.events[i].State = robot.ConfigRollbackFailed
+		h.events[i].Error = multierr.Combine(errors.New(h.events[i].Error), errors.Wrap(err, "rollback")).Error()
+		return h.events[i]
+	}
+	return robot.ConfigEvent{Version: version, State: robot.ConfigRollbackFailed, Error: err.Error()}
+}
+
+// status returns the config status the history describes.
+func (h *configHistory) status() robot.ConfigStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return robot.ConfigStatus{
+		Version: h.current,
+		History: append([]robot.ConfigEvent{}, h.events...),
+	}
+}
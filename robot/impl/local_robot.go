@@ -20,6 +20,7 @@ import (
 
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/discovery"
+	"go.viam.com/rdk/metrics"
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/registry"
@@ -28,6 +29,7 @@ import (
 	"go.viam.com/rdk/robot/client"
 	"go.viam.com/rdk/robot/framesystem"
 	framesystemparts "go.viam.com/rdk/robot/framesystem/parts"
+	"go.viam.com/rdk/robot/journal"
 	"go.viam.com/rdk/robot/web"
 	weboptions "go.viam.com/rdk/robot/web/options"
 	"go.viam.com/rdk/utils"
@@ -63,6 +65,8 @@ type localRobot struct {
 	triggerConfig              chan bool
 	configTimer                *time.Ticker
 	revealSensitiveConfigDiffs bool
+	configHistory              configHistory
+	journal                    *journal.Journal
 }
 
 // webService returns the localRobot's web service. Raises if the service has not been initialized.
@@ -153,6 +157,22 @@ func (r *localRobot) Close(ctx context.Context) error {
 
 // StopAll cancels all current and outstanding operations for the robot and stops all actuators and movement.
 func (r *localRobot) StopAll(ctx context.Context, extra map[resource.Name]map[string]interface{}) error {
+	start := time.Now()
+	err := r.stopAll(ctx, extra)
+	event := journal.Event{
+		Time:       start,
+		Kind:       journal.KindStopAll,
+		Caller:     journal.Caller(ctx),
+		DurationMs: float64(time.Since(start)) / float64(time.Millisecond),
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	r.journal.Record(event)
+	return err
+}
+
+func (r *localRobot) stopAll(ctx context.Context, extra map[resource.Name]map[string]interface{}) error {
 	// Stop all operations
 	for _, op := range r.OperationManager().All() {
 		op.Cancel()
@@ -256,7 +276,16 @@ func (r *localRobot) Status(ctx context.Context, resourceNames []resource.Name)
 
 	// dedupe resourceNames
 	deduped := make(map[resource.Name]struct{}, len(namesToDedupe))
+	var withConfigStatus, withHealthStatus bool
 	for _, name := range namesToDedupe {
+		if name == robot.ConfigStatusName {
+			withConfigStatus = true
+			continue
+		}
+		if name == robot.HealthStatusName {
+			withHealthStatus = true
+			continue
+		}
 		deduped[name] = struct{}{}
 	}
 
@@ -329,6 +358,16 @@ func (r *localRobot) Status(ctx context.Context, resourceNames []resource.Name)
 		}
 		statuses = append(statuses, resourceStatus)
 	}
+	if withConfigStatus {
+		statuses = append(statuses, robot.Status{Name: robot.ConfigStatusName, Status: r.configHistory.status()})
+	}
+	if withHealthStatus {
+		healths, err := r.Health(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, robot.Status{Name: robot.HealthStatusName, Status: robot.HealthStatus{Resources: healths}})
+	}
 	return statuses, nil
 }
 
@@ -394,6 +433,7 @@ func newWithResources(
 		triggerConfig:              make(chan bool),
 		configTimer:                nil,
 		revealSensitiveConfigDiffs: rOpts.revealSensitiveConfigDiffs,
+		journal:                    rOpts.journal,
 	}
 
 	var successful bool
@@ -442,14 +482,17 @@ func newWithResources(
 			if closeCtx.Err() != nil {
 				return
 			}
+			// an explicit trigger retries failed resources without waiting for their backoff
+			var force bool
 			select {
 			case <-closeCtx.Done():
 				return
 			case <-r.triggerConfig:
+				force = true
 			case <-r.configTimer.C:
 			}
 			if r.manager.anyResourcesNotConfigured() {
-				r.manager.completeConfig(closeCtx, r)
+				r.manager.completeConfig(closeCtx, r, force)
 				r.updateDefaultServices(ctx)
 			}
 			if r.manager.updateRemotesResourceNames(ctx, r) {
@@ -458,13 +501,33 @@ func newWithResources(
 		}
 	}, r.activeBackgroundWorkers.Done)
 
+	healthCheckInterval := defaultHealthCheckInterval
+	if rOpts.healthCheckInterval != 0 {
+		healthCheckInterval = rOpts.healthCheckInterval
+	}
+	if healthCheckInterval > 0 {
+		r.monitorHealth(closeCtx, healthCheckInterval)
+	}
+
 	r.internalServices = make(map[internalServiceName]interface{})
-	r.internalServices[webName] = web.New(ctx, r, logger, rOpts.webOptions...)
+	webOptions := rOpts.webOptions
+	if rOpts.journal != nil {
+		webOptions = append(append([]web.Option{}, webOptions...), web.WithJournal(rOpts.journal))
+	}
+	r.internalServices[webName] = web.New(ctx, r, logger, webOptions...)
 	r.internalServices[framesystemName] = framesystem.New(ctx, r, logger)
 
 	r.config = &config.Config{}
 
-	r.Reconfigure(ctx, cfg)
+	// there is no previous config to roll back to, so whatever fails to build at startup is retried
+	// in the background instead
+	r.reconfigure(ctx, cfg, false)
+
+	if rOpts.watchConfigFile && cfg.ConfigFilePath != "" {
+		if err := r.watchConfigFile(closeCtx, cfg); err != nil {
+			return nil, err
+		}
+	}
 
 	for name, res := range resources {
 		r.manager.addResource(name, res)
@@ -638,6 +701,39 @@ func (r *localRobot) TransformPose(
 	return framesystem.TransformPose(ctx, pose, dst, additionalTransforms)
 }
 
+// watchConfigFile reconfigures the robot with the config of the file the given startup config was
+// read from whenever the config changes, until ctx is done.
+func (r *localRobot) watchConfigFile(ctx context.Context, startCfg *config.Config) error {
+	path := startCfg.ConfigFilePath
+	watcher, err := config.NewWatcher(ctx, startCfg, r.logger)
+	if err != nil {
+		return err
+	}
+	r.activeBackgroundWorkers.Add(1)
+	goutils.ManagedGo(func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case cfg := <-watcher.Config():
+				processedCfg, err := config.ProcessConfig(cfg, config.NewTLSConfig(cfg))
+				if err != nil {
+					r.logger.Errorw("error processing config after change", "error", err)
+					continue
+				}
+				r.logger.Infow("config file changed, reconfiguring", "path", path)
+				r.Reconfigure(ctx, processedCfg)
+			}
+		}
+	}, func() {
+		if err := goutils.TryClose(context.Background(), watcher); err != nil {
+			r.logger.Errorw("error closing config watcher", "error", err)
+		}
+		r.activeBackgroundWorkers.Done()
+	})
+	return nil
+}
+
 // RobotFromConfigPath is a helper to read and process a config given its path and then create a robot based on it.
 func RobotFromConfigPath(ctx context.Context, cfgPath string, logger golog.Logger, opts ...Option) (robot.LocalRobot, error) {
 	cfg, err := config.Read(ctx, cfgPath, logger)
@@ -726,11 +822,22 @@ func dialRobotClient(ctx context.Context,
 // Reconfigure will safely reconfigure a robot based on the given config. It will make
 // a best effort to remove no longer in use parts, but if it fails to do so, they could
 // possibly leak resources.
+//
+// Reconfiguration is atomic: every component and service added or modified by the config is built
+// before the instances they replace are closed or reconfigured in place. If one fails to build, the
+// robot keeps its previous config and instances, and the instances built for the config are closed.
+// Processes are only changed once the config is applied. The outcome is recorded in the config
+// status of the robot.
 func (r *localRobot) Reconfigure(ctx context.Context, newConfig *config.Config) {
-	var allErrs error
+	r.reconfigure(ctx, newConfig, true)
+}
 
+// reconfigure reconfigures the robot with newConfig, rolling back to the previous config on a
+// failure if atomic is set.
+func (r *localRobot) reconfigure(ctx context.Context, newConfig *config.Config, atomic bool) {
+	previous := r.config
 	newConfig = r.updateDefaultServiceNames(newConfig)
-	diff, err := config.DiffConfigs(*r.config, *newConfig, r.revealSensitiveConfigDiffs)
+	diff, err := config.DiffConfigs(*previous, *newConfig, r.revealSensitiveConfigDiffs)
 	if err != nil {
 		r.logger.Errorw("error diffing the configs", "error", err)
 		return
@@ -742,6 +849,102 @@ func (r *localRobot) Reconfigure(ctx context.Context, newConfig *config.Config)
 	if r.revealSensitiveConfigDiffs {
 		r.logger.Debugf("(re)configuring with %+v", diff)
 	}
+	if !atomic {
+		allErrs := r.applyDiff(ctx, diff, newConfig)
+		if allErrs != nil {
+			r.logger.Errorw("the following errors were gathered during reconfiguration", "errors", allErrs)
+		}
+		r.recordReconfiguration(r.configHistory.record(robot.ConfigApplied, diff, r.manager.buildFailures(diff), allErrs))
+		return
+	}
+
+	resourcesDiff, processesDiff := splitProcesses(diff)
+	r.manager.beginTransaction()
+	allErrs := r.applyDiff(ctx, resourcesDiff, newConfig)
+	failures := r.manager.buildFailures(diff)
+	if len(failures) == 0 {
+		err := r.manager.commitTransaction(ctx)
+		allErrs = multierr.Combine(allErrs, err, r.applyDiff(ctx, processesDiff, newConfig))
+		if allErrs != nil {
+			r.logger.Errorw("the following errors were gathered during reconfiguration", "errors", allErrs)
+		}
+		r.recordReconfiguration(r.configHistory.record(robot.ConfigApplied, diff, failures, allErrs))
+		return
+	}
+
+	event := r.configHistory.record(robot.ConfigRolledBack, diff, failures, allErrs)
+	r.logger.Errorw("reconfiguration failed, rolling back to the previous config",
+		"version", event.Version, "failed", event.Failed, "error", event.Error)
+	err = r.manager.rollbackTransaction(ctx)
+	r.config = previous
+	r.updateDefaultServices(ctx)
+	if err != nil {
+		r.logger.Errorw("failed to roll back to the previous config", "error", err)
+		event = r.configHistory.rollbackFailed(event.Version, err)
+	}
+	r.recordReconfiguration(event)
+}
+
+// splitProcesses splits diff into the changes of its resources and the changes of its processes.
+func splitProcesses(diff *config.Diff) (*config.Diff, *config.Diff) {
+	resources := *diff
+	processes := &config.Diff{
+		Left:     diff.Left,
+		Right:    diff.Right,
+		Added:    &config.Config{Processes: diff.Added.Processes},
+		Modified: &config.ModifiedConfigDiff{Processes: diff.Modified.Processes},
+		Removed:  &config.Config{Processes: diff.Removed.Processes},
+	}
+	added, removed := *diff.Added, *diff.Removed
+	modified := *diff.Modified
+	added.Processes, modified.Processes, removed.Processes = nil, nil, nil
+	resources.Added, resources.Modified, resources.Removed = &added, &modified, &removed
+	return &resources, processes
+}
+
+// recordReconfiguration records the reconfiguration of the config event in the journal and metrics.
+func (r *localRobot) recordReconfiguration(event robot.ConfigEvent) {
+	details := map[string]interface{}{
+		"version": event.Version,
+		"state":   string(event.State),
+	}
+	for key, names := range map[string][]string{
+		"added":    event.Added,
+		"modified": event.Modified,
+		"removed":  event.Removed,
+		"failed":   event.Failed,
+	} {
+		if len(names) != 0 {
+			details[key] = names
+		}
+	}
+	r.journal.Record(journal.Event{Kind: journal.KindReconfigure, Error: event.Error, Details: details})
+	metrics.Reconfigurations.WithLabelValues(string(event.State)).Inc()
+}
+
+// recordResourceFailure records in the journal that the resource of the given name failed at the
+// given stage, such as its build or its health check.
+func (r *localRobot) recordResourceFailure(name resource.Name, stage string, err error) {
+	r.journal.Record(journal.Event{
+		Kind:     journal.KindResourceFailure,
+		Resource: name.String(),
+		Error:    err.Error(),
+		Details:  map[string]interface{}{"stage": stage},
+	})
+}
+
+// Events returns the events of the journal of the robot matching the filter, oldest first.
+func (r *localRobot) Events(ctx context.Context, filter journal.Filter) ([]journal.Event, error) {
+	if r.journal == nil {
+		return nil, errors.New("robot keeps no event journal")
+	}
+	return r.journal.Query(filter)
+}
+
+// applyDiff applies the changes of diff, which lead from the current config to newConfig, to the
+// resources of the robot. Resources that fail to build are left unconfigured.
+func (r *localRobot) applyDiff(ctx context.Context, diff *config.Diff, newConfig *config.Config) error {
+	var allErrs error
 	// First we remove resources and their children that are not in the graph.
 	filtered, err := r.manager.FilterFromConfig(ctx, diff.Removed, r.logger)
 	if err != nil {
@@ -770,13 +973,12 @@ func (r *localRobot) Reconfigure(ctx context.Context, newConfig *config.Config)
 		allErrs = multierr.Combine(allErrs, err)
 	}
 	r.config = newConfig
-	allErrs = multierr.Combine(allErrs, filtered.Close(ctx))
+	r.manager.health.forget(filtered.resources.Names())
+	allErrs = multierr.Combine(allErrs, r.manager.closeRemoved(ctx, filtered))
 	// Third we attempt to complete the config (see function for details)
-	r.manager.completeConfig(ctx, r)
+	r.manager.completeConfig(ctx, r, false)
 	r.updateDefaultServices(ctx)
-	if allErrs != nil {
-		r.logger.Errorw("the following errors were gathered during reconfiguration", "errors", allErrs)
-	}
+	return allErrs
 }
 
 // checkMaxInstance checks to see if the local robot has reached the maximum number of a specific service type that are local.
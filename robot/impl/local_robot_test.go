@@ -4,10 +4,12 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"math"
 	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -47,6 +49,7 @@ import (
 	"go.viam.com/rdk/robot"
 	"go.viam.com/rdk/robot/client"
 	robotimpl "go.viam.com/rdk/robot/impl"
+	"go.viam.com/rdk/robot/journal"
 	"go.viam.com/rdk/robot/server"
 	weboptions "go.viam.com/rdk/robot/web/options"
 	"go.viam.com/rdk/services/datamanager"
@@ -1488,6 +1491,112 @@ func TestConfigProcess(t *testing.T) {
 	test.That(t, logs.FilterField(zap.String("output", "heythere\n")).Len(), test.ShouldEqual, 1)
 }
 
+func TestConfigFileWatcher(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	configPath := filepath.Join(t.TempDir(), "robot.json")
+	writeConfig := func(armNames ...string) {
+		t.Helper()
+		var cfg config.Config
+		for _, name := range armNames {
+			cfg.Components = append(cfg.Components, config.Component{Name: name, Type: arm.SubtypeName, Model: "fake"})
+		}
+		md, err := json.Marshal(&cfg)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, os.WriteFile(configPath, md, 0o600), test.ShouldBeNil)
+	}
+	writeConfig("arm1")
+
+	r, err := robotimpl.RobotFromConfigPath(context.Background(), configPath, logger, robotimpl.WithConfigFileWatcher())
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, r.Close(context.Background()), test.ShouldBeNil)
+	}()
+	test.That(t, arm.NamesFromRobot(r), test.ShouldResemble, []string{"arm1"})
+
+	writeConfig("arm1", "arm2")
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		_, err := arm.FromRobot(r, "arm2")
+		test.That(tb, err, test.ShouldBeNil)
+	})
+
+	// an invalid config leaves the robot as it is
+	test.That(t, os.WriteFile(configPath, []byte(`{"components": [{"type": "arm", "model": "fake"}]}`), 0o600), test.ShouldBeNil)
+	time.Sleep(time.Second)
+	test.That(t, utils.NewStringSet(arm.NamesFromRobot(r)...), test.ShouldResemble, utils.NewStringSet("arm1", "arm2"))
+
+	writeConfig("arm2")
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, arm.NamesFromRobot(r), test.ShouldResemble, []string{"arm2"})
+	})
+}
+
+func TestJournal(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+	j, err := journal.New(journal.Config{Dir: t.TempDir()}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, j.Close(), test.ShouldBeNil)
+	}()
+
+	armCfg := config.Component{Name: "arm1", Type: arm.SubtypeName, Model: "fake"}
+	cfg, err := config.ValidateConfig(&config.Config{Components: []config.Component{armCfg}})
+	test.That(t, err, test.ShouldBeNil)
+	r, err := robotimpl.New(ctx, cfg, logger, robotimpl.WithJournal(j))
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, r.Close(ctx), test.ShouldBeNil)
+	}()
+	events := func(filter journal.Filter) []journal.Event {
+		t.Helper()
+		events, err := r.(robot.EventJournal).Events(ctx, filter)
+		test.That(t, err, test.ShouldBeNil)
+		return events
+	}
+
+	newCfg, err := config.ValidateConfig(&config.Config{Components: []config.Component{
+		armCfg,
+		{Name: "arm2", Type: arm.SubtypeName, Model: "nope"},
+	}})
+	test.That(t, err, test.ShouldBeNil)
+	r.Reconfigure(ctx, newCfg)
+
+	reconfigurations := events(journal.Filter{Kinds: []journal.Kind{journal.KindReconfigure}})
+	test.That(t, reconfigurations, test.ShouldHaveLength, 2)
+	test.That(t, reconfigurations[0].Details["state"], test.ShouldEqual, string(robot.ConfigApplied))
+	test.That(t, reconfigurations[0].Details["added"], test.ShouldContain, arm.Named("arm1").String())
+	test.That(t, reconfigurations[1].Details["state"], test.ShouldEqual, string(robot.ConfigRolledBack))
+	test.That(t, reconfigurations[1].Error, test.ShouldContainSubstring, "unknown component type")
+
+	failures := events(journal.Filter{Kinds: []journal.Kind{journal.KindResourceFailure}})
+	test.That(t, failures, test.ShouldHaveLength, 1)
+	test.That(t, failures[0].Resource, test.ShouldEqual, arm.Named("arm2").String())
+	test.That(t, failures[0].Details["stage"], test.ShouldEqual, "build")
+
+	// RPC calls served are recorded with their caller
+	options, _, addr := robottestutils.CreateBaseOptionsAndListener(t)
+	test.That(t, r.StartWeb(ctx, options), test.ShouldBeNil)
+	robotClient, err := client.New(ctx, addr, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, robotClient.Close(ctx), test.ShouldBeNil)
+	}()
+	test.That(t, robotClient.StopAll(ctx, nil), test.ShouldBeNil)
+
+	stops := events(journal.Filter{Kinds: []journal.Kind{journal.KindStopAll}})
+	test.That(t, stops, test.ShouldHaveLength, 1)
+	test.That(t, stops[0].Caller, test.ShouldNotBeEmpty)
+	test.That(t, stops[0].Error, test.ShouldBeEmpty)
+
+	clientEvents, err := robotClient.Events(ctx, journal.Filter{Kinds: []journal.Kind{journal.KindRPC}, Method: "StopAll"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, clientEvents, test.ShouldHaveLength, 1)
+	test.That(t, clientEvents[0].Method, test.ShouldEqual, "/viam.robot.v1.RobotService/StopAll")
+	test.That(t, clientEvents[0].Caller, test.ShouldEqual, stops[0].Caller)
+}
+
 func TestReconnectRemote(t *testing.T) {
 	logger := golog.NewTestLogger(t)
 	options, _, addr := robottestutils.CreateBaseOptionsAndListener(t)
@@ -0,0 +1,60 @@
+package robotimpl
+
+import (
+	"fmt"
+
+	"github.com/edaniels/golog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.viam.com/utils/pexec"
+
+	"go.viam.com/rdk/metrics"
+)
+
+// processRestartMessage is what pexec logs whenever a managed process that exited unexpectedly is
+// about to be restarted.
+const processRestartMessage = "restarting process"
+
+// newManagedProcess returns a process managed by pexec which counts in the metrics the restarts of
+// the process after it exited unexpectedly. pexec offers no way to observe restarts but its logs,
+// so the restarts are counted from the logger given to the process.
+func newManagedProcess(cfg pexec.ProcessConfig, logger golog.Logger) pexec.ManagedProcess {
+	if cfg.OneShot {
+		return pexec.NewManagedProcess(cfg, logger)
+	}
+	restarts := metrics.ProcessRestarts.WithLabelValues(fmt.Sprintf("%s_%s", cfg.ID, cfg.Name))
+	logger = logger.Desugar().WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, &restartCounter{restarts: restarts})
+	})).Sugar()
+	return pexec.NewManagedProcess(cfg, logger)
+}
+
+// A restartCounter is a logging core counting the restarts logged by a managed process. It is
+// enabled regardless of the level of the logger it is added to so that restarts are always counted.
+type restartCounter struct {
+	restarts interface{ Inc() }
+}
+
+func (c *restartCounter) Enabled(level zapcore.Level) bool {
+	return level == zapcore.InfoLevel
+}
+
+func (c *restartCounter) With(fields []zapcore.Field) zapcore.Core {
+	return c
+}
+
+func (c *restartCounter) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) && entry.Message == processRestartMessage {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *restartCounter) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	c.restarts.Inc()
+	return nil
+}
+
+func (c *restartCounter) Sync() error {
+	return nil
+}
@@ -0,0 +1,98 @@
+package robotimpl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"go.viam.com/test"
+	"go.viam.com/utils/pexec"
+	"go.viam.com/utils/testutils"
+
+	"go.viam.com/rdk/metrics"
+)
+
+func TestManagedProcess(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+
+	t.Run("restarts", func(t *testing.T) {
+		runs := filepath.Join(t.TempDir(), "runs")
+		proc := newManagedProcess(pexec.ProcessConfig{
+			ID:   "crashy",
+			Name: "sh",
+			Args: []string{"-c", "echo run >> " + runs + "; exit 1"},
+		}, logger)
+		restarts := metrics.ProcessRestarts.WithLabelValues("crashy_sh")
+		before := testutil.ToFloat64(restarts)
+
+		test.That(t, proc.Start(context.Background()), test.ShouldBeNil)
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			test.That(tb, testutil.ToFloat64(restarts), test.ShouldBeGreaterThanOrEqualTo, before+1)
+			out, err := os.ReadFile(runs)
+			test.That(tb, err, test.ShouldBeNil)
+			test.That(tb, string(out), test.ShouldStartWith, "run\nrun\n")
+		})
+		// the process last exited on its own, failing, which Stop reports
+		test.That(t, proc.Stop(), test.ShouldBeError, "exit status 1")
+	})
+
+	t.Run("pexec logs restarts with the counted message", func(t *testing.T) {
+		// the restarts are counted from the logs of pexec, so this fails if pexec changes them
+		core, logs := observer.New(zap.InfoLevel)
+		proc := pexec.NewManagedProcess(pexec.ProcessConfig{ID: "observed", Name: "false"}, zap.New(core).Sugar())
+
+		test.That(t, proc.Start(context.Background()), test.ShouldBeNil)
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			test.That(tb, logs.FilterMessage(processRestartMessage).Len(), test.ShouldBeGreaterThanOrEqualTo, 1)
+		})
+		test.That(t, proc.Stop(), test.ShouldBeError, "exit status 1")
+	})
+
+	t.Run("restarts are counted whatever the log level", func(t *testing.T) {
+		proc := newManagedProcess(pexec.ProcessConfig{ID: "quiet", Name: "false"}, zap.NewNop().Sugar())
+		restarts := metrics.ProcessRestarts.WithLabelValues("quiet_false")
+		before := testutil.ToFloat64(restarts)
+
+		test.That(t, proc.Start(context.Background()), test.ShouldBeNil)
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			test.That(tb, testutil.ToFloat64(restarts), test.ShouldBeGreaterThanOrEqualTo, before+1)
+		})
+		test.That(t, proc.Stop(), test.ShouldBeError, "exit status 1")
+	})
+
+	t.Run("stops", func(t *testing.T) {
+		proc := newManagedProcess(pexec.ProcessConfig{ID: "sleepy", Name: "sleep", Args: []string{"10"}}, logger)
+		restarts := metrics.ProcessRestarts.WithLabelValues("sleepy_sleep")
+
+		test.That(t, proc.Start(context.Background()), test.ShouldBeNil)
+		start := time.Now()
+		test.That(t, proc.Stop(), test.ShouldBeNil)
+		test.That(t, time.Since(start), test.ShouldBeLessThan, time.Second)
+		test.That(t, testutil.ToFloat64(restarts), test.ShouldEqual, 0)
+		test.That(t, proc.Start(context.Background()), test.ShouldNotBeNil)
+	})
+
+	t.Run("stop reports the exit status", func(t *testing.T) {
+		proc := newManagedProcess(pexec.ProcessConfig{
+			ID:   "stubborn",
+			Name: "sh",
+			Args: []string{"-c", "trap 'exit 3' INT; while true; do sleep 0.1; done"},
+		}, logger)
+		restarts := metrics.ProcessRestarts.WithLabelValues("stubborn_sh")
+
+		test.That(t, proc.Start(context.Background()), test.ShouldBeNil)
+		// give the shell time to set up its trap
+		time.Sleep(200 * time.Millisecond)
+		test.That(t, proc.Stop(), test.ShouldBeError, "exit status 3")
+		test.That(t, testutil.ToFloat64(restarts), test.ShouldEqual, 0)
+	})
+}
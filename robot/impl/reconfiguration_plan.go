@@ -0,0 +1,185 @@
+package robotimpl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/multierr"
+	"go.viam.com/utils/pexec"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+)
+
+// PlanReconfiguration validates the unprocessed newConfig and returns the changes reconfiguring a
+// robot running the processed current config with it would make. It runs every attribute
+// converter and validation of the config and resolves its dependency graph, but constructs no
+// resource, so no hardware is touched. Problems of the config are reported in the plan; the error
+// is only set when no plan could be made at all.
+func PlanReconfiguration(current, newConfig *config.Config) (*robot.ReconfigurationPlan, error) {
+	processed, err := config.ValidateConfig(newConfig)
+	if processed == nil {
+		return nil, err
+	}
+	plan := &robot.ReconfigurationPlan{}
+	for _, err := range multierr.Errors(err) {
+		plan.Errors = append(plan.Errors, err.Error())
+	}
+	processed = withDefaultServices(processed)
+
+	diff, err := config.DiffConfigs(*current, *processed, false)
+	if err != nil {
+		return nil, err
+	}
+	plan.Added = planNames(diff.Added.Remotes, diff.Added.Components, diff.Added.Services, diff.Added.Processes)
+	plan.Modified = planNames(diff.Modified.Remotes, diff.Modified.Components, diff.Modified.Services, diff.Modified.Processes)
+	plan.Removed = planNames(diff.Removed.Remotes, diff.Removed.Components, diff.Removed.Services, diff.Removed.Processes)
+
+	checkModels(processed, plan)
+	g := dependencyGraph(processed, plan)
+	for _, name := range g.ReverseTopologicalSort() {
+		plan.BuildOrder = append(plan.BuildOrder, name.String())
+	}
+	return plan, nil
+}
+
+// ValidateConfig validates the given unprocessed config and returns the changes reconfiguring the
+// robot with it would make.
+func (r *localRobot) ValidateConfig(ctx context.Context, cfg *config.Config) (*robot.ReconfigurationPlan, error) {
+	current, err := r.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return PlanReconfiguration(current, cfg)
+}
+
+// withDefaultServices returns a copy of cfg with the default services it does not configure itself,
+// as a robot reconfigured with cfg would have.
+func withDefaultServices(cfg *config.Config) *config.Config {
+	cfgCpy := *cfg
+	cfgCpy.Services = append([]config.Service{}, cfg.Services...)
+	for _, name := range resource.DefaultServices {
+		found := false
+		for _, svc := range cfg.Services {
+			if svc.ResourceName().Subtype == name.Subtype {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		cfgCpy.Services = append(cfgCpy.Services, config.Service{
+			Name:      name.Name,
+			Model:     resource.DefaultModelName,
+			Namespace: name.Namespace,
+			Type:      config.ServiceType(name.ResourceSubtype),
+		})
+	}
+	return &cfgCpy
+}
+
+func planNames(
+	remotes []config.Remote,
+	components []config.Component,
+	services []config.Service,
+	processes []pexec.ProcessConfig,
+) []string {
+	var names []string
+	for _, r := range remotes {
+		names = append(names, fromRemoteNameToRemoteNodeName(r.Name).String())
+	}
+	for _, c := range components {
+		names = append(names, c.ResourceName().String())
+	}
+	for _, s := range services {
+		names = append(names, s.ResourceName().String())
+	}
+	for _, p := range processes {
+		names = append(names, fmt.Sprintf("process %s", p.ID))
+	}
+	return names
+}
+
+// checkModels reports the resources whose model is not registered, and names used twice.
+func checkModels(cfg *config.Config, plan *robot.ReconfigurationPlan) {
+	seen := make(map[resource.Name]bool)
+	checkDuplicate := func(name resource.Name) {
+		if seen[name] {
+			plan.Errors = append(plan.Errors, fmt.Sprintf("resource %s is configured more than once", name))
+		}
+		seen[name] = true
+	}
+	for _, c := range cfg.Components {
+		// components failing validation were already reported
+		if c.Namespace == "" || c.Name == "" {
+			continue
+		}
+		name := c.ResourceName()
+		checkDuplicate(name)
+		if name.ContainsRemoteNames() {
+			continue
+		}
+		if registry.ComponentLookup(name.Subtype, c.Model) == nil {
+			plan.Errors = append(plan.Errors, fmt.Sprintf("unknown model %q for component %s", c.Model, name))
+		}
+	}
+	for _, s := range cfg.Services {
+		if s.Namespace == "" {
+			continue
+		}
+		name := s.ResourceName()
+		checkDuplicate(name)
+		if registry.ServiceLookup(name.Subtype, s.Model) == nil {
+			plan.Errors = append(plan.Errors, fmt.Sprintf("unknown model %q for service %s", s.Model, name))
+		}
+	}
+}
+
+// dependencyGraph resolves the dependencies of the components of cfg into a resource graph,
+// reporting the dependencies that cannot be resolved and the circular ones.
+func dependencyGraph(cfg *config.Config, plan *robot.ReconfigurationPlan) *resource.Graph {
+	g := resource.NewGraph()
+	remotes := make(map[string]bool)
+	for _, r := range cfg.Remotes {
+		remotes[r.Name] = true
+		g.AddNode(fromRemoteNameToRemoteNodeName(r.Name), nil)
+	}
+	for _, c := range cfg.Components {
+		g.AddNode(c.ResourceName(), nil)
+	}
+	for _, s := range cfg.Services {
+		g.AddNode(s.ResourceName(), nil)
+	}
+
+	for _, c := range cfg.Components {
+		name := c.ResourceName()
+		for _, dep := range c.Dependencies() {
+			var parent resource.Name
+			if remote, _, ok := strings.Cut(dep, ":"); ok {
+				if !remotes[remote] {
+					plan.Errors = append(plan.Errors, fmt.Sprintf("%s depends on %q of unknown remote %q", name, dep, remote))
+					continue
+				}
+				parent = fromRemoteNameToRemoteNodeName(remote)
+			} else if p, ok := g.FindNodeByName(dep); ok {
+				parent = *p
+			} else {
+				if len(remotes) != 0 {
+					plan.Warnings = append(plan.Warnings,
+						fmt.Sprintf("%s depends on %q which is not configured locally and must be provided by a remote", name, dep))
+					continue
+				}
+				plan.Errors = append(plan.Errors, fmt.Sprintf("%s depends on unknown resource %q", name, dep))
+				continue
+			}
+			if err := g.AddChildren(name, parent); err != nil {
+				plan.Errors = append(plan.Errors, fmt.Sprintf("%s: %s", name, err))
+			}
+		}
+	}
+	return g
+}
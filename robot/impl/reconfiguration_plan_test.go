@@ -0,0 +1,149 @@
+package robotimpl_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/base"
+	"go.viam.com/rdk/components/board"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+	robotimpl "go.viam.com/rdk/robot/impl"
+)
+
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestPlanReconfiguration(t *testing.T) {
+	armCfg := config.Component{Name: "arm1", Type: arm.SubtypeName, Model: "fake", DependsOn: []string{"base1"}}
+	baseCfg := config.Component{Name: "base1", Type: base.SubtypeName, Model: "fake", DependsOn: []string{"board1"}}
+	boardCfg := config.Component{Name: "board1", Type: board.SubtypeName, Model: "fake"}
+
+	t.Run("valid", func(t *testing.T) {
+		plan, err := robotimpl.PlanReconfiguration(&config.Config{}, &config.Config{
+			Components: []config.Component{armCfg, baseCfg, boardCfg},
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, plan.Valid(), test.ShouldBeTrue)
+		test.That(t, plan.Errors, test.ShouldBeEmpty)
+		test.That(t, plan.Removed, test.ShouldBeEmpty)
+		test.That(t, plan.Modified, test.ShouldBeEmpty)
+		for _, name := range []resource.Name{arm.Named("arm1"), base.Named("base1"), board.Named("board1")} {
+			test.That(t, plan.Added, test.ShouldContain, name.String())
+		}
+		// default services are added too
+		test.That(t, len(plan.Added), test.ShouldEqual, 3+len(resource.DefaultServices))
+
+		armIdx := indexOf(plan.BuildOrder, arm.Named("arm1").String())
+		baseIdx := indexOf(plan.BuildOrder, base.Named("base1").String())
+		boardIdx := indexOf(plan.BuildOrder, board.Named("board1").String())
+		test.That(t, boardIdx, test.ShouldBeGreaterThanOrEqualTo, 0)
+		test.That(t, baseIdx, test.ShouldBeGreaterThan, boardIdx)
+		test.That(t, armIdx, test.ShouldBeGreaterThan, baseIdx)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		plan, err := robotimpl.PlanReconfiguration(&config.Config{}, &config.Config{
+			Components: []config.Component{
+				{Name: "arm1", Type: arm.SubtypeName, Model: "fake", Attributes: config.AttributeMap{"fail_validate": true}},
+				{Name: "arm2", Type: arm.SubtypeName, Model: "nope"},
+				{Name: "arm3", Type: arm.SubtypeName, Model: "fake", DependsOn: []string{"missing"}},
+				{Name: "arm4", Type: arm.SubtypeName, Model: "fake", DependsOn: []string{"other:arm1"}},
+				{Type: arm.SubtypeName, Model: "fake"},
+				boardCfg,
+				boardCfg,
+			},
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, plan.Valid(), test.ShouldBeFalse)
+		errs := plan.Errors
+		test.That(t, errs, test.ShouldHaveLength, 6)
+		test.That(t, errs[0], test.ShouldContainSubstring, "error validating component arm1")
+		test.That(t, errs[1], test.ShouldContainSubstring, `"name" is required`)
+		test.That(t, errs[2], test.ShouldContainSubstring, `unknown model "nope"`)
+		test.That(t, errs[3], test.ShouldContainSubstring, "configured more than once")
+		test.That(t, errs[4], test.ShouldContainSubstring, `depends on unknown resource "missing"`)
+		test.That(t, errs[5], test.ShouldContainSubstring, `unknown remote "other"`)
+	})
+
+	t.Run("circular dependencies", func(t *testing.T) {
+		boardCfg := boardCfg
+		boardCfg.DependsOn = []string{"arm1"}
+		plan, err := robotimpl.PlanReconfiguration(&config.Config{}, &config.Config{
+			Components: []config.Component{armCfg, baseCfg, boardCfg},
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, plan.Errors, test.ShouldHaveLength, 1)
+		test.That(t, plan.Errors[0], test.ShouldContainSubstring, "circular dependency")
+	})
+
+	t.Run("remote dependencies", func(t *testing.T) {
+		plan, err := robotimpl.PlanReconfiguration(&config.Config{}, &config.Config{
+			Remotes: []config.Remote{{Name: "other", Address: "localhost:1234"}},
+			Components: []config.Component{
+				{Name: "arm1", Type: arm.SubtypeName, Model: "fake", DependsOn: []string{"other:base1", "base2"}},
+			},
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, plan.Valid(), test.ShouldBeTrue)
+		test.That(t, plan.Warnings, test.ShouldHaveLength, 1)
+		test.That(t, plan.Warnings[0], test.ShouldContainSubstring, `"base2"`)
+	})
+}
+
+func TestLocalRobotValidateConfig(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+	unprocessed := func() *config.Config {
+		return &config.Config{Components: []config.Component{
+			{Name: "arm1", Type: arm.SubtypeName, Model: "fake", DependsOn: []string{"base1"}},
+			{Name: "base1", Type: base.SubtypeName, Model: "fake"},
+		}}
+	}
+	cfg, err := config.ValidateConfig(unprocessed())
+	test.That(t, err, test.ShouldBeNil)
+	r, err := robotimpl.New(ctx, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, r.Close(ctx), test.ShouldBeNil)
+	}()
+	names := r.ResourceNames()
+
+	plan, err := r.(robot.ConfigValidator).ValidateConfig(ctx, unprocessed())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, plan.Valid(), test.ShouldBeTrue)
+	test.That(t, plan.Added, test.ShouldBeEmpty)
+	test.That(t, plan.Modified, test.ShouldBeEmpty)
+	test.That(t, plan.Removed, test.ShouldBeEmpty)
+	test.That(t, plan.String(), test.ShouldContainSubstring, "no changes")
+
+	newCfg := unprocessed()
+	newCfg.Components = newCfg.Components[1:]
+	newCfg.Components[0].Attributes = config.AttributeMap{"width_mm": 100.}
+	newCfg.Components = append(newCfg.Components, config.Component{Name: "arm2", Type: arm.SubtypeName, Model: "fake"})
+
+	plan, err = r.(robot.ConfigValidator).ValidateConfig(ctx, newCfg)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, plan.Valid(), test.ShouldBeTrue)
+	test.That(t, plan.Added, test.ShouldResemble, []string{arm.Named("arm2").String()})
+	test.That(t, plan.Modified, test.ShouldResemble, []string{base.Named("base1").String()})
+	test.That(t, plan.Removed, test.ShouldResemble, []string{arm.Named("arm1").String()})
+
+	// nothing was applied
+	test.That(t, r.ResourceNames(), test.ShouldHaveLength, len(names))
+	_, err = arm.FromRobot(r, "arm1")
+	test.That(t, err, test.ShouldBeNil)
+	_, err = arm.FromRobot(r, "arm2")
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -0,0 +1,357 @@
+package robotimpl
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	goutils "go.viam.com/utils"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/utils"
+)
+
+// defaultHealthCheckInterval is how often the health of the ready components is checked and the
+// failed resources due for a retry are rebuilt, unless set by WithHealthCheckInterval.
+const defaultHealthCheckInterval = 5 * time.Second
+
+var (
+	// healthCheckTimeout bounds a single health check.
+	healthCheckTimeout = 5 * time.Second
+	// restartBackoff is how long a resource waits before its first rebuild after a failure; it
+	// doubles with every further failure, up to maxRestartBackoff.
+	restartBackoff    = time.Second
+	maxRestartBackoff = 5 * time.Minute
+)
+
+// maxHealthCheckFailures is the number of consecutive failed health checks after which a degraded
+// component is considered failed and rebuilt.
+const maxHealthCheckFailures = 3
+
+type healthEntry struct {
+	state         robot.HealthState
+	lastErr       error
+	retries       int
+	checkFailures int
+	since         time.Time
+	nextRetry     time.Time
+}
+
+// resourceHealth tracks the health of the local resources of a robot.
+type resourceHealth struct {
+	mu      sync.Mutex
+	entries map[resource.Name]*healthEntry
+}
+
+func newResourceHealth() *resourceHealth {
+	return &resourceHealth{entries: make(map[resource.Name]*healthEntry)}
+}
+
+// entry returns the entry of name, creating it if needed. The lock must be held.
+func (h *resourceHealth) entry(name resource.Name) *healthEntry {
+	e, ok := h.entries[name]
+	if !ok {
+		e = &healthEntry{state: robot.HealthConfiguring, since: time.Now()}
+		h.entries[name] = e
+	}
+	return e
+}
+
+func (e *healthEntry) setState(state robot.HealthState) {
+	if e.state != state {
+		e.state = state
+		e.since = time.Now()
+	}
+}
+
+// fail marks the entry failed because of err and schedules its next retry. The lock must be held.
+func (e *healthEntry) fail(err error) {
+	e.setState(robot.HealthFailed)
+	e.lastErr = err
+	e.checkFailures = 0
+	e.retries++
+	backoff := restartBackoff
+	for i := 1; i < e.retries && backoff < maxRestartBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRestartBackoff {
+		backoff = maxRestartBackoff
+	}
+	e.nextRetry = time.Now().Add(backoff)
+}
+
+// configuring marks name as about to be (re)built with a new config, which is attempted right away.
+func (h *resourceHealth) configuring(name resource.Name) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	e := h.entry(name)
+	e.setState(robot.HealthConfiguring)
+	e.retries = 0
+	e.checkFailures = 0
+	e.nextRetry = time.Time{}
+}
+
+// buildFailed records that name could not be built because of err and returns whether the resource
+// is now failed. A resource waiting for a dependency is still configuring.
+func (h *resourceHealth) buildFailed(name resource.Name, err error) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	e := h.entry(name)
+	var notReady *registry.DependencyNotReadyError
+	if errors.As(err, &notReady) {
+		e.setState(robot.HealthConfiguring)
+		e.lastErr = err
+		return false
+	}
+	e.fail(err)
+	return true
+}
+
+// built records that name was built and returns whether it was failed before.
+func (h *resourceHealth) built(name resource.Name) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	e := h.entry(name)
+	wasFailed := e.state == robot.HealthFailed
+	e.setState(robot.HealthReady)
+	e.retries = 0
+	e.checkFailures = 0
+	e.nextRetry = time.Time{}
+	return wasFailed
+}
+
+// checked records the outcome of a health check of name and returns whether the resource must now
+// be considered failed.
+func (h *resourceHealth) checked(name resource.Name, err error) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	e := h.entry(name)
+	if e.state != robot.HealthReady && e.state != robot.HealthDegraded {
+		return false
+	}
+	if err == nil {
+		e.setState(robot.HealthReady)
+		e.checkFailures = 0
+		return false
+	}
+	e.lastErr = err
+	e.checkFailures++
+	if e.checkFailures < maxHealthCheckFailures {
+		e.setState(robot.HealthDegraded)
+		return false
+	}
+	e.fail(err)
+	return true
+}
+
+// retryDue returns whether name may be built now: resources that are not failed always may, failed
+// ones once their backoff elapsed.
+func (h *resourceHealth) retryDue(name resource.Name) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	e, ok := h.entries[name]
+	return !ok || e.state != robot.HealthFailed || !time.Now().Before(e.nextRetry)
+}
+
+// anyRetryDue returns whether a failed resource is due for a rebuild.
+func (h *resourceHealth) anyRetryDue() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	for _, e := range h.entries {
+		if e.state == robot.HealthFailed && !now.Before(e.nextRetry) {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshot returns a copy of the health of every resource, for restore.
+func (h *resourceHealth) snapshot() map[resource.Name]healthEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	entries := make(map[resource.Name]healthEntry, len(h.entries))
+	for name, e := range h.entries {
+		entries[name] = *e
+	}
+	return entries
+}
+
+// restore replaces the health of every resource with the given snapshot.
+func (h *resourceHealth) restore(entries map[resource.Name]healthEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = make(map[resource.Name]*healthEntry, len(entries))
+	for name, e := range entries {
+		e := e
+		h.entries[name] = &e
+	}
+}
+
+// forget drops the health of the given names, once their resources are removed.
+func (h *resourceHealth) forget(names []resource.Name) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, name := range names {
+		delete(h.entries, name)
+	}
+}
+
+// report returns the health of the given names.
+func (h *resourceHealth) report(names []resource.Name) []robot.ResourceHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	healths := make([]robot.ResourceHealth, 0, len(names))
+	for _, name := range names {
+		e := h.entry(name)
+		rh := robot.ResourceHealth{
+			Name:    name.String(),
+			State:   e.state,
+			Since:   e.since.UTC().Format(time.RFC3339Nano),
+			Retries: e.retries,
+		}
+		if e.lastErr != nil {
+			rh.LastError = e.lastErr.Error()
+		}
+		if e.state == robot.HealthFailed {
+			rh.NextRetry = e.nextRetry.UTC().Format(time.RFC3339Nano)
+		}
+		healths = append(healths, rh)
+	}
+	return healths
+}
+
+// Health returns the health of the local resources of the given names, or of all of them if no
+// names are given.
+func (r *localRobot) Health(ctx context.Context, resourceNames []resource.Name) ([]robot.ResourceHealth, error) {
+	local := make(map[resource.Name]bool)
+	for _, name := range r.manager.resources.Names() {
+		if name.ContainsRemoteNames() || name.ResourceType == remoteTypeName || name.ResourceType == unknownTypeName {
+			continue
+		}
+		local[name] = true
+	}
+	names := resourceNames
+	if len(names) == 0 {
+		for name := range local {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool { return names[i].String() < names[j].String() })
+	}
+	for _, name := range names {
+		if !local[name] {
+			return nil, utils.NewResourceNotFoundError(name)
+		}
+	}
+	return r.manager.health.report(names), nil
+}
+
+// monitorHealth checks the health of the ready components and rebuilds the failed resources once
+// their backoff elapsed, every interval until ctx is done.
+func (r *localRobot) monitorHealth(ctx context.Context, interval time.Duration) {
+	r.activeBackgroundWorkers.Add(1)
+	goutils.ManagedGo(func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			r.checkHealth(ctx)
+			if ctx.Err() == nil && r.manager.health.anyRetryDue() {
+				r.manager.completeConfig(ctx, r, false)
+				r.updateDefaultServices(ctx)
+			}
+		}
+	}, r.activeBackgroundWorkers.Done)
+}
+
+// checkHealth checks the health of every built local component implementing
+// resource.HealthChecker. Components failing too many checks in a row are closed and left to be
+// rebuilt.
+func (r *localRobot) checkHealth(ctx context.Context) {
+	for _, name := range r.manager.resources.Names() {
+		if name.ResourceType != resource.ResourceTypeComponent || name.ContainsRemoteNames() {
+			continue
+		}
+		res, ok := r.manager.resources.Node(name)
+		if !ok || res == nil {
+			continue
+		}
+		checker, ok := utils.UnwrapProxy(res).(resource.HealthChecker)
+		if !ok {
+			continue
+		}
+		checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		err := checker.CheckHealth(checkCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			r.logger.Warnw("health check failed", "resource", name, "error", err)
+		}
+		if r.manager.health.checked(name, err) {
+			r.logger.Errorw("resource failed too many health checks, restarting it", "resource", name, "error", err)
+			r.recordResourceFailure(name, "health_check", err)
+			r.manager.failResource(ctx, name, res, errors.Wrap(err, "health check failed"), r)
+		}
+	}
+}
+
+// failResource closes the failed resource res of the given name and leaves it to be rebuilt with
+// its current config, unless it was replaced in the meantime.
+func (manager *resourceManager) failResource(ctx context.Context, name resource.Name, res interface{}, err error, r *localRobot) {
+	manager.configLock.Lock()
+	defer manager.configLock.Unlock()
+	if current, ok := manager.resources.Node(name); !ok || current != res {
+		return
+	}
+	conf, ok := r.configuredComponent(name)
+	if !ok {
+		return
+	}
+	if closeErr := goutils.TryClose(ctx, res); closeErr != nil {
+		manager.logger.Errorw("error closing failed resource", "resource", name, "error", closeErr)
+	}
+	manager.resources.AddNode(name, &resourcePlaceholder{
+		real:   nil,
+		config: conf,
+		err:    err,
+	})
+}
+
+// rebuildChildren marks the local dependents of name to be rebuilt against its new instance.
+func (manager *resourceManager) rebuildChildren(ctx context.Context, name resource.Name, r *localRobot) {
+	for _, child := range manager.resources.GetAllChildrenOf(name) {
+		if child.ContainsRemoteNames() {
+			continue
+		}
+		if _, ok := manager.resources.Node(child); !ok {
+			continue
+		}
+		if _, ok := r.configuredComponent(child); !ok {
+			continue
+		}
+		if err := manager.markChildrenForUpdate(ctx, child, r); err != nil {
+			manager.logger.Errorw("error marking dependent for rebuild", "resource", child, "error", err)
+		}
+	}
+}
+
+// configuredComponent returns the config of the local component of the given name.
+func (r *localRobot) configuredComponent(name resource.Name) (config.Component, bool) {
+	for _, c := range r.config.Components {
+		if c.ResourceName() == name {
+			return c, true
+		}
+	}
+	return config.Component{}, false
+}
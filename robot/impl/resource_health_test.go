@@ -0,0 +1,230 @@
+package robotimpl
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+	"go.viam.com/test"
+	"go.viam.com/utils"
+	"go.viam.com/utils/testutils"
+
+	"go.viam.com/rdk/components/motor"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+// healthCheckedMotor is an injected motor that checks its health.
+type healthCheckedMotor struct {
+	*inject.LocalMotor
+	checkHealth func(ctx context.Context) error
+}
+
+func (m *healthCheckedMotor) CheckHealth(ctx context.Context) error {
+	return m.checkHealth(ctx)
+}
+
+// healthTestMotors builds injected motors and records them. Motors that are unchecked do not
+// check their health.
+type healthTestMotors struct {
+	mu        sync.Mutex
+	builds    map[string]int
+	failFirst map[string]int
+	unhealthy map[string]bool
+	unchecked map[string]bool
+	deps      map[string][]interface{}
+}
+
+func (m *healthTestMotors) constructor(
+	ctx context.Context,
+	deps registry.Dependencies,
+	conf config.Component,
+	logger golog.Logger,
+) (interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.builds[conf.Name]++
+	if m.builds[conf.Name] <= m.failFirst[conf.Name] {
+		return nil, errors.Errorf("build %d of %s failed", m.builds[conf.Name], conf.Name)
+	}
+	for _, dep := range conf.DependsOn {
+		d, err := motor.FromDependencies(deps, dep)
+		if err != nil {
+			return nil, err
+		}
+		m.deps[conf.Name] = append(m.deps[conf.Name], d)
+	}
+	// every new instance starts healthy
+	m.unhealthy[conf.Name] = false
+	name := conf.Name
+	checkHealth := func(ctx context.Context) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.unhealthy[name] {
+			return errors.New("motor driver not responding")
+		}
+		return nil
+	}
+	injectMotor := &inject.LocalMotor{}
+	injectMotor.IsPoweredFunc = func(ctx context.Context, extra map[string]interface{}) (bool, float64, error) {
+		return false, 0, checkHealth(ctx)
+	}
+	injectMotor.PropertiesFunc = func(ctx context.Context, extra map[string]interface{}) (map[motor.Feature]bool, error) {
+		return map[motor.Feature]bool{}, nil
+	}
+	injectMotor.IsMovingFunc = func(ctx context.Context) (bool, error) {
+		return false, nil
+	}
+	if m.unchecked[name] {
+		return injectMotor, nil
+	}
+	return &healthCheckedMotor{LocalMotor: injectMotor, checkHealth: checkHealth}, nil
+}
+
+func (m *healthTestMotors) buildsOf(name string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.builds[name]
+}
+
+func healthOf(tb testing.TB, r robot.LocalRobot, name resource.Name) robot.ResourceHealth {
+	tb.Helper()
+	healths, err := r.(robot.HealthReporter).Health(context.Background(), []resource.Name{name})
+	test.That(tb, err, test.ShouldBeNil)
+	test.That(tb, healths, test.ShouldHaveLength, 1)
+	return healths[0]
+}
+
+func TestResourceHealth(t *testing.T) {
+	origBackoff := restartBackoff
+	restartBackoff = 50 * time.Millisecond
+	defer func() {
+		restartBackoff = origBackoff
+	}()
+
+	motors := &healthTestMotors{
+		builds:    map[string]int{},
+		failFirst: map[string]int{"flaky": 2},
+		unhealthy: map[string]bool{},
+		unchecked: map[string]bool{"unchecked": true},
+		deps:      map[string][]interface{}{},
+	}
+	model := utils.RandomAlphaString(5)
+	registry.RegisterComponent(motor.Subtype, model, registry.Component{Constructor: motors.constructor})
+
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+	cfg, err := config.ValidateConfig(&config.Config{Components: []config.Component{
+		{Name: "m1", Type: motor.SubtypeName, Model: model},
+		{Name: "m2", Type: motor.SubtypeName, Model: model, DependsOn: []string{"m1"}},
+		{Name: "flaky", Type: motor.SubtypeName, Model: model},
+		{Name: "unchecked", Type: motor.SubtypeName, Model: model},
+	}})
+	test.That(t, err, test.ShouldBeNil)
+	r, err := New(ctx, cfg, logger, WithHealthCheckInterval(20*time.Millisecond))
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, r.Close(ctx), test.ShouldBeNil)
+	}()
+
+	t.Run("failed builds are retried with backoff", func(t *testing.T) {
+		health := healthOf(t, r, motor.Named("flaky"))
+		test.That(t, health.State, test.ShouldEqual, robot.HealthFailed)
+		test.That(t, health.Retries, test.ShouldEqual, 1)
+		test.That(t, health.LastError, test.ShouldContainSubstring, "build 1 of flaky failed")
+		test.That(t, health.NextRetry, test.ShouldNotBeEmpty)
+
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			test.That(tb, healthOf(tb, r, motor.Named("flaky")).State, test.ShouldEqual, robot.HealthReady)
+		})
+		test.That(t, motors.buildsOf("flaky"), test.ShouldEqual, 3)
+		health = healthOf(t, r, motor.Named("flaky"))
+		test.That(t, health.Retries, test.ShouldEqual, 0)
+		_, err := motor.FromRobot(r, "flaky")
+		test.That(t, err, test.ShouldBeNil)
+	})
+
+	t.Run("unhealthy resources and their dependents are rebuilt", func(t *testing.T) {
+		test.That(t, healthOf(t, r, motor.Named("m1")).State, test.ShouldEqual, robot.HealthReady)
+		test.That(t, healthOf(t, r, motor.Named("m2")).State, test.ShouldEqual, robot.HealthReady)
+		test.That(t, motors.buildsOf("m1"), test.ShouldEqual, 1)
+		test.That(t, motors.buildsOf("m2"), test.ShouldEqual, 1)
+
+		motors.mu.Lock()
+		motors.unhealthy["m1"] = true
+		motors.mu.Unlock()
+
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			test.That(tb, motors.buildsOf("m1"), test.ShouldEqual, 2)
+			test.That(tb, motors.buildsOf("m2"), test.ShouldEqual, 2)
+			test.That(tb, healthOf(tb, r, motor.Named("m2")).State, test.ShouldEqual, robot.HealthReady)
+		})
+		m1, err := r.ResourceByName(motor.Named("m1"))
+		test.That(t, err, test.ShouldBeNil)
+		motors.mu.Lock()
+		m2Deps := motors.deps["m2"]
+		motors.mu.Unlock()
+		test.That(t, m2Deps, test.ShouldHaveLength, 2)
+		test.That(t, m2Deps[1], test.ShouldEqual, m1)
+		health := healthOf(t, r, motor.Named("m1"))
+		test.That(t, health.State, test.ShouldEqual, robot.HealthReady)
+		test.That(t, health.LastError, test.ShouldContainSubstring, "motor driver not responding")
+	})
+
+	t.Run("resources that do not check their health are not rebuilt", func(t *testing.T) {
+		motors.mu.Lock()
+		motors.unhealthy["unchecked"] = true
+		motors.mu.Unlock()
+
+		// the status of the motor fails, but it is not a health check
+		time.Sleep(200 * time.Millisecond)
+		test.That(t, motors.buildsOf("unchecked"), test.ShouldEqual, 1)
+		test.That(t, healthOf(t, r, motor.Named("unchecked")).State, test.ShouldEqual, robot.HealthReady)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		statuses, err := r.Status(ctx, []resource.Name{robot.HealthStatusName})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, statuses, test.ShouldHaveLength, 1)
+		status := statuses[0].Status.(robot.HealthStatus)
+		names := make([]string, 0, len(status.Resources))
+		for _, health := range status.Resources {
+			names = append(names, health.Name)
+		}
+		test.That(t, names, test.ShouldContain, motor.Named("m1").String())
+		test.That(t, names, test.ShouldContain, motor.Named("flaky").String())
+
+		_, err = r.(robot.HealthReporter).Health(ctx, []resource.Name{motor.Named("nope")})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
+
+func TestResourceHealthRestore(t *testing.T) {
+	health := newResourceHealth()
+	health.configuring(motor.Named("m1"))
+	health.built(motor.Named("m1"))
+	snapshot := health.snapshot()
+
+	health.buildFailed(motor.Named("m1"), errors.New("bad"))
+	health.configuring(motor.Named("m2"))
+	test.That(t, health.snapshot(), test.ShouldHaveLength, 2)
+
+	health.restore(snapshot)
+	test.That(t, health.snapshot(), test.ShouldResemble, snapshot)
+	reports := health.report([]resource.Name{motor.Named("m1")})
+	test.That(t, reports[0].State, test.ShouldEqual, robot.HealthReady)
+	test.That(t, reports[0].Retries, test.ShouldEqual, 0)
+	test.That(t, reports[0].LastError, test.ShouldBeEmpty)
+
+	// the snapshot is a copy, so it is not changed by later failures
+	health.buildFailed(motor.Named("m1"), errors.New("bad"))
+	test.That(t, snapshot[motor.Named("m1")].state, test.ShouldEqual, robot.HealthReady)
+}
@@ -17,6 +17,7 @@ import (
 	"go.viam.com/utils/rpc"
 
 	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/metrics"
 	"go.viam.com/rdk/registry"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
@@ -46,6 +47,10 @@ type resourceManager struct {
 	opts           resourceManagerOptions
 	logger         golog.Logger
 	configLock     *sync.Mutex
+	health         *resourceHealth
+
+	txnMu sync.Mutex
+	txn   *resourceTransaction
 }
 
 // resourcePlaceholder we use resourcePlaceholder during a reconfiguration
@@ -81,6 +86,7 @@ func newResourceManager(
 		opts:           opts,
 		logger:         logger,
 		configLock:     &sync.Mutex{},
+		health:         newResourceHealth(),
 	}
 }
 
@@ -122,6 +128,9 @@ func (manager *resourceManager) updateRemoteResourceNames(
 	lr *localRobot,
 ) bool {
 	visited := map[resource.Name]bool{}
+	if remote, ok := rr.(robot.RemoteRobot); ok {
+		metrics.SetRemoteConnected(remoteName.Name, remote.Connected())
+	}
 	newResources := rr.ResourceNames()
 	oldResources := manager.remoteResourceNames(remoteName)
 	for _, res := range oldResources {
@@ -344,9 +353,11 @@ func (manager *resourceManager) Close(ctx context.Context) error {
 
 // completeConfig process the tree in reverse order and attempts to build
 // or reconfigure resources that are wrapped in a placeholderResource.
+// Failed resources are only rebuilt once their backoff elapsed, unless force is set.
 func (manager *resourceManager) completeConfig(
 	ctx context.Context,
 	robot *localRobot,
+	force bool,
 ) {
 	manager.configLock.Lock()
 	defer manager.configLock.Unlock()
@@ -362,15 +373,28 @@ func (manager *resourceManager) completeConfig(
 		if !ok {
 			continue
 		}
+		if !force && !manager.health.retryDue(r) {
+			manager.logger.Debugw("resource failed recently, waiting before rebuilding it", "resource", r)
+			continue
+		}
 		manager.logger.Debugw("we are now handling the resource", "resource", r)
 		if c, ok := wrap.config.(config.Component); ok {
 			iface, err := manager.processComponent(ctx, r, c, wrap.real, robot)
 			if err != nil {
 				manager.logger.Errorw("error building component", "resource", c.ResourceName(), "model", c.Model, "error", err)
+				// the former resource may have been closed by the attempt
+				wrap.real = iface
 				wrap.err = errors.Wrap(err, "component build error")
+				if manager.health.buildFailed(r, err) {
+					robot.recordResourceFailure(r, "build", err)
+				}
 				continue
 			}
 			manager.resources.AddNode(r, iface)
+			if manager.health.built(r) {
+				// dependents still hold the failed instance, rebuild them later in this pass
+				manager.rebuildChildren(ctx, r, robot)
+			}
 		} else if _, ok := wrap.config.(config.Service); ok {
 			// RSDK-507: Handle services like normal again when dependencies are added to services.
 			manager.logger.Debugw("resource is a service, skipping until the end", "resource", r)
@@ -418,9 +442,13 @@ func (manager *resourceManager) completeConfig(
 		if err != nil {
 			manager.logger.Errorw("error building service", "resource", s.ResourceName(), "model", s.Model, "error", err)
 			wrap.err = errors.Wrap(err, "service build error")
+			if manager.health.buildFailed(r, err) {
+				robot.recordResourceFailure(r, "build", err)
+			}
 			continue
 		}
 		manager.resources.AddNode(r, iface)
+		manager.health.built(r)
 	}
 }
 
@@ -544,7 +572,7 @@ func (manager *resourceManager) processService(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
-	return resource.ReconfigureResource(ctx, old, svc)
+	return manager.replace(ctx, c.ResourceName(), old, svc)
 }
 
 func (manager *resourceManager) markChildrenForUpdate(ctx context.Context, rName resource.Name, r *localRobot) error {
@@ -569,7 +597,7 @@ func (manager *resourceManager) markChildrenForUpdate(ctx context.Context, rName
 				}
 			}
 		}
-		if err := utils.TryClose(ctx, iface); err != nil {
+		if err := manager.release(ctx, x, iface); err != nil {
 			return err
 		}
 		wrapper := &resourcePlaceholder{
@@ -577,6 +605,7 @@ func (manager *resourceManager) markChildrenForUpdate(ctx context.Context, rName
 			config: originalConfig,
 			err:    errors.New("resource not updated yet"),
 		}
+		manager.health.configuring(x)
 		manager.resources.AddNode(x, wrapper)
 	}
 	return nil
@@ -607,7 +636,7 @@ func (manager *resourceManager) processComponent(ctx context.Context,
 		if err != nil {
 			return old, err
 		}
-		rr, err := resource.ReconfigureResource(ctx, old, nr)
+		rr, err := manager.replace(ctx, rName, old, nr)
 		if err != nil {
 			return old, err
 		}
@@ -616,12 +645,20 @@ func (manager *resourceManager) processComponent(ctx context.Context,
 		if err := manager.markChildrenForUpdate(ctx, rName, r); err != nil {
 			return old, err
 		}
+		if manager.transaction() != nil {
+			// the former resource stays open until the transaction is committed
+			nr, err := r.newResource(ctx, conf)
+			if err != nil {
+				return old, err
+			}
+			return nr, manager.release(ctx, rName, old)
+		}
 		if err := utils.TryClose(ctx, old); err != nil {
 			return old, err
 		}
 		nr, err := r.newResource(ctx, conf)
 		if err != nil {
-			return old, err
+			return nil, err
 		}
 		return nr, nil
 	default:
@@ -634,6 +671,7 @@ func (manager *resourceManager) processComponent(ctx context.Context,
 // once done we should have all the information we need to build this resource later on when we call completeConfig.
 func (manager *resourceManager) wrapResource(name resource.Name, config interface{}, deps []string, fn translateToName) error {
 	var wrapper *resourcePlaceholder
+	manager.health.configuring(name)
 	part, _ := manager.resources.Node(name)
 	if wrap, ok := part.(*resourcePlaceholder); ok {
 		wrap.config = config
@@ -737,7 +775,7 @@ func (manager *resourceManager) updateResources(
 		manager.logger.Errorw("error cleaning up app image environement", "error", err)
 	}
 	for _, p := range config.Added.Processes {
-		_, err := manager.processManager.AddProcessFromConfig(ctx, p)
+		_, err := manager.processManager.AddProcess(ctx, newManagedProcess(p, manager.logger), true)
 		if err != nil {
 			manager.logger.Errorw("error while adding process, skipping", "process", p.ID, "error", err)
 			continue
@@ -751,7 +789,7 @@ func (manager *resourceManager) updateResources(
 		} else {
 			manager.logger.Errorw("couldn't find modified process", "process", p.ID)
 		}
-		_, err := manager.processManager.AddProcessFromConfig(ctx, p)
+		_, err := manager.processManager.AddProcess(ctx, newManagedProcess(p, manager.logger), true)
 		if err != nil {
 			manager.logger.Errorw("error while changing process, skipping", "process", p.ID, "error", err)
 			continue
@@ -760,6 +798,38 @@ func (manager *resourceManager) updateResources(
 	return allErrs
 }
 
+// buildFailures returns the errors of the components and services added or modified by diff that
+// could not be built, by resource name. Resources waiting for a dependency that is not ready, such
+// as one from a remote that is not connected yet, are not failures: they are built in the
+// background once the dependency is.
+func (manager *resourceManager) buildFailures(diff *config.Diff) map[resource.Name]error {
+	names := make([]resource.Name, 0, len(diff.Added.Components)+len(diff.Modified.Components))
+	for _, c := range append(append([]config.Component{}, diff.Added.Components...), diff.Modified.Components...) {
+		names = append(names, c.ResourceName())
+	}
+	for _, s := range append(append([]config.Service{}, diff.Added.Services...), diff.Modified.Services...) {
+		names = append(names, s.ResourceName())
+	}
+	failures := make(map[resource.Name]error)
+	for _, name := range names {
+		iface, ok := manager.resources.Node(name)
+		if !ok {
+			failures[name] = errors.New("resource was not added")
+			continue
+		}
+		wrap, ok := iface.(*resourcePlaceholder)
+		if !ok || wrap.err == nil {
+			continue
+		}
+		var notReady *registry.DependencyNotReadyError
+		if errors.As(wrap.err, &notReady) {
+			continue
+		}
+		failures[name] = wrap.err
+	}
+	return failures
+}
+
 // ResourceByName returns the given resource by fully qualified name, if it exists;
 // returns an error otherwise.
 func (manager *resourceManager) ResourceByName(name resource.Name) (interface{}, error) {
@@ -812,6 +882,7 @@ func (manager *resourceManager) FilterFromConfig(ctx context.Context, conf *conf
 		if _, ok := filtered.resources.Node(remoteName); ok {
 			continue
 		}
+		metrics.RemoteConnected.DeleteLabelValues(conf.Name)
 		subG, err := manager.resources.SubGraphFrom(remoteName)
 		if err != nil {
 			manager.logger.Errorw("error while getting a subgraph", "error", err)
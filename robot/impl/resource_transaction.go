@@ -0,0 +1,242 @@
+package robotimpl
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/resource"
+)
+
+// A resourceTransaction holds the resources of a manager as they were before a reconfiguration,
+// so that the reconfiguration can be committed or rolled back once every resource it changes is
+// built. While it is in progress, the instances the reconfiguration replaces or removes are kept
+// open and untouched, and the resources it changes are built as new instances that their dependents
+// are built against.
+type resourceTransaction struct {
+	mu        sync.Mutex
+	resources *resource.Graph
+	health    map[resource.Name]healthEntry
+	// kept are the instances of the resources before the reconfiguration.
+	kept map[interface{}]bool
+	// swaps are the former instances of the resources reconfigured in place, which only happens on
+	// commit, by name.
+	swaps map[resource.Name]interface{}
+}
+
+// instanceOf returns the instance a node of the resource graph holds, which for a placeholder is
+// its former resource.
+func instanceOf(iface interface{}) interface{} {
+	if wrap, ok := iface.(*resourcePlaceholder); ok {
+		return wrap.real
+	}
+	return iface
+}
+
+// isComparable returns whether the instance can be told apart from others, which holds for the
+// pointers resources are in practice.
+func isComparable(iface interface{}) bool {
+	return iface != nil && reflect.TypeOf(iface).Comparable()
+}
+
+// beginTransaction starts a transaction on the resources of the manager.
+func (manager *resourceManager) beginTransaction() {
+	manager.configLock.Lock()
+	defer manager.configLock.Unlock()
+	txn := &resourceTransaction{
+		resources: manager.resources.Clone(),
+		health:    manager.health.snapshot(),
+		kept:      make(map[interface{}]bool),
+		swaps:     make(map[resource.Name]interface{}),
+	}
+	for _, name := range txn.resources.Names() {
+		iface, _ := txn.resources.Node(name)
+		if wrap, ok := iface.(*resourcePlaceholder); ok {
+			// placeholders are updated in place during a reconfiguration
+			copied := *wrap
+			txn.resources.AddNode(name, &copied)
+		}
+		if res := instanceOf(iface); isComparable(res) {
+			txn.kept[res] = true
+		}
+	}
+	manager.txnMu.Lock()
+	manager.txn = txn
+	manager.txnMu.Unlock()
+}
+
+// transaction returns the transaction in progress, if any.
+func (manager *resourceManager) transaction() *resourceTransaction {
+	manager.txnMu.Lock()
+	defer manager.txnMu.Unlock()
+	return manager.txn
+}
+
+// endTransaction ends the transaction in progress and returns it.
+func (manager *resourceManager) endTransaction() *resourceTransaction {
+	manager.txnMu.Lock()
+	defer manager.txnMu.Unlock()
+	txn := manager.txn
+	manager.txn = nil
+	return txn
+}
+
+// release closes an instance the resource of the given name no longer uses, unless a transaction
+// is in progress and the instance is one the robot had before it.
+func (manager *resourceManager) release(ctx context.Context, name resource.Name, iface interface{}) error {
+	txn := manager.transaction()
+	if txn == nil {
+		return utils.TryClose(ctx, iface)
+	}
+	txn.mu.Lock()
+	// the resource is rebuilt, its former instance is closed on commit
+	delete(txn.swaps, name)
+	kept := isComparable(iface) && txn.kept[iface]
+	txn.mu.Unlock()
+	if kept {
+		return nil
+	}
+	return utils.TryClose(ctx, iface)
+}
+
+// closeRemoved closes the resources and processes removed from the manager into removed. During
+// a transaction the resources stay open, as a rollback restores them, and the commit closes them.
+func (manager *resourceManager) closeRemoved(ctx context.Context, removed *resourceManager) error {
+	if manager.transaction() == nil {
+		return removed.Close(ctx)
+	}
+	if err := removed.processManager.Stop(); err != nil {
+		return errors.Wrap(err, "error stopping process manager")
+	}
+	return nil
+}
+
+// replace returns the instance the resource of the given name, built as newRes, should have
+// instead of old. Outside of a transaction, old is reconfigured in place with newRes or closed.
+// During one, the resource has newRes until the commit reconfigures old in place with it, and old
+// stays open.
+func (manager *resourceManager) replace(ctx context.Context, name resource.Name, old, newRes interface{}) (interface{}, error) {
+	txn := manager.transaction()
+	if txn == nil || old == nil {
+		return resource.ReconfigureResource(ctx, old, newRes)
+	}
+	_, oldReconfigurable := old.(resource.Reconfigurable)
+	_, newReconfigurable := newRes.(resource.Reconfigurable)
+	if oldReconfigurable != newReconfigurable {
+		// fails on the reconfigurability mismatch without touching either instance
+		_, err := resource.ReconfigureResource(ctx, old, newRes)
+		if closeErr := utils.TryClose(ctx, newRes); closeErr != nil {
+			manager.logger.Errorw("error closing unused resource", "resource", name, "error", closeErr)
+		}
+		return old, err
+	}
+	txn.mu.Lock()
+	former, swapping := txn.swaps[name]
+	txn.mu.Unlock()
+	if swapping {
+		// old was built during the transaction to reconfigure former with
+		if err := utils.TryClose(ctx, old); err != nil {
+			manager.logger.Errorw("error closing unused resource", "resource", name, "error", err)
+		}
+		old = former
+	}
+	if oldReconfigurable {
+		txn.mu.Lock()
+		txn.swaps[name] = old
+		txn.mu.Unlock()
+		return newRes, nil
+	}
+	if err := manager.release(ctx, name, old); err != nil {
+		return nil, err
+	}
+	return newRes, nil
+}
+
+// commitTransaction ends the transaction in progress by reconfiguring in place the former
+// instances of the resources it reconfigures and closing the instances the robot no longer uses.
+// Former instances that cannot be reconfigured in place are replaced by the new ones.
+func (manager *resourceManager) commitTransaction(ctx context.Context) error {
+	manager.configLock.Lock()
+	defer manager.configLock.Unlock()
+	txn := manager.endTransaction()
+	if txn == nil {
+		return nil
+	}
+	for name, former := range txn.swaps {
+		newRes, ok := manager.resources.Node(name)
+		if !ok {
+			continue
+		}
+		if _, err := resource.ReconfigureResource(ctx, former, newRes); err != nil {
+			manager.logger.Errorw("error reconfiguring resource in place, replacing it", "resource", name, "error", err)
+			continue
+		}
+		// the dependents built against the new instance share what it wraps with the former one
+		manager.resources.AddNode(name, former)
+	}
+
+	inUse := manager.instances()
+	var allErrs error
+	for _, name := range txn.resources.TopologicalSort() {
+		iface, _ := txn.resources.Node(name)
+		res := instanceOf(iface)
+		if !isComparable(res) || inUse[res] {
+			continue
+		}
+		inUse[res] = true // closed once even if several nodes held it
+		if err := utils.TryClose(ctx, res); err != nil {
+			allErrs = multierr.Combine(allErrs, errors.Wrapf(err, "error closing %s", name))
+		}
+	}
+	return allErrs
+}
+
+// rollbackTransaction ends the transaction in progress by restoring the resources and their health
+// as they were before it, and closing the instances it built.
+func (manager *resourceManager) rollbackTransaction(ctx context.Context) error {
+	manager.configLock.Lock()
+	defer manager.configLock.Unlock()
+	txn := manager.endTransaction()
+	if txn == nil {
+		return nil
+	}
+	// the instances built during the transaction are closed dependents first
+	var built []interface{}
+	for _, name := range manager.resources.TopologicalSort() {
+		iface, _ := manager.resources.Node(name)
+		if res := instanceOf(iface); isComparable(res) && !txn.kept[res] {
+			built = append(built, res)
+		}
+	}
+	manager.resources.Restore(txn.resources)
+	manager.health.restore(txn.health)
+
+	var allErrs error
+	closed := make(map[interface{}]bool, len(built))
+	for _, res := range built {
+		if closed[res] {
+			continue
+		}
+		closed[res] = true
+		if err := utils.TryClose(ctx, res); err != nil {
+			allErrs = multierr.Combine(allErrs, errors.Wrap(err, "error closing resource"))
+		}
+	}
+	return allErrs
+}
+
+// instances returns the instances the resource graph holds.
+func (manager *resourceManager) instances() map[interface{}]bool {
+	instances := make(map[interface{}]bool)
+	for _, name := range manager.resources.Names() {
+		iface, _ := manager.resources.Node(name)
+		if res := instanceOf(iface); isComparable(res) {
+			instances[res] = true
+		}
+	}
+	return instances
+}
@@ -1,6 +1,11 @@
 package robotimpl
 
-import "go.viam.com/rdk/robot/web"
+import (
+	"time"
+
+	"go.viam.com/rdk/robot/journal"
+	"go.viam.com/rdk/robot/web"
+)
 
 // options configures a Robot.
 type options struct {
@@ -10,6 +15,15 @@ type options struct {
 	// revealSensitiveConfigDiffs will display config diffs - which may contain secret
 	// information - in log statements
 	revealSensitiveConfigDiffs bool
+
+	// watchConfigFile makes a robot reconfigure itself when the file its config was read from changes.
+	watchConfigFile bool
+
+	// journal records the actions taken on the robot.
+	journal *journal.Journal
+
+	// healthCheckInterval is how often the health of the components is checked, if set.
+	healthCheckInterval time.Duration
 }
 
 // Option configures how we set up the web service.
@@ -50,3 +64,31 @@ func WithRevealSensitiveConfigDiffs() Option {
 		o.revealSensitiveConfigDiffs = true
 	})
 }
+
+// WithConfigFileWatcher returns an Option which makes a robot whose config was read from a file,
+// such as by RobotFromConfigPath, reconfigure itself whenever the file or a file it includes
+// changes. Changes are debounced and invalid configs are skipped.
+func WithConfigFileWatcher() Option {
+	return newFuncOption(func(o *options) {
+		o.watchConfigFile = true
+	})
+}
+
+// WithJournal returns an Option which makes a robot record the RPC calls it serves, its
+// reconfigurations, StopAll calls and resource failures in the given journal. The journal is not
+// closed with the robot.
+func WithJournal(j *journal.Journal) Option {
+	return newFuncOption(func(o *options) {
+		o.journal = j
+	})
+}
+
+// WithHealthCheckInterval returns an Option which sets how often a robot checks the health of its
+// components implementing resource.HealthChecker and rebuilds its failed resources. A negative
+// interval disables health checks; failed builds are then only retried when the robot next
+// completes its config.
+func WithHealthCheckInterval(interval time.Duration) Option {
+	return newFuncOption(func(o *options) {
+		o.healthCheckInterval = interval
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/registry"
 	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
 	"go.viam.com/rdk/services/datamanager"
 	_ "go.viam.com/rdk/services/datamanager/builtin"
 	"go.viam.com/rdk/services/motion"
@@ -46,6 +48,15 @@ import (
 
 var serviceNames = resource.DefaultServices
 
+func configStatusOf(t *testing.T, r robot.Robot) robot.ConfigStatus {
+	t.Helper()
+	statuses, err := r.Status(context.Background(), []resource.Name{robot.ConfigStatusName})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, statuses, test.ShouldHaveLength, 1)
+	test.That(t, statuses[0].Name, test.ShouldResemble, robot.ConfigStatusName)
+	return statuses[0].Status.(robot.ConfigStatus)
+}
+
 func TestRobotReconfigure(t *testing.T) {
 	test.That(t, len(resource.DefaultServices), test.ShouldEqual, 4)
 	ConfigFromFile := func(t *testing.T, filePath string) *config.Config {
@@ -104,6 +115,46 @@ func TestRobotReconfigure(t *testing.T) {
 		},
 	})
 
+	t.Run("removed resources are closed on commit only", func(t *testing.T) {
+		logger := golog.NewTestLogger(t)
+		ConfigFromString := func(t *testing.T, raw string) *config.Config {
+			t.Helper()
+			conf, err := config.FromReader(context.Background(), "", strings.NewReader(raw), logger)
+			test.That(t, err, test.ShouldBeNil)
+			return conf
+		}
+		mockConf := func(name string, shouldFail bool) string {
+			return fmt.Sprintf(`{"name": %q, "type": "mock", "model": %q, "attributes": {"should_fail": %t}}`, name, modelName1, shouldFail)
+		}
+		conf1 := ConfigFromString(t, fmt.Sprintf(`{"components": [%s, %s]}`, mockConf("mock1", false), mockConf("mock2", false)))
+		confBad := ConfigFromString(t, fmt.Sprintf(`{"components": [%s, %s]}`, mockConf("mock1", false), mockConf("mock3", true)))
+		confGood := ConfigFromString(t, fmt.Sprintf(`{"components": [%s]}`, mockConf("mock1", false)))
+
+		ctx := context.Background()
+		robot, err := New(ctx, conf1, logger)
+		test.That(t, err, test.ShouldBeNil)
+		defer func() {
+			test.That(t, robot.Close(context.Background()), test.ShouldBeNil)
+		}()
+
+		mock2, err := robot.ResourceByName(mockNamed("mock2"))
+		test.That(t, err, test.ShouldBeNil)
+
+		// mock3 fails to build, so removing mock2 is rolled back and mock2 is left open
+		robot.Reconfigure(ctx, confBad)
+		test.That(t, string(configStatusOf(t, robot).History[1].State), test.ShouldEqual, "rolled_back")
+		newMock2, err := robot.ResourceByName(mockNamed("mock2"))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock2, test.ShouldEqual, mock2)
+		test.That(t, mock2.(*mockFake).closeCount, test.ShouldEqual, 0)
+
+		robot.Reconfigure(ctx, confGood)
+		test.That(t, string(configStatusOf(t, robot).History[2].State), test.ShouldEqual, "applied")
+		_, err = robot.ResourceByName(mockNamed("mock2"))
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, mock2.(*mockFake).closeCount, test.ShouldEqual, 1)
+	})
+
 	t.Run("no diff", func(t *testing.T) {
 		logger := golog.NewTestLogger(t)
 		conf1 := ConfigFromFile(t, "data/diff_config_1.json")
@@ -301,8 +352,10 @@ func TestRobotReconfigure(t *testing.T) {
 		reconfigurableTrue = false
 		robot.Reconfigure(context.Background(), conf3)
 
+		// mock2 cannot be reconfigured, so the robot keeps its previous instance
 		_, err = robot.ResourceByName(mockNamed("mock2"))
-		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, string(configStatusOf(t, robot).History[1].State), test.ShouldEqual, "rolled_back")
 
 		reconfigurableTrue = true
 
@@ -1774,10 +1827,22 @@ func TestRobotReconfigure(t *testing.T) {
 		reconfigurableTrue = false
 		robot.Reconfigure(context.Background(), conf9)
 
-		mockNames = []resource.Name{
-			mockNamed("mock2"),
-			mockNamed("mock3"),
-		}
+		// mock4 fails to build and mock6 cannot be reconfigured, so the robot is rolled back to conf7
+		// and keeps all of its instances
+		configStatus := configStatusOf(t, robot)
+		test.That(t, configStatus.Version, test.ShouldEqual, 1)
+		test.That(t, configStatus.History, test.ShouldHaveLength, 2)
+		event := configStatus.History[1]
+		test.That(t, event.Version, test.ShouldEqual, 2)
+		test.That(t, string(event.State), test.ShouldEqual, "rolled_back")
+		test.That(t, event.Added, test.ShouldResemble, []string{arm.Named("armFake").String()})
+		test.That(t, event.Failed, test.ShouldResemble, []string{mockNamed("mock4").String(), mockNamed("mock6").String()})
+		test.That(t, event.Error, test.ShouldContainSubstring, "for some obscure reason")
+
+		cfg, err := robot.Config(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, cfg.Components, test.ShouldResemble, conf7.Components)
+
 		test.That(t, utils.NewStringSet(robot.RemoteNames()...), test.ShouldBeEmpty)
 		test.That(
 			t,
@@ -1816,47 +1881,63 @@ func TestRobotReconfigure(t *testing.T) {
 		eB, ok = b.DigitalInterruptByName("encoder-b")
 		test.That(t, ok, test.ShouldBeTrue)
 
-		m, err = motor.FromRobot(robot, "m1")
+		newM, err := motor.FromRobot(robot, "m1")
 		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newM, test.ShouldEqual, m)
 		c, err = m.Position(context.Background(), nil)
 		test.That(t, err, test.ShouldBeNil)
-		test.That(t, c, test.ShouldEqual, 0)
+		test.That(t, c, test.ShouldEqual, 1)
 
-		test.That(t, eA.Tick(context.Background(), false, uint64(time.Now().UnixNano())), test.ShouldBeNil)
-		test.That(t, eB.Tick(context.Background(), true, uint64(time.Now().UnixNano())), test.ShouldBeNil)
+		// the encoder keeps the state of its pins, so the next step forward starts from both high
 		test.That(t, eA.Tick(context.Background(), true, uint64(time.Now().UnixNano())), test.ShouldBeNil)
+		test.That(t, eB.Tick(context.Background(), false, uint64(time.Now().UnixNano())), test.ShouldBeNil)
+		test.That(t, eA.Tick(context.Background(), false, uint64(time.Now().UnixNano())), test.ShouldBeNil)
 
 		testutils.WaitForAssertion(t, func(tb testing.TB) {
 			tb.Helper()
 			c, err = m.Position(context.Background(), nil)
 			test.That(tb, err, test.ShouldBeNil)
-			test.That(tb, c, test.ShouldEqual, 1)
+			test.That(tb, c, test.ShouldEqual, 2)
 		})
 
 		_, err = board.FromRobot(robot, "board2")
 		test.That(t, err, test.ShouldBeNil)
 
-		_, err = robot.ResourceByName(mockNamed("mock1"))
-		test.That(t, err, test.ShouldNotBeNil)
+		newMock1, err := robot.ResourceByName(mockNamed("mock1"))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock1, test.ShouldEqual, mock1)
+		test.That(t, mock1.(*mockFake).x, test.ShouldEqual, 5)
+		test.That(t, mock1.(*mockFake).reconfCount, test.ShouldEqual, 0)
 
-		mock2, err = robot.ResourceByName(mockNamed("mock2"))
+		newMock2, err := robot.ResourceByName(mockNamed("mock2"))
 		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock2, test.ShouldEqual, mock2)
 		test.That(t, mock2.(*mockFake).x, test.ShouldEqual, 5)
-		test.That(t, mock2.(*mockFake).reconfCount, test.ShouldEqual, 1)
+		test.That(t, mock2.(*mockFake).reconfCount, test.ShouldEqual, 0)
 
-		mock3, err = robot.ResourceByName(mockNamed("mock3"))
+		newMock3, err := robot.ResourceByName(mockNamed("mock3"))
 		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock3, test.ShouldEqual, mock3)
 		test.That(t, mock3.(*mockFake).x, test.ShouldEqual, 5)
-		test.That(t, mock3.(*mockFake).reconfCount, test.ShouldEqual, 1)
+		test.That(t, mock3.(*mockFake).reconfCount, test.ShouldEqual, 0)
 
-		_, err = robot.ResourceByName(mockNamed("mock4"))
-		test.That(t, err, test.ShouldNotBeNil)
+		newMock4, err := robot.ResourceByName(mockNamed("mock4"))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock4, test.ShouldEqual, mock4)
+		test.That(t, mock4.(*mockFake).x, test.ShouldEqual, 5)
+		test.That(t, mock4.(*mockFake).reconfCount, test.ShouldEqual, 0)
 
-		_, err = robot.ResourceByName(mockNamed("mock5"))
-		test.That(t, err, test.ShouldNotBeNil)
+		newMock5, err := robot.ResourceByName(mockNamed("mock5"))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock5, test.ShouldEqual, mock5)
+		test.That(t, mock5.(*mockFake).x, test.ShouldEqual, 5)
+		test.That(t, mock5.(*mockFake).reconfCount, test.ShouldEqual, 0)
 
-		_, err = robot.ResourceByName(mockNamed("mock6"))
-		test.That(t, err, test.ShouldNotBeNil)
+		newMock6, err := robot.ResourceByName(mockNamed("mock6"))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock6, test.ShouldEqual, mock6)
+		test.That(t, mock6.(*mockFake).x, test.ShouldEqual, 5)
+		test.That(t, mock6.(*mockFake).reconfCount, test.ShouldEqual, 0)
 
 		_, err = robot.ResourceByName(arm.Named("armFake"))
 		test.That(t, err, test.ShouldNotBeNil)
@@ -1874,21 +1955,18 @@ func TestRobotReconfigure(t *testing.T) {
 				boardNames,
 				mockNames,
 				encoderNames,
-				[]resource.Name{
-					arm.Named("armFake"),
-					mockNamed("mock1"),
-					mockNamed("mock4"),
-					mockNamed("mock5"),
-					mockNamed("mock6"),
-				},
 			)...))
+
+		// mock6 still cannot be reconfigured, so conf9good is rolled back as well
 		conf9good := ConfigFromFile(t, "data/diff_config_deps9_good.json")
 		robot.Reconfigure(context.Background(), conf9good)
 
-		mockNames = []resource.Name{
-			mockNamed("mock2"), mockNamed("mock1"), mockNamed("mock3"),
-			mockNamed("mock4"), mockNamed("mock5"),
-		}
+		configStatus = configStatusOf(t, robot)
+		test.That(t, configStatus.Version, test.ShouldEqual, 1)
+		test.That(t, configStatus.History, test.ShouldHaveLength, 3)
+		test.That(t, string(configStatus.History[2].State), test.ShouldEqual, "rolled_back")
+		test.That(t, configStatus.History[2].Failed, test.ShouldResemble, []string{mockNamed("mock6").String()})
+
 		test.That(t, utils.NewStringSet(robot.RemoteNames()...), test.ShouldBeEmpty)
 		test.That(
 			t,
@@ -1927,53 +2005,62 @@ func TestRobotReconfigure(t *testing.T) {
 		eB, ok = b.DigitalInterruptByName("encoder-b")
 		test.That(t, ok, test.ShouldBeTrue)
 
-		m, err = motor.FromRobot(robot, "m1")
+		newM, err = motor.FromRobot(robot, "m1")
 		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newM, test.ShouldEqual, m)
 		c, err = m.Position(context.Background(), nil)
 		test.That(t, err, test.ShouldBeNil)
-		test.That(t, c, test.ShouldEqual, 1)
+		test.That(t, c, test.ShouldEqual, 2)
 
-		test.That(t, eA.Tick(context.Background(), true, uint64(time.Now().UnixNano())), test.ShouldBeNil)
-		test.That(t, eB.Tick(context.Background(), false, uint64(time.Now().UnixNano())), test.ShouldBeNil)
 		test.That(t, eA.Tick(context.Background(), false, uint64(time.Now().UnixNano())), test.ShouldBeNil)
+		test.That(t, eB.Tick(context.Background(), true, uint64(time.Now().UnixNano())), test.ShouldBeNil)
+		test.That(t, eA.Tick(context.Background(), true, uint64(time.Now().UnixNano())), test.ShouldBeNil)
 
 		testutils.WaitForAssertion(t, func(tb testing.TB) {
 			tb.Helper()
 			c, err = m.Position(context.Background(), nil)
 			test.That(tb, err, test.ShouldBeNil)
-			test.That(tb, c, test.ShouldEqual, 2)
+			test.That(tb, c, test.ShouldEqual, 3)
 		})
 
 		_, err = board.FromRobot(robot, "board2")
 		test.That(t, err, test.ShouldBeNil)
 
-		mock1, err = robot.ResourceByName(mockNamed("mock1"))
+		newMock1, err = robot.ResourceByName(mockNamed("mock1"))
 		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock1, test.ShouldEqual, mock1)
 		test.That(t, mock1.(*mockFake).x, test.ShouldEqual, 5)
-		test.That(t, mock1.(*mockFake).reconfCount, test.ShouldEqual, 1)
+		test.That(t, mock1.(*mockFake).reconfCount, test.ShouldEqual, 0)
 
-		mock2, err = robot.ResourceByName(mockNamed("mock2"))
+		newMock2, err = robot.ResourceByName(mockNamed("mock2"))
 		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock2, test.ShouldEqual, mock2)
 		test.That(t, mock2.(*mockFake).x, test.ShouldEqual, 5)
-		test.That(t, mock2.(*mockFake).reconfCount, test.ShouldEqual, 1)
+		test.That(t, mock2.(*mockFake).reconfCount, test.ShouldEqual, 0)
 
-		mock3, err = robot.ResourceByName(mockNamed("mock3"))
+		newMock3, err = robot.ResourceByName(mockNamed("mock3"))
 		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock3, test.ShouldEqual, mock3)
 		test.That(t, mock3.(*mockFake).x, test.ShouldEqual, 5)
-		test.That(t, mock3.(*mockFake).reconfCount, test.ShouldEqual, 1)
+		test.That(t, mock3.(*mockFake).reconfCount, test.ShouldEqual, 0)
 
-		mock4, err = robot.ResourceByName(mockNamed("mock4"))
+		newMock4, err = robot.ResourceByName(mockNamed("mock4"))
 		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock4, test.ShouldEqual, mock4)
 		test.That(t, mock4.(*mockFake).x, test.ShouldEqual, 5)
-		test.That(t, mock4.(*mockFake).reconfCount, test.ShouldEqual, 1)
+		test.That(t, mock4.(*mockFake).reconfCount, test.ShouldEqual, 0)
 
-		mock5, err = robot.ResourceByName(mockNamed("mock5"))
+		newMock5, err = robot.ResourceByName(mockNamed("mock5"))
 		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock5, test.ShouldEqual, mock5)
 		test.That(t, mock5.(*mockFake).x, test.ShouldEqual, 5)
-		test.That(t, mock5.(*mockFake).reconfCount, test.ShouldEqual, 1)
+		test.That(t, mock5.(*mockFake).reconfCount, test.ShouldEqual, 0)
 
-		_, err = robot.ResourceByName(mockNamed("mock6"))
-		test.That(t, err, test.ShouldNotBeNil)
+		newMock6, err = robot.ResourceByName(mockNamed("mock6"))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock6, test.ShouldEqual, mock6)
+		test.That(t, mock6.(*mockFake).x, test.ShouldEqual, 5)
+		test.That(t, mock6.(*mockFake).reconfCount, test.ShouldEqual, 0)
 
 		_, err = robot.ResourceByName(arm.Named("armFake"))
 		test.That(t, err, test.ShouldNotBeNil)
@@ -1988,11 +2075,72 @@ func TestRobotReconfigure(t *testing.T) {
 		rr, ok := robot.(*localRobot)
 		test.That(t, ok, test.ShouldBeTrue)
 
+		// a rolled back config is not retried in the background
 		rr.triggerConfig <- true
 
 		utils.SelectContextOrWait(context.Background(), 200*time.Millisecond)
-		mock6, err = robot.ResourceByName(mockNamed("mock6"))
+		newMock6, err = robot.ResourceByName(mockNamed("mock6"))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock6, test.ShouldEqual, mock6)
+		test.That(t, mock6.(*mockFake).reconfCount, test.ShouldEqual, 0)
+
+		_, err = robot.ResourceByName(arm.Named("armFake"))
+		test.That(t, err, test.ShouldNotBeNil)
+
+		// now that mock6 can be reconfigured, conf9good applies
+		robot.Reconfigure(context.Background(), conf9good)
+
+		configStatus = configStatusOf(t, robot)
+		test.That(t, configStatus.Version, test.ShouldEqual, 4)
+		test.That(t, configStatus.History, test.ShouldHaveLength, 4)
+		test.That(t, string(configStatus.History[3].State), test.ShouldEqual, "applied")
+		test.That(t, configStatus.History[3].Failed, test.ShouldBeEmpty)
+
+		test.That(t, rdktestutils.NewResourceNameSet(robot.ResourceNames()...), test.ShouldResemble, rdktestutils.NewResourceNameSet(
+			rdktestutils.ConcatResourceNames(
+				boardNames,
+				serviceNames,
+				motorNames,
+				mockNames,
+				encoderNames,
+				[]resource.Name{arm.Named("armFake")},
+			)...))
+		test.That(t, utils.NewStringSet(robot.ProcessManager().ProcessIDs()...), test.ShouldResemble, utils.NewStringSet("1", "2"))
+
+		newMock1, err = robot.ResourceByName(mockNamed("mock1"))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock1, test.ShouldEqual, mock1)
+		test.That(t, mock1.(*mockFake).x, test.ShouldEqual, 5)
+		test.That(t, mock1.(*mockFake).reconfCount, test.ShouldEqual, 1)
+
+		newMock2, err = robot.ResourceByName(mockNamed("mock2"))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock2, test.ShouldEqual, mock2)
+		test.That(t, mock2.(*mockFake).x, test.ShouldEqual, 5)
+		test.That(t, mock2.(*mockFake).reconfCount, test.ShouldEqual, 1)
+
+		newMock3, err = robot.ResourceByName(mockNamed("mock3"))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock3, test.ShouldEqual, mock3)
+		test.That(t, mock3.(*mockFake).x, test.ShouldEqual, 5)
+		test.That(t, mock3.(*mockFake).reconfCount, test.ShouldEqual, 1)
+
+		// mock4 and mock5 depend on reconfigured resources, so they are rebuilt
+		newMock4, err = robot.ResourceByName(mockNamed("mock4"))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock4, test.ShouldNotEqual, mock4)
+		test.That(t, newMock4.(*mockFake).x, test.ShouldEqual, 5)
+		test.That(t, newMock4.(*mockFake).reconfCount, test.ShouldEqual, 0)
+
+		newMock5, err = robot.ResourceByName(mockNamed("mock5"))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock5, test.ShouldNotEqual, mock5)
+		test.That(t, newMock5.(*mockFake).x, test.ShouldEqual, 5)
+		test.That(t, newMock5.(*mockFake).reconfCount, test.ShouldEqual, 0)
+
+		newMock6, err = robot.ResourceByName(mockNamed("mock6"))
 		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock6, test.ShouldEqual, mock6)
 		test.That(t, mock6.(*mockFake).x, test.ShouldEqual, 5)
 		test.That(t, mock6.(*mockFake).reconfCount, test.ShouldEqual, 1)
 
@@ -2010,10 +2158,74 @@ func TestRobotReconfigure(t *testing.T) {
 				encoderNames,
 				[]resource.Name{
 					arm.Named("armFake"),
-					mockNamed("mock6"),
 				},
 			)...))
 	})
+	t.Run("rollback on failure", func(t *testing.T) {
+		logger := golog.NewTestLogger(t)
+		mockConf := func(name string, attrs config.AttributeMap) config.Component {
+			return config.Component{Name: name, Type: "mock", Model: modelName1, Attributes: attrs}
+		}
+		conf1, err := config.ValidateConfig(&config.Config{Components: []config.Component{
+			{Name: "arm1", Type: arm.SubtypeName, Model: "fake"},
+			mockConf("mock1", config.AttributeMap{}),
+		}})
+		test.That(t, err, test.ShouldBeNil)
+		conf2, err := config.ValidateConfig(&config.Config{Components: []config.Component{
+			mockConf("mock1", config.AttributeMap{"blah": 10}),
+			mockConf("mock2", config.AttributeMap{"should_fail": true}),
+		}})
+		test.That(t, err, test.ShouldBeNil)
+
+		robot, err := New(context.Background(), conf1, logger)
+		test.That(t, err, test.ShouldBeNil)
+		defer func() {
+			test.That(t, robot.Close(context.Background()), test.ShouldBeNil)
+		}()
+		mock1, err := robot.ResourceByName(mockNamed("mock1"))
+		test.That(t, err, test.ShouldBeNil)
+
+		robot.Reconfigure(context.Background(), conf2)
+
+		configStatus := configStatusOf(t, robot)
+		test.That(t, configStatus.Version, test.ShouldEqual, 1)
+		test.That(t, configStatus.History, test.ShouldHaveLength, 2)
+		event := configStatus.History[1]
+		test.That(t, event.Version, test.ShouldEqual, 2)
+		test.That(t, string(event.State), test.ShouldEqual, "rolled_back")
+		test.That(t, event.Added, test.ShouldResemble, []string{mockNamed("mock2").String()})
+		test.That(t, event.Modified, test.ShouldResemble, []string{mockNamed("mock1").String()})
+		test.That(t, event.Removed, test.ShouldResemble, []string{arm.Named("arm1").String()})
+		test.That(t, event.Failed, test.ShouldResemble, []string{mockNamed("mock2").String()})
+		test.That(t, event.Error, test.ShouldContainSubstring, "for some obscure reason")
+
+		cfg, err := robot.Config(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, cfg.Components, test.ShouldResemble, conf1.Components)
+		test.That(t, rdktestutils.NewResourceNameSet(robot.ResourceNames()...), test.ShouldResemble, rdktestutils.NewResourceNameSet(
+			rdktestutils.ConcatResourceNames(
+				[]resource.Name{arm.Named("arm1"), mockNamed("mock1")},
+				serviceNames,
+			)...))
+
+		// the unchanged instance was reconfigured back rather than rebuilt
+		newMock1, err := robot.ResourceByName(mockNamed("mock1"))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, newMock1, test.ShouldEqual, mock1)
+		_, err = arm.FromRobot(robot, "arm1")
+		test.That(t, err, test.ShouldBeNil)
+		_, err = robot.ResourceByName(mockNamed("mock2"))
+		test.That(t, err, test.ShouldNotBeNil)
+
+		conf2.Components = conf2.Components[:1]
+		robot.Reconfigure(context.Background(), conf2)
+		configStatus = configStatusOf(t, robot)
+		test.That(t, configStatus.Version, test.ShouldEqual, 3)
+		test.That(t, string(configStatus.History[2].State), test.ShouldEqual, "applied")
+		_, err = arm.FromRobot(robot, "arm1")
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
 	t.Run("complex diff", func(t *testing.T) {
 		logger := golog.NewTestLogger(t)
 		conf1 := ConfigFromFile(t, "data/diff_config_deps11.json")
@@ -2634,6 +2846,7 @@ func TestRemoteRobotsGold(t *testing.T) {
 type mockFake struct {
 	x           int
 	reconfCount int
+	closeCount  int
 }
 
 type mockFakeConfig struct {
@@ -2652,6 +2865,11 @@ func (m *mockFake) Reconfigure(ctx context.Context, newResource resource.Reconfi
 	return nil
 }
 
+func (m *mockFake) Close(ctx context.Context) error {
+	m.closeCount++
+	return nil
+}
+
 func (m *mockFake) UpdateAction(cfg *config.Component) config.UpdateActionType {
 	return config.Reconfigure
 }
@@ -0,0 +1,492 @@
+// Package journal implements a local, rotating journal of the actions taken on a robot, so they can
+// be reviewed after the fact.
+package journal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"go.viam.com/utils"
+	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// A Kind is the kind of an event.
+type Kind string
+
+// The kinds of events a journal records.
+const (
+	KindRPC             = Kind("rpc")
+	KindReconfigure     = Kind("reconfigure")
+	KindStopAll         = Kind("stop_all")
+	KindResourceFailure = Kind("resource_failure")
+)
+
+// An Event is an entry of the journal.
+type Event struct {
+	Time time.Time `json:"time"`
+	Kind Kind      `json:"kind"`
+	// Resource is the name of the resource the event concerns, if any.
+	Resource string `json:"resource,omitempty"`
+	// Method is the full gRPC method of an RPC event.
+	Method string `json:"method,omitempty"`
+	// Caller identifies who caused the event: the authenticated entity or the address of the peer.
+	Caller string `json:"caller,omitempty"`
+	// Args summarizes the request of an RPC event.
+	Args       string                 `json:"args,omitempty"`
+	DurationMs float64                `json:"duration_ms,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+}
+
+// A Filter selects events. Zero fields match every event.
+type Filter struct {
+	Kinds    []Kind `json:"kinds,omitempty"`
+	Resource string `json:"resource,omitempty"`
+	// Method matches the events whose method contains it.
+	Method string    `json:"method,omitempty"`
+	Caller string    `json:"caller,omitempty"`
+	Since  time.Time `json:"since,omitempty"`
+	Until  time.Time `json:"until,omitempty"`
+	// ErrorsOnly only matches the events that have an error.
+	ErrorsOnly bool `json:"errors_only,omitempty"`
+	// Limit keeps only the latest matching events when positive.
+	Limit int `json:"limit,omitempty"`
+}
+
+// Matches returns whether the event is selected by the filter.
+func (f *Filter) Matches(e *Event) bool {
+	if len(f.Kinds) != 0 {
+		found := false
+		for _, k := range f.Kinds {
+			if k == e.Kind {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	switch {
+	case f.Resource != "" && f.Resource != e.Resource:
+		return false
+	case f.Method != "" && !strings.Contains(e.Method, f.Method):
+		return false
+	case f.Caller != "" && f.Caller != e.Caller:
+		return false
+	case !f.Since.IsZero() && e.Time.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && e.Time.After(f.Until):
+		return false
+	case f.ErrorsOnly && e.Error == "":
+		return false
+	}
+	return true
+}
+
+// Config configures a journal.
+type Config struct {
+	// Dir is the directory the journal files are kept in.
+	Dir string
+	// MaxFileBytes is the size after which the journal file is rotated. Defaults to 10MiB.
+	MaxFileBytes int64
+	// MaxFiles is the number of rotated files kept besides the current one. Defaults to 5.
+	MaxFiles int
+}
+
+const (
+	defaultMaxFileBytes = 10 << 20
+	defaultMaxFiles     = 5
+	// maxArgsSummary is the length RPC request summaries are truncated to.
+	maxArgsSummary = 256
+	fileName       = "events.jsonl"
+)
+
+// A Journal appends events as JSON lines to a file that is rotated once it grows too large.
+type Journal struct {
+	mu     sync.Mutex
+	cfg    Config
+	file   *os.File
+	size   int64
+	logger golog.Logger
+}
+
+// New opens the journal kept in the directory of the config, creating it if needed.
+func New(cfg Config, logger golog.Logger) (*Journal, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("journal directory is required")
+	}
+	if cfg.MaxFileBytes <= 0 {
+		cfg.MaxFileBytes = defaultMaxFileBytes
+	}
+	if cfg.MaxFiles <= 0 {
+		cfg.MaxFiles = defaultMaxFiles
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+		return nil, err
+	}
+	j := &Journal{cfg: cfg, logger: logger}
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func (j *Journal) path(index int) string {
+	if index == 0 {
+		return filepath.Join(j.cfg.Dir, fileName)
+	}
+	return filepath.Join(j.cfg.Dir, fmt.Sprintf("events.%d.jsonl", index))
+}
+
+func (j *Journal) open() error {
+	//nolint:gosec
+	f, err := os.OpenFile(j.path(0), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return multierr.Combine(err, f.Close())
+	}
+	j.file, j.size = f, info.Size()
+	return nil
+}
+
+// rotate shifts the journal files by one, dropping the oldest, and starts a new current file. The
+// current file is reopened even if shifting fails, so later events are not lost.
+func (j *Journal) rotate() error {
+	err := j.file.Close()
+	j.file = nil
+	if removeErr := os.Remove(j.path(j.cfg.MaxFiles)); removeErr != nil && !os.IsNotExist(removeErr) {
+		err = multierr.Combine(err, removeErr)
+	}
+	for i := j.cfg.MaxFiles - 1; i >= 0; i-- {
+		if renameErr := os.Rename(j.path(i), j.path(i+1)); renameErr != nil && !os.IsNotExist(renameErr) {
+			err = multierr.Combine(err, renameErr)
+		}
+	}
+	return multierr.Combine(err, j.open())
+}
+
+// Record appends the event to the journal, timestamping it if needed. Failures are logged, as
+// recording must never fail the action recorded.
+func (j *Journal) Record(e Event) {
+	if j == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	e.Time = e.Time.UTC()
+	line, err := json.Marshal(e)
+	if err != nil {
+		j.logger.Errorw("failed to encode journal event", "kind", e.Kind, "error", err)
+		return
+	}
+	line = append(line, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.file == nil {
+		return
+	}
+	if j.size > 0 && j.size+int64(len(line)) > j.cfg.MaxFileBytes {
+		if err := j.rotate(); err != nil {
+			j.logger.Errorw("failed to rotate journal", "error", err)
+			if j.file == nil {
+				return
+			}
+		}
+	}
+	n, err := j.file.Write(line)
+	j.size += int64(n)
+	if err != nil {
+		j.logger.Errorw("failed to write journal event", "kind", e.Kind, "error", err)
+	}
+}
+
+// Query returns the recorded events matching the filter, oldest first.
+func (j *Journal) Query(filter Filter) ([]Event, error) {
+	files, err := j.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	// the files are read without holding the lock so that recording is not held up meanwhile
+	var events []Event
+	for _, f := range files {
+		events, err = readEvents(f, filter, events)
+		if err != nil {
+			break
+		}
+	}
+	for _, f := range files {
+		utils.UncheckedError(f.Close())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[len(events)-filter.Limit:]
+	}
+	return events, nil
+}
+
+// A journalFile is a journal file opened for reading, limited to the events it held when opened.
+type journalFile struct {
+	*os.File
+	size int64
+}
+
+// snapshot opens the journal files, oldest first. Rotation renames files, which does not affect
+// the files already open, and the current file is only read up to its size when opened.
+func (j *Journal) snapshot() ([]journalFile, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var files []journalFile
+	for i := j.cfg.MaxFiles; i >= 0; i-- {
+		//nolint:gosec
+		f, err := os.Open(j.path(i))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			for _, f := range files {
+				utils.UncheckedError(f.Close())
+			}
+			return nil, err
+		}
+		size := int64(-1)
+		if i == 0 && j.file != nil {
+			size = j.size
+		}
+		files = append(files, journalFile{File: f, size: size})
+	}
+	return files, nil
+}
+
+func readEvents(f journalFile, filter Filter, events []Event) ([]Event, error) {
+	var r io.Reader = f.File
+	if f.size >= 0 {
+		r = io.LimitReader(f.File, f.size)
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4<<20)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// a line cut short by a crash; the following ones are still good
+			continue
+		}
+		if filter.Matches(&e) {
+			events = append(events, e)
+		}
+	}
+	return events, scanner.Err()
+}
+
+// Export writes the recorded events matching the filter to w as JSON lines, oldest first.
+func (j *Journal) Export(w io.Writer, filter Filter) error {
+	events, err := j.Query(filter)
+	if err != nil {
+		return err
+	}
+	return WriteJSONLines(w, events)
+}
+
+// WriteJSONLines writes the events to w as JSON lines.
+func WriteJSONLines(w io.Writer, events []Event) error {
+	enc := json.NewEncoder(w)
+	for i := range events {
+		if err := enc.Encode(&events[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the journal; events recorded afterwards are dropped.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
+
+// UnaryServerInterceptor records every unary RPC call served.
+func (j *Journal) UnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	j.Record(rpcEvent(ctx, info.FullMethod, req, start, err))
+	return resp, err
+}
+
+// StreamServerInterceptor records every streaming RPC call served, once the stream ends. The
+// first message received from the client is summarized as its arguments.
+func (j *Journal) StreamServerInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	wrapped := &recordingServerStream{ServerStream: ss}
+	err := handler(srv, wrapped)
+	j.Record(rpcEvent(ss.Context(), info.FullMethod, wrapped.first, start, err))
+	return err
+}
+
+type recordingServerStream struct {
+	grpc.ServerStream
+	first interface{}
+}
+
+func (s *recordingServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.first == nil {
+		s.first = m
+	}
+	return err
+}
+
+func rpcEvent(ctx context.Context, method string, req interface{}, start time.Time, err error) Event {
+	e := Event{
+		Time:       start,
+		Kind:       KindRPC,
+		Method:     method,
+		Caller:     Caller(ctx),
+		Args:       summarize(req),
+		DurationMs: float64(time.Since(start)) / float64(time.Millisecond),
+	}
+	if named, ok := req.(interface{ GetName() string }); ok {
+		e.Resource = named.GetName()
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	return e
+}
+
+// redactedFields are the request fields whose values are left out of summaries since they may
+// carry secrets or file contents, such as the environment of a shell command.
+var redactedFields = map[string]bool{
+	"env":         true,
+	"environment": true,
+	"secret":      true,
+	"password":    true,
+	"token":       true,
+	"credentials": true,
+	"payload":     true,
+	"data":        true,
+	"content":     true,
+	"contents":    true,
+}
+
+const redacted = "[redacted]"
+
+// summarize returns the JSON encoding of a request, with its sensitive fields redacted, truncated.
+func summarize(req interface{}) string {
+	msg, ok := req.(proto.Message)
+	if !ok || msg == nil {
+		return ""
+	}
+	md, err := protojson.MarshalOptions{}.Marshal(msg)
+	if err != nil {
+		return ""
+	}
+	var doc interface{}
+	if err := json.Unmarshal(md, &doc); err != nil {
+		return ""
+	}
+	// re-encoding also drops the spaces protojson randomly adds to its output
+	md, err = json.Marshal(redact(doc))
+	if err != nil {
+		return ""
+	}
+	summary := string(md)
+	if len(summary) > maxArgsSummary {
+		cut := maxArgsSummary
+		for cut > 0 && !utf8.RuneStart(summary[cut]) {
+			cut--
+		}
+		summary = summary[:cut] + "..."
+	}
+	return summary
+}
+
+// redact returns the decoded JSON document with the values of its redacted fields replaced, at any
+// depth.
+func redact(doc interface{}) interface{} {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if redactedFields[strings.ToLower(key)] {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redact(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redact(value)
+		}
+	}
+	return doc
+}
+
+// Caller returns who makes the call of the context: the authenticated entity if any, otherwise the
+// address of the peer, which may be connected over WebRTC. Entities that are not strings, such as the
+// principals of authorization, are recorded by their String method.
+func Caller(ctx context.Context) string {
+	if entity := authEntity(ctx); entity != nil {
+		return fmt.Sprint(entity)
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	if pc, ok := rpc.ContextPeerConnection(ctx); ok && pc.SCTP() != nil {
+		pair, err := pc.SCTP().Transport().ICETransport().GetSelectedCandidatePair()
+		if err == nil && pair != nil && pair.Remote != nil {
+			return net.JoinHostPort(pair.Remote.Address, strconv.Itoa(int(pair.Remote.Port)))
+		}
+	}
+	return ""
+}
+
+// authEntity returns the authenticated entity of the context, if any. The rpc package only offers
+// an accessor that panics on unauthenticated contexts.
+func authEntity(ctx context.Context) (entity interface{}) {
+	defer func() {
+		if recover() != nil {
+			entity = nil
+		}
+	}()
+	return rpc.MustContextAuthEntity(ctx)
+}
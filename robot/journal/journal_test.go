@@ -0,0 +1,204 @@
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/edaniels/golog"
+	pb "go.viam.com/api/component/arm/v1"
+	"go.viam.com/test"
+	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestJournalQuery(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	dir := t.TempDir()
+	j, err := New(Config{Dir: dir}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	start := time.Now().Add(-time.Minute)
+	j.Record(Event{Time: start, Kind: KindReconfigure, Details: map[string]interface{}{"version": 1}})
+	j.Record(Event{Time: start.Add(time.Second), Kind: KindRPC, Method: "/viam.component.arm.v1.ArmService/Stop", Resource: "arm1"})
+	j.Record(Event{Time: start.Add(2 * time.Second), Kind: KindRPC, Method: "/viam.component.arm.v1.ArmService/MoveToPosition",
+		Resource: "arm1", Caller: "alice", Error: "out of reach"})
+	j.Record(Event{Kind: KindStopAll, Caller: "bob"})
+
+	events, err := j.Query(Filter{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, events, test.ShouldHaveLength, 4)
+	test.That(t, events[0].Kind, test.ShouldEqual, KindReconfigure)
+	test.That(t, events[0].Details["version"], test.ShouldEqual, 1.)
+	test.That(t, events[3].Time.IsZero(), test.ShouldBeFalse)
+
+	for _, tc := range []struct {
+		filter  Filter
+		methods []string
+	}{
+		{Filter{Kinds: []Kind{KindRPC}}, []string{
+			"/viam.component.arm.v1.ArmService/Stop",
+			"/viam.component.arm.v1.ArmService/MoveToPosition",
+		}},
+		{Filter{Method: "MoveTo"}, []string{"/viam.component.arm.v1.ArmService/MoveToPosition"}},
+		{Filter{Resource: "arm1", Limit: 1}, []string{"/viam.component.arm.v1.ArmService/MoveToPosition"}},
+		{Filter{ErrorsOnly: true}, []string{"/viam.component.arm.v1.ArmService/MoveToPosition"}},
+		{Filter{Caller: "bob"}, []string{""}},
+		{Filter{Since: start.Add(500 * time.Millisecond), Until: start.Add(1500 * time.Millisecond)}, []string{
+			"/viam.component.arm.v1.ArmService/Stop",
+		}},
+	} {
+		events, err := j.Query(tc.filter)
+		test.That(t, err, test.ShouldBeNil)
+		methods := []string{}
+		for _, e := range events {
+			methods = append(methods, e.Method)
+		}
+		test.That(t, methods, test.ShouldResemble, tc.methods)
+	}
+
+	var buf bytes.Buffer
+	test.That(t, j.Export(&buf, Filter{Kinds: []Kind{KindRPC}}), test.ShouldBeNil)
+	scanner := bufio.NewScanner(&buf)
+	var lines int
+	for scanner.Scan() {
+		var e Event
+		test.That(t, json.Unmarshal(scanner.Bytes(), &e), test.ShouldBeNil)
+		test.That(t, e.Kind, test.ShouldEqual, KindRPC)
+		lines++
+	}
+	test.That(t, lines, test.ShouldEqual, 2)
+
+	// events survive reopening the journal
+	test.That(t, j.Close(), test.ShouldBeNil)
+	j.Record(Event{Kind: KindStopAll})
+	j, err = New(Config{Dir: dir}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, j.Close(), test.ShouldBeNil)
+	}()
+	events, err = j.Query(Filter{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, events, test.ShouldHaveLength, 4)
+}
+
+func TestJournalRotation(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	dir := t.TempDir()
+	j, err := New(Config{Dir: dir, MaxFileBytes: 200, MaxFiles: 2}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, j.Close(), test.ShouldBeNil)
+	}()
+
+	for i := 0; i < 20; i++ {
+		j.Record(Event{Kind: KindRPC, Method: "/service/Method", Details: map[string]interface{}{"i": i}})
+	}
+	for _, name := range []string{"events.jsonl", "events.1.jsonl", "events.2.jsonl"} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, info.Size(), test.ShouldBeLessThanOrEqualTo, 200)
+	}
+	_, err = os.Stat(filepath.Join(dir, "events.3.jsonl"))
+	test.That(t, os.IsNotExist(err), test.ShouldBeTrue)
+
+	// the oldest events were dropped, the others are still in order
+	events, err := j.Query(Filter{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(events), test.ShouldBeBetween, 0, 20)
+	last := events[len(events)-1].Details["i"].(float64)
+	test.That(t, last, test.ShouldEqual, 19)
+	for i, e := range events {
+		test.That(t, e.Details["i"], test.ShouldEqual, last-float64(len(events)-1-i))
+	}
+}
+
+func TestJournalQueryWhileRecording(t *testing.T) {
+	j, err := New(Config{Dir: t.TempDir(), MaxFileBytes: 500, MaxFiles: 2}, golog.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, j.Close(), test.ShouldBeNil)
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			j.Record(Event{Kind: KindRPC, Method: "/service/Method", Details: map[string]interface{}{"i": i}})
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		events, err := j.Query(Filter{})
+		test.That(t, err, test.ShouldBeNil)
+		for k := 1; k < len(events); k++ {
+			test.That(t, events[k].Details["i"], test.ShouldBeGreaterThan, events[k-1].Details["i"])
+		}
+	}
+	wg.Wait()
+}
+
+func TestSummarize(t *testing.T) {
+	req, err := structpb.NewStruct(map[string]interface{}{
+		"command": "ls",
+		"env":     map[string]interface{}{"API_KEY": "hunter2"},
+		"nested":  []interface{}{map[string]interface{}{"password": "hunter2"}},
+	})
+	test.That(t, err, test.ShouldBeNil)
+	summary := summarize(req)
+	test.That(t, summary, test.ShouldContainSubstring, `"ls"`)
+	test.That(t, summary, test.ShouldContainSubstring, redacted)
+	test.That(t, summary, test.ShouldNotContainSubstring, "hunter2")
+
+	req, err = structpb.NewStruct(map[string]interface{}{"command": strings.Repeat("é", maxArgsSummary)})
+	test.That(t, err, test.ShouldBeNil)
+	summary = summarize(req)
+	test.That(t, len(summary), test.ShouldBeLessThanOrEqualTo, maxArgsSummary+len("..."))
+	test.That(t, utf8.ValidString(summary), test.ShouldBeTrue)
+}
+
+func TestJournalInterceptor(t *testing.T) {
+	j, err := New(Config{Dir: t.TempDir()}, golog.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, j.Close(), test.ShouldBeNil)
+	}()
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 1, 2, 3), Port: 5555}})
+	info := &grpc.UnaryServerInfo{FullMethod: "/viam.component.arm.v1.ArmService/Stop"}
+	_, err = j.UnaryServerInterceptor(ctx, &pb.StopRequest{Name: "arm1"}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.New("arm is stuck")
+	})
+	test.That(t, err, test.ShouldBeError, errors.New("arm is stuck"))
+
+	ctx = rpc.ContextWithAuthEntity(ctx, "alice")
+	_, err = j.UnaryServerInterceptor(ctx, &pb.StopRequest{Name: "arm2"}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pb.StopResponse{}, nil
+	})
+	test.That(t, err, test.ShouldBeNil)
+
+	events, err := j.Query(Filter{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, events, test.ShouldHaveLength, 2)
+	test.That(t, events[0].Kind, test.ShouldEqual, KindRPC)
+	test.That(t, events[0].Method, test.ShouldEqual, info.FullMethod)
+	test.That(t, events[0].Resource, test.ShouldEqual, "arm1")
+	test.That(t, events[0].Caller, test.ShouldEqual, "10.1.2.3:5555")
+	test.That(t, events[0].Args, test.ShouldContainSubstring, `"arm1"`)
+	test.That(t, events[0].Error, test.ShouldEqual, "arm is stuck")
+	test.That(t, events[1].Resource, test.ShouldEqual, "arm2")
+	test.That(t, events[1].Caller, test.ShouldEqual, "alice")
+	test.That(t, events[1].Error, test.ShouldBeEmpty)
+}
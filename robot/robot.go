@@ -3,6 +3,8 @@ package robot
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/edaniels/golog"
 	commonpb "go.viam.com/api/common/v1"
@@ -14,6 +16,7 @@ import (
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
 	framesystemparts "go.viam.com/rdk/robot/framesystem/parts"
+	"go.viam.com/rdk/robot/journal"
 	weboptions "go.viam.com/rdk/robot/web/options"
 	"go.viam.com/rdk/utils"
 )
@@ -99,6 +102,154 @@ type LocalRobot interface {
 	StopWeb() error
 }
 
+// A ConfigValidator can validate a config and plan the reconfiguration it would cause, without
+// applying it or touching any hardware.
+type ConfigValidator interface {
+	// ValidateConfig validates the given unprocessed config and returns the changes reconfiguring
+	// the robot with it would make.
+	ValidateConfig(ctx context.Context, cfg *config.Config) (*ReconfigurationPlan, error)
+}
+
+// A ReconfigurationPlan describes what reconfiguring a robot with a new config would do. Resources
+// are identified by their full names and processes by their IDs.
+type ReconfigurationPlan struct {
+	Added    []string `json:"added"`
+	Modified []string `json:"modified"`
+	Removed  []string `json:"removed"`
+	// BuildOrder lists the resources of the new config, dependencies first.
+	BuildOrder []string `json:"build_order"`
+	// Warnings are problems that may resolve themselves once the robot runs, such as dependencies
+	// expected to come from a remote.
+	Warnings []string `json:"warnings,omitempty"`
+	// Errors are the problems that would make the reconfiguration fail.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Valid returns whether the config the plan was made for is valid.
+func (p *ReconfigurationPlan) Valid() bool {
+	return len(p.Errors) == 0
+}
+
+// String returns a human readable summary of the plan.
+func (p *ReconfigurationPlan) String() string {
+	var sb strings.Builder
+	section := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "%s:\n", title)
+		for _, line := range lines {
+			fmt.Fprintf(&sb, "  %s\n", line)
+		}
+	}
+	section("errors", p.Errors)
+	section("warnings", p.Warnings)
+	section("add", p.Added)
+	section("modify", p.Modified)
+	section("remove", p.Removed)
+	section("build order", p.BuildOrder)
+	if len(p.Added)+len(p.Modified)+len(p.Removed) == 0 {
+		sb.WriteString("no changes\n")
+	}
+	return sb.String()
+}
+
+// ConfigStatusName is the name under which a local robot reports its ConfigStatus in Status. It is
+// not a resource, so it is only reported when asked for by name.
+var ConfigStatusName = resource.NameFromSubtype(
+	resource.NewSubtype(resource.ResourceNamespaceRDK, resource.TypeName("robot"), resource.SubtypeName("config")),
+	"config",
+)
+
+// ConfigState is the outcome of reconfiguring a robot with a config version.
+type ConfigState string
+
+// The outcomes of a reconfiguration.
+const (
+	// ConfigApplied means the config version is the one the robot runs.
+	ConfigApplied = ConfigState("applied")
+	// ConfigRolledBack means a resource of the config version failed to build and the robot was
+	// restored to its previous config.
+	ConfigRolledBack = ConfigState("rolled_back")
+	// ConfigRollbackFailed means the robot could not be fully restored to its previous config after
+	// the config version failed; the failed resources of the previous config are retried in the background.
+	ConfigRollbackFailed = ConfigState("rollback_failed")
+)
+
+// A ConfigEvent records the reconfiguration of a robot with a config version.
+type ConfigEvent struct {
+	Version  int         `json:"version"`
+	State    ConfigState `json:"state"`
+	Time     string      `json:"time"`
+	Added    []string    `json:"added,omitempty"`
+	Modified []string    `json:"modified,omitempty"`
+	Removed  []string    `json:"removed,omitempty"`
+	// Failed lists the resources that could not be built.
+	Failed []string `json:"failed,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// ConfigStatus is the status of the config of a robot: the version it runs and the events of the
+// latest config versions it was reconfigured with, oldest first.
+type ConfigStatus struct {
+	Version int           `json:"version"`
+	History []ConfigEvent `json:"history"`
+}
+
+// HealthStatusName is the name under which a local robot reports the HealthStatus of its resources
+// in Status. It is not a resource, so it is only reported when asked for by name.
+var HealthStatusName = resource.NameFromSubtype(
+	resource.NewSubtype(resource.ResourceNamespaceRDK, resource.TypeName("robot"), resource.SubtypeName("health")),
+	"health",
+)
+
+// A HealthReporter reports the health of the resources of a robot.
+type HealthReporter interface {
+	// Health returns the health of the local resources of the given names, or of all of them if
+	// no names are given.
+	Health(ctx context.Context, resourceNames []resource.Name) ([]ResourceHealth, error)
+}
+
+// HealthState is the state of a resource of a robot.
+type HealthState string
+
+// The health states of a resource.
+const (
+	// HealthConfiguring means the resource is being built or waits for its dependencies.
+	HealthConfiguring = HealthState("configuring")
+	// HealthReady means the resource is built and healthy.
+	HealthReady = HealthState("ready")
+	// HealthDegraded means the resource is built but its latest health checks failed.
+	HealthDegraded = HealthState("degraded")
+	// HealthFailed means the resource failed to build, or failed too many health checks, and is
+	// rebuilt with backoff.
+	HealthFailed = HealthState("failed")
+)
+
+// ResourceHealth is the health of a resource of a robot.
+type ResourceHealth struct {
+	Name  string      `json:"name"`
+	State HealthState `json:"state"`
+	// Since is when the resource entered its state.
+	Since     string `json:"since"`
+	LastError string `json:"last_error,omitempty"`
+	// Retries is the number of times the resource failed to build since it last was ready.
+	Retries int `json:"retries"`
+	// NextRetry is when a failed resource is rebuilt next.
+	NextRetry string `json:"next_retry,omitempty"`
+}
+
+// HealthStatus is the status of the resources of a robot reported under HealthStatusName.
+type HealthStatus struct {
+	Resources []ResourceHealth `json:"resources"`
+}
+
+// An EventJournal keeps a journal of the actions taken on a robot.
+type EventJournal interface {
+	// Events returns the recorded events matching the filter, oldest first.
+	Events(ctx context.Context, filter journal.Filter) ([]journal.Event, error)
+}
+
 // A RemoteRobot is a Robot that was created through a connection.
 type RemoteRobot interface {
 	Robot
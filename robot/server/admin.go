@@ -0,0 +1,218 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils/protoutils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.viam.com/rdk/config"
+	pb "go.viam.com/rdk/proto/api/robot/v1"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/robot/journal"
+)
+
+// AdminServer implements the admin service for a robot.
+type AdminServer struct {
+	pb.UnimplementedAdminServiceServer
+	r robot.Robot
+}
+
+// NewAdmin constructs a gRPC admin service server for a Robot.
+func NewAdmin(r robot.Robot) pb.AdminServiceServer {
+	return &AdminServer{r: r}
+}
+
+// ValidateConfig validates the config of the request and returns the reconfiguration plan of the
+// robot for it.
+func (s *AdminServer) ValidateConfig(ctx context.Context, req *pb.ValidateConfigRequest) (*pb.ValidateConfigResponse, error) {
+	validator, ok := s.r.(robot.ConfigValidator)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "robot does not support config validation")
+	}
+	cfg, err := ConfigFromProto(req.Config)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "failed to decode config").Error())
+	}
+	plan, err := validator.ValidateConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ValidateConfigResponse{
+		Added:      plan.Added,
+		Modified:   plan.Modified,
+		Removed:    plan.Removed,
+		BuildOrder: plan.BuildOrder,
+		Warnings:   plan.Warnings,
+		Errors:     plan.Errors,
+	}, nil
+}
+
+// GetHealth returns the health of the resources named by the request.
+func (s *AdminServer) GetHealth(ctx context.Context, req *pb.GetHealthRequest) (*pb.GetHealthResponse, error) {
+	reporter, ok := s.r.(robot.HealthReporter)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "robot does not report resource health")
+	}
+	names := make([]resource.Name, 0, len(req.Names))
+	for _, n := range req.Names {
+		name, err := resource.NewFromString(n)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		names = append(names, name)
+	}
+	healths, err := reporter.Health(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.GetHealthResponse{Resources: make([]*pb.ResourceHealth, 0, len(healths))}
+	for _, h := range healths {
+		resp.Resources = append(resp.Resources, &pb.ResourceHealth{
+			Name:      h.Name,
+			State:     string(h.State),
+			Since:     h.Since,
+			LastError: h.LastError,
+			Retries:   int64(h.Retries),
+			NextRetry: h.NextRetry,
+		})
+	}
+	return resp, nil
+}
+
+// ListEvents returns the journal events of the robot matching the filter of the request.
+func (s *AdminServer) ListEvents(ctx context.Context, req *pb.ListEventsRequest) (*pb.ListEventsResponse, error) {
+	j, ok := s.r.(robot.EventJournal)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "robot keeps no event journal")
+	}
+	events, err := j.Events(ctx, FilterFromProto(req))
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListEventsResponse{Events: make([]*pb.Event, 0, len(events))}
+	for _, e := range events {
+		event, err := EventToProto(e)
+		if err != nil {
+			return nil, err
+		}
+		resp.Events = append(resp.Events, event)
+	}
+	return resp, nil
+}
+
+// ConfigToProto returns the JSON form of the config as a google.protobuf.Struct.
+func ConfigToProto(cfg *config.Config) (*structpb.Struct, error) {
+	md, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	s := &structpb.Struct{}
+	if err := s.UnmarshalJSON(md); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ConfigFromProto decodes the config held by the JSON form of ConfigToProto.
+func ConfigFromProto(s *structpb.Struct) (*config.Config, error) {
+	md, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var cfg config.Config
+	if err := json.Unmarshal(md, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// FilterToProto converts a journal filter to the ListEvents request selecting the same events.
+func FilterToProto(filter journal.Filter) *pb.ListEventsRequest {
+	req := &pb.ListEventsRequest{
+		Resource:   filter.Resource,
+		Method:     filter.Method,
+		Caller:     filter.Caller,
+		ErrorsOnly: filter.ErrorsOnly,
+		Limit:      int64(filter.Limit),
+	}
+	for _, k := range filter.Kinds {
+		req.Kinds = append(req.Kinds, string(k))
+	}
+	if !filter.Since.IsZero() {
+		req.Since = timestamppb.New(filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		req.Until = timestamppb.New(filter.Until)
+	}
+	return req
+}
+
+// FilterFromProto converts a ListEvents request to the journal filter it describes.
+func FilterFromProto(req *pb.ListEventsRequest) journal.Filter {
+	filter := journal.Filter{
+		Resource:   req.Resource,
+		Method:     req.Method,
+		Caller:     req.Caller,
+		ErrorsOnly: req.ErrorsOnly,
+		Limit:      int(req.Limit),
+	}
+	for _, k := range req.Kinds {
+		filter.Kinds = append(filter.Kinds, journal.Kind(k))
+	}
+	if req.Since != nil {
+		filter.Since = req.Since.AsTime()
+	}
+	if req.Until != nil {
+		filter.Until = req.Until.AsTime()
+	}
+	return filter
+}
+
+// EventToProto converts a journal event to its protobuf form.
+func EventToProto(e journal.Event) (*pb.Event, error) {
+	event := &pb.Event{
+		Time:       timestamppb.New(e.Time),
+		Kind:       string(e.Kind),
+		Resource:   e.Resource,
+		Method:     e.Method,
+		Caller:     e.Caller,
+		Args:       e.Args,
+		DurationMs: e.DurationMs,
+		Error:      e.Error,
+	}
+	if len(e.Details) != 0 {
+		details, err := protoutils.StructToStructPb(e.Details)
+		if err != nil {
+			return nil, err
+		}
+		event.Details = details
+	}
+	return event, nil
+}
+
+// EventFromProto converts the protobuf form of a journal event back to the event.
+func EventFromProto(event *pb.Event) journal.Event {
+	e := journal.Event{
+		Kind:       journal.Kind(event.Kind),
+		Resource:   event.Resource,
+		Method:     event.Method,
+		Caller:     event.Caller,
+		Args:       event.Args,
+		DurationMs: event.DurationMs,
+		Error:      event.Error,
+	}
+	if event.Time != nil {
+		e.Time = event.Time.AsTime()
+	}
+	if event.Details != nil {
+		e.Details = event.Details.AsMap()
+	}
+	return e
+}
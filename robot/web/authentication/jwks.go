@@ -0,0 +1,258 @@
+package authentication
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+	goutils "go.viam.com/utils"
+)
+
+const (
+	// keySetRefreshInterval is the least time between two fetches of the keys of an issuer, so
+	// that tokens with unknown key IDs cannot make the robot hammer the identity provider.
+	keySetRefreshInterval = time.Minute
+	keySetFetchTimeout    = 10 * time.Second
+)
+
+// A jsonWebKey is a public key of a JSON Web Key Set (RFC 7517).
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// A keySet holds the signing keys of an identity provider, by key ID.
+type keySet struct {
+	issuer string
+	logger golog.Logger
+
+	mu          sync.Mutex
+	keys        map[string]interface{}
+	lastFetched time.Time
+	// refreshing is closed when the keys being fetched from the issuer, if any, are in.
+	refreshing chan struct{}
+}
+
+func newFileKeySet(path string) (*keySet, error) {
+	//nolint:gosec
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read jwks file")
+	}
+	keys, err := parseKeySet(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse jwks file %q", path)
+	}
+	return &keySet{keys: keys}, nil
+}
+
+// newIssuerKeySet returns a key set fetched from the OpenID configuration of the issuer when
+// first needed, and refetched when a token is signed with an unknown key.
+func newIssuerKeySet(issuer string, logger golog.Logger) *keySet {
+	return &keySet{issuer: issuer, logger: logger, keys: map[string]interface{}{}}
+}
+
+// key returns the key with the given ID. An empty ID is only accepted from a set of one key. The keys
+// are fetched from the issuer without holding the lock, so that a slow identity provider only holds up
+// the tokens signed with unknown keys; concurrent lookups of unknown keys wait for the same fetch.
+func (ks *keySet) key(ctx context.Context, kid string) (interface{}, error) {
+	ks.mu.Lock()
+	if key, ok := ks.lookup(kid); ok {
+		ks.mu.Unlock()
+		return key, nil
+	}
+	if refreshing := ks.refreshing; refreshing != nil {
+		ks.mu.Unlock()
+		select {
+		case <-refreshing:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		ks.mu.Lock()
+		defer ks.mu.Unlock()
+		if key, ok := ks.lookup(kid); ok {
+			return key, nil
+		}
+		return nil, errors.Errorf("unknown signing key %q", kid)
+	}
+	if ks.issuer == "" || time.Since(ks.lastFetched) < keySetRefreshInterval {
+		ks.mu.Unlock()
+		return nil, errors.Errorf("unknown signing key %q", kid)
+	}
+	ks.lastFetched = time.Now()
+	refreshing := make(chan struct{})
+	ks.refreshing = refreshing
+	ks.mu.Unlock()
+
+	keys, err := fetchIssuerKeySet(ctx, ks.issuer)
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.refreshing = nil
+	close(refreshing)
+	if err != nil {
+		ks.logger.Warnw("failed to fetch signing keys of issuer", "issuer", ks.issuer, "error", err)
+		return nil, err
+	}
+	ks.keys = keys
+	if key, ok := ks.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, errors.Errorf("unknown signing key %q", kid)
+}
+
+func (ks *keySet) lookup(kid string) (interface{}, bool) {
+	if kid == "" && len(ks.keys) == 1 {
+		for _, key := range ks.keys {
+			return key, true
+		}
+	}
+	key, ok := ks.keys[kid]
+	return key, ok
+}
+
+func fetchIssuerKeySet(ctx context.Context, issuer string) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, keySetFetchTimeout)
+	defer cancel()
+	var discovery struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	data, err := fetch(ctx, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration")
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &discovery); err != nil {
+		return nil, errors.Wrap(err, "failed to parse openid configuration")
+	}
+	if discovery.JWKSURI == "" {
+		return nil, errors.New("openid configuration has no jwks_uri")
+	}
+	if data, err = fetch(ctx, discovery.JWKSURI); err != nil {
+		return nil, err
+	}
+	return parseKeySet(data)
+}
+
+func fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		goutils.UncheckedError(resp.Body.Close())
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %q fetching %q", resp.Status, url)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// errUnsupportedKey is returned for keys of a type or curve that cannot verify tokens.
+var errUnsupportedKey = errors.New("unsupported key")
+
+// parseKeySet returns the signature keys of a JSON Web Key Set, by key ID. Keys of unsupported types or
+// curves are skipped, since identity providers often publish them alongside the keys they sign with.
+func parseKeySet(data []byte) (map[string]interface{}, error) {
+	var set jsonWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if errors.Is(err, errUnsupportedKey) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "key %q", jwk.Kid)
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("key set has no signature keys")
+	}
+	return keys, nil
+}
+
+func (jwk *jsonWebKey) publicKey() (interface{}, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() {
+			return nil, errors.New("rsa exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.Wrapf(errUnsupportedKey, "curve %q", jwk.Crv)
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, errors.Wrapf(errUnsupportedKey, "key type %q", jwk.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,97 @@
+package authentication
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+)
+
+func TestParseKeySetSkipsUnsupportedKeys(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	test.That(t, err, test.ShouldBeNil)
+	_, data := writeKeySet(t,
+		jsonWebKey{Kty: "OKP", Kid: "ed25519", Crv: "Ed25519", X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"},
+		jsonWebKey{Kty: "EC", Kid: "k256", Crv: "secp256k1", X: "AA", Y: "AA"},
+		jsonWebKey{Kty: "RSA", Kid: "rsa", N: encodeBigInt(key.N), E: encodeBigInt(big.NewInt(int64(key.E)))},
+	)
+	keys, err := parseKeySet(data)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, keys, test.ShouldHaveLength, 1)
+	test.That(t, keys["rsa"], test.ShouldResemble, &key.PublicKey)
+
+	_, data = writeKeySet(t, jsonWebKey{Kty: "OKP", Kid: "ed25519", Crv: "Ed25519", X: "AA"})
+	_, err = parseKeySet(data)
+	test.That(t, err, test.ShouldBeError, "key set has no signature keys")
+
+	// malformed keys of a supported type still fail the set
+	_, data = writeKeySet(t, jsonWebKey{Kty: "RSA", Kid: "rsa", N: "!", E: "AQAB"})
+	_, err = parseKeySet(data)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestKeySetFetchDoesNotBlockKnownKeys(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	test.That(t, err, test.ShouldBeNil)
+	_, jwks := writeKeySet(t, jsonWebKey{Kty: "RSA", Kid: "key1", N: encodeBigInt(key.N), E: encodeBigInt(big.NewInt(int64(key.E)))})
+
+	var fetches int32
+	release := make(chan struct{})
+	var idp *httptest.Server
+	idp = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			atomic.AddInt32(&fetches, 1)
+			<-release
+			//nolint:errcheck
+			json.NewEncoder(w).Encode(map[string]string{"issuer": idp.URL, "jwks_uri": idp.URL + "/keys"})
+		case "/keys":
+			//nolint:errcheck
+			w.Write(jwks)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer idp.Close()
+
+	ks := newIssuerKeySet(idp.URL, logger)
+	ks.keys["key0"] = &key.PublicKey
+
+	results := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := ks.key(ctx, "key1")
+			results <- err
+		}()
+	}
+	for atomic.LoadInt32(&fetches) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// known keys are served while the issuer is slow to answer
+	known, err := ks.key(ctx, "key0")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, known, test.ShouldEqual, &key.PublicKey)
+	select {
+	case err := <-results:
+		t.Fatalf("lookup of an unknown key returned before the fetch finished: %v", err)
+	default:
+	}
+
+	close(release)
+	test.That(t, <-results, test.ShouldBeNil)
+	test.That(t, <-results, test.ShouldBeNil)
+	test.That(t, atomic.LoadInt32(&fetches), test.ShouldEqual, 1)
+}
@@ -0,0 +1,137 @@
+// Package authentication implements auth handlers for credentials issued outside of the robot:
+// JSON Web Tokens from an external identity provider and TLS client certificates.
+//
+// Both handlers are used through the Authenticate method of the RPC auth service, which exchanges
+// the external credentials for an access token of the robot. The roles they grant are passed to
+// the authorization package through authorization.RolesMetadataKey.
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"go.viam.com/utils/rpc"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/robot/web/authorization"
+)
+
+// JWTConfig describes how to verify JSON Web Tokens issued by an external identity provider.
+type JWTConfig struct {
+	// Issuer is the expected "iss" claim. Unless JWKSFile is set, the signing keys are
+	// discovered from the OpenID configuration of the issuer.
+	Issuer string `json:"issuer"`
+	// JWKSFile is a JSON Web Key Set file holding the signing keys.
+	JWKSFile string `json:"jwks_file"`
+	// Audience is the "aud" claim tokens must be issued for.
+	Audience string `json:"audience"`
+	// SubjectClaim identifies the caller, "sub" by default.
+	SubjectClaim string `json:"subject_claim"`
+	// RolesClaim holds a string or a list of strings mapped to roles, "roles" by default.
+	RolesClaim string `json:"roles_claim"`
+	// RoleMapping maps the values of the roles claim to roles. If empty, the values are
+	// used as role names directly.
+	RoleMapping map[string][]string `json:"role_mapping"`
+}
+
+// validJWTMethods are the asymmetric signing methods accepted from identity providers.
+var validJWTMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+type jwtAuthHandler struct {
+	cfg           *JWTConfig
+	checkEntities func(ctx context.Context, entities ...string) error
+	keys          *keySet
+}
+
+// NewJWTAuthHandler returns an auth handler authenticating the given robot entities with
+// externally issued JWTs as payload, configured by the attributes of a JWTConfig.
+func NewJWTAuthHandler(entities []string, attributes config.AttributeMap, logger golog.Logger) (rpc.AuthHandler, error) {
+	converted, err := config.TransformAttributeMapToStruct(&JWTConfig{}, attributes)
+	if err != nil {
+		return nil, err
+	}
+	cfg := converted.(*JWTConfig)
+	if cfg.Audience == "" {
+		return nil, errors.New("jwt auth handler requires an audience")
+	}
+	if cfg.SubjectClaim == "" {
+		cfg.SubjectClaim = "sub"
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = "roles"
+	}
+	var keys *keySet
+	switch {
+	case cfg.JWKSFile != "":
+		if keys, err = newFileKeySet(cfg.JWKSFile); err != nil {
+			return nil, err
+		}
+	case cfg.Issuer != "":
+		keys = newIssuerKeySet(cfg.Issuer, logger)
+	default:
+		return nil, errors.New("jwt auth handler requires an issuer or jwks_file")
+	}
+	return &jwtAuthHandler{cfg: cfg, checkEntities: rpc.MakeEntitiesChecker(entities), keys: keys}, nil
+}
+
+func (h *jwtAuthHandler) Authenticate(ctx context.Context, entity, payload string) (map[string]string, error) {
+	if err := h.checkEntities(ctx, entity); err != nil {
+		return nil, err
+	}
+	var claims jwt.MapClaims
+	parser := jwt.NewParser(jwt.WithValidMethods(validJWTMethods))
+	if _, err := parser.ParseWithClaims(payload, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return h.keys.key(ctx, kid)
+	}); err != nil {
+		return nil, errors.Wrap(err, "invalid token")
+	}
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("token has no expiration or is expired")
+	}
+	if !claims.VerifyAudience(h.cfg.Audience, true) {
+		return nil, errors.Errorf("token is not issued for audience %q", h.cfg.Audience)
+	}
+	if h.cfg.Issuer != "" && !claims.VerifyIssuer(h.cfg.Issuer, true) {
+		return nil, errors.Errorf("token is not issued by %q", h.cfg.Issuer)
+	}
+
+	authMD := map[string]string{}
+	if subject, ok := claims[h.cfg.SubjectClaim].(string); ok && subject != "" {
+		authMD[authorization.SubjectMetadataKey] = subject
+	}
+	if roles := h.roles(claims[h.cfg.RolesClaim]); len(roles) != 0 {
+		authMD[authorization.RolesMetadataKey] = strings.Join(roles, ",")
+	}
+	return authMD, nil
+}
+
+// roles maps the value of the roles claim to roles.
+func (h *jwtAuthHandler) roles(claim interface{}) []string {
+	var values []string
+	switch v := claim.(type) {
+	case string:
+		values = strings.Fields(v)
+	case []interface{}:
+		for _, value := range v {
+			values = append(values, fmt.Sprint(value))
+		}
+	}
+	if len(h.cfg.RoleMapping) == 0 {
+		return values
+	}
+	var roles []string
+	for _, value := range values {
+		roles = append(roles, h.cfg.RoleMapping[value]...)
+	}
+	return roles
+}
+
+func (h *jwtAuthHandler) VerifyEntity(ctx context.Context, entity string) (interface{}, error) {
+	return entity, h.checkEntities(ctx, entity)
+}
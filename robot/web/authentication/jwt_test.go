@@ -0,0 +1,190 @@
+package authentication
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/golang-jwt/jwt/v4"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/robot/web/authorization"
+)
+
+const (
+	testEntity   = "robot.local"
+	testAudience = "rdk"
+)
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func writeKeySet(t *testing.T, keys ...jsonWebKey) (string, []byte) {
+	t.Helper()
+	data, err := json.Marshal(jsonWebKeySet{Keys: keys})
+	test.That(t, err, test.ShouldBeNil)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	test.That(t, os.WriteFile(path, data, 0o600), test.ShouldBeNil)
+	return path, data
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	test.That(t, err, test.ShouldBeNil)
+	return signed
+}
+
+func TestJWTAuthHandler(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	test.That(t, err, test.ShouldBeNil)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err, test.ShouldBeNil)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	test.That(t, err, test.ShouldBeNil)
+	jwksFile, _ := writeKeySet(t,
+		jsonWebKey{Kty: "RSA", Kid: "rsa", Use: "sig", N: encodeBigInt(rsaKey.N), E: encodeBigInt(big.NewInt(int64(rsaKey.E)))},
+		jsonWebKey{Kty: "EC", Kid: "ec", Crv: "P-256", X: encodeBigInt(ecKey.X), Y: encodeBigInt(ecKey.Y)},
+	)
+
+	_, err = NewJWTAuthHandler([]string{testEntity}, config.AttributeMap{"jwks_file": jwksFile}, logger)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "audience")
+	_, err = NewJWTAuthHandler([]string{testEntity}, config.AttributeMap{"audience": testAudience}, logger)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "issuer or jwks_file")
+
+	handler, err := NewJWTAuthHandler([]string{testEntity}, config.AttributeMap{
+		"jwks_file":    jwksFile,
+		"issuer":       "https://idp.example.com",
+		"audience":     testAudience,
+		"roles_claim":  "groups",
+		"role_mapping": map[string]interface{}{"robot-admins": []interface{}{"admin"}, "robot-users": []interface{}{"operator"}},
+	}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":    "alice@example.com",
+			"iss":    "https://idp.example.com",
+			"aud":    []string{"other", testAudience},
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"groups": []string{"robot-users", "everyone"},
+		}
+	}
+
+	t.Run("valid tokens", func(t *testing.T) {
+		authMD, err := handler.Authenticate(ctx, testEntity, signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, validClaims()))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, authMD, test.ShouldResemble, map[string]string{
+			authorization.SubjectMetadataKey: "alice@example.com",
+			authorization.RolesMetadataKey:   "operator",
+		})
+
+		claims := validClaims()
+		claims["groups"] = "robot-admins robot-users"
+		authMD, err = handler.Authenticate(ctx, testEntity, signToken(t, jwt.SigningMethodES256, "ec", ecKey, claims))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, authMD[authorization.RolesMetadataKey], test.ShouldEqual, "admin,operator")
+
+		entity, err := handler.VerifyEntity(ctx, testEntity)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, entity, test.ShouldEqual, testEntity)
+	})
+
+	t.Run("invalid tokens", func(t *testing.T) {
+		_, err := handler.Authenticate(ctx, "someone.else", signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, validClaims()))
+		test.That(t, err, test.ShouldNotBeNil)
+
+		_, err = handler.Authenticate(ctx, testEntity, signToken(t, jwt.SigningMethodRS256, "rsa", otherKey, validClaims()))
+		test.That(t, err.Error(), test.ShouldContainSubstring, "invalid token")
+		_, err = handler.Authenticate(ctx, testEntity, signToken(t, jwt.SigningMethodRS256, "unknown", rsaKey, validClaims()))
+		test.That(t, err.Error(), test.ShouldContainSubstring, "unknown signing key")
+		_, err = handler.Authenticate(ctx, testEntity, signToken(t, jwt.SigningMethodHS256, "rsa", []byte("secret"), validClaims()))
+		test.That(t, err.Error(), test.ShouldContainSubstring, "signing method HS256 is invalid")
+
+		claims := validClaims()
+		claims["aud"] = "other"
+		_, err = handler.Authenticate(ctx, testEntity, signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims))
+		test.That(t, err.Error(), test.ShouldContainSubstring, "audience")
+
+		claims = validClaims()
+		claims["iss"] = "https://evil.example.com"
+		_, err = handler.Authenticate(ctx, testEntity, signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims))
+		test.That(t, err.Error(), test.ShouldContainSubstring, "not issued by")
+
+		claims = validClaims()
+		claims["exp"] = time.Now().Add(-time.Minute).Unix()
+		_, err = handler.Authenticate(ctx, testEntity, signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims))
+		test.That(t, err.Error(), test.ShouldContainSubstring, "expired")
+
+		claims = validClaims()
+		delete(claims, "exp")
+		_, err = handler.Authenticate(ctx, testEntity, signToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims))
+		test.That(t, err.Error(), test.ShouldContainSubstring, "no expiration")
+	})
+}
+
+func TestJWTAuthHandlerIssuerDiscovery(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	test.That(t, err, test.ShouldBeNil)
+	_, jwks := writeKeySet(t, jsonWebKey{Kty: "RSA", Kid: "key1", N: encodeBigInt(key.N), E: encodeBigInt(big.NewInt(int64(key.E)))})
+
+	var fetches int32
+	var idp *httptest.Server
+	idp = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			atomic.AddInt32(&fetches, 1)
+			//nolint:errcheck
+			json.NewEncoder(w).Encode(map[string]string{"issuer": idp.URL, "jwks_uri": idp.URL + "/keys"})
+		case "/keys":
+			//nolint:errcheck
+			w.Write(jwks)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer idp.Close()
+
+	handler, err := NewJWTAuthHandler([]string{testEntity}, config.AttributeMap{"issuer": idp.URL, "audience": testAudience}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	claims := jwt.MapClaims{
+		"sub":   "bob",
+		"iss":   idp.URL,
+		"aud":   testAudience,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"read_only"},
+	}
+	authMD, err := handler.Authenticate(ctx, testEntity, signToken(t, jwt.SigningMethodRS256, "key1", key, claims))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, authMD[authorization.RolesMetadataKey], test.ShouldEqual, "read_only")
+
+	// unknown keys do not refetch the keys more than once a minute
+	_, err = handler.Authenticate(ctx, testEntity, signToken(t, jwt.SigningMethodRS256, "key2", key, claims))
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = handler.Authenticate(ctx, testEntity, signToken(t, jwt.SigningMethodRS256, "key1", key, claims))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, atomic.LoadInt32(&fetches), test.ShouldEqual, 1)
+}
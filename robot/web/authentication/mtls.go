@@ -0,0 +1,213 @@
+package authentication
+
+import (
+	"context"
+	"crypto/x509"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/robot/web/authorization"
+	rutils "go.viam.com/rdk/utils"
+)
+
+// MutualTLSConfig describes which TLS client certificates are accepted.
+type MutualTLSConfig struct {
+	// CAFile holds the PEM encoded certificates of the authorities issuing client certificates.
+	CAFile string `json:"ca_file"`
+	// Subjects lists the accepted certificate subjects. If empty, every certificate issued by
+	// the authorities is accepted, without any role.
+	Subjects []SubjectConfig `json:"subjects"`
+}
+
+// SubjectConfig matches certificate subjects and grants roles to them.
+type SubjectConfig struct {
+	// Subject is a comma separated list of attribute=pattern pairs, such as "CN=arm-*,O=Acme",
+	// which must all match. Patterns use path.Match syntax. The supported attributes are
+	// CN, O, OU, C, L, ST and SERIALNUMBER.
+	Subject string   `json:"subject"`
+	Roles   []string `json:"roles"`
+}
+
+type mutualTLSAuthHandler struct {
+	roots         *x509.CertPool
+	subjects      []subjectMatcher
+	checkEntities func(ctx context.Context, entities ...string) error
+}
+
+// NewMutualTLSAuthHandler returns an auth handler authenticating the given robot entities with
+// the client certificate of the TLS connection, configured by the attributes of a MutualTLSConfig.
+// The payload of the credentials is ignored.
+func NewMutualTLSAuthHandler(entities []string, attributes config.AttributeMap) (rpc.AuthHandler, error) {
+	converted, err := config.TransformAttributeMapToStruct(&MutualTLSConfig{}, attributes)
+	if err != nil {
+		return nil, err
+	}
+	cfg := converted.(*MutualTLSConfig)
+	roots, err := readCertPool(x509.NewCertPool(), cfg.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	h := &mutualTLSAuthHandler{roots: roots, checkEntities: rpc.MakeEntitiesChecker(entities)}
+	for _, subject := range cfg.Subjects {
+		matcher, err := newSubjectMatcher(subject)
+		if err != nil {
+			return nil, err
+		}
+		h.subjects = append(h.subjects, matcher)
+	}
+	return h, nil
+}
+
+// ClientCAs returns the pool of authorities trusted to issue client certificates: the system
+// pool plus the authorities of every mutual TLS handler. It returns nil if there is no such handler.
+func ClientCAs(handlers []config.AuthHandlerConfig) (*x509.CertPool, error) {
+	var pool *x509.CertPool
+	for _, handler := range handlers {
+		if handler.Type != rutils.CredentialsTypeMutualTLS {
+			continue
+		}
+		if pool == nil {
+			var err error
+			if pool, err = x509.SystemCertPool(); err != nil {
+				pool = x509.NewCertPool()
+			}
+		}
+		if _, err := readCertPool(pool, handler.Config.String("ca_file")); err != nil {
+			return nil, err
+		}
+	}
+	return pool, nil
+}
+
+func readCertPool(pool *x509.CertPool, caFile string) (*x509.CertPool, error) {
+	if caFile == "" {
+		return nil, errors.New("mtls auth handler requires a ca_file")
+	}
+	//nolint:gosec
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read ca_file")
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.Errorf("no certificate found in %q", caFile)
+	}
+	return pool, nil
+}
+
+func (h *mutualTLSAuthHandler) Authenticate(ctx context.Context, entity, payload string) (map[string]string, error) {
+	if err := h.checkEntities(ctx, entity); err != nil {
+		return nil, err
+	}
+	cert, err := h.verifiedClientCertificate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	authMD := map[string]string{authorization.SubjectMetadataKey: cert.Subject.String()}
+	if len(h.subjects) == 0 {
+		return authMD, nil
+	}
+	var roles []string
+	matched := false
+	for _, subject := range h.subjects {
+		if subject.matches(cert) {
+			matched = true
+			roles = append(roles, subject.roles...)
+		}
+	}
+	if !matched {
+		return nil, errors.Errorf("client certificate subject %q is not allowed", cert.Subject)
+	}
+	if len(roles) != 0 {
+		authMD[authorization.RolesMetadataKey] = strings.Join(roles, ",")
+	}
+	return authMD, nil
+}
+
+// verifiedClientCertificate returns the client certificate of the connection of the call, once
+// verified against the configured authorities.
+func (h *mutualTLSAuthHandler) verifiedClientCertificate(ctx context.Context) (*x509.Certificate, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return nil, errors.New("no client certificate presented")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return nil, errors.New("no client certificate presented")
+	}
+	cert := tlsInfo.State.PeerCertificates[0]
+	intermediates := x509.NewCertPool()
+	for _, intermediate := range tlsInfo.State.PeerCertificates[1:] {
+		intermediates.AddCert(intermediate)
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         h.roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return nil, errors.Wrap(err, "invalid client certificate")
+	}
+	return cert, nil
+}
+
+func (h *mutualTLSAuthHandler) VerifyEntity(ctx context.Context, entity string) (interface{}, error) {
+	return entity, h.checkEntities(ctx, entity)
+}
+
+type subjectMatcher struct {
+	patterns map[string]string
+	roles    []string
+}
+
+func newSubjectMatcher(cfg SubjectConfig) (subjectMatcher, error) {
+	m := subjectMatcher{patterns: map[string]string{}, roles: cfg.Roles}
+	for _, pair := range strings.Split(cfg.Subject, ",") {
+		attr, pattern, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return subjectMatcher{}, errors.Errorf("subject %q should be a list of attribute=pattern pairs", cfg.Subject)
+		}
+		attr = strings.ToUpper(strings.TrimSpace(attr))
+		if _, ok := subjectAttributes[attr]; !ok {
+			return subjectMatcher{}, errors.Errorf("unsupported subject attribute %q", attr)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return subjectMatcher{}, errors.Wrapf(err, "subject %q", cfg.Subject)
+		}
+		m.patterns[attr] = pattern
+	}
+	return m, nil
+}
+
+var subjectAttributes = map[string]func(cert *x509.Certificate) []string{
+	"CN":           func(cert *x509.Certificate) []string { return []string{cert.Subject.CommonName} },
+	"O":            func(cert *x509.Certificate) []string { return cert.Subject.Organization },
+	"OU":           func(cert *x509.Certificate) []string { return cert.Subject.OrganizationalUnit },
+	"C":            func(cert *x509.Certificate) []string { return cert.Subject.Country },
+	"L":            func(cert *x509.Certificate) []string { return cert.Subject.Locality },
+	"ST":           func(cert *x509.Certificate) []string { return cert.Subject.Province },
+	"SERIALNUMBER": func(cert *x509.Certificate) []string { return []string{cert.Subject.SerialNumber} },
+}
+
+// matches returns whether every attribute pattern matches one of the values of the attribute.
+func (m subjectMatcher) matches(cert *x509.Certificate) bool {
+	for attr, pattern := range m.patterns {
+		found := false
+		for _, value := range subjectAttributes[attr](cert) {
+			if ok, _ := path.Match(pattern, value); ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
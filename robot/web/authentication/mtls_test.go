@@ -0,0 +1,156 @@
+package authentication
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/robot/web/authorization"
+	rutils "go.viam.com/rdk/utils"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err, test.ShouldBeNil)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	test.That(t, err, test.ShouldBeNil)
+	cert, err := x509.ParseCertificate(der)
+	test.That(t, err, test.ShouldBeNil)
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) write(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+	test.That(t, os.WriteFile(path, data, 0o600), test.ShouldBeNil)
+	return path
+}
+
+func (ca *testCA) issue(t *testing.T, subject pkix.Name, usage x509.ExtKeyUsage) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.That(t, err, test.ShouldBeNil)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      subject,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	test.That(t, err, test.ShouldBeNil)
+	cert, err := x509.ParseCertificate(der)
+	test.That(t, err, test.ShouldBeNil)
+	return cert
+}
+
+func contextWithPeerCertificates(certs ...*x509.Certificate) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: certs}},
+	})
+}
+
+func TestMutualTLSAuthHandler(t *testing.T) {
+	ca := newTestCA(t, "Acme Devices")
+	caFile := ca.write(t)
+	otherCA := newTestCA(t, "Somebody Else")
+
+	_, err := NewMutualTLSAuthHandler([]string{testEntity}, config.AttributeMap{})
+	test.That(t, err.Error(), test.ShouldContainSubstring, "ca_file")
+	_, err = NewMutualTLSAuthHandler([]string{testEntity}, config.AttributeMap{
+		"ca_file":  caFile,
+		"subjects": []interface{}{map[string]interface{}{"subject": "EMAIL=a@b.c"}},
+	})
+	test.That(t, err.Error(), test.ShouldContainSubstring, "unsupported subject attribute")
+
+	handler, err := NewMutualTLSAuthHandler([]string{testEntity}, config.AttributeMap{
+		"ca_file": caFile,
+		"subjects": []interface{}{
+			map[string]interface{}{"subject": "CN=arm-*, O=Acme", "roles": []interface{}{"arm_operator"}},
+			map[string]interface{}{"subject": "OU=Maintenance", "roles": []interface{}{"admin"}},
+		},
+	})
+	test.That(t, err, test.ShouldBeNil)
+
+	arm := ca.issue(t, pkix.Name{CommonName: "arm-7", Organization: []string{"Acme"}}, x509.ExtKeyUsageClientAuth)
+	authMD, err := handler.Authenticate(contextWithPeerCertificates(arm), testEntity, "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, authMD, test.ShouldResemble, map[string]string{
+		authorization.SubjectMetadataKey: "CN=arm-7,O=Acme",
+		authorization.RolesMetadataKey:   "arm_operator",
+	})
+
+	both := ca.issue(t, pkix.Name{
+		CommonName:         "arm-8",
+		Organization:       []string{"Acme"},
+		OrganizationalUnit: []string{"Robots", "Maintenance"},
+	}, x509.ExtKeyUsageClientAuth)
+	authMD, err = handler.Authenticate(contextWithPeerCertificates(both), testEntity, "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, authMD[authorization.RolesMetadataKey], test.ShouldEqual, "arm_operator,admin")
+
+	_, err = handler.Authenticate(context.Background(), testEntity, "")
+	test.That(t, err.Error(), test.ShouldContainSubstring, "no client certificate")
+
+	gripper := ca.issue(t, pkix.Name{CommonName: "gripper-1", Organization: []string{"Acme"}}, x509.ExtKeyUsageClientAuth)
+	_, err = handler.Authenticate(contextWithPeerCertificates(gripper), testEntity, "")
+	test.That(t, err.Error(), test.ShouldContainSubstring, "is not allowed")
+
+	impostor := otherCA.issue(t, pkix.Name{CommonName: "arm-7", Organization: []string{"Acme"}}, x509.ExtKeyUsageClientAuth)
+	_, err = handler.Authenticate(contextWithPeerCertificates(impostor), testEntity, "")
+	test.That(t, err.Error(), test.ShouldContainSubstring, "invalid client certificate")
+
+	server := ca.issue(t, pkix.Name{CommonName: "arm-7", Organization: []string{"Acme"}}, x509.ExtKeyUsageServerAuth)
+	_, err = handler.Authenticate(contextWithPeerCertificates(server), testEntity, "")
+	test.That(t, err.Error(), test.ShouldContainSubstring, "invalid client certificate")
+
+	_, err = handler.Authenticate(contextWithPeerCertificates(arm), "someone.else", "")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestClientCAs(t *testing.T) {
+	pool, err := ClientCAs([]config.AuthHandlerConfig{{Type: "api-key"}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pool, test.ShouldBeNil)
+
+	ca := newTestCA(t, "Acme Devices")
+	pool, err = ClientCAs([]config.AuthHandlerConfig{
+		{Type: rutils.CredentialsTypeMutualTLS, Config: config.AttributeMap{"ca_file": ca.write(t)}},
+	})
+	test.That(t, err, test.ShouldBeNil)
+	client := ca.issue(t, pkix.Name{CommonName: "arm-7"}, x509.ExtKeyUsageClientAuth)
+	_, err = client.Verify(x509.VerifyOptions{Roots: pool, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	test.That(t, err, test.ShouldBeNil)
+}
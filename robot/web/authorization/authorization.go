@@ -0,0 +1,270 @@
+// Package authorization enforces role-based access to the RPC methods and resources of a robot.
+//
+// Roles are configured in config.AuthConfig. Auth handlers wrapped by an Authorizer bind the
+// roles of a caller to its authentication entity, and the Authorizer's interceptors check every
+// call against those roles before it reaches a subtype server. Denied calls are audit-logged.
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/resource"
+)
+
+// Auth handlers that identify callers more precisely than by their entity can return these keys
+// in the auth metadata of Authenticate.
+const (
+	// RolesMetadataKey carries the comma separated roles granted to the caller.
+	RolesMetadataKey = "rdk_roles"
+	// SubjectMetadataKey carries the identity of the caller, such as the subject of a token.
+	SubjectMetadataKey = "rdk_subject"
+)
+
+// anonymousEntity is the entity of the callers of a web server without authentication.
+const anonymousEntity = "anonymous"
+
+// A Principal is the authentication entity of a caller when authorization is enabled.
+type Principal struct {
+	Entity string
+	Roles  []string
+}
+
+// String returns the entity of the principal, so that it is what logs and the event journal record
+// as the caller.
+func (p *Principal) String() string {
+	return p.Entity
+}
+
+// An Authorizer checks calls against the roles of the calling Principal.
+type Authorizer struct {
+	roles        map[string]*role
+	keyRoles     map[string][]string
+	entityRoles  map[string][]string
+	defaultRoles []string
+	// unauthenticated is set when the config has no auth handlers, in which case the web server
+	// does not authenticate callers and every caller is anonymous.
+	unauthenticated bool
+	logger          golog.Logger
+
+	servicesOnce sync.Once
+	services     map[string]resource.Subtype
+}
+
+// New returns an Authorizer for the roles of the given config, or nil if the config
+// does not enable authorization. When the config has no auth handlers, the web server does not
+// authenticate callers, and the Authorizer only grants them the default role.
+func New(cfg config.AuthConfig, logger golog.Logger) (*Authorizer, error) {
+	if !cfg.AuthorizationEnabled() {
+		return nil, nil
+	}
+	a := &Authorizer{
+		roles:           make(map[string]*role, len(cfg.Roles)+3),
+		keyRoles:        map[string][]string{},
+		entityRoles:     map[string][]string{},
+		unauthenticated: len(cfg.Handlers) == 0,
+		logger:          logger,
+	}
+	for _, level := range []config.AccessLevel{config.AccessReadOnly, config.AccessOperator, config.AccessAdmin} {
+		a.roles[string(level)] = &role{name: string(level), access: level}
+	}
+	for _, roleCfg := range cfg.Roles {
+		r, err := newRole(roleCfg)
+		if err != nil {
+			return nil, err
+		}
+		a.roles[r.name] = r
+	}
+	for _, binding := range cfg.RoleBindings {
+		if _, ok := a.roles[binding.Role]; !ok {
+			return nil, errors.Errorf("unknown role %q", binding.Role)
+		}
+		for _, key := range binding.APIKeys {
+			a.keyRoles[key] = append(a.keyRoles[key], binding.Role)
+		}
+		for _, entity := range binding.Entities {
+			a.entityRoles[entity] = append(a.entityRoles[entity], binding.Role)
+		}
+	}
+	if cfg.DefaultRole != "" {
+		if _, ok := a.roles[cfg.DefaultRole]; !ok {
+			return nil, errors.Errorf("unknown default role %q", cfg.DefaultRole)
+		}
+		a.defaultRoles = []string{cfg.DefaultRole}
+	}
+	return a, nil
+}
+
+// AuthHandler wraps an auth handler so that the entities it verifies become Principals. Callers
+// authenticating with the handler get the given roles, or when none are given, the roles bound to
+// the API key they authenticated with. Either way they also get the roles the handler grants through
+// RolesMetadataKey, the roles bound to their entity or subject, and the default role if they end up
+// with no role at all.
+func (a *Authorizer) AuthHandler(handler rpc.AuthHandler, roles ...string) rpc.AuthHandler {
+	return &authHandler{AuthHandler: handler, a: a, roles: roles}
+}
+
+type authHandler struct {
+	rpc.AuthHandler
+	a     *Authorizer
+	roles []string
+}
+
+func (h *authHandler) Authenticate(ctx context.Context, entity, payload string) (map[string]string, error) {
+	authMD, err := h.AuthHandler.Authenticate(ctx, entity, payload)
+	if err != nil {
+		return nil, err
+	}
+	roles := h.roles
+	if len(roles) == 0 {
+		roles = h.a.keyRoles[payload]
+	}
+	if len(roles) == 0 {
+		return authMD, nil
+	}
+	withRoles := make(map[string]string, len(authMD)+1)
+	for k, v := range authMD {
+		withRoles[k] = v
+	}
+	if handlerRoles := authMD[RolesMetadataKey]; handlerRoles != "" {
+		roles = append(strings.Split(handlerRoles, ","), roles...)
+	}
+	withRoles[RolesMetadataKey] = strings.Join(roles, ",")
+	return withRoles, nil
+}
+
+func (h *authHandler) VerifyEntity(ctx context.Context, entity string) (interface{}, error) {
+	if _, err := h.AuthHandler.VerifyEntity(ctx, entity); err != nil {
+		return nil, err
+	}
+	authMD := rpc.ContextAuthMetadata(ctx)
+	var roles []string
+	if tokenRoles := authMD[RolesMetadataKey]; tokenRoles != "" {
+		roles = strings.Split(tokenRoles, ",")
+	}
+	if subject := authMD[SubjectMetadataKey]; subject != "" {
+		return h.a.principal(subject, roles, entity, subject), nil
+	}
+	return h.a.principal(entity, roles, entity), nil
+}
+
+// VerifyTLSEntity returns the Principal of a caller authenticated by a TLS client certificate
+// with the given DNS names. It is meant for rpc.WithTLSAuthHandler.
+func (a *Authorizer) VerifyTLSEntity(ctx context.Context, entities ...string) (interface{}, error) {
+	if len(entities) == 0 {
+		return nil, errors.New("no entity to verify")
+	}
+	return a.principal(entities[0], nil, entities...), nil
+}
+
+func (a *Authorizer) principal(entity string, roles []string, boundEntities ...string) *Principal {
+	for _, e := range boundEntities {
+		roles = append(roles, a.entityRoles[e]...)
+	}
+	if len(roles) == 0 {
+		roles = a.defaultRoles
+	}
+	return &Principal{Entity: entity, Roles: roles}
+}
+
+// UnaryServerInterceptor denies unary calls that the roles of the caller do not allow.
+func (a *Authorizer) UnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if err := a.authorize(ctx, info.FullMethod, req, true); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor denies streaming calls that the roles of the caller do not allow. The
+// method is checked when the stream opens and the resource of every received message is checked
+// as it arrives.
+func (a *Authorizer) StreamServerInterceptor(
+	srv interface{},
+	serverStream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := a.authorize(serverStream.Context(), info.FullMethod, nil, false); err != nil {
+		return err
+	}
+	return handler(srv, &authorizedServerStream{ServerStream: serverStream, a: a, method: info.FullMethod})
+}
+
+type authorizedServerStream struct {
+	grpc.ServerStream
+	a      *Authorizer
+	method string
+}
+
+func (s *authorizedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.a.authorize(s.Context(), s.method, m, true)
+}
+
+// authorize returns a PermissionDenied error if none of the roles of the caller allows calling
+// the method with the request. The resource targeted by the request is only checked if checkResource
+// is set. Unless the server is unauthenticated, authentication runs before the interceptors, so
+// every call reaching here has an entity.
+func (a *Authorizer) authorize(ctx context.Context, fullMethod string, req interface{}, checkResource bool) error {
+	c := a.newCall(fullMethod, req)
+	if c.access == "" {
+		return nil
+	}
+	principal := &Principal{Entity: anonymousEntity, Roles: a.defaultRoles}
+	if !a.unauthenticated {
+		authEntity := rpc.MustContextAuthEntity(ctx)
+		var ok bool
+		if principal, ok = authEntity.(*Principal); !ok {
+			// entities from handlers not wrapped by the Authorizer have no roles.
+			principal = &Principal{Entity: fmt.Sprint(authEntity)}
+		}
+	}
+	for _, name := range principal.Roles {
+		if r, ok := a.roles[name]; ok && r.allows(c, checkResource) {
+			return nil
+		}
+	}
+	a.logger.Warnw(
+		"denied call",
+		"entity", principal.Entity,
+		"roles", principal.Roles,
+		"method", fullMethod,
+		"resource", c.name,
+	)
+	if c.name != "" {
+		return status.Errorf(codes.PermissionDenied, "%q is not allowed to call %s on %q", principal.Entity, fullMethod, c.name)
+	}
+	return status.Errorf(codes.PermissionDenied, "%q is not allowed to call %s", principal.Entity, fullMethod)
+}
+
+// subtypeOf returns the subtype whose RPC service is the given one.
+func (a *Authorizer) subtypeOf(service string) (resource.Subtype, bool) {
+	a.servicesOnce.Do(func() {
+		a.services = map[string]resource.Subtype{}
+		for subtype, registration := range registry.RegisteredResourceSubtypes() {
+			if registration.RPCServiceDesc != nil {
+				a.services[registration.RPCServiceDesc.ServiceName] = subtype
+			}
+		}
+	})
+	subtype, ok := a.services[service]
+	return subtype, ok
+}
@@ -0,0 +1,315 @@
+package authorization_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/edaniels/golog"
+	armpb "go.viam.com/api/component/arm/v1"
+	motorpb "go.viam.com/api/component/motor/v1"
+	robotpb "go.viam.com/api/robot/v1"
+	shellpb "go.viam.com/api/service/shell/v1"
+	"go.viam.com/test"
+	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/generic"
+	"go.viam.com/rdk/components/motor"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/config"
+	viamgrpc "go.viam.com/rdk/grpc"
+	mlmodelpb "go.viam.com/rdk/proto/api/service/mlmodel/v1"
+	extendedshellpb "go.viam.com/rdk/proto/api/service/shell/v1"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot/journal"
+	"go.viam.com/rdk/robot/web/authorization"
+	"go.viam.com/rdk/services/mlmodel"
+	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+func method(desc grpc.ServiceDesc, name string) string {
+	return "/" + desc.ServiceName + "/" + name
+}
+
+func TestAuthorize(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	a, err := authorization.New(config.AuthConfig{}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, a, test.ShouldBeNil)
+
+	_, err = authorization.New(config.AuthConfig{DefaultRole: "nope"}, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	a, err = authorization.New(config.AuthConfig{
+		Handlers: []config.AuthHandlerConfig{{Type: rpc.CredentialsTypeAPIKey}},
+		Roles: []config.RoleConfig{
+			{
+				Name:      "arm_operator",
+				Access:    config.AccessOperator,
+				Resources: []string{arm.Subtype.String(), "motor1"},
+			},
+			{
+				Name:      "motor_reader",
+				Access:    config.AccessReadOnly,
+				Resources: []string{motor.Named("motor2").String()},
+				Methods:   []string{"GetPosition"},
+			},
+			{
+				Name:      "model_runner",
+				Access:    config.AccessOperator,
+				Resources: []string{mlmodel.Subtype.String()},
+			},
+		},
+	}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	var (
+		moveArm     = method(armpb.ArmService_ServiceDesc, "MoveToPosition")
+		setPower    = method(motorpb.MotorService_ServiceDesc, "SetPower")
+		getPosition = method(motorpb.MotorService_ServiceDesc, "GetPosition")
+		isPowered   = method(motorpb.MotorService_ServiceDesc, "IsPowered")
+		stopAll     = method(robotpb.RobotService_ServiceDesc, "StopAll")
+		names       = method(robotpb.RobotService_ServiceDesc, "ResourceNames")
+		shell       = method(shellpb.ShellService_ServiceDesc, "Shell")
+		infer       = method(mlmodelpb.MLModelService_ServiceDesc, "Infer")
+		addStream   = "/proto.stream.v1.StreamService/AddStream"
+		listStreams = "/proto.stream.v1.StreamService/ListStreams"
+		listEvents  = "/proto.api.robot.v1.AdminService/ListEvents"
+		getHealth   = "/proto.api.robot.v1.AdminService/GetHealth"
+		unknown     = "/some.unknown.v1.Service/GetThing"
+	)
+	inferReq := &mlmodelpb.InferRequest{Name: "model1"}
+	for _, tc := range []struct {
+		roles   []string
+		method  string
+		req     interface{}
+		allowed bool
+	}{
+		{nil, names, &robotpb.ResourceNamesRequest{}, false},
+		{nil, "/proto.rpc.webrtc.v1.SignalingService/Call", nil, true},
+		{[]string{"admin"}, shell, &shellpb.ShellRequest{}, true},
+		{[]string{"operator"}, shell, &shellpb.ShellRequest{}, false},
+		{[]string{"operator"}, "/proto.api.service.shell.v1.ExtendedShellService/StatFile", &extendedshellpb.StatFileRequest{}, false},
+		{[]string{"operator"}, stopAll, &robotpb.StopAllRequest{}, true},
+		{[]string{"read_only"}, stopAll, &robotpb.StopAllRequest{}, false},
+		{[]string{"read_only"}, names, &robotpb.ResourceNamesRequest{}, true},
+		{[]string{"read_only"}, getPosition, &motorpb.GetPositionRequest{Name: "motor1"}, true},
+		{[]string{"read_only"}, setPower, &motorpb.SetPowerRequest{Name: "motor1"}, false},
+		{[]string{"arm_operator"}, moveArm, &armpb.MoveToPositionRequest{Name: "arm1"}, true},
+		{[]string{"arm_operator"}, setPower, &motorpb.SetPowerRequest{Name: "motor1"}, true},
+		{[]string{"arm_operator"}, setPower, &motorpb.SetPowerRequest{Name: "motor2"}, false},
+		{[]string{"arm_operator"}, stopAll, &robotpb.StopAllRequest{}, false},
+		{[]string{"arm_operator"}, names, &robotpb.ResourceNamesRequest{}, true},
+		{[]string{"motor_reader"}, getPosition, &motorpb.GetPositionRequest{Name: "motor2"}, true},
+		{[]string{"motor_reader"}, isPowered, &motorpb.IsPoweredRequest{Name: "motor2"}, false},
+		{[]string{"motor_reader"}, getPosition, &motorpb.GetPositionRequest{Name: "motor3"}, false},
+		{[]string{"motor_reader", "arm_operator"}, getPosition, &motorpb.GetPositionRequest{Name: "motor1"}, true},
+		{[]string{"model_runner"}, infer, inferReq, true},
+		{[]string{"arm_operator"}, infer, inferReq, false},
+		{[]string{"unknown"}, names, &robotpb.ResourceNamesRequest{}, false},
+		{[]string{"read_only"}, listStreams, nil, true},
+		{[]string{"read_only"}, addStream, nil, false},
+		{[]string{"operator"}, addStream, nil, true},
+		{[]string{"operator"}, listEvents, nil, false},
+		{[]string{"operator"}, getHealth, nil, false},
+		{[]string{"admin"}, listEvents, nil, true},
+		{[]string{"operator"}, unknown, nil, false},
+		{[]string{"admin"}, unknown, nil, true},
+	} {
+		ctx := rpc.ContextWithAuthEntity(context.Background(), &authorization.Principal{Entity: "someone", Roles: tc.roles})
+		called := false
+		_, err := a.UnaryServerInterceptor(ctx, tc.req, &grpc.UnaryServerInfo{FullMethod: tc.method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			})
+		test.That(t, called, test.ShouldEqual, tc.allowed)
+		if tc.allowed {
+			test.That(t, err, test.ShouldBeNil)
+		} else {
+			test.That(t, status.Code(err), test.ShouldEqual, codes.PermissionDenied)
+			test.That(t, err.Error(), test.ShouldContainSubstring, tc.method)
+		}
+	}
+}
+
+func TestVerifyTLSEntity(t *testing.T) {
+	a, err := authorization.New(config.AuthConfig{
+		Handlers:     []config.AuthHandlerConfig{{Type: rpc.CredentialsTypeAPIKey}},
+		RoleBindings: []config.RoleBindingConfig{{Role: "admin", Entities: []string{"other.robot"}}},
+		DefaultRole:  "read_only",
+	}, golog.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+
+	p, err := a.VerifyTLSEntity(context.Background(), "other.robot", "alias")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, p, test.ShouldResemble, &authorization.Principal{Entity: "other.robot", Roles: []string{"admin"}})
+	p, err = a.VerifyTLSEntity(context.Background(), "stranger")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, p, test.ShouldResemble, &authorization.Principal{Entity: "stranger", Roles: []string{"read_only"}})
+}
+
+func TestPrincipalJournalCaller(t *testing.T) {
+	j, err := journal.New(journal.Config{Dir: t.TempDir()}, golog.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, j.Close(), test.ShouldBeNil)
+	}()
+
+	ctx := rpc.ContextWithAuthEntity(context.Background(), &authorization.Principal{Entity: "alice", Roles: []string{"admin"}})
+	test.That(t, journal.Caller(ctx), test.ShouldEqual, "alice")
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/viam.component.arm.v1.ArmService/Stop"}
+	_, err = j.UnaryServerInterceptor(ctx, &armpb.StopRequest{Name: "arm1"}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &armpb.StopResponse{}, nil
+	})
+	test.That(t, err, test.ShouldBeNil)
+	events, err := j.Query(journal.Filter{Caller: "alice"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, events, test.ShouldHaveLength, 1)
+	test.That(t, events[0].Caller, test.ShouldEqual, "alice")
+}
+
+func TestAuthHandlerMetadata(t *testing.T) {
+	a, err := authorization.New(config.AuthConfig{
+		Handlers:     []config.AuthHandlerConfig{{Type: rpc.CredentialsTypeAPIKey}},
+		RoleBindings: []config.RoleBindingConfig{{Role: "operator", APIKeys: []string{"key"}}},
+	}, golog.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+	handler := a.AuthHandler(rpc.MakeFuncAuthHandler(
+		func(ctx context.Context, entity, payload string) (map[string]string, error) {
+			return map[string]string{
+				authorization.SubjectMetadataKey: "alice",
+				authorization.RolesMetadataKey:   "read_only",
+			}, nil
+		},
+		func(ctx context.Context, entity string) (interface{}, error) {
+			return entity, nil
+		},
+	))
+
+	authMD, err := handler.Authenticate(context.Background(), "robot", "key")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, authMD, test.ShouldResemble, map[string]string{
+		authorization.SubjectMetadataKey: "alice",
+		authorization.RolesMetadataKey:   "read_only,operator",
+	})
+	authMD, err = handler.Authenticate(context.Background(), "robot", "token")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, authMD[authorization.RolesMetadataKey], test.ShouldEqual, "read_only")
+}
+
+func TestAuthHandler(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	a, err := authorization.New(config.AuthConfig{
+		Handlers: []config.AuthHandlerConfig{{Type: rpc.CredentialsTypeAPIKey}},
+		Roles:    []config.RoleConfig{{Name: "sensor1_reader", Access: config.AccessReadOnly, Resources: []string{"sensor1"}}},
+		RoleBindings: []config.RoleBindingConfig{
+			{Role: "sensor1_reader", APIKeys: []string{"reader-key"}},
+			{Role: "admin", APIKeys: []string{"admin-key"}},
+		},
+	}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	rpcServer, err := rpc.NewServer(
+		logger,
+		rpc.WithAuthHandler(rpc.CredentialsTypeAPIKey, a.AuthHandler(
+			rpc.MakeSimpleMultiAuthHandler([]string{"robot"}, []string{"reader-key", "admin-key", "unbound-key"}),
+		)),
+		rpc.WithUnaryServerInterceptor(a.UnaryServerInterceptor),
+		rpc.WithStreamServerInterceptor(a.StreamServerInterceptor),
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	injectSensor := &inject.Sensor{}
+	injectSensor.ReadingsFunc = func(ctx context.Context) (map[string]interface{}, error) {
+		return map[string]interface{}{"a": 1.}, nil
+	}
+	injectSensor.DoFunc = generic.EchoFunc
+	sensorSvc, err := subtype.New(map[resource.Name]interface{}{
+		sensor.Named("sensor1"): injectSensor,
+		sensor.Named("sensor2"): injectSensor,
+	})
+	test.That(t, err, test.ShouldBeNil)
+	registry.ResourceSubtypeLookup(sensor.Subtype).RegisterRPCService(context.Background(), rpcServer, sensorSvc)
+	generic.RegisterService(rpcServer, sensorSvc)
+	go rpcServer.Serve(listener)
+	defer rpcServer.Stop()
+
+	dial := func(key string) (rpc.ClientConn, error) {
+		return viamgrpc.Dial(context.Background(), listener.Addr().String(), logger,
+			rpc.WithAllowInsecureWithCredentialsDowngrade(),
+			rpc.WithEntityCredentials("robot", rpc.Credentials{Type: rpc.CredentialsTypeAPIKey, Payload: key}),
+		)
+	}
+
+	t.Run("reader", func(t *testing.T) {
+		conn, err := dial("reader-key")
+		test.That(t, err, test.ShouldBeNil)
+		defer conn.Close()
+
+		_, err = sensor.NewClientFromConn(context.Background(), conn, "sensor1", logger).Readings(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		_, err = sensor.NewClientFromConn(context.Background(), conn, "sensor2", logger).Readings(context.Background())
+		test.That(t, status.Code(err), test.ShouldEqual, codes.PermissionDenied)
+		_, err = sensor.NewClientFromConn(context.Background(), conn, "sensor1", logger).DoCommand(context.Background(), generic.TestCommand)
+		test.That(t, status.Code(err), test.ShouldEqual, codes.PermissionDenied)
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		conn, err := dial("admin-key")
+		test.That(t, err, test.ShouldBeNil)
+		defer conn.Close()
+
+		_, err = sensor.NewClientFromConn(context.Background(), conn, "sensor2", logger).Readings(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		_, err = sensor.NewClientFromConn(context.Background(), conn, "sensor1", logger).DoCommand(context.Background(), generic.TestCommand)
+		test.That(t, err, test.ShouldBeNil)
+	})
+
+	t.Run("unbound key", func(t *testing.T) {
+		conn, err := dial("unbound-key")
+		test.That(t, err, test.ShouldBeNil)
+		defer conn.Close()
+
+		_, err = sensor.NewClientFromConn(context.Background(), conn, "sensor1", logger).Readings(context.Background())
+		test.That(t, status.Code(err), test.ShouldEqual, codes.PermissionDenied)
+	})
+}
+
+func TestAuthorizeUnauthenticated(t *testing.T) {
+	a, err := authorization.New(config.AuthConfig{DefaultRole: "read_only"}, golog.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, a, test.ShouldNotBeNil)
+
+	for _, tc := range []struct {
+		method  string
+		req     interface{}
+		allowed bool
+	}{
+		{method(robotpb.RobotService_ServiceDesc, "ResourceNames"), &robotpb.ResourceNamesRequest{}, true},
+		{method(motorpb.MotorService_ServiceDesc, "GetPosition"), &motorpb.GetPositionRequest{Name: "motor1"}, true},
+		{method(motorpb.MotorService_ServiceDesc, "SetPower"), &motorpb.SetPowerRequest{Name: "motor1"}, false},
+		{method(robotpb.RobotService_ServiceDesc, "StopAll"), &robotpb.StopAllRequest{}, false},
+		{method(shellpb.ShellService_ServiceDesc, "Shell"), &shellpb.ShellRequest{}, false},
+	} {
+		called := false
+		_, err := a.UnaryServerInterceptor(context.Background(), tc.req, &grpc.UnaryServerInfo{FullMethod: tc.method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			})
+		test.That(t, called, test.ShouldEqual, tc.allowed)
+		if !tc.allowed {
+			test.That(t, status.Code(err), test.ShouldEqual, codes.PermissionDenied)
+		}
+	}
+}
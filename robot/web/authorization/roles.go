@@ -0,0 +1,205 @@
+package authorization
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/resource"
+)
+
+// alwaysAllowedServicePrefix prefixes the services of the RPC framework itself, such as
+// authentication and WebRTC signaling, which every authenticated caller needs.
+const alwaysAllowedServicePrefix = "proto.rpc."
+
+// adminServices are the resource subtype services only the admin access level may call.
+var adminServices = map[string]bool{
+	"viam.service.shell.v1.ShellService": true,
+}
+
+// serviceMethods is the access level needed to call each method of the services that are not the
+// RPC service of a resource subtype. Methods of these services that are not listed, and every method
+// of services that are not listed, need the admin access level.
+var serviceMethods = map[string]map[string]config.AccessLevel{
+	"viam.robot.v1.RobotService": {
+		"GetOperations":       config.AccessReadOnly,
+		"ResourceNames":       config.AccessReadOnly,
+		"ResourceRPCSubtypes": config.AccessReadOnly,
+		"CancelOperation":     config.AccessOperator,
+		"BlockForOperation":   config.AccessReadOnly,
+		"DiscoverComponents":  config.AccessReadOnly,
+		"FrameSystemConfig":   config.AccessReadOnly,
+		"TransformPose":       config.AccessReadOnly,
+		"GetStatus":           config.AccessReadOnly,
+		"StreamStatus":        config.AccessReadOnly,
+		"StopAll":             config.AccessOperator,
+	},
+	"proto.api.robot.v1.AdminService": {
+		"ValidateConfig": config.AccessAdmin,
+		"GetHealth":      config.AccessAdmin,
+		"ListEvents":     config.AccessAdmin,
+	},
+	"proto.api.component.motor.v1.ExtendedMotorService": {
+		"GetProperties": config.AccessReadOnly,
+		"GetTelemetry":  config.AccessReadOnly,
+	},
+	"proto.stream.v1.StreamService": {
+		"ListStreams":  config.AccessReadOnly,
+		"AddStream":    config.AccessOperator,
+		"RemoveStream": config.AccessOperator,
+	},
+	"proto.api.service.vision.v1.ExtendedVisionService": {
+		"GetSegmentationMask": config.AccessReadOnly,
+		"GetTracks":           config.AccessReadOnly,
+		"GetTrackEvents":      config.AccessReadOnly,
+	},
+	"grpc.reflection.v1alpha.ServerReflection": {
+		"ServerReflectionInfo": config.AccessReadOnly,
+	},
+}
+
+// readOnlyMethodPrefixes and readOnlyMethods are the methods of resource subtype services the
+// read-only access level may call. Their other methods need the operator access level.
+var (
+	readOnlyMethodPrefixes = []string{"Get", "Is"}
+	readOnlyMethods        = map[string]bool{
+		"RenderFrame": true,
+	}
+)
+
+var accessRanks = map[config.AccessLevel]int{
+	config.AccessReadOnly: 1,
+	config.AccessOperator: 2,
+	config.AccessAdmin:    3,
+}
+
+// methodAccess returns the access level needed to call the method of the service, or the empty
+// level if every caller may call it. isResourceService says whether the service is the RPC service
+// of a resource subtype.
+func methodAccess(service, method string, isResourceService bool) config.AccessLevel {
+	switch {
+	case strings.HasPrefix(service, alwaysAllowedServicePrefix):
+		return ""
+	case !isResourceService:
+		if access, ok := serviceMethods[service][method]; ok {
+			return access
+		}
+		return config.AccessAdmin
+	case adminServices[service]:
+		return config.AccessAdmin
+	case readOnlyMethods[method]:
+		return config.AccessReadOnly
+	}
+	for _, prefix := range readOnlyMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return config.AccessReadOnly
+		}
+	}
+	return config.AccessOperator
+}
+
+// A call is the method and resource of an RPC to authorize.
+type call struct {
+	fullMethod, service, method string
+	access                      config.AccessLevel
+	// name is the short name of the targeted resource, empty if the request targets none.
+	name       string
+	subtype    resource.Subtype
+	hasSubtype bool
+}
+
+func (a *Authorizer) newCall(fullMethod string, req interface{}) call {
+	c := call{fullMethod: fullMethod}
+	if idx := strings.LastIndex(fullMethod, "/"); idx > 0 {
+		c.service, c.method = strings.TrimPrefix(fullMethod[:idx], "/"), fullMethod[idx+1:]
+	}
+	c.subtype, c.hasSubtype = a.subtypeOf(c.service)
+	c.access = methodAccess(c.service, c.method, c.hasSubtype)
+	if r, ok := req.(interface{ GetName() string }); ok {
+		c.name = r.GetName()
+	}
+	return c
+}
+
+type role struct {
+	name   string
+	access config.AccessLevel
+	// when restricted, only the given names, subtypes and full names are allowed.
+	restricted bool
+	names      map[string]bool
+	subtypes   map[resource.Subtype]bool
+	fullNames  map[resource.Subtype]map[string]bool
+	methods    []string
+}
+
+func newRole(cfg config.RoleConfig) (*role, error) {
+	if _, ok := accessRanks[cfg.Access]; !ok {
+		return nil, errors.Errorf("role %q has unknown access level %q", cfg.Name, cfg.Access)
+	}
+	r := &role{
+		name:       cfg.Name,
+		access:     cfg.Access,
+		restricted: len(cfg.Resources) != 0,
+		names:      map[string]bool{},
+		subtypes:   map[resource.Subtype]bool{},
+		fullNames:  map[resource.Subtype]map[string]bool{},
+		methods:    cfg.Methods,
+	}
+	for _, res := range cfg.Resources {
+		switch parts := strings.Split(res, ":"); {
+		case strings.Contains(res, "/"):
+			name, err := resource.NewFromString(res)
+			if err != nil {
+				return nil, errors.Wrapf(err, "role %q", cfg.Name)
+			}
+			if r.fullNames[name.Subtype] == nil {
+				r.fullNames[name.Subtype] = map[string]bool{}
+			}
+			r.fullNames[name.Subtype][name.ShortName()] = true
+		case len(parts) == 3:
+			subtype := resource.NewSubtype(resource.Namespace(parts[0]), resource.TypeName(parts[1]), resource.SubtypeName(parts[2]))
+			if err := subtype.Validate(); err != nil {
+				return nil, errors.Wrapf(err, "role %q", cfg.Name)
+			}
+			r.subtypes[subtype] = true
+		default:
+			r.names[res] = true
+		}
+	}
+	return r, nil
+}
+
+// allows returns whether the role allows the call.
+func (r *role) allows(c call, checkResource bool) bool {
+	if accessRanks[r.access] < accessRanks[c.access] {
+		return false
+	}
+	if len(r.methods) != 0 && !r.allowsMethod(c) {
+		return false
+	}
+	if !r.restricted || !checkResource {
+		return true
+	}
+	if c.name == "" {
+		// calls on the whole robot, like StopAll, are off limits to roles restricted to
+		// some resources unless they only read state.
+		return c.access == config.AccessReadOnly
+	}
+	if r.names[c.name] {
+		return true
+	}
+	return c.hasSubtype && (r.subtypes[c.subtype] || r.fullNames[c.subtype][c.name])
+}
+
+func (r *role) allowsMethod(c call) bool {
+	for _, pattern := range r.methods {
+		switch {
+		case pattern == c.fullMethod, pattern == c.method:
+			return true
+		case strings.HasSuffix(pattern, "/*") && strings.TrimSuffix(pattern, "*") == "/"+c.service+"/":
+			return true
+		}
+	}
+	return false
+}
@@ -19,6 +19,10 @@ type Options struct {
 	// Pprof turns on the pprof profiler accessible at /debug
 	Pprof bool
 
+	// Metrics turns on the Prometheus metrics of the robot accessible at /metrics, with an API key
+	// or the location secret as a bearer token unless the server is unauthenticated
+	Metrics bool
+
 	// SharedDir is the location of static web assets.
 	SharedDir string
 
@@ -0,0 +1,93 @@
+package webstream
+
+import (
+	"context"
+	"image"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/edaniels/gostream"
+	"github.com/edaniels/gostream/codec"
+	"github.com/pion/mediadevices/pkg/wave"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.viam.com/rdk/metrics"
+)
+
+// WithMetrics returns a copy of the stream config whose encoders count the frames and bytes
+// they encode for the stream of the config, from which its frame rate and bitrate are derived.
+func WithMetrics(config gostream.StreamConfig) gostream.StreamConfig {
+	if config.VideoEncoderFactory != nil {
+		config.VideoEncoderFactory = &measuredVideoEncoderFactory{config.VideoEncoderFactory, config.Name}
+	}
+	if config.AudioEncoderFactory != nil {
+		config.AudioEncoderFactory = &measuredAudioEncoderFactory{config.AudioEncoderFactory, config.Name}
+	}
+	return config
+}
+
+type measuredVideoEncoderFactory struct {
+	codec.VideoEncoderFactory
+	stream string
+}
+
+func (f *measuredVideoEncoderFactory) New(height, width, keyFrameInterval int, logger golog.Logger) (codec.VideoEncoder, error) {
+	encoder, err := f.VideoEncoderFactory.New(height, width, keyFrameInterval, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &measuredVideoEncoder{
+		VideoEncoder: encoder,
+		frames:       metrics.StreamFrames.WithLabelValues(f.stream, "video"),
+		bytes:        metrics.StreamBytes.WithLabelValues(f.stream, "video"),
+	}, nil
+}
+
+type measuredVideoEncoder struct {
+	codec.VideoEncoder
+	frames, bytes prometheus.Counter
+}
+
+func (e *measuredVideoEncoder) Encode(ctx context.Context, img image.Image) ([]byte, error) {
+	data, err := e.VideoEncoder.Encode(ctx, img)
+	if err == nil && data != nil {
+		e.frames.Inc()
+		e.bytes.Add(float64(len(data)))
+	}
+	return data, err
+}
+
+type measuredAudioEncoderFactory struct {
+	codec.AudioEncoderFactory
+	stream string
+}
+
+func (f *measuredAudioEncoderFactory) New(
+	sampleRate, channelCount int,
+	latency time.Duration,
+	logger golog.Logger,
+) (codec.AudioEncoder, error) {
+	encoder, err := f.AudioEncoderFactory.New(sampleRate, channelCount, latency, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &measuredAudioEncoder{
+		AudioEncoder: encoder,
+		chunks:       metrics.StreamFrames.WithLabelValues(f.stream, "audio"),
+		bytes:        metrics.StreamBytes.WithLabelValues(f.stream, "audio"),
+	}, nil
+}
+
+type measuredAudioEncoder struct {
+	codec.AudioEncoder
+	chunks, bytes prometheus.Counter
+}
+
+func (e *measuredAudioEncoder) Encode(ctx context.Context, chunk wave.Audio) ([]byte, bool, error) {
+	data, ready, err := e.AudioEncoder.Encode(ctx, chunk)
+	if err == nil && ready {
+		e.chunks.Inc()
+		e.bytes.Add(float64(len(data)))
+	}
+	return data, ready, err
+}
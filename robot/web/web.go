@@ -3,6 +3,10 @@ package web
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"crypto/tls"
 	"fmt"
 	"html/template"
 	"io"
@@ -22,6 +26,8 @@ import (
 	"github.com/edaniels/golog"
 	"github.com/edaniels/gostream"
 	streampb "github.com/edaniels/gostream/proto/stream/v1"
+	"github.com/golang-jwt/jwt/v4"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/jhump/protoreflect/dynamic"
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
@@ -41,10 +47,14 @@ import (
 	"go.viam.com/rdk/components/generic"
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/grpc"
+	"go.viam.com/rdk/metrics"
+	adminpb "go.viam.com/rdk/proto/api/robot/v1"
 	"go.viam.com/rdk/registry"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
 	grpcserver "go.viam.com/rdk/robot/server"
+	"go.viam.com/rdk/robot/web/authentication"
+	"go.viam.com/rdk/robot/web/authorization"
 	weboptions "go.viam.com/rdk/robot/web/options"
 	webstream "go.viam.com/rdk/robot/web/stream"
 	"go.viam.com/rdk/subtype"
@@ -212,6 +222,7 @@ func New(ctx context.Context, r robot.Robot, logger golog.Logger, opts ...Option
 		streamServer: nil,
 		services:     make(map[resource.Subtype]subtype.Service),
 		opts:         wOpts,
+		rpcMetrics:   metrics.NewRPCObserver(),
 	}
 	return webSvc
 }
@@ -223,6 +234,12 @@ type webService struct {
 	streamServer *StreamServer
 	services     map[resource.Subtype]subtype.Service
 	opts         options
+	rpcMetrics   *metrics.RPCObserver
+
+	// authRSAPrivKey signs the access tokens of the rpc server and authHandlers are its auth
+	// handlers, by credentials type, so that the HTTP handlers can accept the same tokens.
+	authRSAPrivKey *rsa.PrivateKey
+	authHandlers   map[rpc.CredentialsType]rpc.AuthHandler
 
 	logger                  golog.Logger
 	cancelFunc              func()
@@ -289,7 +306,9 @@ func (svc *webService) updateResources(resources map[resource.Name]interface{})
 	// so group resources by subtype
 	groupedResources := make(map[resource.Subtype]map[resource.Name]interface{})
 	components := make(map[resource.Name]interface{})
+	names := make([]string, 0, len(resources))
 	for n, v := range resources {
+		names = append(names, n.ShortName())
 		r, ok := groupedResources[n.Subtype]
 		if !ok {
 			r = make(map[resource.Name]interface{})
@@ -301,6 +320,7 @@ func (svc *webService) updateResources(resources map[resource.Name]interface{})
 		}
 	}
 	groupedResources[generic.Subtype] = components
+	svc.rpcMetrics.SetResources(names)
 
 	for s, v := range groupedResources {
 		subtypeSvc, ok := svc.services[s]
@@ -355,7 +375,7 @@ func (svc *webService) addNewStreams(ctx context.Context) error {
 		// Configure new stream
 		config := *svc.opts.streamConfig
 		config.Name = name
-		stream, err := svc.streamServer.Server.NewStream(config)
+		stream, err := svc.streamServer.Server.NewStream(webstream.WithMetrics(config))
 
 		// Skip if stream is already registered, otherwise raise any other errors
 		var registeredError *gostream.StreamAlreadyRegisteredError
@@ -419,7 +439,7 @@ func (svc *webService) makeStreamServer(ctx context.Context) (*StreamServer, err
 		} else {
 			config.VideoEncoderFactory = nil
 		}
-		stream, err := gostream.NewStream(config)
+		stream, err := gostream.NewStream(webstream.WithMetrics(config))
 		if err != nil {
 			return streams, err
 		}
@@ -567,6 +587,14 @@ func (svc *webService) runWeb(ctx context.Context, options weboptions.Options) (
 	); err != nil {
 		return err
 	}
+	if err := svc.rpcServer.RegisterServiceServer(
+		ctx,
+		&adminpb.AdminService_ServiceDesc,
+		grpcserver.NewAdmin(svc.r),
+		adminpb.RegisterAdminServiceHandlerFromEndpoint,
+	); err != nil {
+		return err
+	}
 
 	if err := svc.initResources(); err != nil {
 		return err
@@ -709,20 +737,45 @@ func (svc *webService) initRPCOptions(listenerTCPAddr *net.TCPAddr, options webo
 	}
 
 	if options.Network.TLSConfig != nil {
-		rpcOpts = append(rpcOpts, rpc.WithInternalTLSConfig(options.Network.TLSConfig))
+		tlsConfig, err := withClientCAs(options.Network.TLSConfig, options)
+		if err != nil {
+			return nil, err
+		}
+		rpcOpts = append(rpcOpts, rpc.WithInternalTLSConfig(tlsConfig))
 	}
 
-	authOpts, err := svc.initAuthHandlers(listenerTCPAddr, options)
+	authorizer, err := authorization.New(options.Auth, svc.logger)
+	if err != nil {
+		return nil, err
+	}
+	authOpts, err := svc.initAuthHandlers(listenerTCPAddr, options, authorizer)
 	if err != nil {
 		return nil, err
 	}
 	rpcOpts = append(rpcOpts, authOpts...)
 
+	// only one interceptor of each kind can be set, so authorization is chained before
+	// the operation manager to deny calls before they are tracked.
 	opManager := svc.r.OperationManager()
+	unaryInterceptors := []googlegrpc.UnaryServerInterceptor{opManager.UnaryServerInterceptor}
+	streamInterceptors := []googlegrpc.StreamServerInterceptor{opManager.StreamServerInterceptor}
+	if authorizer != nil {
+		unaryInterceptors = append([]googlegrpc.UnaryServerInterceptor{authorizer.UnaryServerInterceptor}, unaryInterceptors...)
+		streamInterceptors = append([]googlegrpc.StreamServerInterceptor{authorizer.StreamServerInterceptor}, streamInterceptors...)
+	}
+	// the journal comes first so that denied calls are recorded too.
+	if svc.opts.journal != nil {
+		unaryInterceptors = append([]googlegrpc.UnaryServerInterceptor{svc.opts.journal.UnaryServerInterceptor}, unaryInterceptors...)
+		streamInterceptors = append([]googlegrpc.StreamServerInterceptor{svc.opts.journal.StreamServerInterceptor}, streamInterceptors...)
+	}
+	if options.Metrics {
+		unaryInterceptors = append([]googlegrpc.UnaryServerInterceptor{svc.rpcMetrics.UnaryServerInterceptor}, unaryInterceptors...)
+		streamInterceptors = append([]googlegrpc.StreamServerInterceptor{svc.rpcMetrics.StreamServerInterceptor}, streamInterceptors...)
+	}
 	rpcOpts = append(
 		rpcOpts,
-		rpc.WithUnaryServerInterceptor(opManager.UnaryServerInterceptor),
-		rpc.WithStreamServerInterceptor(opManager.StreamServerInterceptor),
+		rpc.WithUnaryServerInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
+		rpc.WithStreamServerInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
 	)
 
 	rpcOpts = append(
@@ -733,9 +786,15 @@ func (svc *webService) initRPCOptions(listenerTCPAddr *net.TCPAddr, options webo
 	return rpcOpts, nil
 }
 
-// Initialize authentication handler options.
-func (svc *webService) initAuthHandlers(listenerTCPAddr *net.TCPAddr, options weboptions.Options) ([]rpc.ServerOption, error) {
+// Initialize authentication handler options. If authorizer is set, authenticated entities are bound to their roles.
+func (svc *webService) initAuthHandlers(
+	listenerTCPAddr *net.TCPAddr,
+	options weboptions.Options,
+	authorizer *authorization.Authorizer,
+) ([]rpc.ServerOption, error) {
 	rpcOpts := []rpc.ServerOption{}
+	svc.authRSAPrivKey = nil
+	svc.authHandlers = make(map[rpc.CredentialsType]rpc.AuthHandler)
 
 	if options.Managed && len(options.Auth.Handlers) == 1 {
 		if options.BakedAuthEntity == "" || options.BakedAuthCreds.Type == "" {
@@ -746,6 +805,14 @@ func (svc *webService) initAuthHandlers(listenerTCPAddr *net.TCPAddr, options we
 	if len(options.Auth.Handlers) == 0 {
 		rpcOpts = append(rpcOpts, rpc.WithUnauthenticated())
 	} else {
+		// the key is generated here rather than by the rpc server so that /metrics can verify tokens
+		authRSAPrivKey, err := rsa.GenerateKey(rand.Reader, 4096)
+		if err != nil {
+			return nil, err
+		}
+		svc.authRSAPrivKey = authRSAPrivKey
+		rpcOpts = append(rpcOpts, rpc.WithAuthRSAPrivateKey(authRSAPrivKey))
+
 		listenerAddr := listenerTCPAddr.String()
 		hosts := options.GetHosts(listenerTCPAddr)
 		authEntities := make([]string, len(hosts.Internal))
@@ -770,9 +837,14 @@ func (svc *webService) initAuthHandlers(listenerTCPAddr *net.TCPAddr, options we
 			}
 		}
 		if options.Secure && len(options.Auth.TLSAuthEntities) != 0 {
-			rpcOpts = append(rpcOpts, rpc.WithTLSAuthHandler(options.Auth.TLSAuthEntities, nil))
+			var verifyEntity func(ctx context.Context, entities ...string) (interface{}, error)
+			if authorizer != nil {
+				verifyEntity = authorizer.VerifyTLSEntity
+			}
+			rpcOpts = append(rpcOpts, rpc.WithTLSAuthHandler(options.Auth.TLSAuthEntities, verifyEntity))
 		}
 		for _, handler := range options.Auth.Handlers {
+			var authHandler rpc.AuthHandler
 			switch handler.Type {
 			case rpc.CredentialsTypeAPIKey:
 				apiKeys := handler.Config.StringSlice("keys")
@@ -783,22 +855,44 @@ func (svc *webService) initAuthHandlers(listenerTCPAddr *net.TCPAddr, options we
 					}
 					apiKeys = []string{apiKey}
 				}
-				rpcOpts = append(rpcOpts, rpc.WithAuthHandler(
-					handler.Type,
-					rpc.MakeSimpleMultiAuthHandler(authEntities, apiKeys),
-				))
+				authHandler = rpc.MakeSimpleMultiAuthHandler(authEntities, apiKeys)
+				if authorizer != nil {
+					authHandler = authorizer.AuthHandler(authHandler)
+				}
 			case rutils.CredentialsTypeRobotLocationSecret:
 				secret := handler.Config.String("secret")
 				if secret == "" {
 					return nil, errors.Errorf("%q handler requires non-empty secret", handler.Type)
 				}
-				rpcOpts = append(rpcOpts, rpc.WithAuthHandler(
-					handler.Type,
-					rpc.MakeSimpleAuthHandler(authEntities, secret),
-				))
+				authHandler = rpc.MakeSimpleAuthHandler(authEntities, secret)
+				if authorizer != nil {
+					// the location secret belongs to the owners of the robot.
+					authHandler = authorizer.AuthHandler(authHandler, string(config.AccessAdmin))
+				}
+			case rutils.CredentialsTypeJWT:
+				var err error
+				if authHandler, err = authentication.NewJWTAuthHandler(authEntities, handler.Config, svc.logger); err != nil {
+					return nil, err
+				}
+				if authorizer != nil {
+					authHandler = authorizer.AuthHandler(authHandler)
+				}
+			case rutils.CredentialsTypeMutualTLS:
+				if !options.Secure {
+					return nil, errors.Errorf("%q handler requires a secure web server", handler.Type)
+				}
+				var err error
+				if authHandler, err = authentication.NewMutualTLSAuthHandler(authEntities, handler.Config); err != nil {
+					return nil, err
+				}
+				if authorizer != nil {
+					authHandler = authorizer.AuthHandler(authHandler)
+				}
 			default:
 				return nil, errors.Errorf("do not know how to handle auth for %q", handler.Type)
 			}
+			rpcOpts = append(rpcOpts, rpc.WithAuthHandler(handler.Type, authHandler))
+			svc.authHandlers[handler.Type] = authHandler
 		}
 	}
 
@@ -864,10 +958,31 @@ func (svc *webService) initHTTPServer(listenerTCPAddr *net.TCPAddr, options webo
 		return httpServer, err
 	}
 	httpServer.TLSConfig = options.Network.TLSConfig.Clone()
+	if options.Secure {
+		if httpServer.TLSConfig == nil {
+			httpServer.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		if httpServer.TLSConfig, err = withClientCAs(httpServer.TLSConfig, options); err != nil {
+			return nil, err
+		}
+	}
 
 	return httpServer, nil
 }
 
+// withClientCAs returns a copy of the TLS config verifying the client certificates accepted
+// by mutual TLS auth handlers, if there are any.
+func withClientCAs(tlsConfig *tls.Config, options weboptions.Options) (*tls.Config, error) {
+	clientCAs, err := authentication.ClientCAs(options.Auth.Handlers)
+	if err != nil || clientCAs == nil {
+		return tlsConfig, err
+	}
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	tlsConfig.ClientCAs = clientCAs
+	return tlsConfig, nil
+}
+
 // Initialize multiplexer between http handlers.
 func (svc *webService) initMux(options weboptions.Options) (*goji.Mux, error) {
 	mux := goji.NewMux()
@@ -882,6 +997,9 @@ func (svc *webService) initMux(options weboptions.Options) (*goji.Mux, error) {
 		mux.HandleFunc(pat.New("/debug/pprof/symbol"), pprof.Symbol)
 		mux.HandleFunc(pat.New("/debug/pprof/trace"), pprof.Trace)
 	}
+	if options.Metrics {
+		mux.Handle(pat.New("/metrics"), svc.metricsHandler(options))
+	}
 
 	prefix := "/viam"
 	addPrefix := func(h http.Handler) http.Handler {
@@ -909,6 +1027,79 @@ func (svc *webService) initMux(options weboptions.Options) (*goji.Mux, error) {
 	return mux, nil
 }
 
+// metricsHandler returns the handler of /metrics. Unless the server is unauthenticated, it requires
+// as a bearer token either one of the API keys or the location secret of the auth handlers, or an
+// access token the rpc server would accept, such as one returned by its Authenticate method.
+func (svc *webService) metricsHandler(options weboptions.Options) http.Handler {
+	handler := metrics.Handler()
+	if len(options.Auth.Handlers) == 0 {
+		return handler
+	}
+	var secrets []string
+	for _, handler := range options.Auth.Handlers {
+		switch handler.Type {
+		case rpc.CredentialsTypeAPIKey:
+			secrets = append(secrets, handler.Config.StringSlice("keys")...)
+			secrets = append(secrets, handler.Config.String("key"))
+		case rutils.CredentialsTypeRobotLocationSecret:
+			secrets = append(secrets, handler.Config.String("secret"))
+		}
+	}
+	authRSAPrivKey, authHandlers := svc.authRSAPrivKey, svc.authHandlers
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const bearer = "Bearer "
+		token := r.Header.Get("Authorization")
+		if strings.HasPrefix(token, bearer) {
+			token = strings.TrimPrefix(token, bearer)
+			for _, secret := range secrets {
+				if secret != "" && subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1 {
+					handler.ServeHTTP(w, r)
+					return
+				}
+			}
+			if err := verifyAccessToken(r.Context(), token, authRSAPrivKey, authHandlers); err == nil {
+				handler.ServeHTTP(w, r)
+				return
+			}
+		}
+		w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	})
+}
+
+// verifyAccessToken verifies an access token the way the rpc server does: the token is signed with
+// its key, or with the key of the auth handler of its credentials type, and that handler verifies
+// the entity it was issued for.
+func verifyAccessToken(
+	ctx context.Context,
+	token string,
+	authRSAPrivKey *rsa.PrivateKey,
+	authHandlers map[rpc.CredentialsType]rpc.AuthHandler,
+) error {
+	var claims rpc.JWTClaims
+	var handler rpc.AuthHandler
+	if _, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
+		var ok bool
+		if handler, ok = authHandlers[claims.CredentialsType]; !ok {
+			return nil, errors.Errorf("no auth handler for %q", claims.CredentialsType)
+		}
+		if provider, ok := handler.(rpc.TokenVerificationKeyProvider); ok {
+			return provider.TokenVerificationKey(token)
+		}
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok || authRSAPrivKey == nil {
+			return nil, errors.Errorf("unexpected signing method %q", token.Method.Alg())
+		}
+		return &authRSAPrivKey.PublicKey, nil
+	}); err != nil {
+		return err
+	}
+	if len(claims.Audience) == 0 {
+		return errors.New("invalid jwt claims; no audience")
+	}
+	_, err := handler.VerifyEntity(ctx, claims.Audience[0])
+	return err
+}
+
 var unimplErr = status.Error(codes.Unimplemented, codes.Unimplemented.String())
 
 func (svc *webService) foreignServiceHandler(srv interface{}, stream googlegrpc.ServerStream) error {
@@ -1,11 +1,18 @@
 package web
 
-import "github.com/edaniels/gostream"
+import (
+	"github.com/edaniels/gostream"
+
+	"go.viam.com/rdk/robot/journal"
+)
 
 // options configures a web service.
 type options struct {
 	// streamConfig is used to enable audio/video streaming over WebRTC.
 	streamConfig *gostream.StreamConfig
+
+	// journal records the RPC calls served.
+	journal *journal.Journal
 }
 
 // Option configures how we set up the web service.
@@ -37,3 +44,10 @@ func WithStreamConfig(config gostream.StreamConfig) Option {
 		o.streamConfig = &config
 	})
 }
+
+// WithJournal returns an Option which records every RPC call served in the given journal.
+func WithJournal(j *journal.Journal) Option {
+	return newFuncOption(func(o *options) {
+		o.journal = j
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"io"
 	"net"
+	"net/http"
 	"testing"
 
 	"github.com/edaniels/golog"
@@ -15,6 +17,7 @@ import (
 	commonpb "go.viam.com/api/common/v1"
 	"go.viam.com/test"
 	"go.viam.com/utils"
+	rpcpb "go.viam.com/utils/proto/rpc/v1"
 	"go.viam.com/utils/rpc"
 	"go.viam.com/utils/testutils"
 	"google.golang.org/grpc"
@@ -97,6 +100,89 @@ func TestWebStartOptions(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 }
 
+func TestWebMetrics(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx, injectRobot := setupRobotCtx(t)
+
+	svc := web.New(ctx, injectRobot, logger)
+
+	options, _, addr := robottestutils.CreateBaseOptionsAndListener(t)
+	options.Metrics = true
+	err := svc.Start(ctx, options)
+	test.That(t, err, test.ShouldBeNil)
+
+	conn, err := rgrpc.Dial(context.Background(), addr, logger)
+	test.That(t, err, test.ShouldBeNil)
+	arm1 := arm.NewClientFromConn(context.Background(), conn, arm1String, logger)
+	_, err = arm1.EndPosition(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+
+	resp, err := http.Get("http://" + addr + "/metrics")
+	test.That(t, err, test.ShouldBeNil)
+	body, err := io.ReadAll(resp.Body)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Body.Close(), test.ShouldBeNil)
+	test.That(t, resp.StatusCode, test.ShouldEqual, http.StatusOK)
+	test.That(t, string(body), test.ShouldContainSubstring,
+		`rdk_rpc_calls_total{method="GetEndPosition",resource="arm1",service="viam.component.arm.v1.ArmService"}`)
+	test.That(t, string(body), test.ShouldContainSubstring, "rdk_rpc_duration_seconds_bucket")
+
+	test.That(t, conn.Close(), test.ShouldBeNil)
+	err = utils.TryClose(context.Background(), svc)
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestWebMetricsWithAuth(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx, injectRobot := setupRobotCtx(t)
+
+	svc := web.New(ctx, injectRobot, logger)
+
+	options, _, addr := robottestutils.CreateBaseOptionsAndListener(t)
+	options.Metrics = true
+	apiKey := "sosecret"
+	options.Auth.Handlers = []config.AuthHandlerConfig{
+		{
+			Type: rpc.CredentialsTypeAPIKey,
+			Config: config.AttributeMap{
+				"key": apiKey,
+			},
+		},
+	}
+	err := svc.Start(ctx, options)
+	test.That(t, err, test.ShouldBeNil)
+
+	getMetrics := func(token string) int {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+"/metrics", nil)
+		test.That(t, err, test.ShouldBeNil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp.Body.Close(), test.ShouldBeNil)
+		return resp.StatusCode
+	}
+	test.That(t, getMetrics(""), test.ShouldEqual, http.StatusUnauthorized)
+	test.That(t, getMetrics("wrong"), test.ShouldEqual, http.StatusUnauthorized)
+	test.That(t, getMetrics(apiKey), test.ShouldEqual, http.StatusOK)
+
+	// an access token of the robot is accepted as well
+	conn, err := rpc.DialDirectGRPC(ctx, addr, logger, rpc.WithInsecure())
+	test.That(t, err, test.ShouldBeNil)
+	authResp, err := rpcpb.NewAuthServiceClient(conn).Authenticate(ctx, &rpcpb.AuthenticateRequest{
+		Entity:      addr,
+		Credentials: &rpcpb.Credentials{Type: string(rpc.CredentialsTypeAPIKey), Payload: apiKey},
+	})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, conn.Close(), test.ShouldBeNil)
+	test.That(t, getMetrics(authResp.AccessToken), test.ShouldEqual, http.StatusOK)
+	test.That(t, getMetrics(authResp.AccessToken+"x"), test.ShouldEqual, http.StatusUnauthorized)
+
+	err = utils.TryClose(context.Background(), svc)
+	test.That(t, err, test.ShouldBeNil)
+}
+
 func TestWebWithAuth(t *testing.T) {
 	logger := golog.NewTestLogger(t)
 	ctx, injectRobot := setupRobotCtx(t)
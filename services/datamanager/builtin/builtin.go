@@ -286,6 +286,7 @@ func (svc *builtIn) initializeOrUpdateCollector(
 	// Create a collector for this resource and method.
 	params := data.CollectorParams{
 		ComponentName: attributes.Name,
+		MethodName:    attributes.Method,
 		Interval:      interval,
 		MethodParams:  methodParams,
 		Target:        targetFile,
@@ -0,0 +1,176 @@
+// Package builtin implements the default ML model service, which runs an ONNX or TFLite model file.
+package builtin
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/edaniels/golog"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	goutils "go.viam.com/utils"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/ml/inference"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/services/mlmodel"
+	"go.viam.com/rdk/utils"
+)
+
+func init() {
+	registry.RegisterService(mlmodel.Subtype, resource.DefaultModelName, registry.Service{
+		Constructor: func(ctx context.Context, r robot.Robot, c config.Service, logger golog.Logger) (interface{}, error) {
+			svcConfig, ok := c.ConvertedAttributes.(*Config)
+			if !ok {
+				return nil, utils.NewUnexpectedTypeError(svcConfig, c.ConvertedAttributes)
+			}
+			return NewBuiltIn(ctx, c.Name, svcConfig, logger)
+		},
+	})
+	cType := config.ServiceType(mlmodel.SubtypeName)
+	config.RegisterServiceAttributeMapConverter(cType, func(attributes config.AttributeMap) (interface{}, error) {
+		var conf Config
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{TagName: "json", Result: &conf})
+		if err != nil {
+			return nil, err
+		}
+		if err := decoder.Decode(attributes); err != nil {
+			return nil, err
+		}
+		return &conf, nil
+	}, &Config{})
+}
+
+// The model types of the builtin ML model service.
+const (
+	ONNXModelType   = "onnx"
+	TFLiteModelType = "tflite"
+)
+
+// Config describes how to configure the service.
+type Config struct {
+	ModelPath string `json:"model_path"`
+	// ModelType is either "onnx" or "tflite", and is guessed from the extension of the model
+	// file when empty.
+	ModelType  string `json:"model_type,omitempty"`
+	NumThreads int    `json:"num_threads,omitempty"`
+	// LabelPath is a file with one label per line, naming the classes of the label output.
+	LabelPath string `json:"label_path,omitempty"`
+	// LabelOutput is the output tensor the labels are for, the first one when empty.
+	LabelOutput string `json:"label_output,omitempty"`
+}
+
+// model is a loaded model file.
+type model interface {
+	// metadata describes the model, the labels of its outputs excepted.
+	metadata() mlmodel.Metadata
+	infer(inputs map[string]inference.Tensor) (map[string]inference.Tensor, error)
+	Close() error
+}
+
+// NewBuiltIn loads the model file of the config and returns an ML model service running it.
+func NewBuiltIn(ctx context.Context, name string, svcConfig *Config, logger golog.Logger) (mlmodel.Service, error) {
+	if svcConfig == nil || svcConfig.ModelPath == "" {
+		return nil, errors.New("model_path is required")
+	}
+	modelType := svcConfig.ModelType
+	if modelType == "" {
+		modelType = strings.TrimPrefix(strings.ToLower(filepath.Ext(svcConfig.ModelPath)), ".")
+	}
+	var m model
+	var err error
+	switch modelType {
+	case ONNXModelType:
+		m, err = loadONNXModel(svcConfig)
+	case TFLiteModelType:
+		m, err = loadTFLiteModel(svcConfig)
+	default:
+		return nil, errors.Errorf("unsupported model type %q, set model_type to %q or %q", modelType, ONNXModelType, TFLiteModelType)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load model %s", svcConfig.ModelPath)
+	}
+	svc, err := newBuiltIn(name, modelType, m, svcConfig)
+	if err != nil {
+		return nil, multierr.Combine(err, m.Close())
+	}
+	logger.Debugw("loaded ML model", "name", name, "type", modelType, "path", svcConfig.ModelPath)
+	return svc, nil
+}
+
+func newBuiltIn(name, modelType string, m model, svcConfig *Config) (*builtIn, error) {
+	md := m.metadata()
+	md.Name, md.Type = name, modelType
+	if svcConfig.LabelPath != "" {
+		labels, err := readLabels(svcConfig.LabelPath)
+		if err != nil {
+			return nil, err
+		}
+		if len(md.Outputs) == 0 {
+			return nil, errors.New("cannot label a model without outputs")
+		}
+		labeled := 0
+		if svcConfig.LabelOutput != "" {
+			labeled = -1
+			for i, out := range md.Outputs {
+				if out.Name == svcConfig.LabelOutput {
+					labeled = i
+				}
+			}
+			if labeled < 0 {
+				return nil, errors.Errorf("model has no output named %q to label", svcConfig.LabelOutput)
+			}
+		}
+		md.Outputs[labeled].Labels = labels
+	}
+	return &builtIn{model: m, md: md}, nil
+}
+
+// readLabels reads the labels of the file, one per line.
+func readLabels(path string) ([]string, error) {
+	//nolint:gosec
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer goutils.UncheckedErrorFunc(f.Close)
+	var labels []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		labels = append(labels, scanner.Text())
+	}
+	return labels, scanner.Err()
+}
+
+type builtIn struct {
+	// mu serializes inferences, which models do not support concurrently.
+	mu    sync.Mutex
+	model model
+	md    mlmodel.Metadata
+}
+
+// Metadata describes the model and its tensors.
+func (svc *builtIn) Metadata(ctx context.Context) (mlmodel.Metadata, error) {
+	return svc.md, nil
+}
+
+// Infer runs the model on the input tensors.
+func (svc *builtIn) Infer(ctx context.Context, inputs map[string]inference.Tensor) (map[string]inference.Tensor, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	return svc.model.infer(inputs)
+}
+
+// Close releases the model.
+func (svc *builtIn) Close() error {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	return svc.model.Close()
+}
@@ -0,0 +1,106 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/ml/inference"
+	"go.viam.com/rdk/services/mlmodel"
+	"go.viam.com/rdk/utils"
+)
+
+// fakeModel doubles its float32 input.
+type fakeModel struct {
+	closed bool
+}
+
+func (m *fakeModel) metadata() mlmodel.Metadata {
+	return mlmodel.Metadata{
+		Inputs:  []mlmodel.TensorInfo{{Name: "x", Shape: []int64{2}, DataType: "float32"}},
+		Outputs: []mlmodel.TensorInfo{{Name: "boxes", DataType: "float32"}, {Name: "classes", DataType: "float32"}},
+	}
+}
+
+func (m *fakeModel) infer(inputs map[string]inference.Tensor) (map[string]inference.Tensor, error) {
+	x := inputs["x"].Data.([]float32)
+	return map[string]inference.Tensor{"boxes": {Data: []float32{2 * x[0], 2 * x[1]}}}, nil
+}
+
+func (m *fakeModel) Close() error {
+	m.closed = true
+	return nil
+}
+
+func TestBuiltIn(t *testing.T) {
+	labelPath := filepath.Join(t.TempDir(), "labels.txt")
+	test.That(t, os.WriteFile(labelPath, []byte("cat\ndog\n"), 0o600), test.ShouldBeNil)
+
+	m := &fakeModel{}
+	svc, err := newBuiltIn("model1", "fake", m, &Config{LabelPath: labelPath, LabelOutput: "classes"})
+	test.That(t, err, test.ShouldBeNil)
+	md, err := svc.Metadata(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, md.Name, test.ShouldEqual, "model1")
+	test.That(t, md.Type, test.ShouldEqual, "fake")
+	test.That(t, md.Outputs[0].Labels, test.ShouldBeNil)
+	test.That(t, md.Outputs[1].Labels, test.ShouldResemble, []string{"cat", "dog"})
+
+	outputs, err := svc.Infer(context.Background(), map[string]inference.Tensor{"x": {Data: []float32{1, 2}}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, outputs["boxes"].Data, test.ShouldResemble, []float32{2, 4})
+	test.That(t, svc.Close(), test.ShouldBeNil)
+	test.That(t, m.closed, test.ShouldBeTrue)
+
+	svc, err = newBuiltIn("model1", "fake", m, &Config{LabelPath: labelPath})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, svc.md.Outputs[0].Labels, test.ShouldResemble, []string{"cat", "dog"})
+	_, err = newBuiltIn("model1", "fake", m, &Config{LabelPath: labelPath, LabelOutput: "nope"})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = newBuiltIn("model1", "fake", m, &Config{LabelPath: filepath.Join(t.TempDir(), "nope.txt")})
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestNewBuiltIn(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	_, err := NewBuiltIn(ctx, "model1", &Config{}, logger)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "model_path is required")
+	_, err = NewBuiltIn(ctx, "model1", &Config{ModelPath: "model.pb"}, logger)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `unsupported model type "pb"`)
+	_, err = NewBuiltIn(ctx, "model1", &Config{ModelPath: "model.onnx"}, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestONNXBuiltIn(t *testing.T) {
+	modelPath := utils.ResolveFile("ml/inference/testing_files/relu.onnx")
+	svc, err := NewBuiltIn(context.Background(), "relu", &Config{ModelPath: modelPath, NumThreads: 1}, golog.NewTestLogger(t))
+	if errors.Is(err, inference.ErrONNXNotSupported) {
+		t.Skip(err)
+	}
+	if err != nil && strings.Contains(err.Error(), "could not load the onnxruntime library") {
+		t.Skipf("onnxruntime is not available: %v", err)
+	}
+	test.That(t, err, test.ShouldBeNil)
+	md, err := svc.Metadata(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, md, test.ShouldResemble, mlmodel.Metadata{
+		Name:        "relu",
+		Type:        ONNXModelType,
+		Description: "relu of 4 values",
+		Inputs:      []mlmodel.TensorInfo{{Name: "x", Shape: []int64{-1, 4}, DataType: "float32"}},
+		Outputs: []mlmodel.TensorInfo{
+			{Name: "y", Shape: []int64{-1, 4}, DataType: "float32", Labels: []string{"a", "b", "c", "d"}},
+		},
+	})
+	outputs, err := svc.Infer(context.Background(), map[string]inference.Tensor{"x": {Data: []float32{-1, 2, -3, 4}}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, outputs["y"], test.ShouldResemble, inference.Tensor{Shape: []int64{1, 4}, Data: []float32{0, 2, 0, 4}})
+	test.That(t, svc.(*builtIn).Close(), test.ShouldBeNil)
+}
@@ -0,0 +1,63 @@
+package builtin
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/ml/inference"
+	"go.viam.com/rdk/services/mlmodel"
+)
+
+// onnxModel runs an ONNX model.
+type onnxModel struct {
+	*inference.ONNXStruct
+}
+
+func loadONNXModel(svcConfig *Config) (*onnxModel, error) {
+	var loader *inference.ONNXModelLoader
+	var err error
+	if svcConfig.NumThreads <= 0 {
+		loader, err = inference.NewDefaultONNXModelLoader()
+	} else {
+		loader, err = inference.NewONNXModelLoader(svcConfig.NumThreads)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get loader")
+	}
+	m, err := loader.Load(svcConfig.ModelPath)
+	if err != nil {
+		return nil, err
+	}
+	return &onnxModel{m}, nil
+}
+
+// metadata describes the tensors of the model and takes its description from the ONNX metadata,
+// along with the labels of its first output if the custom metadata holds comma separated "labels".
+func (m *onnxModel) metadata() mlmodel.Metadata {
+	md := mlmodel.Metadata{
+		Inputs:  onnxTensorInfos(m.Info.Inputs),
+		Outputs: onnxTensorInfos(m.Info.Outputs),
+	}
+	if raw, err := m.Metadata(); err == nil {
+		if onnxMD, ok := raw.(*inference.ONNXMetadata); ok && onnxMD != nil {
+			md.Description = onnxMD.Description
+			if labels := onnxMD.CustomMetadata["labels"]; labels != "" && len(md.Outputs) > 0 {
+				md.Outputs[0].Labels = strings.Split(labels, ",")
+			}
+		}
+	}
+	return md
+}
+
+func onnxTensorInfos(infos []inference.ONNXTensorInfo) []mlmodel.TensorInfo {
+	out := make([]mlmodel.TensorInfo, 0, len(infos))
+	for _, info := range infos {
+		out = append(out, mlmodel.TensorInfo{Name: info.Name, Shape: info.Shape, DataType: info.DataType})
+	}
+	return out
+}
+
+func (m *onnxModel) infer(inputs map[string]inference.Tensor) (map[string]inference.Tensor, error) {
+	return m.InferTensors(inputs)
+}
@@ -0,0 +1,86 @@
+//go:build !arm
+
+package builtin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/ml/inference"
+	"go.viam.com/rdk/services/mlmodel"
+)
+
+// tfliteInputName is the name of the single input tensor of a TFLite model.
+const tfliteInputName = "input"
+
+// tfliteModel runs a TFLite model, whose output tensors are named by its metadata if it has any.
+type tfliteModel struct {
+	*inference.TFLiteStruct
+	outputNames []string
+}
+
+func loadTFLiteModel(svcConfig *Config) (*tfliteModel, error) {
+	var loader *inference.TFLiteModelLoader
+	var err error
+	if svcConfig.NumThreads <= 0 {
+		loader, err = inference.NewDefaultTFLiteModelLoader()
+	} else {
+		loader, err = inference.NewTFLiteModelLoader(svcConfig.NumThreads)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get loader")
+	}
+	m, err := loader.Load(svcConfig.ModelPath)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, m.Info.OutputTensorCount)
+	for i := range names {
+		names[i] = "output" + strconv.Itoa(i)
+	}
+	if md, err := m.Metadata(); err == nil && len(md.SubgraphMetadata) > 0 {
+		if outs := md.SubgraphMetadata[0].OutputTensorMetadata; len(outs) == len(names) {
+			for i, out := range outs {
+				if out.Name != "" {
+					names[i] = out.Name
+				}
+			}
+		}
+	}
+	return &tfliteModel{TFLiteStruct: m, outputNames: names}, nil
+}
+
+// metadata describes the tensors of the model. The shapes of the outputs are unknown.
+func (m *tfliteModel) metadata() mlmodel.Metadata {
+	shape := make([]int64, len(m.Info.InputShape))
+	for i, dim := range m.Info.InputShape {
+		shape[i] = int64(dim)
+	}
+	md := mlmodel.Metadata{
+		Inputs: []mlmodel.TensorInfo{{
+			Name: tfliteInputName, Shape: shape, DataType: strings.ToLower(string(m.Info.InputTensorType)),
+		}},
+	}
+	for i, name := range m.outputNames {
+		md.Outputs = append(md.Outputs, mlmodel.TensorInfo{Name: name, DataType: strings.ToLower(m.Info.OutputTensorTypes[i])})
+	}
+	return md
+}
+
+func (m *tfliteModel) infer(inputs map[string]inference.Tensor) (map[string]inference.Tensor, error) {
+	input, ok := inputs[tfliteInputName]
+	if !ok || len(inputs) != 1 {
+		return nil, errors.Errorf("a TFLite model takes a single input tensor named %q", tfliteInputName)
+	}
+	outs, err := m.Infer(input.Data)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make(map[string]inference.Tensor, len(outs))
+	for i, data := range outs {
+		outputs[m.outputNames[i]] = inference.Tensor{Data: data}
+	}
+	return outputs, nil
+}
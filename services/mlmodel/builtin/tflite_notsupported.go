@@ -0,0 +1,11 @@
+//go:build arm
+
+package builtin
+
+import (
+	"github.com/pkg/errors"
+)
+
+func loadTFLiteModel(svcConfig *Config) (model, error) {
+	return nil, errors.New("TFLite models are not supported on 32 bit arm")
+}
@@ -0,0 +1,49 @@
+package mlmodel
+
+import (
+	"context"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/utils/rpc"
+
+	"go.viam.com/rdk/ml/inference"
+	pb "go.viam.com/rdk/proto/api/service/mlmodel/v1"
+)
+
+// client implements MLModelServiceClient.
+type client struct {
+	name   string
+	conn   rpc.ClientConn
+	client pb.MLModelServiceClient
+	logger golog.Logger
+}
+
+// NewClientFromConn constructs a new Client from connection passed in.
+func NewClientFromConn(ctx context.Context, conn rpc.ClientConn, name string, logger golog.Logger) Service {
+	return &client{
+		name:   name,
+		conn:   conn,
+		client: pb.NewMLModelServiceClient(conn),
+		logger: logger,
+	}
+}
+
+func (c *client) Metadata(ctx context.Context) (Metadata, error) {
+	resp, err := c.client.Metadata(ctx, &pb.MetadataRequest{Name: c.name})
+	if err != nil {
+		return Metadata{}, err
+	}
+	return metadataFromProto(resp.Metadata), nil
+}
+
+func (c *client) Infer(ctx context.Context, inputs map[string]inference.Tensor) (map[string]inference.Tensor, error) {
+	encoded, err := encodeTensors(inputs)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Infer(ctx, &pb.InferRequest{Name: c.name, InputTensors: encoded})
+	if err != nil {
+		return nil, err
+	}
+	return decodeTensors(resp.OutputTensors)
+}
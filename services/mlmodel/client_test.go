@@ -0,0 +1,125 @@
+package mlmodel_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+	"go.viam.com/test"
+	"go.viam.com/utils/rpc"
+
+	viamgrpc "go.viam.com/rdk/grpc"
+	"go.viam.com/rdk/ml/inference"
+	pb "go.viam.com/rdk/proto/api/service/mlmodel/v1"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/services/mlmodel"
+	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+const testSvcName = "model1"
+
+func TestClient(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	md := mlmodel.Metadata{
+		Name: testSvcName,
+		Type: "onnx",
+		Inputs: []mlmodel.TensorInfo{
+			{Name: "x", Shape: []int64{-1, 4}, DataType: "float32"},
+		},
+		Outputs: []mlmodel.TensorInfo{
+			{Name: "scores", Shape: []int64{-1, 2}, DataType: "float32", Labels: []string{"cat", "dog"}},
+			{Name: "count", Shape: []int64{1}, DataType: "int64"},
+		},
+	}
+	injectSvc := &inject.MLModelService{
+		MetadataFunc: func(ctx context.Context) (mlmodel.Metadata, error) {
+			return md, nil
+		},
+		InferFunc: func(ctx context.Context, inputs map[string]inference.Tensor) (map[string]inference.Tensor, error) {
+			x, ok := inputs["x"].Data.([]float32)
+			if !ok {
+				return nil, errors.New("x must be float32")
+			}
+			return map[string]inference.Tensor{
+				"scores": {Shape: []int64{1, 2}, Data: []float32{x[0] + x[1], x[2] + x[3]}},
+				"count":  {Shape: []int64{1}, Data: []int64{int64(len(x))}},
+			}, nil
+		},
+	}
+	subtypeSvc, err := subtype.New(map[resource.Name]interface{}{mlmodel.Named(testSvcName): injectSvc})
+	test.That(t, err, test.ShouldBeNil)
+
+	resourceSubtype := registry.ResourceSubtypeLookup(mlmodel.Subtype)
+	test.That(t, resourceSubtype, test.ShouldNotBeNil)
+	test.That(t, resourceSubtype.ReflectRPCServiceDesc.GetFullyQualifiedName(), test.ShouldEqual, pb.MLModelService_ServiceDesc.ServiceName)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	rpcServer, err := rpc.NewServer(logger, rpc.WithUnauthenticated())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resourceSubtype.RegisterRPCService(context.Background(), rpcServer, subtypeSvc), test.ShouldBeNil)
+	go rpcServer.Serve(listener)
+	defer rpcServer.Stop()
+
+	conn, err := viamgrpc.Dial(context.Background(), listener.Addr().String(), logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, conn.Close(), test.ShouldBeNil)
+	}()
+	client := mlmodel.NewClientFromConn(context.Background(), conn, testSvcName, logger)
+	ctx := context.Background()
+
+	t.Run("metadata", func(t *testing.T) {
+		got, err := client.Metadata(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, got, test.ShouldResemble, md)
+	})
+
+	t.Run("infer", func(t *testing.T) {
+		outputs, err := client.Infer(ctx, map[string]inference.Tensor{"x": {Shape: []int64{1, 4}, Data: []float32{0.5, 1, 2, -3}}})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, outputs, test.ShouldResemble, map[string]inference.Tensor{
+			"scores": {Shape: []int64{1, 2}, Data: []float32{1.5, -1}},
+			"count":  {Shape: []int64{1}, Data: []int64{4}},
+		})
+
+		_, err = client.Infer(ctx, map[string]inference.Tensor{"x": {Data: []float64{1, 2, 3, 4}}})
+		test.That(t, err.Error(), test.ShouldContainSubstring, "x must be float32")
+		_, err = client.Infer(ctx, map[string]inference.Tensor{"x": {Data: []string{"nope"}}})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	t.Run("unknown service", func(t *testing.T) {
+		other := mlmodel.NewClientFromConn(context.Background(), conn, "other", logger)
+		_, err := other.Metadata(ctx)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "not found")
+	})
+}
+
+func TestFromRobot(t *testing.T) {
+	svc := &inject.MLModelService{}
+	r := &inject.Robot{}
+	r.ResourceByNameFunc = func(name resource.Name) (interface{}, error) {
+		if name == mlmodel.Named(testSvcName) {
+			return svc, nil
+		}
+		return "not an ML model", nil
+	}
+	got, err := mlmodel.FromRobot(r, testSvcName)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, got, test.ShouldEqual, svc)
+	_, err = mlmodel.FromRobot(r, "other")
+	test.That(t, err, test.ShouldBeError, mlmodel.NewUnimplementedInterfaceError("not an ML model"))
+
+	reconf, err := mlmodel.WrapWithReconfigurable(svc)
+	test.That(t, err, test.ShouldBeNil)
+	again, err := mlmodel.WrapWithReconfigurable(reconf)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, again, test.ShouldEqual, reconf)
+	_, err = mlmodel.WrapWithReconfigurable(r)
+	test.That(t, err, test.ShouldNotBeNil)
+}
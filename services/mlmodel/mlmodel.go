@@ -0,0 +1,167 @@
+// Package mlmodel contains a service that runs machine learning models on named tensors, along with
+// a gRPC server and client.
+package mlmodel
+
+import (
+	"context"
+	"sync"
+
+	"github.com/edaniels/golog"
+	goutils "go.viam.com/utils"
+	"go.viam.com/utils/rpc"
+
+	"go.viam.com/rdk/ml/inference"
+	pb "go.viam.com/rdk/proto/api/service/mlmodel/v1"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/utils"
+)
+
+func init() {
+	registry.RegisterResourceSubtype(Subtype, registry.ResourceSubtype{
+		RegisterRPCService: func(ctx context.Context, rpcServer rpc.Server, subtypeSvc subtype.Service) error {
+			return rpcServer.RegisterServiceServer(
+				ctx,
+				&pb.MLModelService_ServiceDesc,
+				NewServer(subtypeSvc),
+				pb.RegisterMLModelServiceHandlerFromEndpoint,
+			)
+		},
+		RPCServiceDesc: &pb.MLModelService_ServiceDesc,
+		RPCClient: func(ctx context.Context, conn rpc.ClientConn, name string, logger golog.Logger) interface{} {
+			return NewClientFromConn(ctx, conn, name, logger)
+		},
+		Reconfigurable: WrapWithReconfigurable,
+	})
+}
+
+// A Service runs a machine learning model on tensors. Other services use it through FromRobot.
+type Service interface {
+	// Metadata describes the model and its input and output tensors.
+	Metadata(ctx context.Context) (Metadata, error)
+	// Infer runs the model on the input tensors by name and returns its output tensors by name.
+	Infer(ctx context.Context, inputs map[string]inference.Tensor) (map[string]inference.Tensor, error)
+}
+
+// Metadata describes a model and its tensors.
+type Metadata struct {
+	Name        string       `json:"name"`
+	Type        string       `json:"type"`
+	Description string       `json:"description,omitempty"`
+	Inputs      []TensorInfo `json:"inputs"`
+	Outputs     []TensorInfo `json:"outputs"`
+}
+
+// TensorInfo describes an input or output tensor of a model.
+type TensorInfo struct {
+	Name string `json:"name"`
+	// Shape holds -1 for the dimensions of dynamic size, and is empty when unknown.
+	Shape    []int64 `json:"shape,omitempty"`
+	DataType string  `json:"data_type"`
+	// Labels names the classes the values of an output tensor stand for.
+	Labels []string `json:"labels,omitempty"`
+}
+
+var (
+	_ = Service(&reconfigurableMLModel{})
+	_ = resource.Reconfigurable(&reconfigurableMLModel{})
+	_ = goutils.ContextCloser(&reconfigurableMLModel{})
+)
+
+// NewUnimplementedInterfaceError is used when there is a failed interface check.
+func NewUnimplementedInterfaceError(actual interface{}) error {
+	return utils.NewUnimplementedInterfaceError((Service)(nil), actual)
+}
+
+// SubtypeName is the name of the type of service.
+const SubtypeName = resource.SubtypeName("mlmodel")
+
+// Subtype is a constant that identifies the ML model service resource subtype.
+var Subtype = resource.NewSubtype(
+	resource.ResourceNamespaceRDK,
+	resource.ResourceTypeService,
+	SubtypeName,
+)
+
+// Named is a helper for getting the named ML model service's typed resource name.
+func Named(name string) resource.Name {
+	return resource.NameFromSubtype(Subtype, name)
+}
+
+// FromRobot is a helper for getting the named ML model service from the given Robot.
+func FromRobot(r robot.Robot, name string) (Service, error) {
+	resource, err := r.ResourceByName(Named(name))
+	if err != nil {
+		return nil, utils.NewResourceNotFoundError(Named(name))
+	}
+	svc, ok := resource.(Service)
+	if !ok {
+		return nil, NewUnimplementedInterfaceError(resource)
+	}
+	return svc, nil
+}
+
+// FindFirstName returns name of first ML model service found.
+func FindFirstName(r robot.Robot) string {
+	for _, val := range robot.NamesBySubtype(r, Subtype) {
+		return val
+	}
+	return ""
+}
+
+type reconfigurableMLModel struct {
+	mu     sync.RWMutex
+	actual Service
+}
+
+func (svc *reconfigurableMLModel) Metadata(ctx context.Context) (Metadata, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	return svc.actual.Metadata(ctx)
+}
+
+func (svc *reconfigurableMLModel) Infer(
+	ctx context.Context,
+	inputs map[string]inference.Tensor,
+) (map[string]inference.Tensor, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	return svc.actual.Infer(ctx, inputs)
+}
+
+func (svc *reconfigurableMLModel) Close(ctx context.Context) error {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	return goutils.TryClose(ctx, svc.actual)
+}
+
+// Reconfigure replaces the old ML model service with a new one.
+func (svc *reconfigurableMLModel) Reconfigure(ctx context.Context, newSvc resource.Reconfigurable) error {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	rSvc, ok := newSvc.(*reconfigurableMLModel)
+	if !ok {
+		return utils.NewUnexpectedTypeError(svc, newSvc)
+	}
+	if err := goutils.TryClose(ctx, svc.actual); err != nil {
+		golog.Global().Errorw("error closing old", "error", err)
+	}
+	svc.actual = rSvc.actual
+	return nil
+}
+
+// WrapWithReconfigurable wraps an ML model service as a Reconfigurable.
+func WrapWithReconfigurable(s interface{}) (resource.Reconfigurable, error) {
+	svc, ok := s.(Service)
+	if !ok {
+		return nil, NewUnimplementedInterfaceError(s)
+	}
+
+	if reconfigurable, ok := s.(*reconfigurableMLModel); ok {
+		return reconfigurable, nil
+	}
+
+	return &reconfigurableMLModel{actual: svc}, nil
+}
@@ -0,0 +1,110 @@
+package mlmodel
+
+import (
+	"context"
+
+	pb "go.viam.com/rdk/proto/api/service/mlmodel/v1"
+	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/utils"
+)
+
+// subtypeServer implements the contract from proto/api/service/mlmodel/v1/mlmodel.proto.
+type subtypeServer struct {
+	pb.UnimplementedMLModelServiceServer
+	subtypeSvc subtype.Service
+}
+
+// NewServer constructs an ML model gRPC service server.
+func NewServer(s subtype.Service) pb.MLModelServiceServer {
+	return &subtypeServer{subtypeSvc: s}
+}
+
+func (server *subtypeServer) service(serviceName string) (Service, error) {
+	resource := server.subtypeSvc.Resource(serviceName)
+	if resource == nil {
+		return nil, utils.NewResourceNotFoundError(Named(serviceName))
+	}
+	svc, ok := resource.(Service)
+	if !ok {
+		return nil, NewUnimplementedInterfaceError(resource)
+	}
+	return svc, nil
+}
+
+// Metadata returns the metadata of the model of the named service.
+func (server *subtypeServer) Metadata(ctx context.Context, req *pb.MetadataRequest) (*pb.MetadataResponse, error) {
+	svc, err := server.service(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	md, err := svc.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.MetadataResponse{Metadata: metadataToProto(md)}, nil
+}
+
+// Infer runs the model of the named service on the input tensors of the request.
+func (server *subtypeServer) Infer(ctx context.Context, req *pb.InferRequest) (*pb.InferResponse, error) {
+	svc, err := server.service(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := decodeTensors(req.InputTensors)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := svc.Infer(ctx, inputs)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := encodeTensors(outputs)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.InferResponse{OutputTensors: encoded}, nil
+}
+
+func metadataToProto(md Metadata) *pb.Metadata {
+	tensorInfos := func(infos []TensorInfo) []*pb.TensorInfo {
+		converted := make([]*pb.TensorInfo, 0, len(infos))
+		for _, info := range infos {
+			converted = append(converted, &pb.TensorInfo{
+				Name:     info.Name,
+				Shape:    info.Shape,
+				DataType: info.DataType,
+				Labels:   info.Labels,
+			})
+		}
+		return converted
+	}
+	return &pb.Metadata{
+		Name:        md.Name,
+		Type:        md.Type,
+		Description: md.Description,
+		Inputs:      tensorInfos(md.Inputs),
+		Outputs:     tensorInfos(md.Outputs),
+	}
+}
+
+func metadataFromProto(md *pb.Metadata) Metadata {
+	tensorInfos := func(infos []*pb.TensorInfo) []TensorInfo {
+		converted := make([]TensorInfo, 0, len(infos))
+		for _, info := range infos {
+			converted = append(converted, TensorInfo{
+				Name:     info.Name,
+				Shape:    info.Shape,
+				DataType: info.DataType,
+				Labels:   info.Labels,
+			})
+		}
+		return converted
+	}
+	return Metadata{
+		Name:        md.Name,
+		Type:        md.Type,
+		Description: md.Description,
+		Inputs:      tensorInfos(md.Inputs),
+		Outputs:     tensorInfos(md.Outputs),
+	}
+}
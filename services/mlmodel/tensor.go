@@ -0,0 +1,76 @@
+package mlmodel
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/ml/inference"
+	pb "go.viam.com/rdk/proto/api/service/mlmodel/v1"
+)
+
+// tensorDataTypes maps the data types of tensors to the slice types holding their data.
+var tensorDataTypes = map[string]reflect.Type{
+	"float32": reflect.TypeOf([]float32(nil)),
+	"float64": reflect.TypeOf([]float64(nil)),
+	"int8":    reflect.TypeOf([]int8(nil)),
+	"int16":   reflect.TypeOf([]int16(nil)),
+	"int32":   reflect.TypeOf([]int32(nil)),
+	"int64":   reflect.TypeOf([]int64(nil)),
+	"uint8":   reflect.TypeOf([]uint8(nil)),
+	"uint16":  reflect.TypeOf([]uint16(nil)),
+	"uint32":  reflect.TypeOf([]uint32(nil)),
+	"uint64":  reflect.TypeOf([]uint64(nil)),
+	"bool":    reflect.TypeOf([]bool(nil)),
+}
+
+// TensorDataType returns the data type of the tensor data, such as "float32" for a []float32.
+func TensorDataType(data interface{}) (string, error) {
+	t := reflect.TypeOf(data)
+	for name, sliceType := range tensorDataTypes {
+		if t == sliceType {
+			return name, nil
+		}
+	}
+	return "", errors.Errorf("unsupported tensor data of type %T", data)
+}
+
+// encodeTensors returns the tensors for the wire, with their data held as its little endian bytes.
+func encodeTensors(tensors map[string]inference.Tensor) (map[string]*pb.Tensor, error) {
+	encoded := make(map[string]*pb.Tensor, len(tensors))
+	for name, t := range tensors {
+		dataType, err := TensorDataType(t.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "tensor %q", name)
+		}
+		var buf bytes.Buffer
+		if err := binary.Write(&buf, binary.LittleEndian, t.Data); err != nil {
+			return nil, errors.Wrapf(err, "tensor %q", name)
+		}
+		encoded[name] = &pb.Tensor{Shape: t.Shape, DataType: dataType, Data: buf.Bytes()}
+	}
+	return encoded, nil
+}
+
+// decodeTensors decodes tensors encoded by encodeTensors.
+func decodeTensors(encoded map[string]*pb.Tensor) (map[string]inference.Tensor, error) {
+	tensors := make(map[string]inference.Tensor, len(encoded))
+	for name, t := range encoded {
+		sliceType, ok := tensorDataTypes[t.DataType]
+		if !ok {
+			return nil, errors.Errorf("tensor %q has unsupported data type %q", name, t.DataType)
+		}
+		size := int(sliceType.Elem().Size())
+		if len(t.Data)%size != 0 {
+			return nil, errors.Errorf("tensor %q has %d bytes, not a multiple of the %d bytes of a %s", name, len(t.Data), size, t.DataType)
+		}
+		data := reflect.MakeSlice(sliceType, len(t.Data)/size, len(t.Data)/size).Interface()
+		if err := binary.Read(bytes.NewReader(t.Data), binary.LittleEndian, data); err != nil {
+			return nil, errors.Wrapf(err, "tensor %q", name)
+		}
+		tensors[name] = inference.Tensor{Shape: t.Shape, Data: data}
+	}
+	return tensors, nil
+}
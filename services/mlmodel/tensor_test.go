@@ -0,0 +1,37 @@
+package mlmodel
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/ml/inference"
+	pb "go.viam.com/rdk/proto/api/service/mlmodel/v1"
+)
+
+func TestTensorEncoding(t *testing.T) {
+	tensors := map[string]inference.Tensor{
+		"floats": {Shape: []int64{2, 2}, Data: []float32{1.5, -2, 3, 4}},
+		"ids":    {Shape: []int64{3}, Data: []int64{-1, 0, 1 << 40}},
+		"bytes":  {Data: []uint8{1, 2, 3}},
+		"mask":   {Data: []bool{true, false}},
+	}
+	encoded, err := encodeTensors(tensors)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, encoded["floats"].DataType, test.ShouldEqual, "float32")
+	test.That(t, encoded["floats"].Data, test.ShouldHaveLength, 16)
+	decoded, err := decodeTensors(encoded)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, decoded, test.ShouldResemble, tensors)
+
+	_, err = encodeTensors(map[string]inference.Tensor{"x": {Data: []string{"nope"}}})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = decodeTensors(map[string]*pb.Tensor{"x": {DataType: "complex64", Data: []byte{0}}})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = decodeTensors(map[string]*pb.Tensor{"x": {DataType: "float32", Data: []byte{0, 0, 0}}})
+	test.That(t, err.Error(), test.ShouldContainSubstring, "not a multiple")
+
+	dataType, err := TensorDataType([]uint16{1})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, dataType, test.ShouldEqual, "uint16")
+}
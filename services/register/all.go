@@ -6,6 +6,7 @@ import (
 	_ "go.viam.com/rdk/services/armremotecontrol/register"
 	_ "go.viam.com/rdk/services/baseremotecontrol/register"
 	_ "go.viam.com/rdk/services/datamanager/register"
+	_ "go.viam.com/rdk/services/mlmodel/register"
 	_ "go.viam.com/rdk/services/motion/register"
 	_ "go.viam.com/rdk/services/navigation/register"
 	_ "go.viam.com/rdk/services/sensors/register"
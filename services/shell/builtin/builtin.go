@@ -3,7 +3,6 @@ package builtin
 
 import (
 	"context"
-	"errors"
 	"io"
 	"os"
 	"os/exec"
@@ -12,6 +11,8 @@ import (
 
 	"github.com/creack/pty"
 	"github.com/edaniels/golog"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
 	"go.viam.com/utils"
 
 	"go.viam.com/rdk/config"
@@ -24,19 +25,51 @@ import (
 func init() {
 	registry.RegisterService(shell.Subtype, resource.DefaultModelName, registry.Service{
 		Constructor: func(ctx context.Context, r robot.Robot, c config.Service, logger golog.Logger) (interface{}, error) {
-			return NewBuiltIn(logger)
+			svcConfig, _ := c.ConvertedAttributes.(*Config)
+			return NewBuiltIn(svcConfig, logger)
 		},
 	},
 	)
+	cType := config.ServiceType(shell.SubtypeName)
+	config.RegisterServiceAttributeMapConverter(cType, func(attributes config.AttributeMap) (interface{}, error) {
+		var conf Config
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{TagName: "json", Result: &conf})
+		if err != nil {
+			return nil, err
+		}
+		if err := decoder.Decode(attributes); err != nil {
+			return nil, err
+		}
+		return &conf, nil
+	}, &Config{})
+}
+
+// Config describes how to configure the service.
+type Config struct {
+	// AllowedPaths are the directories files may be uploaded to and downloaded from, along with
+	// their subdirectories. No file can be transferred if there are none.
+	AllowedPaths []string `json:"allowed_paths,omitempty"`
 }
 
-// NewBuiltIn returns a new shell service for the given robot.
-func NewBuiltIn(logger golog.Logger) (shell.Service, error) {
-	return &builtIn{logger: logger}, nil
+// NewBuiltIn returns a new shell service for the given robot. The config is optional.
+func NewBuiltIn(svcConfig *Config, logger golog.Logger) (shell.Service, error) {
+	svc := &builtIn{logger: logger}
+	if svcConfig == nil {
+		return svc, nil
+	}
+	for _, path := range svcConfig.AllowedPaths {
+		resolved, err := resolvePath(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid allowed path %q", path)
+		}
+		svc.allowedPaths = append(svc.allowedPaths, resolved)
+	}
+	return svc, nil
 }
 
 type builtIn struct {
 	logger                  golog.Logger
+	allowedPaths            []string
 	activeBackgroundWorkers sync.WaitGroup
 }
 
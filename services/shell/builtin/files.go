@@ -0,0 +1,216 @@
+package builtin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/services/shell"
+)
+
+var _ = shell.ExtendedService(&builtIn{})
+
+// Exec runs the command without a shell and returns its exit code. Unlike file transfers, commands
+// are not restricted to the allowed paths since the shell already gives the same access.
+func (svc *builtIn) Exec(ctx context.Context, cmd shell.Command, stdout, stderr io.Writer) (int, error) {
+	if len(cmd.Args) == 0 {
+		return 0, errors.New("no command to run")
+	}
+	//nolint:gosec
+	execCmd := exec.CommandContext(ctx, cmd.Args[0], cmd.Args[1:]...)
+	execCmd.Env = append(os.Environ(), cmd.Env...)
+	execCmd.Dir = cmd.Dir
+	execCmd.Stdout = stdout
+	execCmd.Stderr = stderr
+	err := execCmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && ctx.Err() == nil {
+		return exitErr.ExitCode(), nil
+	}
+	return 0, err
+}
+
+// Stat returns the info of the file at path.
+func (svc *builtIn) Stat(ctx context.Context, path string, checksum bool) (shell.FileInfo, error) {
+	resolved, err := svc.allowedPath(path)
+	if err != nil {
+		return shell.FileInfo{}, err
+	}
+	return statFile(resolved, checksum)
+}
+
+// Upload writes data to the partial upload file of path from offset on, then renames it to path.
+// The partial file is only readable by the robot until it takes the mode of the file it replaces.
+func (svc *builtIn) Upload(ctx context.Context, path string, offset int64, data io.Reader, checksum string) (shell.FileInfo, error) {
+	resolved, err := svc.allowedPath(path)
+	if err != nil {
+		return shell.FileInfo{}, err
+	}
+	partial := shell.PartialUploadPath(resolved)
+	//nolint:gosec
+	f, err := os.OpenFile(partial, os.O_WRONLY|os.O_CREATE, 0o600)
+	if err != nil {
+		return shell.FileInfo{}, err
+	}
+	defer utils.UncheckedErrorFunc(f.Close)
+	info, err := f.Stat()
+	if err != nil {
+		return shell.FileInfo{}, err
+	}
+	if offset < 0 || offset > info.Size() {
+		return shell.FileInfo{}, errors.Errorf("cannot resume upload at offset %d of a file of %d bytes", offset, info.Size())
+	}
+	if err := f.Truncate(offset); err != nil {
+		return shell.FileInfo{}, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return shell.FileInfo{}, err
+	}
+	if _, err := io.Copy(f, contextReader{ctx, data}); err != nil {
+		return shell.FileInfo{}, err
+	}
+	if err := f.Close(); err != nil {
+		return shell.FileInfo{}, err
+	}
+	written, err := statFile(partial, checksum != "")
+	if err != nil {
+		return shell.FileInfo{}, err
+	}
+	if checksum != "" && !strings.EqualFold(checksum, written.SHA256) {
+		// resuming an upload that does not match can not fix it
+		utils.UncheckedError(os.Remove(partial))
+		return shell.FileInfo{}, errors.Errorf("checksum of uploaded file %q is %s, expected %s", path, written.SHA256, checksum)
+	}
+	if replaced, err := os.Stat(resolved); err == nil {
+		if err := os.Chmod(partial, replaced.Mode().Perm()); err != nil {
+			return shell.FileInfo{}, err
+		}
+		written.Mode = replaced.Mode().Perm()
+	}
+	if err := os.Rename(partial, resolved); err != nil {
+		return shell.FileInfo{}, err
+	}
+	written.Path = resolved
+	return written, nil
+}
+
+// Download writes the content of the file at path from offset on to data.
+func (svc *builtIn) Download(ctx context.Context, path string, offset int64, data io.Writer) (shell.FileInfo, error) {
+	resolved, err := svc.allowedPath(path)
+	if err != nil {
+		return shell.FileInfo{}, err
+	}
+	// the checksum lets the downloader verify the whole file, even when resuming
+	info, err := statFile(resolved, true)
+	if err != nil {
+		return shell.FileInfo{}, err
+	}
+	if offset < 0 || offset > info.Size {
+		return shell.FileInfo{}, errors.Errorf("cannot resume download at offset %d of a file of %d bytes", offset, info.Size)
+	}
+	//nolint:gosec
+	f, err := os.Open(resolved)
+	if err != nil {
+		return shell.FileInfo{}, err
+	}
+	defer utils.UncheckedErrorFunc(f.Close)
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return shell.FileInfo{}, err
+	}
+	if _, err := io.Copy(data, contextReader{ctx, io.LimitReader(f, info.Size-offset)}); err != nil {
+		return shell.FileInfo{}, err
+	}
+	return info, nil
+}
+
+// allowedPath returns the resolved path if it is within the allowed paths of the service.
+func (svc *builtIn) allowedPath(path string) (string, error) {
+	resolved, err := resolvePath(path)
+	if err != nil {
+		return "", err
+	}
+	for _, allowed := range svc.allowedPaths {
+		if resolved == allowed || strings.HasPrefix(resolved, allowed+string(filepath.Separator)) {
+			return resolved, nil
+		}
+	}
+	return "", errors.Errorf("path %q is not within the allowed paths of the shell service", path)
+}
+
+// resolvePath returns the absolute path with its symbolic links evaluated, so that a link can not
+// lead outside of an allowed path. The file itself does not need to exist.
+func resolvePath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err == nil {
+		return resolved, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+	dir, err := filepath.EvalSymlinks(filepath.Dir(abs))
+	if os.IsNotExist(err) {
+		// none of the path exists yet, it can only be an allowed path to be created later
+		return abs, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, filepath.Base(abs)), nil
+}
+
+// statFile returns the info of the file at path, including the checksum of its content if checksum
+// is set, which takes reading the whole file.
+func statFile(path string, checksum bool) (shell.FileInfo, error) {
+	//nolint:gosec
+	f, err := os.Open(path)
+	if err != nil {
+		return shell.FileInfo{}, err
+	}
+	defer utils.UncheckedErrorFunc(f.Close)
+	info, err := f.Stat()
+	if err != nil {
+		return shell.FileInfo{}, err
+	}
+	if info.IsDir() {
+		return shell.FileInfo{}, errors.Errorf("%q is a directory", path)
+	}
+	fileInfo := shell.FileInfo{
+		Path:    path,
+		Size:    info.Size(),
+		Mode:    info.Mode(),
+		ModTime: info.ModTime(),
+	}
+	if checksum {
+		hash := sha256.New()
+		if _, err := io.Copy(hash, f); err != nil {
+			return shell.FileInfo{}, err
+		}
+		fileInfo.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	}
+	return fileInfo, nil
+}
+
+// contextReader stops reading once its context is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
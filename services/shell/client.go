@@ -2,12 +2,17 @@ package shell
 
 import (
 	"context"
+	"errors"
+	"io"
 	"sync"
 
 	"github.com/edaniels/golog"
+	"go.uber.org/multierr"
 	pb "go.viam.com/api/service/shell/v1"
 	"go.viam.com/utils"
 	"go.viam.com/utils/rpc"
+
+	extendedpb "go.viam.com/rdk/proto/api/service/shell/v1"
 )
 
 // client implements ShellServiceClient.
@@ -15,18 +20,20 @@ type client struct {
 	name                    string
 	conn                    rpc.ClientConn
 	client                  pb.ShellServiceClient
+	extendedClient          extendedpb.ExtendedShellServiceClient
 	logger                  golog.Logger
 	activeBackgroundWorkers sync.WaitGroup
 }
 
 // NewClientFromConn constructs a new Client from connection passed in.
-func NewClientFromConn(ctx context.Context, conn rpc.ClientConn, name string, logger golog.Logger) Service {
+func NewClientFromConn(ctx context.Context, conn rpc.ClientConn, name string, logger golog.Logger) ExtendedService {
 	grpcClient := pb.NewShellServiceClient(conn)
 	c := &client{
-		name:   name,
-		conn:   conn,
-		client: grpcClient,
-		logger: logger,
+		name:           name,
+		conn:           conn,
+		client:         grpcClient,
+		extendedClient: extendedpb.NewExtendedShellServiceClient(conn),
+		logger:         logger,
 	}
 	return c
 }
@@ -99,3 +106,100 @@ func (c *client) Shell(ctx context.Context) (chan<- string, <-chan Output, error
 
 	return input, output, nil
 }
+
+func (c *client) Exec(ctx context.Context, cmd Command, stdout, stderr io.Writer) (int, error) {
+	stream, err := c.extendedClient.Exec(ctx, &extendedpb.ExecRequest{
+		Name: c.name,
+		Args: cmd.Args,
+		Env:  cmd.Env,
+		Dir:  cmd.Dir,
+	})
+	if err != nil {
+		return 0, err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return 0, errors.New("command output ended without an exit code")
+			}
+			return 0, err
+		}
+		switch output := resp.Output.(type) {
+		case *extendedpb.ExecResponse_Stdout:
+			if _, err := stdout.Write(output.Stdout); err != nil {
+				return 0, err
+			}
+		case *extendedpb.ExecResponse_Stderr:
+			if _, err := stderr.Write(output.Stderr); err != nil {
+				return 0, err
+			}
+		case *extendedpb.ExecResponse_ExitCode:
+			return int(output.ExitCode), nil
+		}
+	}
+}
+
+func (c *client) Stat(ctx context.Context, path string, checksum bool) (FileInfo, error) {
+	resp, err := c.extendedClient.StatFile(ctx, &extendedpb.StatFileRequest{Name: c.name, Path: path, Checksum: checksum})
+	if err != nil {
+		return FileInfo{}, err
+	}
+	return fileInfoFromProto(resp.Info), nil
+}
+
+func (c *client) Upload(ctx context.Context, path string, offset int64, data io.Reader, checksum string) (FileInfo, error) {
+	stream, err := c.extendedClient.UploadFile(ctx)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	// a send fails with io.EOF when the server ended the call, whose error is then received below
+	sendErr := stream.Send(&extendedpb.UploadFileRequest{Upload: &extendedpb.UploadFileRequest_Metadata{
+		Metadata: &extendedpb.UploadFileMetadata{Name: c.name, Path: path, Offset: offset, Sha256: checksum},
+	}})
+	buf := make([]byte, fileChunkSize)
+	for sendErr == nil {
+		n, err := data.Read(buf)
+		if n > 0 {
+			sendErr = stream.Send(&extendedpb.UploadFileRequest{Upload: &extendedpb.UploadFileRequest_Data{Data: buf[:n]}})
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return FileInfo{}, multierr.Combine(err, stream.CloseSend())
+		}
+	}
+	if sendErr != nil && !errors.Is(sendErr, io.EOF) {
+		return FileInfo{}, sendErr
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return FileInfo{}, err
+	}
+	return fileInfoFromProto(resp.Info), nil
+}
+
+func (c *client) Download(ctx context.Context, path string, offset int64, data io.Writer) (FileInfo, error) {
+	stream, err := c.extendedClient.DownloadFile(ctx, &extendedpb.DownloadFileRequest{Name: c.name, Path: path, Offset: offset})
+	if err != nil {
+		return FileInfo{}, err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return FileInfo{}, errors.New("file content ended without the file info")
+			}
+			return FileInfo{}, err
+		}
+		switch download := resp.Download.(type) {
+		case *extendedpb.DownloadFileResponse_Data:
+			if _, err := data.Write(download.Data); err != nil {
+				return FileInfo{}, err
+			}
+		case *extendedpb.DownloadFileResponse_Info:
+			return fileInfoFromProto(download.Info), nil
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package shell_test
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+	"go.viam.com/utils/rpc"
+
+	viamgrpc "go.viam.com/rdk/grpc"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/services/shell"
+	"go.viam.com/rdk/services/shell/builtin"
+	"go.viam.com/rdk/subtype"
+)
+
+func TestClientExecAndFiles(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	allowed := t.TempDir()
+	outside := t.TempDir()
+
+	svc, err := builtin.NewBuiltIn(&builtin.Config{AllowedPaths: []string{allowed}}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	subtypeSvc, err := subtype.New(map[resource.Name]interface{}{shell.Named(testSvcName1): svc})
+	test.That(t, err, test.ShouldBeNil)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	rpcServer, err := rpc.NewServer(logger, rpc.WithUnauthenticated())
+	test.That(t, err, test.ShouldBeNil)
+	resourceSubtype := registry.ResourceSubtypeLookup(shell.Subtype)
+	test.That(t, resourceSubtype.RegisterRPCService(context.Background(), rpcServer, subtypeSvc), test.ShouldBeNil)
+	go rpcServer.Serve(listener)
+	defer rpcServer.Stop()
+
+	conn, err := viamgrpc.Dial(context.Background(), listener.Addr().String(), logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, conn.Close(), test.ShouldBeNil)
+	}()
+	client := shell.NewClientFromConn(context.Background(), conn, testSvcName1, logger)
+	ctx := context.Background()
+
+	t.Run("exec", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		exitCode, err := client.Exec(ctx, shell.Command{
+			Args: []string{"sh", "-c", `pwd; echo "$GREETING"; echo oops >&2; exit 3`},
+			Env:  []string{"GREETING=hello"},
+			Dir:  allowed,
+		}, &stdout, &stderr)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, exitCode, test.ShouldEqual, 3)
+		resolved, err := filepath.EvalSymlinks(allowed)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, stdout.String(), test.ShouldEqual, resolved+"\nhello\n")
+		test.That(t, stderr.String(), test.ShouldEqual, "oops\n")
+
+		_, err = client.Exec(ctx, shell.Command{Args: []string{"/does/not/exist"}}, &stdout, &stderr)
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	t.Run("upload and download", func(t *testing.T) {
+		content := []byte(strings.Repeat("0123456789", 20000))
+		local := filepath.Join(outside, "local.bin")
+		test.That(t, os.WriteFile(local, content, 0o600), test.ShouldBeNil)
+		remote := filepath.Join(allowed, "remote.bin")
+
+		// an interrupted upload left the start of the file in its partial upload file
+		test.That(t, os.WriteFile(remote, []byte("previous"), 0o640), test.ShouldBeNil)
+		test.That(t, os.Chmod(remote, 0o640), test.ShouldBeNil)
+		test.That(t, os.WriteFile(shell.PartialUploadPath(remote), content[:12345], 0o600), test.ShouldBeNil)
+		info, err := shell.UploadFile(ctx, client, local, remote)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, info.Size, test.ShouldEqual, len(content))
+		uploadedSHA256 := info.SHA256
+		uploaded, err := os.ReadFile(remote)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, uploaded, test.ShouldResemble, content)
+		_, err = os.Stat(shell.PartialUploadPath(remote))
+		test.That(t, os.IsNotExist(err), test.ShouldBeTrue)
+		// the uploaded file keeps the mode of the file it replaced
+		test.That(t, info.Mode, test.ShouldEqual, os.FileMode(0o640))
+		replaced, err := os.Stat(remote)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, replaced.Mode().Perm(), test.ShouldEqual, os.FileMode(0o640))
+
+		// a new file is only readable by the robot
+		info, err = client.Upload(ctx, filepath.Join(allowed, "new.bin"), 0, strings.NewReader("data"), "")
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, info.Mode, test.ShouldEqual, os.FileMode(0o600))
+
+		stat, err := client.Stat(ctx, remote, true)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, stat.SHA256, test.ShouldEqual, uploadedSHA256)
+		stat, err = client.Stat(ctx, remote, false)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, stat.Size, test.ShouldEqual, len(content))
+		test.That(t, stat.SHA256, test.ShouldBeEmpty)
+
+		// an upload whose checksum does not match fails and leaves the file as it was
+		_, err = client.Upload(ctx, remote, 0, strings.NewReader("corrupted"), strings.Repeat("0", 64))
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "checksum")
+		uploaded, err = os.ReadFile(remote)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, uploaded, test.ShouldResemble, content)
+		_, err = os.Stat(shell.PartialUploadPath(remote))
+		test.That(t, os.IsNotExist(err), test.ShouldBeTrue)
+
+		// an interrupted download is resumed, a local file not matching the remote one is replaced
+		downloaded := filepath.Join(outside, "downloaded.bin")
+		test.That(t, os.WriteFile(downloaded, content[:777], 0o600), test.ShouldBeNil)
+		info, err = shell.DownloadFile(ctx, client, remote, downloaded)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, info.SHA256, test.ShouldEqual, uploadedSHA256)
+		test.That(t, os.WriteFile(downloaded, []byte("garbage"), 0o600), test.ShouldBeNil)
+		_, err = shell.DownloadFile(ctx, client, remote, downloaded)
+		test.That(t, err, test.ShouldBeNil)
+		got, err := os.ReadFile(downloaded)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, got, test.ShouldResemble, content)
+	})
+
+	t.Run("paths outside of the allow-list", func(t *testing.T) {
+		_, err := client.Stat(ctx, filepath.Join(outside, "local.bin"), false)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "not within the allowed paths")
+
+		_, err = client.Download(ctx, filepath.Join(allowed, "..", filepath.Base(outside), "local.bin"), 0, &bytes.Buffer{})
+		test.That(t, err, test.ShouldNotBeNil)
+
+		// a link can not lead out of an allowed path
+		link := filepath.Join(allowed, "link")
+		test.That(t, os.Symlink(outside, link), test.ShouldBeNil)
+		_, err = client.Upload(ctx, filepath.Join(link, "escaped.bin"), 0, strings.NewReader("data"), "")
+		test.That(t, err, test.ShouldNotBeNil)
+		_, err = os.Stat(filepath.Join(outside, "escaped.bin"))
+		test.That(t, os.IsNotExist(err), test.ShouldBeTrue)
+	})
+}
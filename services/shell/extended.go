@@ -0,0 +1,191 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	goutils "go.viam.com/utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "go.viam.com/rdk/proto/api/service/shell/v1"
+	"go.viam.com/rdk/subtype"
+	"go.viam.com/rdk/utils"
+)
+
+// fileChunkSize is the most file content sent in a single message.
+const fileChunkSize = 64 * 1024
+
+// extendedServer implements the contract from proto/api/service/shell/v1/shell.proto.
+type extendedServer struct {
+	pb.UnimplementedExtendedShellServiceServer
+	subtypeSvc subtype.Service
+}
+
+// NewExtendedServer constructs an extended shell gRPC service server.
+func NewExtendedServer(s subtype.Service) pb.ExtendedShellServiceServer {
+	return &extendedServer{subtypeSvc: s}
+}
+
+func (server *extendedServer) service(serviceName string) (ExtendedService, error) {
+	resource := server.subtypeSvc.Resource(serviceName)
+	if resource == nil {
+		return nil, utils.NewResourceNotFoundError(Named(serviceName))
+	}
+	svc, ok := resource.(ExtendedService)
+	if !ok {
+		return nil, NewUnimplementedExtendedInterfaceError(resource)
+	}
+	return svc, nil
+}
+
+// StatFile returns the info of the file named by the request.
+func (server *extendedServer) StatFile(ctx context.Context, req *pb.StatFileRequest) (*pb.StatFileResponse, error) {
+	svc, err := server.service(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := svc.Stat(ctx, req.Path, req.Checksum)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.StatFileResponse{Info: fileInfoToProto(info)}, nil
+}
+
+// UploadFile writes the data of the stream to the file named by its first message.
+func (server *extendedServer) UploadFile(stream pb.ExtendedShellService_UploadFileServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	metadata := req.GetMetadata()
+	if metadata == nil {
+		return errors.New("upload must start with its metadata")
+	}
+	svc, err := server.service(metadata.Name)
+	if err != nil {
+		return err
+	}
+
+	// the file is written while the data is received
+	pr, pw := io.Pipe()
+	var info FileInfo
+	var uploadErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	goutils.PanicCapturingGo(func() {
+		defer wg.Done()
+		info, uploadErr = svc.Upload(stream.Context(), metadata.Path, metadata.Offset, pr, metadata.Sha256)
+		// unblocks the receiving loop if the upload ended before all data was written
+		pr.CloseWithError(errors.New("upload ended"))
+	})
+	pw.CloseWithError(receiveData(stream, pw))
+	wg.Wait()
+	if uploadErr != nil {
+		return uploadErr
+	}
+	return stream.SendAndClose(&pb.UploadFileResponse{Info: fileInfoToProto(info)})
+}
+
+// receiveData writes the data received on the stream to w until the client is done sending.
+func receiveData(stream pb.ExtendedShellService_UploadFileServer, w io.Writer) error {
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if _, err := w.Write(req.GetData()); err != nil {
+			return err
+		}
+	}
+}
+
+// DownloadFile sends the content of the file named by the request in chunks, followed by its info.
+func (server *extendedServer) DownloadFile(req *pb.DownloadFileRequest, stream pb.ExtendedShellService_DownloadFileServer) error {
+	svc, err := server.service(req.Name)
+	if err != nil {
+		return err
+	}
+	w := &chunkWriter{send: func(data []byte) error {
+		return stream.Send(&pb.DownloadFileResponse{Download: &pb.DownloadFileResponse_Data{Data: data}})
+	}}
+	info, err := svc.Download(stream.Context(), req.Path, req.Offset, w)
+	if err != nil {
+		return err
+	}
+	return stream.Send(&pb.DownloadFileResponse{Download: &pb.DownloadFileResponse_Info{Info: fileInfoToProto(info)}})
+}
+
+// Exec runs the command of the request, sending its output as it is written, followed by its
+// exit code.
+func (server *extendedServer) Exec(req *pb.ExecRequest, stream pb.ExtendedShellService_ExecServer) error {
+	svc, err := server.service(req.Name)
+	if err != nil {
+		return err
+	}
+	// the output of the command is written concurrently to stdout and stderr
+	var mu sync.Mutex
+	send := func(resp *pb.ExecResponse) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return stream.Send(resp)
+	}
+	stdout := &chunkWriter{send: func(data []byte) error {
+		return send(&pb.ExecResponse{Output: &pb.ExecResponse_Stdout{Stdout: data}})
+	}}
+	stderr := &chunkWriter{send: func(data []byte) error {
+		return send(&pb.ExecResponse{Output: &pb.ExecResponse_Stderr{Stderr: data}})
+	}}
+	cmd := Command{Args: req.Args, Env: req.Env, Dir: req.Dir}
+	exitCode, err := svc.Exec(stream.Context(), cmd, stdout, stderr)
+	if err != nil {
+		return err
+	}
+	return send(&pb.ExecResponse{Output: &pb.ExecResponse_ExitCode{ExitCode: int64(exitCode)}})
+}
+
+func fileInfoToProto(info FileInfo) *pb.FileInfo {
+	return &pb.FileInfo{
+		Path:    info.Path,
+		Size:    info.Size,
+		Mode:    uint32(info.Mode),
+		ModTime: timestamppb.New(info.ModTime),
+		Sha256:  info.SHA256,
+	}
+}
+
+func fileInfoFromProto(info *pb.FileInfo) FileInfo {
+	return FileInfo{
+		Path:    info.Path,
+		Size:    info.Size,
+		Mode:    os.FileMode(info.Mode),
+		ModTime: info.ModTime.AsTime(),
+		SHA256:  info.Sha256,
+	}
+}
+
+// chunkWriter sends what is written to it in chunks of at most fileChunkSize bytes.
+type chunkWriter struct {
+	send func(data []byte) error
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	var n int
+	for len(p) > 0 {
+		size := len(p)
+		if size > fileChunkSize {
+			size = fileChunkSize
+		}
+		if err := w.send(p[:size]); err != nil {
+			return n, err
+		}
+		n += size
+		p = p[size:]
+	}
+	return n, nil
+}
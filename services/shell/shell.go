@@ -3,13 +3,18 @@ package shell
 
 import (
 	"context"
+	"io"
+	"os"
+	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/edaniels/golog"
 	servicepb "go.viam.com/api/service/shell/v1"
 	"go.viam.com/utils"
 	"go.viam.com/utils/rpc"
 
+	extendedpb "go.viam.com/rdk/proto/api/service/shell/v1"
 	"go.viam.com/rdk/registry"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/subtype"
@@ -19,11 +24,19 @@ import (
 func init() {
 	registry.RegisterResourceSubtype(Subtype, registry.ResourceSubtype{
 		RegisterRPCService: func(ctx context.Context, rpcServer rpc.Server, subtypeSvc subtype.Service) error {
-			return rpcServer.RegisterServiceServer(
+			if err := rpcServer.RegisterServiceServer(
 				ctx,
 				&servicepb.ShellService_ServiceDesc,
 				NewServer(subtypeSvc),
 				servicepb.RegisterShellServiceHandlerFromEndpoint,
+			); err != nil {
+				return err
+			}
+			return rpcServer.RegisterServiceServer(
+				ctx,
+				&extendedpb.ExtendedShellService_ServiceDesc,
+				NewExtendedServer(subtypeSvc),
+				extendedpb.RegisterExtendedShellServiceHandlerFromEndpoint,
 			)
 		},
 		RPCServiceDesc: &servicepb.ShellService_ServiceDesc,
@@ -39,8 +52,32 @@ type Service interface {
 	Shell(ctx context.Context) (input chan<- string, output <-chan Output, retErr error)
 }
 
+// An ExtendedService is a Service that also runs commands to completion and transfers files. Shell
+// services that do not implement it only serve interactive shells.
+type ExtendedService interface {
+	Service
+
+	// Exec runs the command to completion, writing its output to stdout and stderr, and returns
+	// its exit code.
+	Exec(ctx context.Context, cmd Command, stdout, stderr io.Writer) (int, error)
+
+	// Stat returns the info of the file at path, with the checksum of its content if checksum is
+	// set.
+	Stat(ctx context.Context, path string, checksum bool) (FileInfo, error)
+
+	// Upload writes the content read from data to the partial upload file of path from offset on,
+	// truncating it there, so that an interrupted upload can be resumed. Once written, the partial
+	// file replaces the file at path, after its SHA-256 is verified to match checksum if set. It
+	// returns the info of the written file.
+	Upload(ctx context.Context, path string, offset int64, data io.Reader, checksum string) (FileInfo, error)
+
+	// Download writes the content of the file at path from offset on to data and returns the info
+	// of the file, with its checksum.
+	Download(ctx context.Context, path string, offset int64, data io.Writer) (FileInfo, error)
+}
+
 var (
-	_ = Service(&reconfigurableShell{})
+	_ = ExtendedService(&reconfigurableShell{})
 	_ = resource.Reconfigurable(&reconfigurableShell{})
 	_ = utils.ContextCloser(&reconfigurableShell{})
 )
@@ -50,6 +87,12 @@ func NewUnimplementedInterfaceError(actual interface{}) error {
 	return rdkutils.NewUnimplementedInterfaceError((Service)(nil), actual)
 }
 
+// NewUnimplementedExtendedInterfaceError is used when a shell service does not implement
+// ExtendedService.
+func NewUnimplementedExtendedInterfaceError(actual interface{}) error {
+	return rdkutils.NewUnimplementedInterfaceError((ExtendedService)(nil), actual)
+}
+
 // Output reflects an instance of shell output on either stdout or stderr.
 type Output struct {
 	Output string // reflects stdout
@@ -57,6 +100,32 @@ type Output struct {
 	EOF    bool
 }
 
+// A Command is a command run to completion by Exec.
+type Command struct {
+	// Args holds the path of the command followed by its arguments.
+	Args []string `json:"args"`
+	// Env holds environment variables of the form "key=value" added to those of the robot.
+	Env []string `json:"env,omitempty"`
+	// Dir is the working directory of the command, that of the robot if empty.
+	Dir string `json:"dir,omitempty"`
+}
+
+// FileInfo describes a file of the robot.
+type FileInfo struct {
+	Path    string      `json:"path"`
+	Size    int64       `json:"size"`
+	Mode    os.FileMode `json:"mode"`
+	ModTime time.Time   `json:"mod_time"`
+	// SHA256 is the hex encoded SHA-256 checksum of the whole file, if requested.
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// PartialUploadPath returns the path of the file an upload to path is written to until it is
+// complete, which is hidden in the same directory so that it can be renamed to path.
+func PartialUploadPath(path string) string {
+	return filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".upload")
+}
+
 // SubtypeName is the name of the type of service.
 const SubtypeName = resource.SubtypeName("shell")
 
@@ -83,6 +152,56 @@ func (svc *reconfigurableShell) Shell(ctx context.Context) (input chan<- string,
 	return svc.actual.Shell(ctx)
 }
 
+// extended returns the current service as an ExtendedService. Commands and transfers run on it
+// without holding the lock, so that they do not block reconfiguration while they last.
+func (svc *reconfigurableShell) extended() (ExtendedService, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	actual, ok := svc.actual.(ExtendedService)
+	if !ok {
+		return nil, NewUnimplementedExtendedInterfaceError(svc.actual)
+	}
+	return actual, nil
+}
+
+func (svc *reconfigurableShell) Exec(ctx context.Context, cmd Command, stdout, stderr io.Writer) (int, error) {
+	actual, err := svc.extended()
+	if err != nil {
+		return 0, err
+	}
+	return actual.Exec(ctx, cmd, stdout, stderr)
+}
+
+func (svc *reconfigurableShell) Stat(ctx context.Context, path string, checksum bool) (FileInfo, error) {
+	actual, err := svc.extended()
+	if err != nil {
+		return FileInfo{}, err
+	}
+	return actual.Stat(ctx, path, checksum)
+}
+
+func (svc *reconfigurableShell) Upload(
+	ctx context.Context,
+	path string,
+	offset int64,
+	data io.Reader,
+	checksum string,
+) (FileInfo, error) {
+	actual, err := svc.extended()
+	if err != nil {
+		return FileInfo{}, err
+	}
+	return actual.Upload(ctx, path, offset, data, checksum)
+}
+
+func (svc *reconfigurableShell) Download(ctx context.Context, path string, offset int64, data io.Writer) (FileInfo, error) {
+	actual, err := svc.extended()
+	if err != nil {
+		return FileInfo{}, err
+	}
+	return actual.Download(ctx, path, offset, data)
+}
+
 func (svc *reconfigurableShell) Close(ctx context.Context) error {
 	svc.mu.RLock()
 	defer svc.mu.RUnlock()
@@ -2,6 +2,7 @@ package shell_test
 
 import (
 	"context"
+	"io"
 	"testing"
 
 	"go.viam.com/test"
@@ -58,6 +59,51 @@ func TestReconfigurable(t *testing.T) {
 	test.That(t, err, test.ShouldBeError, rutils.NewUnexpectedTypeError(reconfSvc1, nil))
 }
 
+func TestReconfigurableExtended(t *testing.T) {
+	ctx := context.Background()
+	plain := &mock{name: testSvcName1}
+	reconfPlain, err := shell.WrapWithReconfigurable(plain)
+	test.That(t, err, test.ShouldBeNil)
+	_, err = reconfPlain.(shell.ExtendedService).Stat(ctx, "/tmp/file", false)
+	test.That(t, err, test.ShouldBeError, shell.NewUnimplementedExtendedInterfaceError(plain))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	actual := &extendedMock{mock: mock{name: testSvcName1}, started: started, release: release}
+	reconfSvc1, err := shell.WrapWithReconfigurable(actual)
+	test.That(t, err, test.ShouldBeNil)
+	reconfSvc2, err := shell.WrapWithReconfigurable(&extendedMock{mock: mock{name: testSvcName2}})
+	test.That(t, err, test.ShouldBeNil)
+
+	downloaded := make(chan error)
+	go func() {
+		_, err := reconfSvc1.(shell.ExtendedService).Download(ctx, "/tmp/file", 0, io.Discard)
+		downloaded <- err
+	}()
+	<-started
+	// a transfer in progress does not block reconfiguration
+	test.That(t, reconfSvc1.Reconfigure(ctx, reconfSvc2), test.ShouldBeNil)
+	test.That(t, actual.reconfCount, test.ShouldEqual, 1)
+	close(release)
+	test.That(t, <-downloaded, test.ShouldBeNil)
+}
+
+type extendedMock struct {
+	mock
+	shell.ExtendedService
+	started, release chan struct{}
+}
+
+func (m *extendedMock) Shell(ctx context.Context) (chan<- string, <-chan shell.Output, error) {
+	return m.mock.Shell(ctx)
+}
+
+func (m *extendedMock) Download(ctx context.Context, path string, offset int64, data io.Writer) (shell.FileInfo, error) {
+	close(m.started)
+	<-m.release
+	return shell.FileInfo{Path: path}, nil
+}
+
 type mock struct {
 	shell.Service
 	name        string
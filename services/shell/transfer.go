@@ -0,0 +1,113 @@
+package shell
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+)
+
+// UploadFile uploads the local file to the remote path through the shell service. An earlier upload
+// that was interrupted is resumed when its partial upload file holds the start of the local file.
+// The uploaded file is verified against the checksum of the local file before it replaces the
+// remote file.
+func UploadFile(ctx context.Context, svc ExtendedService, localPath, remotePath string) (FileInfo, error) {
+	//nolint:gosec
+	f, err := os.Open(localPath)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	defer utils.UncheckedErrorFunc(f.Close)
+	checksum, err := checksumOf(f, -1)
+	if err != nil {
+		return FileInfo{}, err
+	}
+
+	local, err := f.Stat()
+	if err != nil {
+		return FileInfo{}, err
+	}
+
+	if remote, err := svc.Stat(ctx, remotePath, true); err == nil && remote.SHA256 == checksum {
+		return remote, nil
+	}
+	var offset int64
+	// a missing partial file is uploaded from the start, other errors are reported by the upload
+	if partial, err := svc.Stat(ctx, PartialUploadPath(remotePath), true); err == nil && partial.Size <= local.Size() {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return FileInfo{}, err
+		}
+		prefix, err := checksumOf(f, partial.Size)
+		if err != nil {
+			return FileInfo{}, err
+		}
+		if prefix == partial.SHA256 {
+			offset = partial.Size
+		}
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return FileInfo{}, err
+	}
+	return svc.Upload(ctx, remotePath, offset, f, checksum)
+}
+
+// DownloadFile downloads the remote file to the local path through the shell service. An earlier
+// download that was interrupted is resumed from the end of the local file. The downloaded file is
+// verified against the checksum of the remote file and downloaded again from the start if it does
+// not match.
+func DownloadFile(ctx context.Context, svc ExtendedService, remotePath, localPath string) (FileInfo, error) {
+	//nolint:gosec
+	f, err := os.OpenFile(localPath, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	defer utils.UncheckedErrorFunc(f.Close)
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	for {
+		info, err := svc.Download(ctx, remotePath, offset, f)
+		if err != nil {
+			return FileInfo{}, err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return FileInfo{}, err
+		}
+		checksum, err := checksumOf(f, -1)
+		if err != nil {
+			return FileInfo{}, err
+		}
+		if checksum == info.SHA256 {
+			return info, f.Close()
+		}
+		if offset == 0 {
+			return FileInfo{}, errors.Errorf("checksum of downloaded file %q is %s, expected %s", localPath, checksum, info.SHA256)
+		}
+		// the local file did not hold the start of the remote file
+		offset = 0
+		if err := f.Truncate(0); err != nil {
+			return FileInfo{}, err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return FileInfo{}, err
+		}
+	}
+}
+
+// checksumOf returns the hex encoded SHA-256 checksum of the next n bytes of r, or of all of them
+// if n is negative.
+func checksumOf(r io.Reader, n int64) (string, error) {
+	if n >= 0 {
+		r = io.LimitReader(r, n)
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
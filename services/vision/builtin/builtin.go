@@ -22,6 +22,7 @@ import (
 	viz "go.viam.com/rdk/vision"
 	"go.viam.com/rdk/vision/classification"
 	objdet "go.viam.com/rdk/vision/objectdetection"
+	"go.viam.com/rdk/vision/segmentation"
 )
 
 func init() {
@@ -142,7 +143,8 @@ func (vs *builtIn) DetectionsFromCamera(ctx context.Context, cameraName, detecto
 	}
 	defer release()
 
-	return detector(ctx, img)
+	// trackers follow the images of every camera separately
+	return detector(objdet.ContextWithStreamName(ctx, cameraName), img)
 }
 
 // Detections returns the detections of given image using the given detector.
@@ -163,6 +165,45 @@ func (vs *builtIn) Detections(ctx context.Context, img image.Image, detectorName
 	return detector(ctx, img)
 }
 
+// TracksFromCamera returns the objects the given tracker follows in the images of the given camera,
+// after tracking them in its next image.
+func (vs *builtIn) TracksFromCamera(ctx context.Context, cameraName, trackerName string) ([]objdet.Track, error) {
+	ctx, span := trace.StartSpan(ctx, "service::vision::TracksFromCamera")
+	defer span.End()
+	m, err := vs.modReg.modelLookup(trackerName)
+	if err != nil {
+		return nil, err
+	}
+	trackers, err := m.toTrackers()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := vs.DetectionsFromCamera(ctx, cameraName, trackerName); err != nil {
+		return nil, err
+	}
+	return trackers.Get(cameraName).Tracks(), nil
+}
+
+// TrackEvents returns the latest events of the objects the given tracker follows in the images of
+// the given camera whose sequence number is greater than after.
+func (vs *builtIn) TrackEvents(ctx context.Context, cameraName, trackerName string, after uint64) ([]objdet.TrackEvent, error) {
+	_, span := trace.StartSpan(ctx, "service::vision::TrackEvents")
+	defer span.End()
+	m, err := vs.modReg.modelLookup(trackerName)
+	if err != nil {
+		return nil, err
+	}
+	trackers, err := m.toTrackers()
+	if err != nil {
+		return nil, err
+	}
+	tracker, ok := trackers.Lookup(cameraName)
+	if !ok {
+		return []objdet.TrackEvent{}, nil
+	}
+	return tracker.Events(after), nil
+}
+
 // ClassifierNames returns a list of the all the names of the classifiers in the registry.
 func (vs *builtIn) ClassifierNames(ctx context.Context) ([]string, error) {
 	_, span := trace.StartSpan(ctx, "service::vision::ClassifierNames")
@@ -291,6 +332,50 @@ func (vs *builtIn) GetObjectPointClouds(
 	return segmenter(ctx, cam)
 }
 
+// SegmentationMaskFromCamera returns the mask of the next image from the given camera computed by
+// the given segmenter.
+func (vs *builtIn) SegmentationMaskFromCamera(
+	ctx context.Context,
+	cameraName string,
+	segmenterName string,
+) (*segmentation.Mask, error) {
+	ctx, span := trace.StartSpan(ctx, "service::vision::SegmentationMaskFromCamera")
+	defer span.End()
+	cam, err := camera.FromRobot(vs.r, cameraName)
+	if err != nil {
+		return nil, err
+	}
+	s, err := vs.modReg.modelLookup(segmenterName)
+	if err != nil {
+		return nil, err
+	}
+	maskSegmenter, err := s.toMaskSegmenter()
+	if err != nil {
+		return nil, err
+	}
+	img, release, err := camera.ReadImage(ctx, cam)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return maskSegmenter(ctx, img)
+}
+
+// SegmentationMask returns the mask of the given image computed by the given segmenter.
+func (vs *builtIn) SegmentationMask(ctx context.Context, img image.Image, segmenterName string) (*segmentation.Mask, error) {
+	ctx, span := trace.StartSpan(ctx, "service::vision::SegmentationMask")
+	defer span.End()
+	s, err := vs.modReg.modelLookup(segmenterName)
+	if err != nil {
+		return nil, err
+	}
+	maskSegmenter, err := s.toMaskSegmenter()
+	if err != nil {
+		return nil, err
+	}
+	return maskSegmenter(ctx, img)
+}
+
 // Close removes all existing detectors from the vision service.
 func (vs *builtIn) Close() error {
 	models := vs.modReg.ModelNames()
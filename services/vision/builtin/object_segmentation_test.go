@@ -79,7 +79,11 @@ func TestObjectSegmentationFailures(t *testing.T) {
 		"mean_k_filtering":      10.,
 	}
 
-	err = obs.AddSegmenter(context.Background(), vision.VisModelConfig{builtin.RadiusClusteringSegmenter, string(builtin.RCSegmenter), params})
+	err = obs.AddSegmenter(context.Background(), vision.VisModelConfig{
+		Name:       builtin.RadiusClusteringSegmenter,
+		Type:       string(builtin.RCSegmenter),
+		Parameters: params,
+	})
 	test.That(t, err, test.ShouldBeNil)
 	_, err = obs.GetObjectPointClouds(context.Background(), "fakeCamera", builtin.RadiusClusteringSegmenter)
 	test.That(t, errors.Is(err, transform.ErrNoIntrinsics), test.ShouldBeTrue)
@@ -116,7 +120,11 @@ func TestGetObjectPointClouds(t *testing.T) {
 		"clustering_radius_mm":  5.,
 		"mean_k_filtering":      10.,
 	}
-	err = obs.AddSegmenter(context.Background(), vision.VisModelConfig{builtin.RadiusClusteringSegmenter, string(builtin.RCSegmenter), params})
+	err = obs.AddSegmenter(context.Background(), vision.VisModelConfig{
+		Name:       builtin.RadiusClusteringSegmenter,
+		Type:       string(builtin.RCSegmenter),
+		Parameters: params,
+	})
 	test.That(t, err, test.ShouldBeNil)
 
 	// see if it ws registered
@@ -254,7 +262,7 @@ func TestFullClientServerLoop(t *testing.T) {
 	}
 	err = client.AddSegmenter(
 		context.Background(),
-		vision.VisModelConfig{builtin.RadiusClusteringSegmenter, string(builtin.RCSegmenter), params},
+		vision.VisModelConfig{Name: builtin.RadiusClusteringSegmenter, Type: string(builtin.RCSegmenter), Parameters: params},
 	)
 	test.That(t, err, test.ShouldBeNil)
 
@@ -0,0 +1,120 @@
+//go:build !arm
+package builtin
+
+import (
+	"context"
+	"image"
+	"math"
+	"strconv"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+	"go.uber.org/multierr"
+
+	"go.viam.com/rdk/config"
+	inf "go.viam.com/rdk/ml/inference"
+	"go.viam.com/rdk/services/vision"
+	"go.viam.com/rdk/utils"
+	"go.viam.com/rdk/vision/classification"
+)
+
+// ONNXClassifierConfig specifies the fields necessary for creating an ONNX classifier.
+type ONNXClassifierConfig struct {
+	// this should come from the attributes part of the classifier config
+	ModelPath  string  `json:"model_path"`
+	NumThreads int     `json:"num_threads"`
+	LabelPath  *string `json:"label_path"`
+	// the size of the model input, only needed when the model has dynamic image dimensions
+	InputWidth  int `json:"input_width,omitempty"`
+	InputHeight int `json:"input_height,omitempty"`
+	// per channel normalization of float inputs, applied to the RGB values scaled to [0, 1]
+	Mean []float64 `json:"mean,omitempty"`
+	Std  []float64 `json:"std,omitempty"`
+	// whether the first output of the model holds logits that should be turned into probabilities
+	ApplySoftmax bool `json:"apply_softmax,omitempty"`
+}
+
+// NewONNXClassifier creates an RDK classifier given a VisModelConfig of an ONNX model.
+// The model takes a single RGB image and its first output holds the score of each class.
+func NewONNXClassifier(
+	ctx context.Context,
+	conf *vision.VisModelConfig,
+	logger golog.Logger,
+) (classification.Classifier, *inf.ONNXStruct, error) {
+	ctx, span := trace.StartSpan(ctx, "service::vision::NewONNXClassifier")
+	defer span.End()
+
+	var o ONNXClassifierConfig
+	onnxParams, err := config.TransformAttributeMapToStruct(&o, conf.Parameters)
+	if err != nil {
+		return nil, nil, errors.New("error getting parameters from config")
+	}
+	params, ok := onnxParams.(*ONNXClassifierConfig)
+	if !ok {
+		err := utils.NewUnexpectedTypeError(params, onnxParams)
+		return nil, nil, errors.Wrapf(err, "register onnx classifier %s", conf.Name)
+	}
+
+	model, err := addONNXModel(ctx, params.ModelPath, params.NumThreads)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "something wrong with adding the model")
+	}
+	input, err := newONNXImageInput(model.Info, params.InputWidth, params.InputHeight, params.Mean, params.Std)
+	if err != nil {
+		return nil, nil, multierr.Combine(err, model.Close())
+	}
+	if len(model.Info.Outputs) == 0 {
+		return nil, nil, multierr.Combine(errors.New("model has no outputs"), model.Close())
+	}
+	output := model.Info.Outputs[0].Name
+	labels := onnxLabels(model, params.LabelPath, logger)
+
+	return func(ctx context.Context, img image.Image) (classification.Classifications, error) {
+		_, span := trace.StartSpan(ctx, "service::vision::onnxClassify")
+		defer span.End()
+		out, err := model.InferTensors(map[string]inf.Tensor{input.name: input.tensor(img)})
+		if err != nil {
+			return nil, errors.Wrap(err, "couldn't infer from model")
+		}
+		scores, err := onnxOutputValues(out, output)
+		if err != nil {
+			return nil, err
+		}
+		return unpackONNXClassifications(scores, params.ApplySoftmax, labels), nil
+	}, model, nil
+}
+
+// unpackONNXClassifications labels the class scores, turning them into probabilities first if asked.
+func unpackONNXClassifications(scores []float64, applySoftmax bool, labels []string) classification.Classifications {
+	if applySoftmax {
+		scores = softmax(scores)
+	}
+	out := make(classification.Classifications, 0, len(scores))
+	for i, s := range scores {
+		label := strconv.Itoa(i)
+		if i < len(labels) {
+			label = labels[i]
+		}
+		out = append(out, classification.NewClassification(s, label))
+	}
+	return out
+}
+
+// softmax returns the normalized exponentials of the logits.
+func softmax(logits []float64) []float64 {
+	maxLogit := math.Inf(-1)
+	for _, l := range logits {
+		maxLogit = math.Max(maxLogit, l)
+	}
+	probs := make([]float64, len(logits))
+	sum := 0.
+	for i, l := range logits {
+		probs[i] = math.Exp(l - maxLogit)
+		sum += probs[i]
+	}
+	for i := range probs {
+		probs[i] /= sum
+	}
+	return probs
+}
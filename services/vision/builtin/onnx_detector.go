@@ -0,0 +1,415 @@
+//go:build !arm
+package builtin
+
+import (
+	"context"
+	"image"
+	"math"
+	fp "path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/edaniels/golog"
+	"github.com/nfnt/resize"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+	"go.uber.org/multierr"
+
+	"go.viam.com/rdk/config"
+	inf "go.viam.com/rdk/ml/inference"
+	"go.viam.com/rdk/services/vision"
+	"go.viam.com/rdk/utils"
+	"go.viam.com/rdk/vision/objectdetection"
+)
+
+// ONNXDetectorConfig specifies the fields necessary for creating an ONNX detector.
+type ONNXDetectorConfig struct {
+	// this should come from the attributes part of the detector config
+	ModelPath  string  `json:"model_path"`
+	NumThreads int     `json:"num_threads"`
+	LabelPath  *string `json:"label_path"`
+	// the size of the model input, only needed when the model has dynamic image dimensions
+	InputWidth  int `json:"input_width,omitempty"`
+	InputHeight int `json:"input_height,omitempty"`
+	// per channel normalization of float inputs, applied to the RGB values scaled to [0, 1]
+	Mean []float64 `json:"mean,omitempty"`
+	Std  []float64 `json:"std,omitempty"`
+	// the names of the output tensors, guessed from the output names when empty
+	BoxesOutput  string `json:"boxes_output,omitempty"`
+	ScoresOutput string `json:"scores_output,omitempty"`
+	LabelsOutput string `json:"labels_output,omitempty"`
+	// the order of the box coordinates, either "xyxy" (default) or "yxyx"
+	BoxFormat string `json:"box_format,omitempty"`
+}
+
+// onnxDetectorOutputs are the names of the output tensors a detection is read from.
+type onnxDetectorOutputs struct {
+	boxes, scores, labels string
+}
+
+// NewONNXDetector creates an RDK detector given a VisModelConfig of an ONNX model.
+// The model takes a single RGB image and outputs bounding boxes with optional scores and labels.
+func NewONNXDetector(
+	ctx context.Context,
+	cfg *vision.VisModelConfig,
+	logger golog.Logger,
+) (objectdetection.Detector, *inf.ONNXStruct, error) {
+	ctx, span := trace.StartSpan(ctx, "service::vision::NewONNXDetector")
+	defer span.End()
+
+	var o ONNXDetectorConfig
+	onnxParams, err := config.TransformAttributeMapToStruct(&o, cfg.Parameters)
+	if err != nil {
+		return nil, nil, errors.New("error getting parameters from config")
+	}
+	params, ok := onnxParams.(*ONNXDetectorConfig)
+	if !ok {
+		err := utils.NewUnexpectedTypeError(params, onnxParams)
+		return nil, nil, errors.Wrapf(err, "register onnx detector %s", cfg.Name)
+	}
+	switch params.BoxFormat {
+	case "":
+		params.BoxFormat = "xyxy"
+	case "xyxy", "yxyx":
+	default:
+		return nil, nil, errors.Errorf("box_format must be xyxy or yxyx, got %q", params.BoxFormat)
+	}
+
+	model, err := addONNXModel(ctx, params.ModelPath, params.NumThreads)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "something wrong with adding the model")
+	}
+	input, err := newONNXImageInput(model.Info, params.InputWidth, params.InputHeight, params.Mean, params.Std)
+	if err != nil {
+		return nil, nil, multierr.Combine(err, model.Close())
+	}
+	outputs, err := findONNXDetectorOutputs(model.Info.Outputs, params)
+	if err != nil {
+		return nil, nil, multierr.Combine(err, model.Close())
+	}
+	labelMap := onnxLabels(model, params.LabelPath, logger)
+
+	return func(ctx context.Context, img image.Image) ([]objectdetection.Detection, error) {
+		_, span := trace.StartSpan(ctx, "service::vision::onnxDetect")
+		defer span.End()
+		out, err := model.InferTensors(map[string]inf.Tensor{input.name: input.tensor(img)})
+		if err != nil {
+			return nil, errors.Wrap(err, "couldn't infer from model")
+		}
+		return unpackONNXDetections(out, outputs, params.BoxFormat, labelMap, input.width, input.height, img.Bounds())
+	}, model, nil
+}
+
+// addONNXModel uses the loader from the inference package to load an ONNX model.
+// Default is chosen if there's no numThreads given.
+func addONNXModel(ctx context.Context, filepath string, numThreads int) (*inf.ONNXStruct, error) {
+	_, span := trace.StartSpan(ctx, "service::vision::addONNXModel")
+	defer span.End()
+	var loader *inf.ONNXModelLoader
+	var err error
+	if numThreads <= 0 {
+		loader, err = inf.NewDefaultONNXModelLoader()
+	} else {
+		loader, err = inf.NewONNXModelLoader(numThreads)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get loader")
+	}
+	if fullpath, err := fp.Abs(filepath); err == nil {
+		filepath = fullpath
+	}
+	model, err := loader.Load(filepath)
+	if err != nil {
+		return nil, errors.Wrap(err, "loader could not load model")
+	}
+	return model, nil
+}
+
+// onnxLabels reads the labels from the label file if there is one, and otherwise from the
+// comma separated "labels" entry of the custom metadata of the model.
+func onnxLabels(model *inf.ONNXStruct, labelPath *string, logger golog.Logger) []string {
+	if labelPath != nil && *labelPath != "" {
+		labels, err := loadLabels(*labelPath)
+		if err == nil {
+			return labels
+		}
+		logger.Warnw("did not retrieve class labels", "error", err)
+	}
+	if m, err := model.Metadata(); err == nil {
+		if md, ok := m.(*inf.ONNXMetadata); ok && md != nil && md.CustomMetadata["labels"] != "" {
+			return strings.Split(md.CustomMetadata["labels"], ",")
+		}
+	}
+	return nil
+}
+
+// onnxImageInput describes how an image is fed to the image input tensor of an ONNX model.
+type onnxImageInput struct {
+	name          string
+	width, height int
+	channelsFirst bool
+	isUInt8       bool
+	mean, std     []float64
+}
+
+// newONNXImageInput reads the layout of the single image input of a model from its shape, which is
+// either NCHW or NHWC with 3 channels. The configured width and height size dynamic dimensions.
+func newONNXImageInput(info *inf.ONNXInfo, width, height int, mean, std []float64) (*onnxImageInput, error) {
+	if len(info.Inputs) != 1 {
+		return nil, errors.Errorf("expected a model with a single image input, got %d inputs", len(info.Inputs))
+	}
+	in := info.Inputs[0]
+	if len(in.Shape) != 4 {
+		return nil, errors.Errorf("expected an image input of rank 4, got input %q of shape %v", in.Name, in.Shape)
+	}
+	input := &onnxImageInput{name: in.Name, mean: []float64{0, 0, 0}, std: []float64{1, 1, 1}}
+	var h, w int64
+	switch {
+	case in.Shape[1] == 3:
+		input.channelsFirst = true
+		h, w = in.Shape[2], in.Shape[3]
+	case in.Shape[3] == 3:
+		h, w = in.Shape[1], in.Shape[2]
+	default:
+		return nil, errors.Errorf("expected an NCHW or NHWC input with 3 channels, got input %q of shape %v", in.Name, in.Shape)
+	}
+	input.width, input.height = int(w), int(h)
+	if width > 0 {
+		input.width = width
+	}
+	if height > 0 {
+		input.height = height
+	}
+	if input.width <= 0 || input.height <= 0 {
+		return nil, errors.Errorf("input %q of shape %v has a dynamic size, set input_width and input_height", in.Name, in.Shape)
+	}
+	switch in.DataType {
+	case "float32":
+	case "uint8":
+		input.isUInt8 = true
+	default:
+		return nil, errors.Errorf("invalid input type %q. try uint8 or float32", in.DataType)
+	}
+	if len(mean) > 0 {
+		if len(mean) != 3 {
+			return nil, errors.Errorf("mean must have 3 values, got %d", len(mean))
+		}
+		input.mean = mean
+	}
+	if len(std) > 0 {
+		if len(std) != 3 {
+			return nil, errors.Errorf("std must have 3 values, got %d", len(std))
+		}
+		for _, s := range std {
+			if s == 0 {
+				return nil, errors.New("std values cannot be zero")
+			}
+		}
+		input.std = std
+	}
+	return input, nil
+}
+
+// tensor resizes the image to the model input and returns it as a batch of one image.
+func (in *onnxImageInput) tensor(img image.Image) inf.Tensor {
+	resized := resize.Resize(uint(in.width), uint(in.height), img, resize.Bilinear)
+	bounds := resized.Bounds()
+	size := in.width * in.height
+	// index returns the position of channel c of pixel (x, y) in the flattened tensor
+	index := func(x, y, c int) int {
+		if in.channelsFirst {
+			return c*size + y*in.width + x
+		}
+		return (y*in.width+x)*3 + c
+	}
+	shape := []int64{1, int64(in.height), int64(in.width), 3}
+	if in.channelsFirst {
+		shape = []int64{1, 3, int64(in.height), int64(in.width)}
+	}
+
+	var bytes []uint8
+	var floats []float32
+	if in.isUInt8 {
+		bytes = make([]uint8, size*3)
+	} else {
+		floats = make([]float32, size*3)
+	}
+	for y := 0; y < in.height; y++ {
+		for x := 0; x < in.width; x++ {
+			r, g, b, a := resized.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			rr, gg, bb, _ := rgbaTo8Bit(r, g, b, a)
+			for c, v := range [3]uint8{rr, gg, bb} {
+				if in.isUInt8 {
+					bytes[index(x, y, c)] = v
+				} else {
+					floats[index(x, y, c)] = float32((float64(v)/255 - in.mean[c]) / in.std[c])
+				}
+			}
+		}
+	}
+	if in.isUInt8 {
+		return inf.Tensor{Shape: shape, Data: bytes}
+	}
+	return inf.Tensor{Shape: shape, Data: floats}
+}
+
+// findONNXDetectorOutputs returns the configured output tensor names, or guesses them from the
+// names of the model outputs. Only the boxes are required.
+func findONNXDetectorOutputs(infos []inf.ONNXTensorInfo, params *ONNXDetectorConfig) (onnxDetectorOutputs, error) {
+	var outputs onnxDetectorOutputs
+	var err error
+	if outputs.boxes, err = findONNXOutput(infos, params.BoxesOutput, "box", "location"); err != nil {
+		return outputs, err
+	}
+	if outputs.boxes == "" {
+		return outputs, errors.New("cannot find the boxes output of the model, set boxes_output")
+	}
+	if outputs.scores, err = findONNXOutput(infos, params.ScoresOutput, "score", "conf"); err != nil {
+		return outputs, err
+	}
+	if outputs.labels, err = findONNXOutput(infos, params.LabelsOutput, "label", "class", "categor"); err != nil {
+		return outputs, err
+	}
+	return outputs, nil
+}
+
+// findONNXOutput returns the configured output name if it exists, or else the first output whose
+// name contains one of the keywords, or "" if there is none.
+func findONNXOutput(infos []inf.ONNXTensorInfo, configured string, keywords ...string) (string, error) {
+	if configured != "" {
+		for _, info := range infos {
+			if info.Name == configured {
+				return configured, nil
+			}
+		}
+		return "", errors.Errorf("model has no output named %q", configured)
+	}
+	for _, info := range infos {
+		name := strings.ToLower(info.Name)
+		for _, k := range keywords {
+			if strings.Contains(name, k) {
+				return info.Name, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// unpackONNXDetections reads the detections from the output tensors. Box coordinates are either
+// normalized to [0, 1] or in pixels of the model input, and are scaled to the image bounds.
+func unpackONNXDetections(
+	tensors map[string]inf.Tensor,
+	outputs onnxDetectorOutputs,
+	boxFormat string,
+	labelMap []string,
+	inW, inH int,
+	bounds image.Rectangle,
+) ([]objectdetection.Detection, error) {
+	boxes, err := onnxOutputValues(tensors, outputs.boxes)
+	if err != nil {
+		return nil, err
+	}
+	if len(boxes)%4 != 0 {
+		return nil, errors.Errorf("boxes output %q has %d values, not a multiple of 4", outputs.boxes, len(boxes))
+	}
+	count := len(boxes) / 4
+	var scores, labels []float64
+	if outputs.scores != "" {
+		if scores, err = onnxOutputValues(tensors, outputs.scores); err != nil {
+			return nil, err
+		}
+		if len(scores) < count {
+			return nil, errors.Errorf("scores output %q has %d values for %d boxes", outputs.scores, len(scores), count)
+		}
+	}
+	if outputs.labels != "" {
+		if labels, err = onnxOutputValues(tensors, outputs.labels); err != nil {
+			return nil, err
+		}
+		if len(labels) < count {
+			return nil, errors.Errorf("labels output %q has %d values for %d boxes", outputs.labels, len(labels), count)
+		}
+	}
+
+	// scale normalized boxes to the image, and boxes in model input pixels from the input size
+	scaleX, scaleY := float64(bounds.Dx()), float64(bounds.Dy())
+	maxCoord := 0.
+	for _, b := range boxes {
+		maxCoord = math.Max(maxCoord, b)
+	}
+	if maxCoord > 1.5 {
+		scaleX /= float64(inW)
+		scaleY /= float64(inH)
+	}
+
+	detections := make([]objectdetection.Detection, 0, count)
+	for i := 0; i < count; i++ {
+		x0, y0, x1, y1 := boxes[4*i], boxes[4*i+1], boxes[4*i+2], boxes[4*i+3]
+		if boxFormat == "yxyx" {
+			x0, y0, x1, y1 = y0, x0, y1, x1
+		}
+		rect := image.Rect(
+			bounds.Min.X+int(x0*scaleX), bounds.Min.Y+int(y0*scaleY),
+			bounds.Min.X+int(x1*scaleX), bounds.Min.Y+int(y1*scaleY),
+		).Intersect(bounds)
+		if rect.Empty() {
+			continue
+		}
+		score := 1.0
+		if scores != nil {
+			score = scores[i]
+		}
+		var label string
+		if labels != nil {
+			l := int(labels[i])
+			if l >= 0 && l < len(labelMap) {
+				label = labelMap[l]
+			} else {
+				label = strconv.Itoa(l)
+			}
+		}
+		detections = append(detections, objectdetection.NewDetection(rect, score, label))
+	}
+	return detections, nil
+}
+
+// onnxOutputValues returns the data of the named output tensor as float64s.
+func onnxOutputValues(tensors map[string]inf.Tensor, name string) ([]float64, error) {
+	t, ok := tensors[name]
+	if !ok {
+		return nil, errors.Errorf("missing output tensor %q", name)
+	}
+	return tensorValues(name, t.Data)
+}
+
+// tensorValues returns the data of the named tensor as float64s.
+func tensorValues(name string, data interface{}) ([]float64, error) {
+	var values []float64
+	switch data := data.(type) {
+	case []float32:
+		values = make([]float64, len(data))
+		for i, v := range data {
+			values[i] = float64(v)
+		}
+	case []float64:
+		values = data
+	case []int64:
+		values = make([]float64, len(data))
+		for i, v := range data {
+			values[i] = float64(v)
+		}
+	case []int32:
+		values = make([]float64, len(data))
+		for i, v := range data {
+			values[i] = float64(v)
+		}
+	case []uint8:
+		values = make([]float64, len(data))
+		for i, v := range data {
+			values[i] = float64(v)
+		}
+	default:
+		return nil, errors.Errorf("output tensor %q has unsupported data of type %T", name, data)
+	}
+	return values, nil
+}
@@ -0,0 +1,129 @@
+package builtin
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/config"
+	inf "go.viam.com/rdk/ml/inference"
+	"go.viam.com/rdk/services/vision"
+)
+
+func TestONNXImageInput(t *testing.T) {
+	info := &inf.ONNXInfo{Inputs: []inf.ONNXTensorInfo{{Name: "images", Shape: []int64{1, 3, 2, 4}, DataType: "float32"}}}
+	input, err := newONNXImageInput(info, 0, 0, []float64{0.5, 0.5, 0.5}, []float64{0.5, 0.5, 0.5})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, input.channelsFirst, test.ShouldBeTrue)
+	test.That(t, input.width, test.ShouldEqual, 4)
+	test.That(t, input.height, test.ShouldEqual, 2)
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			img.Set(x, y, color.RGBA{255, 0, 0, 255})
+		}
+	}
+	tensor := input.tensor(img)
+	test.That(t, tensor.Shape, test.ShouldResemble, []int64{1, 3, 2, 4})
+	data := tensor.Data.([]float32)
+	test.That(t, data, test.ShouldHaveLength, 24)
+	test.That(t, data[0], test.ShouldEqual, 1)  // red
+	test.That(t, data[8], test.ShouldEqual, -1) // green
+	test.That(t, data[23], test.ShouldEqual, -1)
+
+	info.Inputs[0] = inf.ONNXTensorInfo{Name: "images", Shape: []int64{-1, -1, -1, 3}, DataType: "uint8"}
+	_, err = newONNXImageInput(info, 0, 0, nil, nil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "set input_width and input_height")
+	input, err = newONNXImageInput(info, 4, 2, nil, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, input.channelsFirst, test.ShouldBeFalse)
+	tensor = input.tensor(img)
+	test.That(t, tensor.Shape, test.ShouldResemble, []int64{1, 2, 4, 3})
+	test.That(t, tensor.Data.([]uint8)[:6], test.ShouldResemble, []uint8{255, 0, 0, 255, 0, 0})
+
+	info.Inputs[0].Shape = []int64{1, 1, 28, 28}
+	_, err = newONNXImageInput(info, 0, 0, nil, nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	info.Inputs[0].Shape = []int64{1, 3, 2, 4}
+	_, err = newONNXImageInput(info, 0, 0, nil, []float64{1, 0, 1})
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestUnpackONNXDetections(t *testing.T) {
+	infos := []inf.ONNXTensorInfo{{Name: "detection_boxes"}, {Name: "detection_scores"}, {Name: "detection_classes"}}
+	outputs, err := findONNXDetectorOutputs(infos, &ONNXDetectorConfig{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, outputs, test.ShouldResemble, onnxDetectorOutputs{"detection_boxes", "detection_scores", "detection_classes"})
+	_, err = findONNXDetectorOutputs(infos, &ONNXDetectorConfig{ScoresOutput: "nope"})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = findONNXDetectorOutputs(infos[1:], &ONNXDetectorConfig{})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	bounds := image.Rect(0, 0, 200, 100)
+	tensors := map[string]inf.Tensor{
+		"detection_boxes":   {Data: []float32{0.1, 0.2, 0.5, 0.6, 0, 0, 0, 0}},
+		"detection_scores":  {Data: []float32{0.9, 0.1}},
+		"detection_classes": {Data: []int64{1, 7}},
+	}
+	dets, err := unpackONNXDetections(tensors, outputs, "xyxy", []string{"a", "b"}, 10, 10, bounds)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, dets, test.ShouldHaveLength, 1) // the empty box is dropped
+	test.That(t, dets[0].BoundingBox(), test.ShouldResemble, &image.Rectangle{image.Pt(20, 20), image.Pt(100, 60)})
+	test.That(t, dets[0].Score(), test.ShouldAlmostEqual, 0.9, 1e-6)
+	test.That(t, dets[0].Label(), test.ShouldEqual, "b")
+
+	// boxes in pixels of a 20x10 model input, in yxyx order
+	tensors["detection_boxes"] = inf.Tensor{Data: []float32{2, 4, 8, 300, 1, 1, 2, 2}}
+	dets, err = unpackONNXDetections(tensors, outputs, "yxyx", nil, 20, 10, bounds)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, dets, test.ShouldHaveLength, 2)
+	test.That(t, dets[0].BoundingBox(), test.ShouldResemble, &image.Rectangle{image.Pt(40, 20), image.Pt(200, 80)})
+	test.That(t, dets[1].Label(), test.ShouldEqual, "7")
+
+	tensors["detection_scores"] = inf.Tensor{Data: []float32{0.9}}
+	_, err = unpackONNXDetections(tensors, outputs, "xyxy", nil, 20, 10, bounds)
+	test.That(t, err, test.ShouldNotBeNil)
+	tensors["detection_boxes"] = inf.Tensor{Data: []string{"nope"}}
+	_, err = unpackONNXDetections(tensors, outputs, "xyxy", nil, 20, 10, bounds)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestUnpackONNXClassifications(t *testing.T) {
+	classifications := unpackONNXClassifications([]float64{0.2, 0.8}, false, []string{"cat"})
+	test.That(t, classifications, test.ShouldHaveLength, 2)
+	test.That(t, classifications[0].Label(), test.ShouldEqual, "cat")
+	test.That(t, classifications[1].Label(), test.ShouldEqual, "1")
+	test.That(t, classifications[1].Score(), test.ShouldEqual, 0.8)
+
+	classifications = unpackONNXClassifications([]float64{1, 1, 1, 1}, true, nil)
+	for _, c := range classifications {
+		test.That(t, c.Score(), test.ShouldAlmostEqual, 0.25)
+	}
+}
+
+func TestNewONNXDetectorErrors(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	cfg := vision.VisModelConfig{
+		Name: "onnx", Type: string(ONNXDetector),
+		Parameters: config.AttributeMap{"model_path": "nope.onnx", "box_format": "xywh"},
+	}
+	_, _, err := NewONNXDetector(ctx, &cfg, logger)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "box_format")
+
+	cfg.Parameters["box_format"] = "yxyx"
+	_, _, err = NewONNXDetector(ctx, &cfg, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, _, err = NewONNXClassifier(ctx, &cfg, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	mm := make(modelMap)
+	err = registerNewVisModels(ctx, mm, &vision.Attributes{ModelRegistry: []vision.VisModelConfig{cfg}}, logger)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "could not register onnx detector")
+	test.That(t, mm.ModelNames(), test.ShouldBeEmpty)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/edaniels/golog"
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
+	"go.uber.org/multierr"
 
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/services/vision"
@@ -71,6 +72,55 @@ func registerTfliteDetector(ctx context.Context, mm modelMap, conf *vision.VisMo
 	return mm.RegisterVisModel(conf.Name, &regModel, logger)
 }
 
+func registerTFLiteSegmenter(ctx context.Context, mm modelMap, conf *vision.VisModelConfig, logger golog.Logger) error {
+	ctx, span := trace.StartSpan(ctx, "service::vision::registerTFLiteSegmenter")
+	defer span.End()
+	if conf == nil {
+		return errors.New("config for tflite segmenter cannot be nil")
+	}
+	maskSegmenter, params, model, err := NewTFLiteSegmenter(ctx, conf, logger)
+	if err != nil {
+		return errors.Wrapf(err, "could not register tflite segmenter %s", conf.Name)
+	}
+	segmenter, err := segmentation.MaskSegmenterToSegmenter(maskSegmenter, params.MinPixels, params.MeanK, params.Sigma)
+	if err != nil {
+		return multierr.Combine(errors.Wrapf(err, "could not register tflite segmenter %s", conf.Name), model.Close())
+	}
+
+	regModel := registeredModel{Model: segmenter, ModelType: TFLiteSegmenter, Closer: model, MaskSegmenter: maskSegmenter}
+	return mm.RegisterVisModel(conf.Name, &regModel, logger)
+}
+
+func registerONNXClassifier(ctx context.Context, mm modelMap, conf *vision.VisModelConfig, logger golog.Logger) error {
+	ctx, span := trace.StartSpan(ctx, "service::vision::registerONNXClassifier")
+	defer span.End()
+	if conf == nil {
+		return errors.New("object detection config for onnx classifier cannot be nil")
+	}
+	classifier, model, err := NewONNXClassifier(ctx, conf, logger)
+	if err != nil {
+		return errors.Wrapf(err, "could not register onnx classifier %s", conf.Name)
+	}
+
+	regModel := registeredModel{Model: classifier, ModelType: ONNXClassifier, Closer: model}
+	return mm.RegisterVisModel(conf.Name, &regModel, logger)
+}
+
+func registerONNXDetector(ctx context.Context, mm modelMap, conf *vision.VisModelConfig, logger golog.Logger) error {
+	ctx, span := trace.StartSpan(ctx, "service::vision::registerONNXDetector")
+	defer span.End()
+	if conf == nil {
+		return errors.New("object detection config for onnx detector cannot be nil")
+	}
+	detector, model, err := NewONNXDetector(ctx, conf, logger)
+	if err != nil {
+		return errors.Wrapf(err, "could not register onnx detector %s", conf.Name)
+	}
+
+	regModel := registeredModel{Model: detector, ModelType: ONNXDetector, Closer: model}
+	return mm.RegisterVisModel(conf.Name, &regModel, logger)
+}
+
 func registerRCSegmenter(ctx context.Context, mm modelMap, conf *vision.VisModelConfig, logger golog.Logger) error {
 	_, span := trace.StartSpan(ctx, "service::vision::registerRCSegmenter")
 	defer span.End()
@@ -118,3 +168,45 @@ func registerSegmenterFromDetector(ctx context.Context, mm modelMap, conf *visio
 	regModel := registeredModel{Model: segmenter, ModelType: DetectorSegmenter, Closer: nil}
 	return mm.RegisterVisModel(conf.Name, &regModel, logger)
 }
+
+// registerTracker creates a detector following the detections of another detector across frames and
+// registers it to the detector map.
+func registerTracker(ctx context.Context, mm modelMap, conf *vision.VisModelConfig, logger golog.Logger) error {
+	_, span := trace.StartSpan(ctx, "service::vision::registerTracker")
+	defer span.End()
+	if conf == nil {
+		return errors.New("config for tracker cannot be nil")
+	}
+	var p objdet.TrackerConfig
+	attrs, err := config.TransformAttributeMapToStruct(&p, conf.Parameters)
+	if err != nil {
+		return errors.Wrapf(err, "register tracker %s", conf.Name)
+	}
+	params, ok := attrs.(*objdet.TrackerConfig)
+	if !ok {
+		err := utils.NewUnexpectedTypeError(params, attrs)
+		return errors.Wrapf(err, "register tracker %s", conf.Name)
+	}
+	d, err := mm.modelLookup(params.DetectorName)
+	if err != nil {
+		return errors.Wrapf(err, "register tracker %s", conf.Name)
+	}
+	detector, err := d.toDetector()
+	if err != nil {
+		return errors.Wrapf(err, "register tracker %s", conf.Name)
+	}
+	trackers, err := objdet.NewTrackers(*params)
+	if err != nil {
+		return errors.Wrapf(err, "register tracker %s", conf.Name)
+	}
+	trackingDetector, err := objdet.NewTrackingDetector(detector, trackers, func(camera string, events []objdet.TrackEvent) {
+		for _, event := range events {
+			logger.Debugw("track event", "tracker", conf.Name, "camera", camera, "event", event.Type, "track", event.Track.String())
+		}
+	})
+	if err != nil {
+		return errors.Wrapf(err, "register tracker %s", conf.Name)
+	}
+	regModel := registeredModel{Model: trackingDetector, ModelType: Tracker, Closer: nil, Trackers: trackers}
+	return mm.RegisterVisModel(conf.Name, &regModel, logger)
+}
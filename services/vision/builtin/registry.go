@@ -29,6 +29,10 @@ const (
 	TFClassifier      = vision.VisModelType("tf_classifier")
 	RCSegmenter       = vision.VisModelType("radius_clustering_segmenter")
 	DetectorSegmenter = vision.VisModelType("detector_segmenter")
+	Tracker           = vision.VisModelType("tracker")
+	ONNXDetector      = vision.VisModelType("onnx_detector")
+	ONNXClassifier    = vision.VisModelType("onnx_classifier")
+	TFLiteSegmenter   = vision.VisModelType("tflite_segmenter")
 )
 
 // registeredModelParameterSchemas maps the vision model types to the necessary parameters needed to create them.
@@ -38,6 +42,10 @@ var registeredModelParameterSchemas = map[vision.VisModelType]*jsonschema.Schema
 	TFLiteClassifier:  jsonschema.Reflect(&TFLiteClassifierConfig{}),
 	RCSegmenter:       jsonschema.Reflect(&segmentation.RadiusClusteringConfig{}),
 	DetectorSegmenter: jsonschema.Reflect(&segmentation.DetectionSegmenterConfig{}),
+	Tracker:           jsonschema.Reflect(&objectdetection.TrackerConfig{}),
+	ONNXDetector:      jsonschema.Reflect(&ONNXDetectorConfig{}),
+	ONNXClassifier:    jsonschema.Reflect(&ONNXClassifierConfig{}),
+	TFLiteSegmenter:   jsonschema.Reflect(&TFLiteSegmenterConfig{}),
 }
 
 // The set of operations supported by the vision model types.
@@ -56,6 +64,10 @@ var visModelToOpMap = map[vision.VisModelType]VisOperation{
 	TFClassifier:      VisClassification,
 	RCSegmenter:       VisSegmentation,
 	DetectorSegmenter: VisSegmentation,
+	Tracker:           VisDetection,
+	ONNXDetector:      VisDetection,
+	ONNXClassifier:    VisClassification,
+	TFLiteSegmenter:   VisSegmentation,
 }
 
 // newVisModelTypeNotImplemented is used when the model type is not implemented.
@@ -70,6 +82,10 @@ type registeredModel struct {
 	Model     interface{}
 	ModelType vision.VisModelType
 	Closer    io.Closer
+	// MaskSegmenter computes the per pixel masks of the segmenters that support them.
+	MaskSegmenter segmentation.MaskSegmenter
+	// Trackers are the trackers of every camera of a tracker model.
+	Trackers *objectdetection.Trackers
 }
 
 // ToDetector converts model to a dectector.
@@ -99,6 +115,22 @@ func (m *registeredModel) toSegmenter() (segmentation.Segmenter, error) {
 	return toReturn, nil
 }
 
+// toMaskSegmenter returns the mask segmenter of the model.
+func (m *registeredModel) toMaskSegmenter() (segmentation.MaskSegmenter, error) {
+	if m.MaskSegmenter == nil {
+		return nil, errors.New("model does not compute segmentation masks")
+	}
+	return m.MaskSegmenter, nil
+}
+
+// toTrackers returns the trackers of the model.
+func (m *registeredModel) toTrackers() (*objectdetection.Trackers, error) {
+	if m.Trackers == nil {
+		return nil, errors.New("model does not track objects")
+	}
+	return m.Trackers, nil
+}
+
 // DetectorNames returns list copy of all detector names.
 func (mm modelMap) DetectorNames() []string {
 	names := make([]string, 0, len(mm))
@@ -191,7 +223,7 @@ func (mm modelMap) RegisterVisModel(name string, m *registeredModel, logger golo
 	}
 	if m.Closer != nil {
 		mm[name] = registeredModel{
-			Model: m.Model, ModelType: m.ModelType, Closer: m.Closer,
+			Model: m.Model, ModelType: m.ModelType, Closer: m.Closer, MaskSegmenter: m.MaskSegmenter, Trackers: m.Trackers,
 		}
 		return nil
 	}
@@ -200,8 +232,32 @@ func (mm modelMap) RegisterVisModel(name string, m *registeredModel, logger golo
 	}
 
 	mm[name] = registeredModel{
-		Model: m.Model, ModelType: m.ModelType, Closer: nil,
+		Model: m.Model, ModelType: m.ModelType, Closer: nil, MaskSegmenter: m.MaskSegmenter, Trackers: m.Trackers,
+	}
+	return nil
+}
+
+// addPostprocessing postprocesses the detections of the registered detector.
+func (mm modelMap) addPostprocessing(name string, cfg *objectdetection.PostprocessorConfig) error {
+	m, err := mm.modelLookup(name)
+	if err != nil {
+		return err
+	}
+	if visModelToOpMap[m.ModelType] != VisDetection {
+		return errors.Errorf("cannot postprocess the detections of vision model %q of type %q", name, m.ModelType)
+	}
+	detector, err := m.toDetector()
+	if err != nil {
+		return err
 	}
+	post, err := objectdetection.NewPostprocessor(cfg)
+	if err != nil {
+		return errors.Wrapf(err, "postprocessing of detector %s", name)
+	}
+	if m.Model, err = objectdetection.Build(nil, detector, post); err != nil {
+		return err
+	}
+	mm[name] = m
 	return nil
 }
 
@@ -213,24 +269,40 @@ func registerNewVisModels(ctx context.Context, mm modelMap, attrs *vision.Attrib
 	var err error
 	for _, attr := range attrs.ModelRegistry {
 		logger.Debugf("adding vision model %q of type %q", attr.Name, attr.Type)
+		var registerErr error
 		switch vision.VisModelType(attr.Type) {
 		case TFLiteDetector:
-			multierr.AppendInto(&err, registerTfliteDetector(ctx, mm, &attr, logger))
+			registerErr = registerTfliteDetector(ctx, mm, &attr, logger)
 		case TFLiteClassifier:
-			multierr.AppendInto(&err, registerTfliteClassifier(ctx, mm, &attr, logger))
+			registerErr = registerTfliteClassifier(ctx, mm, &attr, logger)
+		case ONNXDetector:
+			registerErr = registerONNXDetector(ctx, mm, &attr, logger)
+		case ONNXClassifier:
+			registerErr = registerONNXClassifier(ctx, mm, &attr, logger)
 		case TFDetector:
-			multierr.AppendInto(&err, newVisModelTypeNotImplemented(attr.Type))
+			registerErr = newVisModelTypeNotImplemented(attr.Type)
 		case TFClassifier:
-			multierr.AppendInto(&err, newVisModelTypeNotImplemented(attr.Type))
+			registerErr = newVisModelTypeNotImplemented(attr.Type)
 		case ColorDetector:
-			multierr.AppendInto(&err, registerColorDetector(ctx, mm, &attr, logger))
+			registerErr = registerColorDetector(ctx, mm, &attr, logger)
 		case RCSegmenter:
-			multierr.AppendInto(&err, registerRCSegmenter(ctx, mm, &attr, logger))
+			registerErr = registerRCSegmenter(ctx, mm, &attr, logger)
+		case TFLiteSegmenter:
+			registerErr = registerTFLiteSegmenter(ctx, mm, &attr, logger)
 		case DetectorSegmenter:
-			multierr.AppendInto(&err, registerSegmenterFromDetector(ctx, mm, &attr, logger))
+			registerErr = registerSegmenterFromDetector(ctx, mm, &attr, logger)
+		case Tracker:
+			registerErr = registerTracker(ctx, mm, &attr, logger)
 		default:
-			multierr.AppendInto(&err, newVisModelTypeNotImplemented(attr.Type))
+			registerErr = newVisModelTypeNotImplemented(attr.Type)
+		}
+		if registerErr == nil && attr.Postprocessing != nil {
+			// a detector is not registered without the postprocessing it was configured with
+			if registerErr = mm.addPostprocessing(attr.Name, attr.Postprocessing); registerErr != nil {
+				multierr.AppendInto(&registerErr, mm.removeVisModel(attr.Name, logger))
+			}
 		}
+		multierr.AppendInto(&err, registerErr)
 	}
 	return err
 }
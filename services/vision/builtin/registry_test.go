@@ -58,7 +58,7 @@ func TestCloser(t *testing.T) {
 	fakeDetectFn := func(context.Context, image.Image) ([]objdet.Detection, error) {
 		return []objdet.Detection{objdet.NewDetection(image.Rectangle{}, 0.0, "")}, nil
 	}
-	closer := inf.TFLiteStruct{Info: &inf.TFLiteInfo{100, 100, 3, []int{1, 100, 100, 3}, "uint8", 1, 4, []string{}}}
+	closer := inf.TFLiteStruct{Info: &inf.TFLiteInfo{100, 100, 3, []int{1, 100, 100, 3}, "uint8", 1, 4, []string{}, [][]int{}}}
 
 	d := registeredModel{Model: fakeDetectFn, Closer: &closer, ModelType: ColorDetector}
 	reg := make(modelMap)
@@ -162,6 +162,99 @@ func TestRegisterColorDetector(t *testing.T) {
 	test.That(t, err.Error(), test.ShouldContainSubstring, "unexpected EOF")
 }
 
+func TestRegisterTracker(t *testing.T) {
+	conf := &vision.Attributes{
+		ModelRegistry: []vision.VisModelConfig{
+			{
+				Name: "my_color_det",
+				Type: "color_detector",
+				Parameters: config.AttributeMap{
+					"segment_size_px":   150000,
+					"hue_tolerance_pct": 0.44,
+					"detect_color":      "#4F3815",
+				},
+			},
+			{
+				Name: "my_tracker",
+				Type: "tracker",
+				Parameters: config.AttributeMap{
+					"detector_name":   "my_color_det",
+					"max_lost_frames": 10,
+				},
+			},
+		},
+	}
+	reg := make(modelMap)
+	err := registerNewVisModels(context.Background(), reg, conf, golog.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, reg.DetectorNames(), test.ShouldContain, "my_tracker")
+
+	// every camera has its own tracker
+	m, err := reg.modelLookup("my_tracker")
+	test.That(t, err, test.ShouldBeNil)
+	trackers, err := m.toTrackers()
+	test.That(t, err, test.ShouldBeNil)
+	detector, err := m.toDetector()
+	test.That(t, err, test.ShouldBeNil)
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	for _, cam := range []string{"cam1", "cam2"} {
+		_, err = detector(objdet.ContextWithStreamName(context.Background(), cam), img)
+		test.That(t, err, test.ShouldBeNil)
+	}
+	cam1, ok := trackers.Lookup("cam1")
+	test.That(t, ok, test.ShouldBeTrue)
+	cam2, ok := trackers.Lookup("cam2")
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, cam1, test.ShouldNotEqual, cam2)
+	colorDet, err := reg.modelLookup("my_color_det")
+	test.That(t, err, test.ShouldBeNil)
+	_, err = colorDet.toTrackers()
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// the tracked detector has to be registered first
+	conf.ModelRegistry = conf.ModelRegistry[1:]
+	conf.ModelRegistry[0].Parameters = config.AttributeMap{"detector_name": "not_real"}
+	err = registerNewVisModels(context.Background(), make(modelMap), conf, golog.NewTestLogger(t))
+	test.That(t, err.Error(), test.ShouldContainSubstring, "no such vision model")
+}
+
+func TestRegisterPostprocessing(t *testing.T) {
+	var fn objdet.Detector = func(context.Context, image.Image) ([]objdet.Detection, error) {
+		return []objdet.Detection{
+			objdet.NewDetection(image.Rect(0, 0, 10, 10), 0.5, "cat"),
+			objdet.NewDetection(image.Rect(0, 0, 10, 10), 0.9, "dog"),
+		}, nil
+	}
+	reg := make(modelMap)
+	logger := golog.NewTestLogger(t)
+	test.That(t, reg.RegisterVisModel("det", &registeredModel{Model: fn, ModelType: ColorDetector}, logger), test.ShouldBeNil)
+	err := reg.addPostprocessing("det", &objdet.PostprocessorConfig{DenyLabels: []string{"dog"}})
+	test.That(t, err, test.ShouldBeNil)
+	m, err := reg.modelLookup("det")
+	test.That(t, err, test.ShouldBeNil)
+	detector, err := m.toDetector()
+	test.That(t, err, test.ShouldBeNil)
+	dets, err := detector(context.Background(), nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, dets, test.ShouldHaveLength, 1)
+	test.That(t, dets[0].Label(), test.ShouldEqual, "cat")
+
+	// only detections are postprocessed
+	conf := &vision.Attributes{
+		ModelRegistry: []vision.VisModelConfig{
+			{
+				Name:           "my_segmenter",
+				Type:           "detector_segmenter",
+				Parameters:     config.AttributeMap{"detector_name": "det"},
+				Postprocessing: &objdet.PostprocessorConfig{MaxDetections: 1},
+			},
+		},
+	}
+	err = registerNewVisModels(context.Background(), reg, conf, logger)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "cannot postprocess")
+	test.That(t, reg.SegmenterNames(), test.ShouldBeEmpty)
+}
+
 func TestRegisterUnknown(t *testing.T) {
 	conf := &vision.Attributes{
 		ModelRegistry: []vision.VisModelConfig{
@@ -0,0 +1,243 @@
+//go:build !arm
+package builtin
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"runtime"
+	"strconv"
+
+	"github.com/edaniels/golog"
+	"github.com/nfnt/resize"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"go.viam.com/rdk/config"
+	inf "go.viam.com/rdk/ml/inference"
+	"go.viam.com/rdk/services/vision"
+	"go.viam.com/rdk/utils"
+	"go.viam.com/rdk/vision/segmentation"
+)
+
+// The kinds of masks a TFLite segmenter can output.
+const (
+	SemanticMask = "semantic"
+	InstanceMask = "instance"
+)
+
+// TFLiteSegmenterConfig specifies the fields necessary for creating a TFLite segmenter.
+type TFLiteSegmenterConfig struct {
+	// this should come from the attributes part of the segmenter config
+	ModelPath  string  `json:"model_path"`
+	NumThreads int     `json:"num_threads"`
+	LabelPath  *string `json:"label_path"`
+	// MaskType is "semantic" for models outputting the class of each pixel, as class ids [1, H, W]
+	// or class scores [1, H, W, C], or "instance" for models outputting the masks of N instances
+	// [1, N, H, W] followed by their classes and scores [1, N]. Defaults to "semantic".
+	MaskType string `json:"mask_type,omitempty"`
+	// BackgroundClass is the class of the pixels that show no object.
+	BackgroundClass int `json:"background_class,omitempty"`
+	// ScoreThreshold is the minimum score of the instances kept in instance masks.
+	ScoreThreshold float64 `json:"score_threshold,omitempty"`
+	// the regions of the mask projected to objects, and how their point clouds are filtered
+	MinPixels int     `json:"min_pixels,omitempty"`
+	MeanK     int     `json:"mean_k,omitempty"`
+	Sigma     float64 `json:"sigma,omitempty"`
+}
+
+// NewTFLiteSegmenter creates a mask segmenter given a VisModelConfig of a TFLite segmentation
+// model, along with the parameters of the config.
+func NewTFLiteSegmenter(
+	ctx context.Context,
+	conf *vision.VisModelConfig,
+	logger golog.Logger,
+) (segmentation.MaskSegmenter, *TFLiteSegmenterConfig, *inf.TFLiteStruct, error) {
+	ctx, span := trace.StartSpan(ctx, "service::vision::NewTFLiteSegmenter")
+	defer span.End()
+
+	var t TFLiteSegmenterConfig
+	tfParams, err := config.TransformAttributeMapToStruct(&t, conf.Parameters)
+	if err != nil {
+		return nil, nil, nil, errors.New("error getting parameters from config")
+	}
+	params, ok := tfParams.(*TFLiteSegmenterConfig)
+	if !ok {
+		err := utils.NewUnexpectedTypeError(params, tfParams)
+		return nil, nil, nil, errors.Wrapf(err, "register tflite segmenter %s", conf.Name)
+	}
+	if params.MaskType == "" {
+		params.MaskType = SemanticMask
+	}
+	if params.MaskType != SemanticMask && params.MaskType != InstanceMask {
+		return nil, nil, nil, errors.Errorf("mask_type must be %q or %q, not %q", SemanticMask, InstanceMask, params.MaskType)
+	}
+	// Secret but hard limit on num_threads
+	if params.NumThreads > runtime.NumCPU()/4 {
+		params.NumThreads = runtime.NumCPU() / 4
+	}
+
+	model, err := addTFLiteModel(ctx, params.ModelPath, &params.NumThreads)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "something wrong with adding the model")
+	}
+
+	var inHeight, inWidth uint
+	if shape := model.Info.InputShape; getIndex(shape, 3) == 1 {
+		inHeight, inWidth = uint(shape[2]), uint(shape[3])
+	} else {
+		inHeight, inWidth = uint(shape[1]), uint(shape[2])
+	}
+
+	if params.LabelPath == nil {
+		blank := ""
+		params.LabelPath = &blank
+	}
+	labels, err := loadLabels(*params.LabelPath)
+	if err != nil {
+		logger.Warn("did not retrieve class labels")
+	}
+
+	return func(ctx context.Context, img image.Image) (*segmentation.Mask, error) {
+		resizedImg := resize.Resize(inWidth, inHeight, img, resize.Bilinear)
+		outTensors, err := tfliteInfer(ctx, model, resizedImg)
+		if err != nil {
+			return nil, err
+		}
+		mask, err := unpackSegmentationTensors(outTensors, model.Info, labels, params)
+		if err != nil {
+			return nil, err
+		}
+		return mask.Resize(img.Bounds()), nil
+	}, params, model, nil
+}
+
+// unpackSegmentationTensors decodes the output tensors of a segmentation model into a mask.
+func unpackSegmentationTensors(
+	tensors []interface{},
+	info *inf.TFLiteInfo,
+	labels []string,
+	params *TFLiteSegmenterConfig,
+) (*segmentation.Mask, error) {
+	if len(tensors) == 0 || len(tensors) != len(info.OutputTensorShapes) {
+		return nil, errors.New("segmentation model returned no output tensors")
+	}
+	if params.MaskType == SemanticMask {
+		values, err := tensorValues("mask", tensors[0])
+		if err != nil {
+			return nil, err
+		}
+		return decodeSemanticMask(values, info.OutputTensorShapes[0], labels, params.BackgroundClass)
+	}
+	// the masks are the output of rank 4, followed by the classes and scores
+	masksIdx := -1
+	for i, shape := range info.OutputTensorShapes {
+		if len(shape) == 4 {
+			masksIdx = i
+			break
+		}
+	}
+	if masksIdx < 0 || len(tensors) < 3 {
+		return nil, errors.New("instance segmentation models must output masks [1, N, H, W], classes and scores")
+	}
+	var others [][]float64
+	for i, t := range tensors {
+		if i == masksIdx {
+			continue
+		}
+		values, err := tensorValues("output"+strconv.Itoa(i), t)
+		if err != nil {
+			return nil, err
+		}
+		others = append(others, values)
+	}
+	masks, err := tensorValues("masks", tensors[masksIdx])
+	if err != nil {
+		return nil, err
+	}
+	maskThreshold := 0.5
+	if info.OutputTensorTypes[masksIdx] == "UInt8" {
+		maskThreshold = 127.5
+	}
+	return decodeInstanceMask(
+		masks, info.OutputTensorShapes[masksIdx], others[0], others[1],
+		labels, params.BackgroundClass, params.ScoreThreshold, maskThreshold,
+	)
+}
+
+// decodeSemanticMask decodes the class ids [1, H, W] or class scores [1, H, W, C] of each pixel.
+func decodeSemanticMask(values []float64, shape []int, labels []string, background int) (*segmentation.Mask, error) {
+	var height, width, classes int
+	switch {
+	case len(shape) == 2:
+		height, width, classes = shape[0], shape[1], 1
+	case len(shape) == 3:
+		height, width, classes = shape[1], shape[2], 1
+	case len(shape) == 4:
+		height, width, classes = shape[1], shape[2], shape[3]
+	default:
+		return nil, errors.Errorf("cannot decode a semantic mask of shape %v", shape)
+	}
+	if height*width*classes != len(values) {
+		return nil, errors.Errorf("semantic mask of shape %v has %d values", shape, len(values))
+	}
+	mask := segmentation.NewMask(image.Rect(0, 0, width, height), labels, background)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			pixel := values[(y*width+x)*classes : (y*width+x+1)*classes]
+			class := int(pixel[0])
+			if classes > 1 {
+				class = 0
+				for c, score := range pixel {
+					if score > pixel[class] {
+						class = c
+					}
+				}
+			}
+			mask.Classes.SetGray16(x, y, color.Gray16{uint16(class)})
+		}
+	}
+	return mask, nil
+}
+
+// decodeInstanceMask decodes the masks [1, N, H, W] of N instances with their classes and scores,
+// keeping the instances scoring at least scoreThreshold. A pixel belongs to the first instance
+// whose mask is above maskThreshold there.
+func decodeInstanceMask(
+	masks []float64,
+	shape []int,
+	classes, scores []float64,
+	labels []string,
+	background int,
+	scoreThreshold, maskThreshold float64,
+) (*segmentation.Mask, error) {
+	if len(shape) != 4 {
+		return nil, errors.Errorf("cannot decode instance masks of shape %v", shape)
+	}
+	n, height, width := shape[1], shape[2], shape[3]
+	if n*height*width != len(masks) {
+		return nil, errors.Errorf("instance masks of shape %v have %d values", shape, len(masks))
+	}
+	if len(classes) < n || len(scores) < n {
+		return nil, errors.Errorf("expected the classes and scores of %d instances", n)
+	}
+	mask := segmentation.NewMask(image.Rect(0, 0, width, height), labels, background)
+	mask.Instances = image.NewGray16(mask.Bounds())
+	instance := 0
+	for i := 0; i < n; i++ {
+		if scores[i] < scoreThreshold {
+			continue
+		}
+		instance++
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				if masks[(i*height+y)*width+x] <= maskThreshold || mask.Instance(x, y) != 0 {
+					continue
+				}
+				mask.Classes.SetGray16(x, y, color.Gray16{uint16(classes[i])})
+				mask.Instances.SetGray16(x, y, color.Gray16{uint16(instance)})
+			}
+		}
+	}
+	return mask, nil
+}
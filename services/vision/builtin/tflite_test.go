@@ -2,6 +2,7 @@ package builtin
 
 import (
 	"context"
+	"image"
 	"testing"
 
 	"github.com/edaniels/golog"
@@ -254,3 +255,64 @@ func TestMoreClassifierModels(t *testing.T) {
 	test.That(t, bestClass[0].Label(), test.ShouldResemble, "292")
 	test.That(t, bestClass[0].Score(), test.ShouldBeGreaterThan, 0.93)
 }
+
+func TestDecodeSemanticMask(t *testing.T) {
+	labels := []string{"background", "cup", "plate"}
+	// class ids of a 3x2 image
+	mask, err := decodeSemanticMask([]float64{0, 1, 1, 2, 0, 0}, []int{1, 2, 3}, labels, 0)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, mask.Bounds(), test.ShouldResemble, image.Rect(0, 0, 3, 2))
+	test.That(t, mask.Class(1, 0), test.ShouldEqual, 1)
+	test.That(t, mask.Class(0, 1), test.ShouldEqual, 2)
+	test.That(t, mask.Instances, test.ShouldBeNil)
+
+	// class scores of a 2x1 image
+	mask, err = decodeSemanticMask([]float64{0.1, 0.7, 0.2, 0.9, 0, 0.1}, []int{1, 1, 2, 3}, labels, 0)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, mask.Class(0, 0), test.ShouldEqual, 1)
+	test.That(t, mask.Class(1, 0), test.ShouldEqual, 0)
+
+	_, err = decodeSemanticMask([]float64{0, 1}, []int{1, 2, 3}, labels, 0)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = decodeSemanticMask([]float64{0, 1}, []int{2}, labels, 0)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestDecodeInstanceMask(t *testing.T) {
+	labels := []string{"background", "cup", "plate"}
+	// three instances of a 3x1 image, the second of which scores too low
+	masks := []float64{
+		0.9, 0.8, 0.1,
+		0.1, 0.1, 0.9,
+		0.1, 0.9, 0.9,
+	}
+	mask, err := decodeInstanceMask(masks, []int{1, 3, 1, 3}, []float64{1, 1, 2}, []float64{0.9, 0.2, 0.8}, labels, 0, 0.5, 0.5)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, mask.Class(0, 0), test.ShouldEqual, 1)
+	test.That(t, mask.Instance(0, 0), test.ShouldEqual, 1)
+	// the pixel of both kept instances belongs to the first one
+	test.That(t, mask.Instance(1, 0), test.ShouldEqual, 1)
+	test.That(t, mask.Class(2, 0), test.ShouldEqual, 2)
+	test.That(t, mask.Instance(2, 0), test.ShouldEqual, 2)
+	test.That(t, mask.Regions(1), test.ShouldHaveLength, 2)
+
+	_, err = decodeInstanceMask(masks, []int{1, 3, 3}, nil, nil, labels, 0, 0.5, 0.5)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = decodeInstanceMask(masks, []int{1, 3, 1, 3}, []float64{1}, []float64{1}, labels, 0, 0.5, 0.5)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestNewTFLiteSegmenterErrors(t *testing.T) {
+	cfg := vision.VisModelConfig{
+		Name: "segmenter", Type: string(TFLiteSegmenter),
+		Parameters: config.AttributeMap{"model_path": "model.tflite", "mask_type": "panoptic"},
+	}
+	_, _, _, err := NewTFLiteSegmenter(context.Background(), &cfg, golog.NewTestLogger(t))
+	test.That(t, err.Error(), test.ShouldContainSubstring, "mask_type")
+
+	mm := make(modelMap)
+	err = registerTFLiteSegmenter(context.Background(), mm, nil, golog.NewTestLogger(t))
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = (&registeredModel{Model: 1}).toMaskSegmenter()
+	test.That(t, err, test.ShouldNotBeNil)
+}
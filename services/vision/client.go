@@ -16,29 +16,33 @@ import (
 	"go.viam.com/utils/rpc"
 
 	"go.viam.com/rdk/pointcloud"
+	extendedpb "go.viam.com/rdk/proto/api/service/vision/v1"
 	"go.viam.com/rdk/rimage"
 	"go.viam.com/rdk/utils"
 	"go.viam.com/rdk/vision"
 	"go.viam.com/rdk/vision/classification"
 	objdet "go.viam.com/rdk/vision/objectdetection"
+	"go.viam.com/rdk/vision/segmentation"
 )
 
 // client implements VisionServiceClient.
 type client struct {
-	name   string
-	conn   rpc.ClientConn
-	client pb.VisionServiceClient
-	logger golog.Logger
+	name           string
+	conn           rpc.ClientConn
+	client         pb.VisionServiceClient
+	extendedClient extendedpb.ExtendedVisionServiceClient
+	logger         golog.Logger
 }
 
 // NewClientFromConn constructs a new Client from connection passed in.
 func NewClientFromConn(ctx context.Context, conn rpc.ClientConn, name string, logger golog.Logger) Service {
 	grpcClient := pb.NewVisionServiceClient(conn)
 	c := &client{
-		name:   name,
-		conn:   conn,
-		client: grpcClient,
-		logger: logger,
+		name:           name,
+		conn:           conn,
+		client:         grpcClient,
+		extendedClient: extendedpb.NewExtendedVisionServiceClient(conn),
+		logger:         logger,
 	}
 	return c
 }
@@ -71,7 +75,11 @@ func (c *client) DetectorNames(ctx context.Context) ([]string, error) {
 func (c *client) AddDetector(ctx context.Context, cfg VisModelConfig) error {
 	ctx, span := trace.StartSpan(ctx, "service::vision::client::AddDetector")
 	defer span.End()
-	params, err := protoutils.StructToStructPb(cfg.Parameters)
+	detectorParams, err := detectorParameters(cfg)
+	if err != nil {
+		return err
+	}
+	params, err := protoutils.StructToStructPb(detectorParams)
 	if err != nil {
 		return err
 	}
@@ -309,6 +317,86 @@ func (c *client) GetObjectPointClouds(ctx context.Context,
 	return protoToObjects(resp.Objects)
 }
 
+func (c *client) SegmentationMaskFromCamera(ctx context.Context,
+	cameraName string,
+	segmenterName string,
+) (*segmentation.Mask, error) {
+	ctx, span := trace.StartSpan(ctx, "service::vision::client::SegmentationMaskFromCamera")
+	defer span.End()
+	return c.segmentationMask(ctx, &extendedpb.GetSegmentationMaskRequest{
+		Name:          c.name,
+		SegmenterName: segmenterName,
+		CameraName:    cameraName,
+	})
+}
+
+func (c *client) SegmentationMask(ctx context.Context,
+	img image.Image,
+	segmenterName string,
+) (*segmentation.Mask, error) {
+	ctx, span := trace.StartSpan(ctx, "service::vision::client::SegmentationMask")
+	defer span.End()
+	mimeType := utils.MimeTypeRawRGBA
+	imgBytes, err := rimage.EncodeImage(ctx, img, mimeType)
+	if err != nil {
+		return nil, err
+	}
+	return c.segmentationMask(ctx, &extendedpb.GetSegmentationMaskRequest{
+		Name:          c.name,
+		SegmenterName: segmenterName,
+		Image:         imgBytes,
+		MimeType:      mimeType,
+		Width:         int64(img.Bounds().Dx()),
+		Height:        int64(img.Bounds().Dy()),
+	})
+}
+
+// segmentationMask calls GetSegmentationMask of the extended vision service.
+func (c *client) segmentationMask(ctx context.Context, req *extendedpb.GetSegmentationMaskRequest) (*segmentation.Mask, error) {
+	resp, err := c.extendedClient.GetSegmentationMask(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return decodeMask(resp)
+}
+
+func (c *client) TracksFromCamera(ctx context.Context, cameraName, trackerName string) ([]objdet.Track, error) {
+	ctx, span := trace.StartSpan(ctx, "service::vision::client::TracksFromCamera")
+	defer span.End()
+	resp, err := c.extendedClient.GetTracks(ctx, &extendedpb.GetTracksRequest{
+		Name:        c.name,
+		TrackerName: trackerName,
+		CameraName:  cameraName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	tracks := make([]objdet.Track, 0, len(resp.Tracks))
+	for _, t := range resp.Tracks {
+		tracks = append(tracks, trackFromProto(t))
+	}
+	return tracks, nil
+}
+
+func (c *client) TrackEvents(ctx context.Context, cameraName, trackerName string, after uint64) ([]objdet.TrackEvent, error) {
+	ctx, span := trace.StartSpan(ctx, "service::vision::client::TrackEvents")
+	defer span.End()
+	resp, err := c.extendedClient.GetTrackEvents(ctx, &extendedpb.GetTrackEventsRequest{
+		Name:        c.name,
+		TrackerName: trackerName,
+		CameraName:  cameraName,
+		After:       after,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]objdet.TrackEvent, 0, len(resp.Events))
+	for _, e := range resp.Events {
+		events = append(events, trackEventFromProto(e))
+	}
+	return events, nil
+}
+
 func protoToObjects(pco []*commonpb.PointCloudObject) ([]*vision.Object, error) {
 	objects := make([]*vision.Object, len(pco))
 	for i, o := range pco {
@@ -3,10 +3,13 @@ package vision_test
 import (
 	"context"
 	"image"
+	"image/color"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
 	servicepb "go.viam.com/api/service/vision/v1"
 	"go.viam.com/test"
 	"go.viam.com/utils"
@@ -28,6 +31,7 @@ import (
 	"go.viam.com/rdk/testutils"
 	"go.viam.com/rdk/testutils/inject"
 	viz "go.viam.com/rdk/vision"
+	objdet "go.viam.com/rdk/vision/objectdetection"
 	"go.viam.com/rdk/vision/segmentation"
 )
 
@@ -344,6 +348,34 @@ func TestInjectedServiceClient(t *testing.T) {
 		test.That(t, utils.TryClose(context.Background(), workingDialedClient), test.ShouldBeNil)
 		test.That(t, conn.Close(), test.ShouldBeNil)
 	})
+	t.Run("add detector with postprocessing", func(t *testing.T) {
+		var added vision.VisModelConfig
+		injectVision.AddDetectorFunc = func(ctx context.Context, cfg vision.VisModelConfig) error {
+			added = cfg
+			return nil
+		}
+		conn, err := viamgrpc.Dial(context.Background(), listener1.Addr().String(), logger)
+		test.That(t, err, test.ShouldBeNil)
+		client := vision.NewClientFromConn(context.Background(), conn, testVisionServiceName, logger)
+
+		cfg := vision.VisModelConfig{
+			Name:       "detector",
+			Type:       "tflite_detector",
+			Parameters: config.AttributeMap{"model_path": "model.tflite"},
+			Postprocessing: &objdet.PostprocessorConfig{
+				LabelMap:          map[string]string{"truck": "vehicle"},
+				DenyLabels:        []string{"person"},
+				RegionsOfInterest: []objdet.Region{{XMin: 1, YMin: 2, XMax: 30, YMax: 40}},
+				NMSIoUThreshold:   0.45,
+				MaxDetections:     10,
+			},
+		}
+		test.That(t, client.AddDetector(context.Background(), cfg), test.ShouldBeNil)
+		test.That(t, added, test.ShouldResemble, cfg)
+
+		test.That(t, utils.TryClose(context.Background(), client), test.ShouldBeNil)
+		test.That(t, conn.Close(), test.ShouldBeNil)
+	})
 	t.Run("test segmentation", func(t *testing.T) {
 		params := config.AttributeMap{
 			"min_points_in_plane":   100,
@@ -383,6 +415,94 @@ func TestInjectedServiceClient(t *testing.T) {
 		test.That(t, utils.TryClose(context.Background(), client), test.ShouldBeNil)
 		test.That(t, conn.Close(), test.ShouldBeNil)
 	})
+	t.Run("segmentation masks", func(t *testing.T) {
+		mask := segmentation.NewMask(image.Rect(0, 0, 4, 3), []string{"background", "cup"}, 0)
+		mask.Classes.SetGray16(1, 1, color.Gray16{1})
+		mask.Instances = image.NewGray16(mask.Bounds())
+		mask.Instances.SetGray16(1, 1, color.Gray16{1})
+		var gotCamera string
+		injectVision.SegmentationMaskFromCameraFunc = func(ctx context.Context, cameraName, segmenterName string,
+		) (*segmentation.Mask, error) {
+			gotCamera = cameraName
+			return mask, nil
+		}
+		var gotBounds image.Rectangle
+		injectVision.SegmentationMaskFunc = func(ctx context.Context, img image.Image, segmenterName string,
+		) (*segmentation.Mask, error) {
+			gotBounds = img.Bounds()
+			return mask, nil
+		}
+		conn, err := viamgrpc.Dial(context.Background(), listener1.Addr().String(), logger)
+		test.That(t, err, test.ShouldBeNil)
+		client := vision.NewClientFromConn(context.Background(), conn, testVisionServiceName, logger)
+
+		got, err := client.SegmentationMaskFromCamera(context.Background(), "cam", "segmenter")
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, gotCamera, test.ShouldEqual, "cam")
+		test.That(t, got, test.ShouldResemble, mask)
+
+		got, err = client.SegmentationMask(context.Background(), image.NewRGBA(image.Rect(0, 0, 4, 3)), "segmenter")
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, gotBounds, test.ShouldResemble, image.Rect(0, 0, 4, 3))
+		test.That(t, got.Class(1, 1), test.ShouldEqual, 1)
+		test.That(t, got.Instance(1, 1), test.ShouldEqual, 1)
+		test.That(t, got.Label(1), test.ShouldEqual, "cup")
+
+		injectVision.SegmentationMaskFromCameraFunc = func(ctx context.Context, cameraName, segmenterName string,
+		) (*segmentation.Mask, error) {
+			return nil, errors.New("no mask")
+		}
+		_, err = client.SegmentationMaskFromCamera(context.Background(), "cam", "segmenter")
+		test.That(t, err.Error(), test.ShouldContainSubstring, "no mask")
+
+		test.That(t, utils.TryClose(context.Background(), client), test.ShouldBeNil)
+		test.That(t, conn.Close(), test.ShouldBeNil)
+	})
+	t.Run("tracks", func(t *testing.T) {
+		tracker, err := objdet.NewTracker(objdet.TrackerConfig{})
+		test.That(t, err, test.ShouldBeNil)
+		now := time.Now()
+		tracker.Update([]objdet.Detection{objdet.NewDetection(image.Rect(0, 0, 10, 10), 0.9, "A")}, now)
+		tracks, _ := tracker.Update([]objdet.Detection{objdet.NewDetection(image.Rect(2, 0, 12, 10), 0.8, "A")}, now.Add(time.Second))
+		tracker.Update(nil, now.Add(2*time.Second))
+		var gotCamera, gotTracker string
+		injectVision.TracksFromCameraFunc = func(ctx context.Context, cameraName, trackerName string) ([]objdet.Track, error) {
+			gotCamera, gotTracker = cameraName, trackerName
+			return tracks, nil
+		}
+		var gotAfter uint64
+		injectVision.TrackEventsFunc = func(ctx context.Context, cameraName, trackerName string, after uint64,
+		) ([]objdet.TrackEvent, error) {
+			gotAfter = after
+			return tracker.Events(after), nil
+		}
+		conn, err := viamgrpc.Dial(context.Background(), listener1.Addr().String(), logger)
+		test.That(t, err, test.ShouldBeNil)
+		client := vision.NewClientFromConn(context.Background(), conn, testVisionServiceName, logger)
+
+		got, err := client.TracksFromCamera(context.Background(), "cam", "tracker")
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, gotCamera, test.ShouldEqual, "cam")
+		test.That(t, gotTracker, test.ShouldEqual, "tracker")
+		test.That(t, got, test.ShouldHaveLength, 1)
+		test.That(t, got[0].ID(), test.ShouldEqual, 1)
+		test.That(t, got[0].Age(), test.ShouldEqual, 2)
+		test.That(t, got[0].Velocity().X, test.ShouldBeGreaterThan, 0)
+		test.That(t, got[0].Velocity(), test.ShouldResemble, tracks[0].Velocity())
+		test.That(t, *got[0].BoundingBox(), test.ShouldResemble, *tracks[0].BoundingBox())
+
+		events, err := client.TrackEvents(context.Background(), "cam", "tracker", 1)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, gotAfter, test.ShouldEqual, uint64(1))
+		test.That(t, events, test.ShouldHaveLength, 1)
+		test.That(t, events[0].Seq, test.ShouldEqual, uint64(2))
+		test.That(t, events[0].Type, test.ShouldEqual, objdet.TrackEventLost)
+		test.That(t, events[0].Track.State(), test.ShouldEqual, objdet.TrackLost)
+		test.That(t, events[0].Time.Equal(now.Add(2*time.Second)), test.ShouldBeTrue)
+
+		test.That(t, utils.TryClose(context.Background(), client), test.ShouldBeNil)
+		test.That(t, conn.Close(), test.ShouldBeNil)
+	})
 }
 
 func TestClientDialerOption(t *testing.T) {
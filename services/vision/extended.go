@@ -0,0 +1,192 @@
+package vision
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+
+	"github.com/golang/geo/r2"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "go.viam.com/rdk/proto/api/service/vision/v1"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/subtype"
+	objdet "go.viam.com/rdk/vision/objectdetection"
+	"go.viam.com/rdk/vision/segmentation"
+)
+
+// extendedServer implements the contract from proto/api/service/vision/v1/vision.proto.
+type extendedServer struct {
+	pb.UnimplementedExtendedVisionServiceServer
+	*subtypeServer
+}
+
+// NewExtendedServer constructs an extended vision gRPC service server.
+func NewExtendedServer(s subtype.Service) pb.ExtendedVisionServiceServer {
+	return &extendedServer{subtypeServer: &subtypeServer{subtypeSvc: s}}
+}
+
+// GetSegmentationMask returns the segmentation mask of an image or of the next image of a camera.
+func (server *extendedServer) GetSegmentationMask(
+	ctx context.Context,
+	req *pb.GetSegmentationMaskRequest,
+) (*pb.GetSegmentationMaskResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "service::vision::server::GetSegmentationMask")
+	defer span.End()
+	svc, err := server.service(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	var mask *segmentation.Mask
+	if req.CameraName != "" {
+		mask, err = svc.SegmentationMaskFromCamera(ctx, req.CameraName, req.SegmenterName)
+	} else {
+		var img image.Image
+		img, err = rimage.DecodeImage(ctx, req.Image, req.MimeType, int(req.Width), int(req.Height))
+		if err != nil {
+			return nil, err
+		}
+		mask, err = svc.SegmentationMask(ctx, img, req.SegmenterName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return encodeMask(mask)
+}
+
+// GetTracks returns the objects a tracker follows in the images of a camera, after tracking them in
+// its next image.
+func (server *extendedServer) GetTracks(ctx context.Context, req *pb.GetTracksRequest) (*pb.GetTracksResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "service::vision::server::GetTracks")
+	defer span.End()
+	svc, err := server.service(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	tracks, err := svc.TracksFromCamera(ctx, req.CameraName, req.TrackerName)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.GetTracksResponse{Tracks: make([]*pb.Track, 0, len(tracks))}
+	for i := range tracks {
+		resp.Tracks = append(resp.Tracks, trackToProto(&tracks[i]))
+	}
+	return resp, nil
+}
+
+// GetTrackEvents returns the latest events of the objects a tracker follows in the images of a
+// camera.
+func (server *extendedServer) GetTrackEvents(ctx context.Context, req *pb.GetTrackEventsRequest) (*pb.GetTrackEventsResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "service::vision::server::GetTrackEvents")
+	defer span.End()
+	svc, err := server.service(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	events, err := svc.TrackEvents(ctx, req.CameraName, req.TrackerName, req.After)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.GetTrackEventsResponse{Events: make([]*pb.TrackEvent, 0, len(events))}
+	for i := range events {
+		resp.Events = append(resp.Events, &pb.TrackEvent{
+			Seq:   events[i].Seq,
+			Time:  timestamppb.New(events[i].Time),
+			Type:  string(events[i].Type),
+			Track: trackToProto(&events[i].Track),
+		})
+	}
+	return resp, nil
+}
+
+func trackToProto(t *objdet.Track) *pb.Track {
+	box := t.BoundingBox()
+	return &pb.Track{
+		Id:         int64(t.ID()),
+		Label:      t.Label(),
+		Score:      t.Score(),
+		XMin:       int64(box.Min.X),
+		YMin:       int64(box.Min.Y),
+		XMax:       int64(box.Max.X),
+		YMax:       int64(box.Max.Y),
+		VelocityX:  t.Velocity().X,
+		VelocityY:  t.Velocity().Y,
+		Age:        int64(t.Age()),
+		Hits:       int64(t.Hits()),
+		LostFrames: int64(t.LostFrames()),
+		State:      string(t.State()),
+	}
+}
+
+func trackFromProto(t *pb.Track) objdet.Track {
+	box := image.Rect(int(t.XMin), int(t.YMin), int(t.XMax), int(t.YMax))
+	return objdet.NewTrack(
+		int(t.Id),
+		objdet.NewDetection(box, t.Score, t.Label),
+		r2.Point{X: t.VelocityX, Y: t.VelocityY},
+		int(t.Age),
+		int(t.Hits),
+		int(t.LostFrames),
+		objdet.TrackState(t.State),
+	)
+}
+
+func trackEventFromProto(e *pb.TrackEvent) objdet.TrackEvent {
+	event := objdet.TrackEvent{Seq: e.Seq, Type: objdet.TrackEventType(e.Type)}
+	if e.Time != nil {
+		event.Time = e.Time.AsTime()
+	}
+	if e.Track != nil {
+		event.Track = trackFromProto(e.Track)
+	}
+	return event
+}
+
+// encodeMask encodes the mask for the wire.
+func encodeMask(mask *segmentation.Mask) (*pb.GetSegmentationMaskResponse, error) {
+	if mask == nil || mask.Classes == nil {
+		return nil, errors.New("segmenter returned no mask")
+	}
+	var classes bytes.Buffer
+	if err := png.Encode(&classes, mask.Classes); err != nil {
+		return nil, err
+	}
+	resp := &pb.GetSegmentationMaskResponse{Classes: classes.Bytes(), Labels: mask.Labels, Background: int64(mask.Background)}
+	if mask.Instances != nil {
+		var instances bytes.Buffer
+		if err := png.Encode(&instances, mask.Instances); err != nil {
+			return nil, err
+		}
+		resp.Instances = instances.Bytes()
+	}
+	return resp, nil
+}
+
+// decodeMask decodes a mask encoded by encodeMask.
+func decodeMask(resp *pb.GetSegmentationMaskResponse) (*segmentation.Mask, error) {
+	decodeGray16 := func(data []byte) (*image.Gray16, error) {
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		gray, ok := img.(*image.Gray16)
+		if !ok {
+			return nil, errors.Errorf("expected a 16 bit gray mask, got %T", img)
+		}
+		return gray, nil
+	}
+	classes, err := decodeGray16(resp.Classes)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode mask classes")
+	}
+	mask := &segmentation.Mask{Classes: classes, Labels: resp.Labels, Background: int(resp.Background)}
+	if len(resp.Instances) > 0 {
+		if mask.Instances, err = decodeGray16(resp.Instances); err != nil {
+			return nil, errors.Wrap(err, "could not decode mask instances")
+		}
+	}
+	return mask, nil
+}
@@ -94,10 +94,9 @@ func (server *subtypeServer) AddDetector(
 		return nil, err
 	}
 	params := config.AttributeMap(req.DetectorParameters.AsMap())
-	cfg := VisModelConfig{
-		Name:       req.DetectorName,
-		Type:       req.DetectorModelType,
-		Parameters: params,
+	cfg, err := detectorConfig(req.DetectorName, req.DetectorModelType, params)
+	if err != nil {
+		return nil, err
 	}
 	err = svc.AddDetector(ctx, cfg)
 	if err != nil {
@@ -4,6 +4,7 @@ package vision
 
 import (
 	"context"
+	"encoding/json"
 	"image"
 	"sync"
 
@@ -14,6 +15,7 @@ import (
 	"go.viam.com/utils/rpc"
 
 	"go.viam.com/rdk/config"
+	extendedpb "go.viam.com/rdk/proto/api/service/vision/v1"
 	"go.viam.com/rdk/registry"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
@@ -22,16 +24,25 @@ import (
 	viz "go.viam.com/rdk/vision"
 	"go.viam.com/rdk/vision/classification"
 	objdet "go.viam.com/rdk/vision/objectdetection"
+	"go.viam.com/rdk/vision/segmentation"
 )
 
 func init() {
 	registry.RegisterResourceSubtype(Subtype, registry.ResourceSubtype{
 		RegisterRPCService: func(ctx context.Context, rpcServer rpc.Server, subtypeSvc subtype.Service) error {
-			return rpcServer.RegisterServiceServer(
+			if err := rpcServer.RegisterServiceServer(
 				ctx,
 				&servicepb.VisionService_ServiceDesc,
 				NewServer(subtypeSvc),
 				servicepb.RegisterVisionServiceHandlerFromEndpoint,
+			); err != nil {
+				return err
+			}
+			return rpcServer.RegisterServiceServer(
+				ctx,
+				&extendedpb.ExtendedVisionService_ServiceDesc,
+				NewExtendedServer(subtypeSvc),
+				extendedpb.RegisterExtendedVisionServiceHandlerFromEndpoint,
 			)
 		},
 		RPCServiceDesc: &servicepb.VisionService_ServiceDesc,
@@ -53,6 +64,11 @@ type Service interface {
 	RemoveDetector(ctx context.Context, detectorName string) error
 	DetectionsFromCamera(ctx context.Context, cameraName, detectorName string) ([]objdet.Detection, error)
 	Detections(ctx context.Context, img image.Image, detectorName string) ([]objdet.Detection, error)
+	// TracksFromCamera returns the objects a tracker follows in the images of a camera, after
+	// tracking them in its next image, and TrackEvents the latest events of those objects whose
+	// sequence number is greater than after.
+	TracksFromCamera(ctx context.Context, cameraName, trackerName string) ([]objdet.Track, error)
+	TrackEvents(ctx context.Context, cameraName, trackerName string, after uint64) ([]objdet.TrackEvent, error)
 	// classifier methods
 	ClassifierNames(ctx context.Context) ([]string, error)
 	AddClassifier(ctx context.Context, cfg VisModelConfig) error
@@ -64,6 +80,10 @@ type Service interface {
 	AddSegmenter(ctx context.Context, cfg VisModelConfig) error
 	RemoveSegmenter(ctx context.Context, segmenterName string) error
 	GetObjectPointClouds(ctx context.Context, cameraName, segmenterName string) ([]*viz.Object, error)
+	// SegmentationMaskFromCamera and SegmentationMask return the per pixel mask of an image, for
+	// the segmenters that compute one.
+	SegmentationMaskFromCamera(ctx context.Context, cameraName, segmenterName string) (*segmentation.Mask, error)
+	SegmentationMask(ctx context.Context, img image.Image, segmenterName string) (*segmentation.Mask, error)
 }
 
 var (
@@ -124,11 +144,62 @@ func FirstFromRobot(r robot.Robot) (Service, error) {
 type VisModelType string
 
 // VisModelConfig specifies the name of the detector, the type of detector,
-// and the necessary parameters needed to build the detector.
+// and the necessary parameters needed to build the detector. The detections of any
+// detector can be postprocessed, e.g. with non-maximum suppression or label filters.
 type VisModelConfig struct {
-	Name       string              `json:"name"`
-	Type       string              `json:"type"`
-	Parameters config.AttributeMap `json:"parameters"`
+	Name           string                      `json:"name"`
+	Type           string                      `json:"type"`
+	Parameters     config.AttributeMap         `json:"parameters"`
+	Postprocessing *objdet.PostprocessorConfig `json:"postprocessing,omitempty"`
+}
+
+// postprocessingParameter is the parameter holding the postprocessing of a detector over gRPC,
+// as the AddDetector request only holds the parameters of the detector.
+const postprocessingParameter = "postprocessing"
+
+// detectorParameters returns the parameters of the detector along with its postprocessing.
+func detectorParameters(cfg VisModelConfig) (config.AttributeMap, error) {
+	if cfg.Postprocessing == nil {
+		return cfg.Parameters, nil
+	}
+	data, err := json.Marshal(cfg.Postprocessing)
+	if err != nil {
+		return nil, err
+	}
+	var postprocessing map[string]interface{}
+	if err := json.Unmarshal(data, &postprocessing); err != nil {
+		return nil, err
+	}
+	params := make(config.AttributeMap, len(cfg.Parameters)+1)
+	for k, v := range cfg.Parameters {
+		params[k] = v
+	}
+	params[postprocessingParameter] = postprocessing
+	return params, nil
+}
+
+// detectorConfig returns the config of a detector whose parameters may hold its postprocessing.
+func detectorConfig(name, modelType string, params config.AttributeMap) (VisModelConfig, error) {
+	cfg := VisModelConfig{Name: name, Type: modelType, Parameters: params}
+	postprocessing, ok := params[postprocessingParameter].(map[string]interface{})
+	if !ok {
+		return cfg, nil
+	}
+	conf, err := config.TransformAttributeMapToStruct(&objdet.PostprocessorConfig{}, postprocessing)
+	if err != nil {
+		return VisModelConfig{}, err
+	}
+	cfg.Postprocessing, ok = conf.(*objdet.PostprocessorConfig)
+	if !ok {
+		return VisModelConfig{}, utils.NewUnexpectedTypeError(cfg.Postprocessing, conf)
+	}
+	cfg.Parameters = make(config.AttributeMap, len(params)-1)
+	for k, v := range params {
+		if k != postprocessingParameter {
+			cfg.Parameters[k] = v
+		}
+	}
+	return cfg, nil
 }
 
 // Attributes contains a list of the user-provided details necessary to register a new vision service.
@@ -239,6 +310,43 @@ func (svc *reconfigurableVision) GetObjectPointClouds(ctx context.Context,
 	return svc.actual.GetObjectPointClouds(ctx, cameraName, segmenterName)
 }
 
+func (svc *reconfigurableVision) TracksFromCamera(ctx context.Context,
+	cameraName,
+	trackerName string,
+) ([]objdet.Track, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	return svc.actual.TracksFromCamera(ctx, cameraName, trackerName)
+}
+
+func (svc *reconfigurableVision) TrackEvents(ctx context.Context,
+	cameraName,
+	trackerName string,
+	after uint64,
+) ([]objdet.TrackEvent, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	return svc.actual.TrackEvents(ctx, cameraName, trackerName, after)
+}
+
+func (svc *reconfigurableVision) SegmentationMaskFromCamera(ctx context.Context,
+	cameraName,
+	segmenterName string,
+) (*segmentation.Mask, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	return svc.actual.SegmentationMaskFromCamera(ctx, cameraName, segmenterName)
+}
+
+func (svc *reconfigurableVision) SegmentationMask(ctx context.Context,
+	img image.Image,
+	segmenterName string,
+) (*segmentation.Mask, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	return svc.actual.SegmentationMask(ctx, img, segmenterName)
+}
+
 func (svc *reconfigurableVision) Close(ctx context.Context) error {
 	svc.mu.RLock()
 	defer svc.mu.RUnlock()
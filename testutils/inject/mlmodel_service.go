@@ -0,0 +1,31 @@
+package inject
+
+import (
+	"context"
+
+	"go.viam.com/rdk/ml/inference"
+	"go.viam.com/rdk/services/mlmodel"
+)
+
+// MLModelService represents a fake instance of an ML model service.
+type MLModelService struct {
+	mlmodel.Service
+	MetadataFunc func(ctx context.Context) (mlmodel.Metadata, error)
+	InferFunc    func(ctx context.Context, inputs map[string]inference.Tensor) (map[string]inference.Tensor, error)
+}
+
+// Metadata calls the injected MetadataFunc or the real version.
+func (s *MLModelService) Metadata(ctx context.Context) (mlmodel.Metadata, error) {
+	if s.MetadataFunc == nil {
+		return s.Service.Metadata(ctx)
+	}
+	return s.MetadataFunc(ctx)
+}
+
+// Infer calls the injected InferFunc or the real version.
+func (s *MLModelService) Infer(ctx context.Context, inputs map[string]inference.Tensor) (map[string]inference.Tensor, error) {
+	if s.InferFunc == nil {
+		return s.Service.Infer(ctx, inputs)
+	}
+	return s.InferFunc(ctx, inputs)
+}
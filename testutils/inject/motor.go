@@ -18,6 +18,7 @@ type Motor struct {
 	PropertiesFunc        func(ctx context.Context, extra map[string]interface{}) (map[motor.Feature]bool, error)
 	StopFunc              func(ctx context.Context, extra map[string]interface{}) error
 	IsPoweredFunc         func(ctx context.Context, extra map[string]interface{}) (bool, float64, error)
+	TelemetryFunc         func(ctx context.Context, extra map[string]interface{}) (motor.Telemetry, error)
 }
 
 // SetPower calls the injected Power or the real version.
@@ -84,6 +85,14 @@ func (m *Motor) IsPowered(ctx context.Context, extra map[string]interface{}) (bo
 	return m.IsPoweredFunc(ctx, extra)
 }
 
+// Telemetry calls the injected Telemetry or the real version.
+func (m *Motor) Telemetry(ctx context.Context, extra map[string]interface{}) (motor.Telemetry, error) {
+	if m.TelemetryFunc == nil {
+		return motor.TelemetryFromMotor(ctx, m.Motor, "(name unavailable)", extra)
+	}
+	return m.TelemetryFunc(ctx, extra)
+}
+
 // DoCommand calls the injected DoCommand or the real version.
 func (m *Motor) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
 	if m.DoFunc == nil {
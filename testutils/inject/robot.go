@@ -17,6 +17,8 @@ import (
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
 	framesystemparts "go.viam.com/rdk/robot/framesystem/parts"
+	"go.viam.com/rdk/robot/journal"
+	rutils "go.viam.com/rdk/utils"
 )
 
 // Robot is an injected robot.
@@ -30,6 +32,7 @@ type Robot struct {
 	ResourceRPCSubtypesFunc func() []resource.RPCSubtype
 	ProcessManagerFunc      func() pexec.ProcessManager
 	ConfigFunc              func(ctx context.Context) (*config.Config, error)
+	ReconfigureFunc         func(ctx context.Context, newConfig *config.Config)
 	LoggerFunc              func() golog.Logger
 	CloseFunc               func(ctx context.Context) error
 	StopAllFunc             func(ctx context.Context, extra map[resource.Name]map[string]interface{}) error
@@ -41,7 +44,10 @@ type Robot struct {
 		dst string,
 		additionalTransforms []*commonpb.Transform,
 	) (*referenceframe.PoseInFrame, error)
-	StatusFunc func(ctx context.Context, resourceNames []resource.Name) ([]robot.Status, error)
+	StatusFunc         func(ctx context.Context, resourceNames []resource.Name) ([]robot.Status, error)
+	ValidateConfigFunc func(ctx context.Context, cfg *config.Config) (*robot.ReconfigurationPlan, error)
+	HealthFunc         func(ctx context.Context, resourceNames []resource.Name) ([]robot.ResourceHealth, error)
+	EventsFunc         func(ctx context.Context, filter journal.Filter) ([]journal.Event, error)
 
 	ops     *operation.Manager
 	opsLock sync.Mutex
@@ -132,6 +138,15 @@ func (r *Robot) Config(ctx context.Context) (*config.Config, error) {
 	return r.ConfigFunc(ctx)
 }
 
+// Reconfigure calls the injected Reconfigure or the real version.
+func (r *Robot) Reconfigure(ctx context.Context, newConfig *config.Config) {
+	if r.ReconfigureFunc == nil {
+		r.LocalRobot.Reconfigure(ctx, newConfig)
+		return
+	}
+	r.ReconfigureFunc(ctx, newConfig)
+}
+
 // Logger calls the injected Logger or the real version.
 func (r *Robot) Logger() golog.Logger {
 	if r.LoggerFunc == nil {
@@ -204,3 +219,36 @@ func (r *Robot) Status(ctx context.Context, resourceNames []resource.Name) ([]ro
 	}
 	return r.StatusFunc(ctx, resourceNames)
 }
+
+// ValidateConfig calls the injected ValidateConfig or the real version.
+func (r *Robot) ValidateConfig(ctx context.Context, cfg *config.Config) (*robot.ReconfigurationPlan, error) {
+	if r.ValidateConfigFunc == nil {
+		if validator, ok := r.LocalRobot.(robot.ConfigValidator); ok {
+			return validator.ValidateConfig(ctx, cfg)
+		}
+		return nil, rutils.NewUnimplementedInterfaceError((*robot.ConfigValidator)(nil), r.LocalRobot)
+	}
+	return r.ValidateConfigFunc(ctx, cfg)
+}
+
+// Health calls the injected Health or the real version.
+func (r *Robot) Health(ctx context.Context, resourceNames []resource.Name) ([]robot.ResourceHealth, error) {
+	if r.HealthFunc == nil {
+		if reporter, ok := r.LocalRobot.(robot.HealthReporter); ok {
+			return reporter.Health(ctx, resourceNames)
+		}
+		return nil, rutils.NewUnimplementedInterfaceError((*robot.HealthReporter)(nil), r.LocalRobot)
+	}
+	return r.HealthFunc(ctx, resourceNames)
+}
+
+// Events calls the injected Events or the real version.
+func (r *Robot) Events(ctx context.Context, filter journal.Filter) ([]journal.Event, error) {
+	if r.EventsFunc == nil {
+		if j, ok := r.LocalRobot.(robot.EventJournal); ok {
+			return j.Events(ctx, filter)
+		}
+		return nil, rutils.NewUnimplementedInterfaceError((*robot.EventJournal)(nil), r.LocalRobot)
+	}
+	return r.EventsFunc(ctx, filter)
+}
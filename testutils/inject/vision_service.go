@@ -10,6 +10,7 @@ import (
 	viz "go.viam.com/rdk/vision"
 	"go.viam.com/rdk/vision/classification"
 	"go.viam.com/rdk/vision/objectdetection"
+	"go.viam.com/rdk/vision/segmentation"
 )
 
 // VisionService represents a fake instance of a vision service.
@@ -22,6 +23,8 @@ type VisionService struct {
 	RemoveDetectorFunc       func(ctx context.Context, detectorName string) error
 	DetectionsFromCameraFunc func(ctx context.Context, cameraName, detectorName string) ([]objectdetection.Detection, error)
 	DetectionsFunc           func(ctx context.Context, img image.Image, detectorName string) ([]objectdetection.Detection, error)
+	TracksFromCameraFunc     func(ctx context.Context, cameraName, trackerName string) ([]objectdetection.Track, error)
+	TrackEventsFunc          func(ctx context.Context, cameraName, trackerName string, after uint64) ([]objectdetection.TrackEvent, error)
 	// classification functions
 	ClassifierNamesFunc           func(ctx context.Context) ([]string, error)
 	AddClassifierFunc             func(ctx context.Context, cfg vision.VisModelConfig) error
@@ -32,10 +35,12 @@ type VisionService struct {
 		n int) (classification.Classifications, error)
 
 	// segmentation functions
-	SegmenterNamesFunc       func(ctx context.Context) ([]string, error)
-	AddSegmenterFunc         func(ctx context.Context, cfg vision.VisModelConfig) error
-	RemoveSegmenterFunc      func(ctx context.Context, segmenterName string) error
-	GetObjectPointCloudsFunc func(ctx context.Context, cameraName, segmenterName string) ([]*viz.Object, error)
+	SegmenterNamesFunc             func(ctx context.Context) ([]string, error)
+	AddSegmenterFunc               func(ctx context.Context, cfg vision.VisModelConfig) error
+	RemoveSegmenterFunc            func(ctx context.Context, segmenterName string) error
+	GetObjectPointCloudsFunc       func(ctx context.Context, cameraName, segmenterName string) ([]*viz.Object, error)
+	SegmentationMaskFromCameraFunc func(ctx context.Context, cameraName, segmenterName string) (*segmentation.Mask, error)
+	SegmentationMaskFunc           func(ctx context.Context, img image.Image, segmenterName string) (*segmentation.Mask, error)
 }
 
 // GetModelParameterSchema calls the injected ModelParameters or the real variant.
@@ -144,6 +149,52 @@ func (vs *VisionService) GetObjectPointClouds(
 	return vs.GetObjectPointCloudsFunc(ctx, cameraName, segmenterName)
 }
 
+// TracksFromCamera calls the injected TracksFromCamera or the real variant.
+func (vs *VisionService) TracksFromCamera(
+	ctx context.Context,
+	cameraName, trackerName string,
+) ([]objectdetection.Track, error) {
+	if vs.TracksFromCameraFunc == nil {
+		return vs.Service.TracksFromCamera(ctx, cameraName, trackerName)
+	}
+	return vs.TracksFromCameraFunc(ctx, cameraName, trackerName)
+}
+
+// TrackEvents calls the injected TrackEvents or the real variant.
+func (vs *VisionService) TrackEvents(
+	ctx context.Context,
+	cameraName, trackerName string,
+	after uint64,
+) ([]objectdetection.TrackEvent, error) {
+	if vs.TrackEventsFunc == nil {
+		return vs.Service.TrackEvents(ctx, cameraName, trackerName, after)
+	}
+	return vs.TrackEventsFunc(ctx, cameraName, trackerName, after)
+}
+
+// SegmentationMaskFromCamera calls the injected SegmentationMaskFromCamera or the real variant.
+func (vs *VisionService) SegmentationMaskFromCamera(
+	ctx context.Context,
+	cameraName, segmenterName string,
+) (*segmentation.Mask, error) {
+	if vs.SegmentationMaskFromCameraFunc == nil {
+		return vs.Service.SegmentationMaskFromCamera(ctx, cameraName, segmenterName)
+	}
+	return vs.SegmentationMaskFromCameraFunc(ctx, cameraName, segmenterName)
+}
+
+// SegmentationMask calls the injected SegmentationMask or the real variant.
+func (vs *VisionService) SegmentationMask(
+	ctx context.Context,
+	img image.Image,
+	segmenterName string,
+) (*segmentation.Mask, error) {
+	if vs.SegmentationMaskFunc == nil {
+		return vs.Service.SegmentationMask(ctx, img, segmenterName)
+	}
+	return vs.SegmentationMaskFunc(ctx, img, segmenterName)
+}
+
 // SegmenterNames calls the injected SegmenterNames or the real variant.
 func (vs *VisionService) SegmenterNames(ctx context.Context) ([]string, error) {
 	if vs.SegmenterNamesFunc == nil {
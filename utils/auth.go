@@ -6,4 +6,10 @@ const (
 
 	// CredentialsTypeRobotLocationSecret is for credentials used against the cloud managing this robot's location.
 	CredentialsTypeRobotLocationSecret = "robot-location-secret"
+
+	// CredentialsTypeJWT is for JSON Web Tokens issued by an external identity provider.
+	CredentialsTypeJWT = "jwt"
+
+	// CredentialsTypeMutualTLS is for clients presenting a TLS client certificate.
+	CredentialsTypeMutualTLS = "mtls"
 )
@@ -29,6 +29,9 @@ func drawDetection(img *gg.Context, d Detection) {
 	box := d.BoundingBox()
 	rimage.DrawRectangleEmpty(img, *box, red, 2.0)
 	text := fmt.Sprintf("%s: %.2f", d.Label(), d.Score())
+	if t, ok := d.(*Track); ok {
+		text = fmt.Sprintf("%s #%d: %.2f", t.Label(), t.ID(), t.Score())
+	}
 	rimage.DrawString(img, text, image.Point{box.Min.X, box.Min.Y}, red, 30)
 }
 
@@ -38,3 +41,14 @@ func OverlayText(img image.Image, text string) image.Image {
 	rimage.DrawString(gimg, text, image.Point{30, 30}, color.NRGBA{255, 0, 0, 255}, 30)
 	return gimg.Image()
 }
+
+// IoU returns the intersection over union of two boxes.
+func IoU(a, b image.Rectangle) float64 {
+	intersection := a.Intersect(b)
+	if intersection.Empty() {
+		return 0
+	}
+	intersectionArea := intersection.Dx() * intersection.Dy()
+	unionArea := a.Dx()*a.Dy() + b.Dx()*b.Dy() - intersectionArea
+	return float64(intersectionArea) / float64(unionArea)
+}
@@ -1,6 +1,13 @@
 package objectdetection
 
-import "sort"
+import (
+	"image"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/rimage"
+)
 
 // Postprocessor defines a function that filters/modifies on an incoming array of Detections.
 type Postprocessor func([]Detection) []Detection
@@ -40,3 +47,194 @@ func SortByArea() Postprocessor {
 		return in
 	}
 }
+
+// NewNMS returns a function that performs non-maximum suppression, dropping every detection that
+// overlaps a detection of higher score by more than the IoU threshold. If perClass is true, only
+// detections with the same label suppress each other.
+func NewNMS(iouThreshold float64, perClass bool) Postprocessor {
+	return func(in []Detection) []Detection {
+		sorted := make([]Detection, len(in))
+		copy(sorted, in)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].Score() > sorted[j].Score()
+		})
+		out := make([]Detection, 0, len(sorted))
+		for _, d := range sorted {
+			suppressed := false
+			for _, kept := range out {
+				if perClass && kept.Label() != d.Label() {
+					continue
+				}
+				if IoU(*kept.BoundingBox(), *d.BoundingBox()) > iouThreshold {
+					suppressed = true
+					break
+				}
+			}
+			if !suppressed {
+				out = append(out, d)
+			}
+		}
+		return out
+	}
+}
+
+// NewLabelFilter returns a function that filters out detections whose label is not in the allow
+// list, if it is not empty, or is in the deny list.
+func NewLabelFilter(allow, deny []string) Postprocessor {
+	allowed := make(map[string]bool, len(allow))
+	for _, label := range allow {
+		allowed[label] = true
+	}
+	denied := make(map[string]bool, len(deny))
+	for _, label := range deny {
+		denied[label] = true
+	}
+	return func(in []Detection) []Detection {
+		out := make([]Detection, 0, len(in))
+		for _, d := range in {
+			if (len(allowed) == 0 || allowed[d.Label()]) && !denied[d.Label()] {
+				out = append(out, d)
+			}
+		}
+		return out
+	}
+}
+
+// NewLabelMapper returns a function that renames the labels of detections found in the map.
+func NewLabelMapper(labels map[string]string) Postprocessor {
+	return func(in []Detection) []Detection {
+		out := make([]Detection, 0, len(in))
+		for _, d := range in {
+			if label, ok := labels[d.Label()]; ok {
+				d = NewDetection(*d.BoundingBox(), d.Score(), label)
+			}
+			out = append(out, d)
+		}
+		return out
+	}
+}
+
+// NewMaxDetections returns a function that keeps the n detections with the highest score.
+func NewMaxDetections(n int) Postprocessor {
+	return func(in []Detection) []Detection {
+		if len(in) <= n {
+			return in
+		}
+		out := make([]Detection, len(in))
+		copy(out, in)
+		sort.SliceStable(out, func(i, j int) bool {
+			return out[i].Score() > out[j].Score()
+		})
+		return out[:n]
+	}
+}
+
+// NewRegionFilter returns a function that filters out detections whose center is not within one of
+// the regions.
+func NewRegionFilter(regions []image.Rectangle) Postprocessor {
+	return func(in []Detection) []Detection {
+		out := make([]Detection, 0, len(in))
+		for _, d := range in {
+			center := boxCenter(*d.BoundingBox())
+			for _, region := range regions {
+				if center.In(region) {
+					out = append(out, d)
+					break
+				}
+			}
+		}
+		return out
+	}
+}
+
+// NewMaskFilter returns a function that filters out detections whose center is on a black pixel
+// of the mask, or outside of it.
+func NewMaskFilter(mask image.Image) Postprocessor {
+	return func(in []Detection) []Detection {
+		out := make([]Detection, 0, len(in))
+		for _, d := range in {
+			center := boxCenter(*d.BoundingBox())
+			if !center.In(mask.Bounds()) {
+				continue
+			}
+			if r, g, b, _ := mask.At(center.X, center.Y).RGBA(); r+g+b > 0 {
+				out = append(out, d)
+			}
+		}
+		return out
+	}
+}
+
+func boxCenter(box image.Rectangle) image.Point {
+	return image.Point{(box.Min.X + box.Max.X) / 2, (box.Min.Y + box.Max.Y) / 2}
+}
+
+// Chain returns a function that applies the postprocessors one after the other.
+func Chain(posts ...Postprocessor) Postprocessor {
+	return func(in []Detection) []Detection {
+		for _, post := range posts {
+			in = post(in)
+		}
+		return in
+	}
+}
+
+// Region is a rectangular region of an image, in pixels.
+type Region struct {
+	XMin int `json:"x_min"`
+	YMin int `json:"y_min"`
+	XMax int `json:"x_max"`
+	YMax int `json:"y_max"`
+}
+
+// PostprocessorConfig are the parameters of the postprocessing of the detections of any detector.
+// The detections are relabeled first, so that filters and per class non-maximum suppression apply
+// to the new labels, then filtered by label and region, suppressed, and finally limited in number.
+type PostprocessorConfig struct {
+	LabelMap          map[string]string `json:"label_map,omitempty"`
+	AllowLabels       []string          `json:"allow_labels,omitempty"`
+	DenyLabels        []string          `json:"deny_labels,omitempty"`
+	RegionsOfInterest []Region          `json:"regions_of_interest,omitempty"`
+	MaskPath          string            `json:"mask_path,omitempty"`
+	NMSIoUThreshold   float64           `json:"nms_iou_threshold,omitempty"`
+	ClassAgnosticNMS  bool              `json:"class_agnostic_nms,omitempty"`
+	MaxDetections     int               `json:"max_detections,omitempty"`
+}
+
+// NewPostprocessor returns the postprocessor described by the config.
+func NewPostprocessor(cfg *PostprocessorConfig) (Postprocessor, error) {
+	var posts []Postprocessor
+	if len(cfg.LabelMap) > 0 {
+		posts = append(posts, NewLabelMapper(cfg.LabelMap))
+	}
+	if len(cfg.AllowLabels) > 0 || len(cfg.DenyLabels) > 0 {
+		posts = append(posts, NewLabelFilter(cfg.AllowLabels, cfg.DenyLabels))
+	}
+	if len(cfg.RegionsOfInterest) > 0 {
+		regions := make([]image.Rectangle, 0, len(cfg.RegionsOfInterest))
+		for _, r := range cfg.RegionsOfInterest {
+			regions = append(regions, image.Rect(r.XMin, r.YMin, r.XMax, r.YMax))
+		}
+		posts = append(posts, NewRegionFilter(regions))
+	}
+	if cfg.MaskPath != "" {
+		mask, err := rimage.NewImageFromFile(cfg.MaskPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read detection mask")
+		}
+		posts = append(posts, NewMaskFilter(mask))
+	}
+	if cfg.NMSIoUThreshold < 0 || cfg.NMSIoUThreshold > 1 {
+		return nil, errors.Errorf("nms_iou_threshold must be between 0 and 1, got %v", cfg.NMSIoUThreshold)
+	}
+	if cfg.NMSIoUThreshold > 0 {
+		posts = append(posts, NewNMS(cfg.NMSIoUThreshold, !cfg.ClassAgnosticNMS))
+	}
+	if cfg.MaxDetections < 0 {
+		return nil, errors.Errorf("max_detections cannot be negative, got %d", cfg.MaxDetections)
+	}
+	if cfg.MaxDetections > 0 {
+		posts = append(posts, NewMaxDetections(cfg.MaxDetections))
+	}
+	return Chain(posts...), nil
+}
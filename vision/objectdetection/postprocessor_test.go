@@ -2,6 +2,7 @@ package objectdetection
 
 import (
 	"image"
+	"image/color"
 	"testing"
 
 	"go.viam.com/test"
@@ -45,3 +46,95 @@ func TestPostprocessors(t *testing.T) {
 	test.That(t, labelList, test.ShouldContain, "C")
 	test.That(t, labelList, test.ShouldContain, "D")
 }
+
+func labelsOf(dets []Detection) []string {
+	labels := make([]string, 0, len(dets))
+	for _, d := range dets {
+		labels = append(labels, d.Label())
+	}
+	return labels
+}
+
+func TestNMS(t *testing.T) {
+	d := []Detection{
+		NewDetection(image.Rect(0, 0, 100, 100), 0.5, "A"),
+		NewDetection(image.Rect(5, 5, 105, 105), 0.9, "A"),
+		NewDetection(image.Rect(0, 0, 100, 100), 0.7, "B"),
+		NewDetection(image.Rect(200, 200, 300, 300), 0.6, "A"),
+	}
+	got := NewNMS(0.5, true)(d)
+	test.That(t, labelsOf(got), test.ShouldResemble, []string{"A", "B", "A"})
+	test.That(t, got[0].Score(), test.ShouldEqual, 0.9)
+
+	got = NewNMS(0.5, false)(d)
+	test.That(t, labelsOf(got), test.ShouldResemble, []string{"A", "A"})
+	test.That(t, got[1].Score(), test.ShouldEqual, 0.6)
+	// the input is left as it was
+	test.That(t, d[0].Score(), test.ShouldEqual, 0.5)
+}
+
+func TestLabelPostprocessors(t *testing.T) {
+	d := []Detection{
+		NewDetection(image.Rect(0, 0, 10, 10), 0.5, "car"),
+		NewDetection(image.Rect(0, 0, 10, 10), 0.6, "truck"),
+		NewDetection(image.Rect(0, 0, 10, 10), 0.7, "person"),
+	}
+	test.That(t, labelsOf(NewLabelFilter([]string{"car", "person"}, nil)(d)), test.ShouldResemble, []string{"car", "person"})
+	test.That(t, labelsOf(NewLabelFilter(nil, []string{"car"})(d)), test.ShouldResemble, []string{"truck", "person"})
+	test.That(t, labelsOf(NewLabelFilter([]string{"car"}, []string{"car"})(d)), test.ShouldBeEmpty)
+
+	got := NewLabelMapper(map[string]string{"car": "vehicle", "truck": "vehicle"})(d)
+	test.That(t, labelsOf(got), test.ShouldResemble, []string{"vehicle", "vehicle", "person"})
+	test.That(t, got[1].Score(), test.ShouldEqual, 0.6)
+	test.That(t, *got[1].BoundingBox(), test.ShouldResemble, image.Rect(0, 0, 10, 10))
+}
+
+func TestMaxDetectionsAndRegions(t *testing.T) {
+	d := []Detection{
+		NewDetection(image.Rect(0, 0, 10, 10), 0.5, "A"),
+		NewDetection(image.Rect(50, 50, 60, 60), 0.9, "B"),
+		NewDetection(image.Rect(90, 0, 100, 10), 0.7, "C"),
+	}
+	test.That(t, labelsOf(NewMaxDetections(2)(d)), test.ShouldResemble, []string{"B", "C"})
+	test.That(t, NewMaxDetections(5)(d), test.ShouldHaveLength, 3)
+
+	regions := []image.Rectangle{image.Rect(0, 0, 20, 20), image.Rect(40, 40, 100, 100)}
+	test.That(t, labelsOf(NewRegionFilter(regions)(d)), test.ShouldResemble, []string{"A", "B"})
+
+	mask := image.NewGray(image.Rect(0, 0, 100, 100))
+	mask.SetGray(95, 5, color.Gray{255})
+	test.That(t, labelsOf(NewMaskFilter(mask)(d)), test.ShouldResemble, []string{"C"})
+}
+
+func TestNewPostprocessor(t *testing.T) {
+	d := []Detection{
+		NewDetection(image.Rect(0, 0, 100, 100), 0.5, "car"),
+		NewDetection(image.Rect(5, 5, 105, 105), 0.9, "truck"),
+		NewDetection(image.Rect(0, 0, 100, 100), 0.7, "person"),
+		NewDetection(image.Rect(200, 200, 300, 300), 0.6, "car"),
+		NewDetection(image.Rect(400, 400, 500, 500), 0.8, "car"),
+	}
+	post, err := NewPostprocessor(&PostprocessorConfig{
+		LabelMap:          map[string]string{"car": "vehicle", "truck": "vehicle"},
+		DenyLabels:        []string{"person"},
+		RegionsOfInterest: []Region{{XMin: 0, YMin: 0, XMax: 300, YMax: 300}},
+		NMSIoUThreshold:   0.5,
+		MaxDetections:     1,
+	})
+	test.That(t, err, test.ShouldBeNil)
+	got := post(d)
+	test.That(t, got, test.ShouldHaveLength, 1)
+	test.That(t, got[0].Label(), test.ShouldEqual, "vehicle")
+	test.That(t, got[0].Score(), test.ShouldEqual, 0.9)
+
+	post, err = NewPostprocessor(&PostprocessorConfig{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, post(d), test.ShouldHaveLength, len(d))
+
+	_, err = NewPostprocessor(&PostprocessorConfig{NMSIoUThreshold: 2})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = NewPostprocessor(&PostprocessorConfig{MaxDetections: -1})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = NewPostprocessor(&PostprocessorConfig{MaskPath: "/does/not/exist.png"})
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -0,0 +1,566 @@
+package objectdetection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/geo/r2"
+	"github.com/pkg/errors"
+)
+
+// The default parameters of a Tracker.
+const (
+	DefaultTrackerIoUThreshold  = 0.3
+	DefaultTrackerMinHits       = 1
+	DefaultTrackerMaxLostFrames = 5
+)
+
+// maxTrackEvents is the number of the latest events a Tracker keeps for Events.
+const maxTrackEvents = 1000
+
+// The noise of the constant velocity model of the tracks, in pixels and seconds.
+const (
+	measurementVariance     = 4.0 // std of 2 px on the detected box
+	accelerationVariance    = 1e4 // std of 100 px/s² on the motion of the box
+	initialVelocityVariance = 1e6 // std of 1000 px/s on the velocity of a new track
+)
+
+// TrackerConfig are the parameters of a tracker following the detections of a detector across frames.
+type TrackerConfig struct {
+	DetectorName  string  `json:"detector_name"`
+	IoUThreshold  float64 `json:"iou_threshold"`
+	MinHits       int     `json:"min_hits"`
+	MaxLostFrames int     `json:"max_lost_frames"`
+}
+
+// TrackState is the state of a track.
+type TrackState string
+
+// The states of a track.
+const (
+	// TrackTentative tracks have not been detected in enough frames to be reported yet.
+	TrackTentative = TrackState("tentative")
+	// TrackTracked tracks were detected in the latest frame.
+	TrackTracked = TrackState("tracked")
+	// TrackLost tracks were not detected in the latest frame, their box is predicted from their motion.
+	TrackLost = TrackState("lost")
+)
+
+// TrackEventType is the type of a TrackEvent.
+type TrackEventType string
+
+// The types of TrackEvent.
+const (
+	// TrackEventNew is sent when an object is first tracked.
+	TrackEventNew = TrackEventType("new")
+	// TrackEventLost is sent when a tracked object was not detected in a frame.
+	TrackEventLost = TrackEventType("lost")
+	// TrackEventFound is sent when a lost object is detected again.
+	TrackEventFound = TrackEventType("found")
+	// TrackEventRemoved is sent when an object was lost for too many frames and is not tracked anymore.
+	TrackEventRemoved = TrackEventType("removed")
+)
+
+// TrackEvent is a change of a track in a frame.
+type TrackEvent struct {
+	// Seq is the sequence number of the event in its tracker, starting at 1.
+	Seq uint64 `json:"seq"`
+	// Time is the capture time of the frame.
+	Time  time.Time      `json:"time"`
+	Type  TrackEventType `json:"type"`
+	Track Track          `json:"track"`
+}
+
+// Track is the state of an object followed across frames. It is a Detection whose bounding box is
+// the estimated box of the object in the latest frame.
+type Track struct {
+	id          int
+	label       string
+	score       float64
+	boundingBox image.Rectangle
+	velocity    r2.Point
+	age         int
+	hits        int
+	lostFrames  int
+	state       TrackState
+}
+
+// NewTrack returns a track of the given state whose object was last seen as the detection, such
+// as one received from another robot. Tracks are otherwise made by a Tracker.
+func NewTrack(id int, detection Detection, velocity r2.Point, age, hits, lostFrames int, state TrackState) Track {
+	return Track{
+		id:          id,
+		label:       detection.Label(),
+		score:       detection.Score(),
+		boundingBox: *detection.BoundingBox(),
+		velocity:    velocity,
+		age:         age,
+		hits:        hits,
+		lostFrames:  lostFrames,
+		state:       state,
+	}
+}
+
+// ID returns the id of the track, unique within its tracker.
+func (t *Track) ID() int {
+	return t.id
+}
+
+// BoundingBox returns the estimated box around the tracked object.
+func (t *Track) BoundingBox() *image.Rectangle {
+	return &t.boundingBox
+}
+
+// Score returns the score of the latest detection of the object.
+func (t *Track) Score() float64 {
+	return t.score
+}
+
+// Label returns the class label of the tracked object.
+func (t *Track) Label() string {
+	return t.label
+}
+
+// Velocity returns the estimated velocity of the center of the object in pixels per second.
+func (t *Track) Velocity() r2.Point {
+	return t.velocity
+}
+
+// Age returns the number of frames since the object was first detected.
+func (t *Track) Age() int {
+	return t.age
+}
+
+// Hits returns the number of frames the object was detected in.
+func (t *Track) Hits() int {
+	return t.hits
+}
+
+// LostFrames returns the number of frames since the object was last detected.
+func (t *Track) LostFrames() int {
+	return t.lostFrames
+}
+
+// State returns the state of the track.
+func (t *Track) State() TrackState {
+	return t.state
+}
+
+// trackJSON is the JSON encoding of a Track.
+type trackJSON struct {
+	ID          int        `json:"id"`
+	Label       string     `json:"label"`
+	Score       float64    `json:"score"`
+	BoundingBox [4]int     `json:"bounding_box"` // x min, y min, x max, y max
+	Velocity    [2]float64 `json:"velocity"`
+	Age         int        `json:"age"`
+	Hits        int        `json:"hits"`
+	LostFrames  int        `json:"lost_frames"`
+	State       TrackState `json:"state"`
+}
+
+// MarshalJSON encodes the track as JSON.
+func (t Track) MarshalJSON() ([]byte, error) {
+	return json.Marshal(trackJSON{
+		ID:          t.id,
+		Label:       t.label,
+		Score:       t.score,
+		BoundingBox: [4]int{t.boundingBox.Min.X, t.boundingBox.Min.Y, t.boundingBox.Max.X, t.boundingBox.Max.Y},
+		Velocity:    [2]float64{t.velocity.X, t.velocity.Y},
+		Age:         t.age,
+		Hits:        t.hits,
+		LostFrames:  t.lostFrames,
+		State:       t.state,
+	})
+}
+
+// UnmarshalJSON decodes a track encoded by MarshalJSON.
+func (t *Track) UnmarshalJSON(data []byte) error {
+	var tj trackJSON
+	if err := json.Unmarshal(data, &tj); err != nil {
+		return err
+	}
+	*t = Track{
+		id:          tj.ID,
+		label:       tj.Label,
+		score:       tj.Score,
+		boundingBox: image.Rect(tj.BoundingBox[0], tj.BoundingBox[1], tj.BoundingBox[2], tj.BoundingBox[3]),
+		velocity:    r2.Point{X: tj.Velocity[0], Y: tj.Velocity[1]},
+		age:         tj.Age,
+		hits:        tj.Hits,
+		lostFrames:  tj.LostFrames,
+		state:       tj.State,
+	}
+	return nil
+}
+
+// String turns the track into a string.
+func (t *Track) String() string {
+	return fmt.Sprintf("ID: %d, Label: %s, Score: %.2f, Box: %v, State: %s", t.id, t.label, t.score, t.boundingBox, t.state)
+}
+
+// Tracker follows the detections of objects across the frames of a single video stream in the
+// manner of SORT: every track estimates the box of its object with a constant velocity Kalman
+// filter, and the boxes predicted for a frame are matched with its detections of the same label by
+// their intersection over union.
+type Tracker struct {
+	mu         sync.Mutex
+	cfg        TrackerConfig
+	nextID     int
+	tracks     []*kalmanTrack
+	lastUpdate time.Time
+	events     []TrackEvent
+	lastSeq    uint64
+}
+
+// NewTracker returns a new tracker, using the default of every unset parameter of the config.
+func NewTracker(cfg TrackerConfig) (*Tracker, error) {
+	if cfg.IoUThreshold == 0 {
+		cfg.IoUThreshold = DefaultTrackerIoUThreshold
+	}
+	if cfg.MinHits == 0 {
+		cfg.MinHits = DefaultTrackerMinHits
+	}
+	if cfg.MaxLostFrames == 0 {
+		cfg.MaxLostFrames = DefaultTrackerMaxLostFrames
+	}
+	if cfg.IoUThreshold < 0 || cfg.IoUThreshold > 1 {
+		return nil, errors.Errorf("tracker iou_threshold must be between 0 and 1, got %v", cfg.IoUThreshold)
+	}
+	if cfg.MinHits < 0 || cfg.MaxLostFrames < 0 {
+		return nil, errors.New("tracker min_hits and max_lost_frames cannot be negative")
+	}
+	return &Tracker{cfg: cfg, nextID: 1}, nil
+}
+
+// Update matches the detections of the frame captured at the given time with the tracks and
+// returns the tracks that are not tentative anymore, along with the events of the frame.
+func (tr *Tracker) Update(dets []Detection, captured time.Time) ([]Track, []TrackEvent) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	var dt float64
+	if !tr.lastUpdate.IsZero() && captured.After(tr.lastUpdate) {
+		dt = captured.Sub(tr.lastUpdate).Seconds()
+	}
+	tr.lastUpdate = captured
+	for _, t := range tr.tracks {
+		t.predict(dt)
+	}
+
+	matches := tr.match(dets)
+	var events []TrackEvent
+	kept := make([]*kalmanTrack, 0, len(tr.tracks)+len(dets))
+	matched := make(map[*kalmanTrack]bool, len(matches))
+	for detIdx, t := range matches {
+		if t == nil {
+			continue
+		}
+		matched[t] = true
+		wasLost := t.state == TrackLost
+		t.update(dets[detIdx])
+		if t.state == TrackTentative && t.hits >= tr.cfg.MinHits {
+			t.state = TrackTracked
+			events = append(events, TrackEvent{Type: TrackEventNew, Track: t.snapshot()})
+		} else if wasLost {
+			t.state = TrackTracked
+			events = append(events, TrackEvent{Type: TrackEventFound, Track: t.snapshot()})
+		}
+	}
+	for _, t := range tr.tracks {
+		if matched[t] {
+			kept = append(kept, t)
+			continue
+		}
+		t.lostFrames++
+		switch {
+		case t.state == TrackTentative:
+			// objects that were not detected in consecutive frames are not followed
+			continue
+		case t.lostFrames > tr.cfg.MaxLostFrames:
+			events = append(events, TrackEvent{Type: TrackEventRemoved, Track: t.snapshot()})
+			continue
+		case t.state == TrackTracked:
+			t.state = TrackLost
+			events = append(events, TrackEvent{Type: TrackEventLost, Track: t.snapshot()})
+		}
+		kept = append(kept, t)
+	}
+	for detIdx, t := range matches {
+		if t != nil {
+			continue
+		}
+		newTrack := newKalmanTrack(tr.nextID, dets[detIdx])
+		tr.nextID++
+		if newTrack.hits >= tr.cfg.MinHits {
+			newTrack.state = TrackTracked
+			events = append(events, TrackEvent{Type: TrackEventNew, Track: newTrack.snapshot()})
+		}
+		kept = append(kept, newTrack)
+	}
+	tr.tracks = kept
+	for i := range events {
+		tr.lastSeq++
+		events[i].Seq = tr.lastSeq
+		events[i].Time = captured
+	}
+	tr.events = append(tr.events, events...)
+	if extra := len(tr.events) - maxTrackEvents; extra > 0 {
+		tr.events = append(tr.events[:0], tr.events[extra:]...)
+	}
+	return tr.currentTracks(), events
+}
+
+// Tracks returns the tracks that are not tentative.
+func (tr *Tracker) Tracks() []Track {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	return tr.currentTracks()
+}
+
+func (tr *Tracker) currentTracks() []Track {
+	tracks := make([]Track, 0, len(tr.tracks))
+	for _, t := range tr.tracks {
+		if t.state != TrackTentative {
+			tracks = append(tracks, t.snapshot())
+		}
+	}
+	sort.Slice(tracks, func(i, j int) bool { return tracks[i].id < tracks[j].id })
+	return tracks
+}
+
+// Events returns the events whose sequence number is greater than after, among the latest events
+// of the tracker.
+func (tr *Tracker) Events(after uint64) []TrackEvent {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	idx := sort.Search(len(tr.events), func(i int) bool { return tr.events[i].Seq > after })
+	return append([]TrackEvent(nil), tr.events[idx:]...)
+}
+
+// match greedily matches every detection with the unmatched track of the same label whose
+// predicted box overlaps it the most, starting from the pairs overlapping the most. The returned
+// slice holds the track matched with every detection, or nil.
+func (tr *Tracker) match(dets []Detection) []*kalmanTrack {
+	type pair struct {
+		track, det int
+		iou        float64
+	}
+	pairs := make([]pair, 0, len(tr.tracks)*len(dets))
+	for i, t := range tr.tracks {
+		for j, d := range dets {
+			if t.label != d.Label() {
+				continue
+			}
+			iou := IoU(t.box(), *d.BoundingBox())
+			if iou >= tr.cfg.IoUThreshold && iou > 0 {
+				pairs = append(pairs, pair{i, j, iou})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].iou > pairs[j].iou })
+
+	matches := make([]*kalmanTrack, len(dets))
+	trackMatched := make([]bool, len(tr.tracks))
+	for _, p := range pairs {
+		if trackMatched[p.track] || matches[p.det] != nil {
+			continue
+		}
+		trackMatched[p.track] = true
+		matches[p.det] = tr.tracks[p.track]
+	}
+	return matches
+}
+
+// Trackers holds one Tracker per video stream, such as the images of a camera, so that the tracks
+// of different streams are never mixed.
+type Trackers struct {
+	mu       sync.Mutex
+	cfg      TrackerConfig
+	trackers map[string]*Tracker
+}
+
+// NewTrackers returns trackers creating the tracker of every stream from the config, using the
+// default of every unset parameter.
+func NewTrackers(cfg TrackerConfig) (*Trackers, error) {
+	tracker, err := NewTracker(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Trackers{cfg: tracker.cfg, trackers: map[string]*Tracker{}}, nil
+}
+
+// Get returns the tracker of the stream, creating it if needed.
+func (ts *Trackers) Get(stream string) *Tracker {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	tracker, ok := ts.trackers[stream]
+	if !ok {
+		tracker = &Tracker{cfg: ts.cfg, nextID: 1}
+		ts.trackers[stream] = tracker
+	}
+	return tracker
+}
+
+// Lookup returns the tracker of the stream if it was given any frame.
+func (ts *Trackers) Lookup(stream string) (*Tracker, bool) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	tracker, ok := ts.trackers[stream]
+	return tracker, ok
+}
+
+type streamNameKey struct{}
+
+// ContextWithStreamName returns a context telling the tracking detectors which video stream, such as
+// a camera, the images they are given come from.
+func ContextWithStreamName(ctx context.Context, stream string) context.Context {
+	return context.WithValue(ctx, streamNameKey{}, stream)
+}
+
+// StreamNameFromContext returns the name of the video stream set by ContextWithStreamName, or the
+// empty string.
+func StreamNameFromContext(ctx context.Context) string {
+	stream, _ := ctx.Value(streamNameKey{}).(string)
+	return stream
+}
+
+// NewTrackingDetector returns a detector reporting the tracks that were detected by the given
+// detector in the latest image. The images are tracked by the tracker of the stream named in their
+// context with ContextWithStreamName, and the events of every image are passed to onEvents if it
+// is not nil.
+func NewTrackingDetector(
+	det Detector, trackers *Trackers, onEvents func(stream string, events []TrackEvent),
+) (Detector, error) {
+	if det == nil || trackers == nil {
+		return nil, errors.New("must have a Detector and Trackers to build a tracking detector")
+	}
+	return func(ctx context.Context, img image.Image) ([]Detection, error) {
+		captured := time.Now()
+		dets, err := det(ctx, img)
+		if err != nil {
+			return nil, err
+		}
+		stream := StreamNameFromContext(ctx)
+		tracks, events := trackers.Get(stream).Update(dets, captured)
+		if onEvents != nil && len(events) > 0 {
+			onEvents(stream, events)
+		}
+		out := make([]Detection, 0, len(tracks))
+		for i := range tracks {
+			if tracks[i].state == TrackTracked {
+				// the estimated box may reach past the edges of the image
+				tracks[i].boundingBox = tracks[i].boundingBox.Intersect(img.Bounds())
+				out = append(out, &tracks[i])
+			}
+		}
+		return out, nil
+	}, nil
+}
+
+// kalmanTrack is a track whose box is estimated by constant velocity filters of its center and size.
+type kalmanTrack struct {
+	Track
+	filters [4]constantVelocity // center x, center y, width and height
+}
+
+func newKalmanTrack(id int, det Detection) *kalmanTrack {
+	box := det.BoundingBox()
+	t := &kalmanTrack{
+		Track: Track{
+			id:          id,
+			label:       det.Label(),
+			score:       det.Score(),
+			boundingBox: *box,
+			age:         1,
+			hits:        1,
+			state:       TrackTentative,
+		},
+	}
+	for i, z := range boxMeasurement(*box) {
+		t.filters[i] = newConstantVelocity(z)
+	}
+	return t
+}
+
+func (t *kalmanTrack) predict(dt float64) {
+	for i := range t.filters {
+		t.filters[i].predict(dt)
+	}
+	t.age++
+	t.boundingBox = t.box()
+	t.velocity = r2.Point{X: t.filters[0].v, Y: t.filters[1].v}
+}
+
+func (t *kalmanTrack) update(det Detection) {
+	for i, z := range boxMeasurement(*det.BoundingBox()) {
+		t.filters[i].update(z)
+	}
+	t.score = det.Score()
+	t.hits++
+	t.lostFrames = 0
+	t.boundingBox = t.box()
+	t.velocity = r2.Point{X: t.filters[0].v, Y: t.filters[1].v}
+}
+
+// box returns the box estimated by the filters.
+func (t *kalmanTrack) box() image.Rectangle {
+	cx, cy := t.filters[0].x, t.filters[1].x
+	w, h := math.Max(t.filters[2].x, 1), math.Max(t.filters[3].x, 1)
+	minX, minY := int(math.Round(cx-w/2)), int(math.Round(cy-h/2))
+	return image.Rect(minX, minY, minX+int(math.Round(w)), minY+int(math.Round(h)))
+}
+
+func (t *kalmanTrack) snapshot() Track {
+	return t.Track
+}
+
+// boxMeasurement returns the center and size of the box, as measured by the filters of a track.
+func boxMeasurement(box image.Rectangle) [4]float64 {
+	return [4]float64{
+		float64(box.Min.X+box.Max.X) / 2,
+		float64(box.Min.Y+box.Max.Y) / 2,
+		float64(box.Dx()),
+		float64(box.Dy()),
+	}
+}
+
+// constantVelocity is a Kalman filter of a value changing at a constant velocity, whose
+// acceleration is white noise.
+type constantVelocity struct {
+	x, v float64
+	p    [2][2]float64
+}
+
+func newConstantVelocity(x float64) constantVelocity {
+	return constantVelocity{x: x, p: [2][2]float64{{measurementVariance, 0}, {0, initialVelocityVariance}}}
+}
+
+func (f *constantVelocity) predict(dt float64) {
+	f.x += f.v * dt
+	p := f.p
+	dt2 := dt * dt
+	f.p[0][0] = p[0][0] + dt*(p[0][1]+p[1][0]) + dt2*p[1][1] + accelerationVariance*dt2*dt2/4
+	f.p[0][1] = p[0][1] + dt*p[1][1] + accelerationVariance*dt2*dt/2
+	f.p[1][0] = p[1][0] + dt*p[1][1] + accelerationVariance*dt2*dt/2
+	f.p[1][1] = p[1][1] + accelerationVariance*dt2
+}
+
+func (f *constantVelocity) update(z float64) {
+	p := f.p
+	s := p[0][0] + measurementVariance
+	k0, k1 := p[0][0]/s, p[1][0]/s
+	y := z - f.x
+	f.x += k0 * y
+	f.v += k1 * y
+	f.p[0][0] = (1 - k0) * p[0][0]
+	f.p[0][1] = (1 - k0) * p[0][1]
+	f.p[1][0] = p[1][0] - k1*p[0][0]
+	f.p[1][1] = p[1][1] - k1*p[0][1]
+}
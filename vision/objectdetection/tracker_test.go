@@ -0,0 +1,177 @@
+package objectdetection
+
+import (
+	"context"
+	"encoding/json"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/r2"
+	"go.viam.com/test"
+)
+
+func TestIoU(t *testing.T) {
+	test.That(t, IoU(image.Rect(0, 0, 10, 10), image.Rect(0, 0, 10, 10)), test.ShouldEqual, 1)
+	test.That(t, IoU(image.Rect(0, 0, 10, 10), image.Rect(5, 0, 15, 10)), test.ShouldAlmostEqual, 50./150.)
+	test.That(t, IoU(image.Rect(0, 0, 10, 10), image.Rect(20, 20, 30, 30)), test.ShouldEqual, 0)
+}
+
+func TestTracker(t *testing.T) {
+	_, err := NewTracker(TrackerConfig{IoUThreshold: 2})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	tracker, err := NewTracker(TrackerConfig{MaxLostFrames: 2})
+	test.That(t, err, test.ShouldBeNil)
+	start := time.Now()
+	frame := func(i int) time.Time { return start.Add(time.Duration(i) * 100 * time.Millisecond) }
+	// a person moving right at 100 px/s and a still box
+	person := func(i int) Detection { return NewDetection(image.Rect(10*i, 0, 10*i+50, 100), 0.9, "person") }
+	box := NewDetection(image.Rect(300, 300, 340, 340), 0.8, "box")
+
+	tracks, events := tracker.Update([]Detection{person(0), box}, frame(0))
+	test.That(t, tracks, test.ShouldHaveLength, 2)
+	test.That(t, events, test.ShouldHaveLength, 2)
+	test.That(t, events[0].Type, test.ShouldEqual, TrackEventNew)
+	personID, boxID := tracks[0].ID(), tracks[1].ID()
+	test.That(t, personID, test.ShouldNotEqual, boxID)
+
+	for i := 1; i < 10; i++ {
+		tracks, events = tracker.Update([]Detection{person(i), box}, frame(i))
+		test.That(t, events, test.ShouldBeEmpty)
+		test.That(t, tracks, test.ShouldHaveLength, 2)
+		test.That(t, tracks[0].ID(), test.ShouldEqual, personID)
+		test.That(t, tracks[1].ID(), test.ShouldEqual, boxID)
+	}
+	test.That(t, tracks[0].Age(), test.ShouldEqual, 10)
+	test.That(t, tracks[0].Velocity().X, test.ShouldAlmostEqual, 100, 5)
+	test.That(t, tracks[0].Velocity().Y, test.ShouldAlmostEqual, 0, 1)
+	test.That(t, tracks[1].Velocity().Norm(), test.ShouldAlmostEqual, 0, 1)
+
+	// the person is lost, then found again where its motion predicts it to be
+	tracks, events = tracker.Update([]Detection{box}, frame(10))
+	test.That(t, events, test.ShouldHaveLength, 1)
+	test.That(t, events[0].Type, test.ShouldEqual, TrackEventLost)
+	test.That(t, events[0].Track.ID(), test.ShouldEqual, personID)
+	test.That(t, tracks[0].State(), test.ShouldEqual, TrackLost)
+	test.That(t, tracks[0].BoundingBox().Min.X, test.ShouldAlmostEqual, 100, 5)
+	tracks, events = tracker.Update([]Detection{person(11), box}, frame(11))
+	test.That(t, events, test.ShouldHaveLength, 1)
+	test.That(t, events[0].Type, test.ShouldEqual, TrackEventFound)
+	test.That(t, tracks[0].ID(), test.ShouldEqual, personID)
+	test.That(t, tracks[0].Hits(), test.ShouldEqual, 11)
+
+	// a detection of another label at the same place is another object
+	other := NewDetection(*box.BoundingBox(), 0.7, "cat")
+	tracks, events = tracker.Update([]Detection{person(12), other}, frame(12))
+	test.That(t, tracks, test.ShouldHaveLength, 3)
+	test.That(t, events, test.ShouldHaveLength, 2)
+	test.That(t, tracks[2].Label(), test.ShouldEqual, "cat")
+
+	// the box is removed after being lost for too many frames
+	for i := 13; i < 14; i++ {
+		_, events = tracker.Update([]Detection{person(i), other}, frame(i))
+		test.That(t, events, test.ShouldBeEmpty)
+	}
+	tracks, events = tracker.Update([]Detection{person(14), other}, frame(14))
+	test.That(t, events, test.ShouldHaveLength, 1)
+	test.That(t, events[0].Type, test.ShouldEqual, TrackEventRemoved)
+	test.That(t, events[0].Track.ID(), test.ShouldEqual, boxID)
+	test.That(t, tracks, test.ShouldHaveLength, 2)
+}
+
+func TestTrackerMinHits(t *testing.T) {
+	tracker, err := NewTracker(TrackerConfig{MinHits: 3})
+	test.That(t, err, test.ShouldBeNil)
+	det := NewDetection(image.Rect(0, 0, 20, 20), 1, "A")
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		tracks, events := tracker.Update([]Detection{det}, start.Add(time.Duration(i)*time.Second))
+		test.That(t, tracks, test.ShouldBeEmpty)
+		test.That(t, events, test.ShouldBeEmpty)
+	}
+	tracks, events := tracker.Update([]Detection{det}, start.Add(2*time.Second))
+	test.That(t, tracks, test.ShouldHaveLength, 1)
+	test.That(t, events, test.ShouldHaveLength, 1)
+	test.That(t, events[0].Type, test.ShouldEqual, TrackEventNew)
+}
+
+func TestTrackingDetector(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	detector := func(ctx context.Context, img image.Image) ([]Detection, error) {
+		return []Detection{NewDetection(image.Rect(80, 80, 100, 100), 0.5, "A")}, nil
+	}
+	trackers, err := NewTrackers(TrackerConfig{})
+	test.That(t, err, test.ShouldBeNil)
+	var got []TrackEvent
+	tracking, err := NewTrackingDetector(detector, trackers, func(stream string, events []TrackEvent) {
+		test.That(t, stream, test.ShouldEqual, "cam1")
+		got = append(got, events...)
+	})
+	test.That(t, err, test.ShouldBeNil)
+	ctx := ContextWithStreamName(context.Background(), "cam1")
+	for i := 0; i < 3; i++ {
+		dets, err := tracking(ctx, img)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, dets, test.ShouldHaveLength, 1)
+		test.That(t, dets[0].(*Track).ID(), test.ShouldEqual, 1)
+		test.That(t, dets[0].BoundingBox().In(img.Bounds()), test.ShouldBeTrue)
+	}
+	test.That(t, got, test.ShouldHaveLength, 1)
+	test.That(t, got[0].Seq, test.ShouldEqual, uint64(1))
+	_, ok := trackers.Lookup("cam2")
+	test.That(t, ok, test.ShouldBeFalse)
+	_, err = Overlay(img, []Detection{&Track{id: 1, label: "A", boundingBox: image.Rect(0, 0, 10, 10)}})
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestTrackersPerStream(t *testing.T) {
+	trackers, err := NewTrackers(TrackerConfig{MaxLostFrames: 1})
+	test.That(t, err, test.ShouldBeNil)
+	now := time.Now()
+	det := NewDetection(image.Rect(0, 0, 10, 10), 0.9, "A")
+
+	cam1 := trackers.Get("cam1")
+	test.That(t, trackers.Get("cam1"), test.ShouldEqual, cam1)
+	cam2 := trackers.Get("cam2")
+	tracks, _ := cam1.Update([]Detection{det}, now)
+	test.That(t, tracks, test.ShouldHaveLength, 1)
+	tracks, _ = cam2.Update(nil, now)
+	test.That(t, tracks, test.ShouldBeEmpty)
+	test.That(t, cam1.Tracks(), test.ShouldHaveLength, 1)
+
+	cam1.Update(nil, now.Add(time.Second))
+	cam1.Update(nil, now.Add(2*time.Second))
+	events := cam1.Events(0)
+	test.That(t, events, test.ShouldHaveLength, 3)
+	for i, typ := range []TrackEventType{TrackEventNew, TrackEventLost, TrackEventRemoved} {
+		test.That(t, events[i].Seq, test.ShouldEqual, uint64(i+1))
+		test.That(t, events[i].Type, test.ShouldEqual, typ)
+		test.That(t, events[i].Track.ID(), test.ShouldEqual, 1)
+	}
+	test.That(t, events[1].Time, test.ShouldEqual, now.Add(time.Second))
+	test.That(t, cam1.Events(2), test.ShouldHaveLength, 1)
+	test.That(t, cam1.Events(3), test.ShouldBeEmpty)
+	test.That(t, cam2.Events(0), test.ShouldBeEmpty)
+}
+
+func TestTrackJSON(t *testing.T) {
+	track := Track{
+		id:          3,
+		label:       "A",
+		score:       0.5,
+		boundingBox: image.Rect(1, 2, 3, 4),
+		velocity:    r2.Point{X: 1.5, Y: -2},
+		age:         4,
+		hits:        3,
+		lostFrames:  1,
+		state:       TrackLost,
+	}
+	data, err := json.Marshal(TrackEvent{Seq: 2, Type: TrackEventLost, Track: track})
+	test.That(t, err, test.ShouldBeNil)
+	var event TrackEvent
+	test.That(t, json.Unmarshal(data, &event), test.ShouldBeNil)
+	test.That(t, event.Seq, test.ShouldEqual, uint64(2))
+	test.That(t, event.Type, test.ShouldEqual, TrackEventLost)
+	test.That(t, event.Track, test.ShouldResemble, track)
+}
@@ -0,0 +1,289 @@
+package segmentation
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/rimage/depthadapter"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/vision"
+)
+
+// A MaskSegmenter labels each pixel of an image with the class of what it shows.
+type MaskSegmenter func(ctx context.Context, img image.Image) (*Mask, error)
+
+// Mask is a per pixel segmentation of an image.
+type Mask struct {
+	// Classes holds the class of each pixel, which indexes Labels.
+	Classes *image.Gray16
+	// Instances holds the instance each pixel belongs to, numbered from 1 with 0 for none. It is
+	// nil for semantic segmentation, whose instances are the connected regions of each class.
+	Instances *image.Gray16
+	Labels    []string
+	// Background is the class of the pixels that show no object.
+	Background int
+}
+
+// NewMask returns a mask of the given size where every pixel is background.
+func NewMask(bounds image.Rectangle, labels []string, background int) *Mask {
+	m := &Mask{Classes: image.NewGray16(bounds), Labels: labels, Background: background}
+	if background != 0 {
+		for i := 0; i < len(m.Classes.Pix); i += 2 {
+			m.Classes.Pix[i], m.Classes.Pix[i+1] = uint8(background>>8), uint8(background)
+		}
+	}
+	return m
+}
+
+// Bounds returns the bounds of the image the mask segments.
+func (m *Mask) Bounds() image.Rectangle {
+	return m.Classes.Bounds()
+}
+
+// Class returns the class of the pixel at (x, y).
+func (m *Mask) Class(x, y int) int {
+	return int(m.Classes.Gray16At(x, y).Y)
+}
+
+// Instance returns the instance of the pixel at (x, y), 0 if it belongs to none or the mask has no
+// instances.
+func (m *Mask) Instance(x, y int) int {
+	if m.Instances == nil {
+		return 0
+	}
+	return int(m.Instances.Gray16At(x, y).Y)
+}
+
+// Label returns the label of the class, or an empty string if it has none.
+func (m *Mask) Label(class int) string {
+	if class < 0 || class >= len(m.Labels) {
+		return ""
+	}
+	return m.Labels[class]
+}
+
+// Resize returns the mask scaled to the bounds with nearest neighbor sampling, as masks are
+// usually computed on images resized to the input of a model.
+func (m *Mask) Resize(bounds image.Rectangle) *Mask {
+	src := m.Bounds()
+	if src == bounds {
+		return m
+	}
+	resized := &Mask{Classes: image.NewGray16(bounds), Labels: m.Labels, Background: m.Background}
+	if m.Instances != nil {
+		resized.Instances = image.NewGray16(bounds)
+	}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		sy := src.Min.Y + (y-bounds.Min.Y)*src.Dy()/bounds.Dy()
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			sx := src.Min.X + (x-bounds.Min.X)*src.Dx()/bounds.Dx()
+			resized.Classes.SetGray16(x, y, m.Classes.Gray16At(sx, sy))
+			if m.Instances != nil {
+				resized.Instances.SetGray16(x, y, m.Instances.Gray16At(sx, sy))
+			}
+		}
+	}
+	return resized
+}
+
+// A MaskRegion is the set of pixels showing one object in a mask.
+type MaskRegion struct {
+	Class  int
+	Label  string
+	Pixels []image.Point
+	Bounds image.Rectangle
+}
+
+// neighbors4 are the offsets of the 4-connected neighbors of a pixel.
+var neighbors4 = []image.Point{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+
+// Regions returns the objects of the mask with at least minPixels pixels: its instances if it has
+// any, or else the 4-connected regions of each class but the background.
+func (m *Mask) Regions(minPixels int) []MaskRegion {
+	bounds := m.Bounds()
+	visited := make([]bool, bounds.Dx()*bounds.Dy())
+	index := func(p image.Point) int {
+		return (p.Y-bounds.Min.Y)*bounds.Dx() + p.X - bounds.Min.X
+	}
+	// same returns whether the pixel belongs to the same object as the seed pixel.
+	same := func(p, seed image.Point) bool {
+		if m.Instances != nil {
+			return m.Instance(p.X, p.Y) == m.Instance(seed.X, seed.Y)
+		}
+		return m.Class(p.X, p.Y) == m.Class(seed.X, seed.Y)
+	}
+	var regions []MaskRegion
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			seed := image.Pt(x, y)
+			if visited[index(seed)] {
+				continue
+			}
+			visited[index(seed)] = true
+			if m.Class(x, y) == m.Background || (m.Instances != nil && m.Instance(x, y) == 0) {
+				continue
+			}
+			// flood fill the region of the seed
+			region := MaskRegion{Class: m.Class(x, y), Label: m.Label(m.Class(x, y))}
+			queue := []image.Point{seed}
+			for len(queue) > 0 {
+				p := queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+				region.Pixels = append(region.Pixels, p)
+				region.Bounds = region.Bounds.Union(image.Rectangle{p, p.Add(image.Pt(1, 1))})
+				for _, d := range neighbors4 {
+					n := p.Add(d)
+					if !n.In(bounds) || visited[index(n)] || !same(n, seed) {
+						continue
+					}
+					visited[index(n)] = true
+					queue = append(queue, n)
+				}
+			}
+			if len(region.Pixels) >= minPixels {
+				regions = append(regions, region)
+			}
+		}
+	}
+	return regions
+}
+
+// classColor returns the color the class is drawn with, spreading the hues of consecutive classes.
+func classColor(class int) color.NRGBA {
+	hue := math.Mod(float64(class)*137.508, 360)
+	r, g, b, _ := rimage.NewColorFromHSV(hue, 0.8, 1).RGBA()
+	return color.NRGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), 255}
+}
+
+// Colorize returns an image of the mask where each class has its own color and the background is
+// transparent.
+func (m *Mask) Colorize() *image.NRGBA {
+	bounds := m.Bounds()
+	img := image.NewNRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if class := m.Class(x, y); class != m.Background {
+				img.SetNRGBA(x, y, classColor(class))
+			}
+		}
+	}
+	return img
+}
+
+// OverlayMask blends the colorized mask into the image with the given opacity in [0, 1], the mask
+// being resized to the image first.
+func OverlayMask(img image.Image, m *Mask, opacity float64) *image.NRGBA {
+	bounds := img.Bounds()
+	m = m.Resize(bounds)
+	out := image.NewNRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if class := m.Class(x, y); class != m.Background {
+				mc := classColor(class)
+				blend := func(a, b uint8) uint8 {
+					return uint8(math.Round(float64(a)*(1-opacity) + float64(b)*opacity))
+				}
+				c = color.NRGBA{blend(c.R, mc.R), blend(c.G, mc.G), blend(c.B, mc.B), c.A}
+			}
+			out.SetNRGBA(x, y, c)
+		}
+	}
+	return out
+}
+
+// MaskObjects projects the regions of the mask with at least minPixels pixels to labeled 3D
+// objects, using only the depth of the pixels of each region. The mask is resized to the depth map.
+func MaskObjects(m *Mask, dm *rimage.DepthMap, proj transform.Projector, minPixels int) ([]*vision.Object, error) {
+	return maskObjects(m, dm, proj, minPixels, nil)
+}
+
+// maskObjects projects the regions of the mask to objects whose point clouds are filtered if
+// filter is not nil.
+func maskObjects(
+	m *Mask,
+	dm *rimage.DepthMap,
+	proj transform.Projector,
+	minPixels int,
+	filter func(pointcloud.PointCloud) (pointcloud.PointCloud, error),
+) ([]*vision.Object, error) {
+	if dm == nil || proj == nil {
+		return nil, errors.New("mask objects need a depth map and a projector")
+	}
+	m = m.Resize(image.Rect(0, 0, dm.Width(), dm.Height()))
+	objects := []*vision.Object{}
+	for _, region := range m.Regions(minPixels) {
+		regionDepth := rimage.NewEmptyDepthMap(dm.Width(), dm.Height())
+		for _, p := range region.Pixels {
+			regionDepth.Set(p.X, p.Y, dm.GetDepth(p.X, p.Y))
+		}
+		cloud := pointcloud.NewWithPrealloc(len(region.Pixels))
+		var err error
+		depthadapter.ToPointCloud(regionDepth, proj).Iterate(0, 0, func(pt r3.Vector, d pointcloud.Data) bool {
+			err = cloud.Set(pt, d)
+			return err == nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if filter != nil {
+			if cloud, err = filter(cloud); err != nil {
+				return nil, err
+			}
+		}
+		// the region has no depth or was filtered away
+		if cloud.Size() == 0 {
+			continue
+		}
+		obj, err := vision.NewObjectWithLabel(cloud, region.Label)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// MaskSegmenterToSegmenter turns a MaskSegmenter into a Segmenter whose objects are the regions of
+// the mask of the camera image with at least minPixels pixels, projected with the camera depth.
+// The objects are filtered by a statistical outlier filter when meanK and sigma are positive.
+func MaskSegmenterToSegmenter(maskSegmenter MaskSegmenter, minPixels, meanK int, sigma float64) (Segmenter, error) {
+	if maskSegmenter == nil {
+		return nil, errors.New("mask segmenter cannot be nil")
+	}
+	var filter func(pointcloud.PointCloud) (pointcloud.PointCloud, error)
+	if meanK > 0 && sigma > 0.0 {
+		var err error
+		filter, err = pointcloud.StatisticalOutlierFilter(meanK, sigma)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return func(ctx context.Context, cam camera.Camera) ([]*vision.Object, error) {
+		proj, err := cam.Projector(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pc, err := cam.NextPointCloud(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "mask segmenter")
+		}
+		img, dm, err := proj.PointCloudToRGBD(pc)
+		if err != nil {
+			return nil, err
+		}
+		mask, err := maskSegmenter(ctx, rimage.CloneImage(img))
+		if err != nil {
+			return nil, err
+		}
+		return maskObjects(mask, dm, proj, minPixels, filter)
+	}, nil
+}
@@ -0,0 +1,116 @@
+package segmentation
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/rimage/transform"
+)
+
+// testMask returns an 8x4 mask with background class 0, two separate regions of class 1 and a
+// region of class 2:
+//
+//	1 1 0 0 0 0 2 2
+//	1 1 0 0 0 0 2 2
+//	0 0 0 1 0 0 0 0
+//	0 0 0 0 0 0 0 0
+func testMask() *Mask {
+	m := NewMask(image.Rect(0, 0, 8, 4), []string{"background", "cup", "plate"}, 0)
+	for _, p := range []image.Point{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {3, 2}} {
+		m.Classes.SetGray16(p.X, p.Y, color.Gray16{1})
+	}
+	for _, p := range []image.Point{{6, 0}, {7, 0}, {6, 1}, {7, 1}} {
+		m.Classes.SetGray16(p.X, p.Y, color.Gray16{2})
+	}
+	return m
+}
+
+func TestMaskRegions(t *testing.T) {
+	m := testMask()
+	test.That(t, m.Class(7, 1), test.ShouldEqual, 2)
+	test.That(t, m.Label(2), test.ShouldEqual, "plate")
+	test.That(t, m.Label(3), test.ShouldEqual, "")
+
+	regions := m.Regions(1)
+	test.That(t, regions, test.ShouldHaveLength, 3)
+	test.That(t, regions[0].Label, test.ShouldEqual, "cup")
+	test.That(t, regions[0].Pixels, test.ShouldHaveLength, 4)
+	test.That(t, regions[0].Bounds, test.ShouldResemble, image.Rect(0, 0, 2, 2))
+	test.That(t, regions[1].Label, test.ShouldEqual, "plate")
+	test.That(t, regions[2].Bounds, test.ShouldResemble, image.Rect(3, 2, 4, 3))
+	test.That(t, m.Regions(2), test.ShouldHaveLength, 2)
+
+	// instances split and join regions regardless of their class
+	m.Instances = image.NewGray16(m.Bounds())
+	for _, p := range []image.Point{{0, 0}, {1, 0}, {3, 2}} {
+		m.Instances.SetGray16(p.X, p.Y, color.Gray16{1})
+	}
+	m.Instances.SetGray16(0, 1, color.Gray16{2})
+	regions = m.Regions(1)
+	test.That(t, regions, test.ShouldHaveLength, 3)
+	test.That(t, regions[0].Pixels, test.ShouldHaveLength, 2)
+	test.That(t, regions[1].Pixels, test.ShouldHaveLength, 1)
+	test.That(t, regions[2].Bounds, test.ShouldResemble, image.Rect(3, 2, 4, 3))
+
+	background := NewMask(image.Rect(0, 0, 2, 2), nil, 300)
+	test.That(t, background.Class(1, 1), test.ShouldEqual, 300)
+	test.That(t, background.Regions(0), test.ShouldBeEmpty)
+}
+
+func TestMaskImages(t *testing.T) {
+	m := testMask()
+	resized := m.Resize(image.Rect(0, 0, 16, 8))
+	test.That(t, resized.Class(3, 3), test.ShouldEqual, 1)
+	test.That(t, resized.Class(14, 0), test.ShouldEqual, 2)
+	test.That(t, resized.Class(4, 4), test.ShouldEqual, 0)
+	test.That(t, resized.Regions(1), test.ShouldHaveLength, 3)
+	test.That(t, m.Resize(m.Bounds()), test.ShouldEqual, m)
+
+	colorized := m.Colorize()
+	test.That(t, colorized.NRGBAAt(2, 2).A, test.ShouldEqual, 0)
+	test.That(t, colorized.NRGBAAt(0, 0), test.ShouldResemble, classColor(1))
+	test.That(t, classColor(1), test.ShouldNotResemble, classColor(2))
+
+	img := image.NewNRGBA(image.Rect(0, 0, 16, 8))
+	for i := range img.Pix {
+		img.Pix[i] = 255
+	}
+	overlaid := OverlayMask(img, m, 1)
+	test.That(t, overlaid.Bounds(), test.ShouldResemble, img.Bounds())
+	test.That(t, overlaid.NRGBAAt(13, 1), test.ShouldResemble, classColor(2))
+	test.That(t, overlaid.NRGBAAt(8, 6), test.ShouldResemble, color.NRGBA{255, 255, 255, 255})
+	overlaid = OverlayMask(img, m, 0)
+	test.That(t, overlaid.NRGBAAt(13, 1), test.ShouldResemble, color.NRGBA{255, 255, 255, 255})
+}
+
+func TestMaskObjects(t *testing.T) {
+	m := testMask()
+	dm := rimage.NewEmptyDepthMap(8, 4)
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 8; x++ {
+			dm.Set(x, y, 1000)
+		}
+	}
+	// the small cup has no depth
+	dm.Set(3, 2, 0)
+	proj := &transform.PinholeCameraIntrinsics{Width: 8, Height: 4, Fx: 100, Fy: 100, Ppx: 4, Ppy: 2}
+
+	objects, err := MaskObjects(m, dm, proj, 1)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, objects, test.ShouldHaveLength, 2)
+	test.That(t, objects[0].Geometry.Label(), test.ShouldEqual, "cup")
+	test.That(t, objects[0].Size(), test.ShouldEqual, 4)
+	test.That(t, objects[1].Geometry.Label(), test.ShouldEqual, "plate")
+	test.That(t, objects[1].Size(), test.ShouldEqual, 4)
+	// the plate is to the right of the cup
+	test.That(t, objects[1].Geometry.Pose().Point().X, test.ShouldBeGreaterThan, objects[0].Geometry.Pose().Point().X)
+
+	_, err = MaskObjects(m, nil, proj, 1)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = MaskSegmenterToSegmenter(nil, 1, 0, 0)
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/edaniels/gostream"
 	"github.com/edaniels/gostream/codec/opus"
 	"github.com/edaniels/gostream/codec/x264"
+	"github.com/pkg/errors"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"go.viam.com/utils"
@@ -20,6 +21,7 @@ import (
 
 	"go.viam.com/rdk/config"
 	robotimpl "go.viam.com/rdk/robot/impl"
+	"go.viam.com/rdk/robot/journal"
 	"go.viam.com/rdk/robot/web"
 	weboptions "go.viam.com/rdk/robot/web/options"
 )
@@ -33,8 +35,11 @@ type Arguments struct {
 	SharedDir                  string `flag:"shareddir,usage=web resource directory"`
 	Version                    bool   `flag:"version,usage=print version"`
 	WebProfile                 bool   `flag:"webprofile,usage=include profiler in http server"`
+	WebMetrics                 bool   `flag:"webmetrics,usage=export prometheus metrics at /metrics in http server"`
 	WebRTC                     bool   `flag:"webrtc,usage=force webrtc connections instead of direct"`
 	RevealSensitiveConfigDiffs bool   `flag:"reveal-sensitive-config-diffs,usage=show config diffs"`
+	DryRun                     bool   `flag:"dry-run,usage=validate the config and print its plan without starting the robot"`
+	JournalDir                 string `flag:"journal-dir,usage=keep a journal of robot actions in this directory"`
 }
 
 type robotServer struct {
@@ -74,6 +79,10 @@ func RunServer(ctx context.Context, args []string, _ golog.Logger) (err error) {
 		return
 	}
 
+	if argsParsed.DryRun {
+		return dryRun(argsParsed.ConfigFile)
+	}
+
 	if argsParsed.CPUProfile != "" {
 		f, err := os.Create(argsParsed.CPUProfile)
 		if err != nil {
@@ -131,6 +140,26 @@ func RunServer(ctx context.Context, args []string, _ golog.Logger) (err error) {
 	return err
 }
 
+// dryRun validates the config file and prints the plan of the reconfiguration starting a robot
+// with it would follow, without starting the robot or touching any hardware.
+func dryRun(configFile string) error {
+	cfg, err := config.ReadUnprocessed(configFile)
+	if err != nil {
+		return err
+	}
+	plan, err := robotimpl.PlanReconfiguration(&config.Config{}, cfg)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stdout.WriteString(plan.String()); err != nil {
+		return err
+	}
+	if !plan.Valid() {
+		return errors.Errorf("config %q is invalid", configFile)
+	}
+	return nil
+}
+
 // runServer is an entry point to starting the web server after the local config is read. Once the local config
 // is read the logger may be initialized to remote log. This ensure we capture errors starting up the server and report to the cloud.
 func (s *robotServer) runServer(ctx context.Context) error {
@@ -156,6 +185,7 @@ func (s *robotServer) createWebOptions(cfg *config.Config) (weboptions.Options,
 		return weboptions.Options{}, err
 	}
 	options.Pprof = s.args.WebProfile
+	options.Metrics = s.args.WebMetrics
 	options.SharedDir = s.args.SharedDir
 	options.Debug = s.args.Debug || cfg.Debug
 	options.WebRTC = s.args.WebRTC
@@ -242,6 +272,17 @@ func (s *robotServer) serveWeb(ctx context.Context, cfg *config.Config) (err err
 		robotOptions = append(robotOptions, robotimpl.WithRevealSensitiveConfigDiffs())
 	}
 
+	if s.args.JournalDir != "" {
+		robotJournal, journalErr := journal.New(journal.Config{Dir: s.args.JournalDir}, s.logger)
+		if journalErr != nil {
+			return journalErr
+		}
+		defer func() {
+			err = multierr.Combine(err, robotJournal.Close())
+		}()
+		robotOptions = append(robotOptions, robotimpl.WithJournal(robotJournal))
+	}
+
 	myRobot, err := robotimpl.New(ctx, processedConfig, s.logger, robotOptions...)
 	if err != nil {
 		return err